@@ -90,6 +90,8 @@ func handleRoutes(Router fiber.Router, sdkP *sdk.SDK) {
 	DiagramRouter.Put("/", diagram.Put(sdkP))
 	DiagramRouter.Get("/", diagram.Get(sdkP))
 	DiagramRouter.Delete("/", diagram.Delete(sdkP))
+	DiagramRouter.Get("/import", diagram.GetImport(sdkP))
+	DiagramRouter.Post("/sync", diagram.Sync(sdkP))
 	DiagramRouter.Post("/issues", diagramIssues.Post(sdkP))
 	DiagramRouter.Delete("/issues", diagramIssues.Delete(sdkP))
 
@@ -124,6 +126,7 @@ func handleRoutes(Router fiber.Router, sdkP *sdk.SDK) {
 	Router.Get("/.well-known/jwks.json", JWKSet(sdkP))
 
 	Router.Use("/ws", WebSocketUpgrade())
+	Router.Get("/ws/import/:jobId", isAuthenticated(sdkP), WebSocketImportHandler(sdkP))
 	Router.Get("/ws/:diagramId", isAuthenticated(sdkP), WebSocketDiagramHandler(sdkP))
 }
 
@@ -0,0 +1,222 @@
+package diagram
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/junioryono/ProUML/backend/sdk"
+	"github.com/junioryono/ProUML/backend/source"
+	"github.com/junioryono/ProUML/backend/transpiler"
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+	httpTypes "github.com/junioryono/ProUML/backend/types"
+)
+
+const (
+	// Imports are transpiled in the background, at most this many at a time on every server
+	maxRunningImports = 2
+
+	// Imports that wait for a running import to finish beyond this number are rejected
+	maxQueuedImports = 32
+
+	// An import fails when transpiling takes longer than this
+	importTimeout = 10 * time.Minute
+
+	// The progress of parsing is published about this many times, instead of after every file
+	importProgressSteps = 20
+)
+
+var (
+	importSlots   = make(chan struct{}, maxRunningImports)
+	queuedImports int32
+)
+
+// Returns the status of an import job of the user
+func GetImport(sdkP *sdk.SDK) fiber.Handler {
+	return func(fbCtx *fiber.Ctx) error {
+		jobId := fbCtx.Query("id")
+		if jobId == "" {
+			return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
+				Success: false,
+				Reason:  httpTypes.ErrInvalidRequest,
+			})
+		}
+
+		userId, err := sdkP.Postgres.Auth.Client.GetUserId(fbCtx.Locals("idToken").(string))
+		if err != nil {
+			return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
+				Success: false,
+				Reason:  err.Error(),
+			})
+		}
+
+		// Jobs of other users are not found either
+		job, err2 := sdkP.Redis.GetImportJob(jobId)
+		if err2 != nil || job.UserId != userId {
+			return fbCtx.Status(fiber.StatusNotFound).JSON(httpTypes.Status{
+				Success: false,
+				Reason:  httpTypes.ErrImportNotFound,
+			})
+		}
+
+		return fbCtx.Status(fiber.StatusOK).JSON(httpTypes.Status{
+			Success:  true,
+			Response: job,
+		})
+	}
+}
+
+// Reads the files of an import job
+type importReader func(ctx context.Context) (*source.Snapshot, *httpTypes.WrappedError)
+
+// Returns a reader of files that were already read
+func readSnapshot(snapshot *source.Snapshot) importReader {
+	return func(ctx context.Context) (*source.Snapshot, *httpTypes.WrappedError) {
+		return snapshot, nil
+	}
+}
+
+// Saves the diagram of an import job once its files are transpiled
+type importSaver func(job *httpTypes.ImportJob, transpiledProject []any) *httpTypes.WrappedError
+
+// Saves the transpiled project as a new diagram of the user
+func createDiagram(sdkP *sdk.SDK, userId, projectId string) importSaver {
+	return func(job *httpTypes.ImportJob, transpiledProject []any) *httpTypes.WrappedError {
+		diagramId, err := sdkP.Postgres.Diagram.CreateForUser(userId, projectId, &transpiledProject, job.Diagnostics, job.Commit)
+		if err != nil {
+			return err
+		}
+
+		job.DiagramId = diagramId
+		return nil
+	}
+}
+
+// Saves a new import job of the user and reads and transpiles its files in the background.
+// The id token of the request may expire before the job is done, so the job only knows the id of the user.
+func startImport(sdkP *sdk.SDK, userId, layout string, read importReader, save importSaver) (*httpTypes.ImportJob, *httpTypes.WrappedError) {
+	if atomic.AddInt32(&queuedImports, 1) > maxQueuedImports {
+		atomic.AddInt32(&queuedImports, -1)
+		return nil, httpTypes.Wrap(errors.New("import queue is full"), httpTypes.ErrTooManyImports)
+	}
+
+	job := &httpTypes.ImportJob{
+		Id:        uuid.New().String(),
+		UserId:    userId,
+		Status:    httpTypes.ImportQueued,
+		CreatedAt: time.Now(),
+	}
+
+	if err := sdkP.Redis.SetImportJob(job); err != nil {
+		atomic.AddInt32(&queuedImports, -1)
+		return nil, httpTypes.Wrap(err, httpTypes.ErrInternalServerError)
+	}
+
+	// The job is changed by the import from now on, so the caller gets a copy
+	response := *job
+
+	go func() {
+		importSlots <- struct{}{}
+		atomic.AddInt32(&queuedImports, -1)
+
+		defer func() { <-importSlots }()
+
+		runImport(sdkP, job, layout, read, save)
+	}()
+
+	return &response, nil
+}
+
+// Reads and transpiles the files of an import job, saves them and publishes the progress of the job
+func runImport(sdkP *sdk.SDK, job *httpTypes.ImportJob, layout string, read importReader, save importSaver) {
+	var mutex sync.Mutex
+
+	// The job keeps running when its progress cannot be published, the next update may succeed
+	publish := func() {
+		if err := sdkP.Redis.SetImportJob(job); err != nil {
+			log.Printf("could not save import job %s: %v", job.Id, err)
+		}
+	}
+
+	fail := func(reason string) {
+		job.Status = httpTypes.ImportFailed
+		job.Reason = reason
+		publish()
+	}
+
+	// The router does not recover panics of goroutines that it did not start
+	defer func() {
+		if r := recover(); r != nil {
+			fail(httpTypes.ErrInternalServerError)
+		}
+	}()
+
+	job.Status = httpTypes.ImportRunning
+	publish()
+
+	ctx, cancel := context.WithTimeout(context.Background(), importTimeout)
+	defer cancel()
+
+	snapshot, err := read(ctx)
+	if err != nil {
+		fail(err.Error())
+		return
+	}
+
+	job.Commit = snapshot.Commit
+	job.Diagnostics = snapshot.Diagnostics
+	for _, file := range snapshot.Files {
+		if file.Code != nil {
+			job.Files++
+		}
+	}
+
+	publish()
+
+	step := job.Files / importProgressSteps
+	if step == 0 {
+		step = 1
+	}
+
+	ctx = types.WithProgress(ctx, func(stage string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch stage {
+		case types.ProgressFileParsed:
+			job.ParsedFiles++
+			if job.ParsedFiles%step != 0 {
+				return
+			}
+		case types.ProgressParsed:
+			job.Parsed = true
+		case types.ProgressLayout:
+			job.LayoutDone = true
+		}
+
+		publish()
+	})
+
+	transpiledProject, languages, transpileDiagnostics, err := transpiler.Transpile(ctx, sdkP, snapshot.Files, layout)
+
+	// The workers that report the progress are done once the transpiler returns
+	job.Languages = languages
+	job.Diagnostics = append(job.Diagnostics, transpileDiagnostics...)
+	if err != nil {
+		fail(err.Error())
+		return
+	}
+
+	if err := save(job, transpiledProject); err != nil {
+		fail(err.Error())
+		return
+	}
+
+	job.Status = httpTypes.ImportSucceeded
+	publish()
+}
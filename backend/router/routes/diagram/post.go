@@ -2,118 +2,55 @@ package diagram
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
-	"strings"
-
-	"archive/zip"
+	"mime/multipart"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/junioryono/ProUML/backend/sdk"
+	"github.com/junioryono/ProUML/backend/source"
 	"github.com/junioryono/ProUML/backend/templates"
-	"github.com/junioryono/ProUML/backend/transpiler"
-	"github.com/junioryono/ProUML/backend/transpiler/types"
 	httpTypes "github.com/junioryono/ProUML/backend/types"
 )
 
+// Uploaded projects and Git bundles must be smaller than this
+const maxProjectSize = 50 * 1024 * 1024
+
 func Post(sdkP *sdk.SDK) fiber.Handler {
 	return func(fbCtx *fiber.Ctx) error {
 		projectId := fbCtx.FormValue("projectId")
 
-		// Check if user uploaded a project
-		if project, err := fbCtx.FormFile("project"); err == nil {
-			if project.Header.Get("Content-Type") != "zip" &&
-				project.Header.Get("Content-Type") != "application/octet-stream" &&
-				project.Header.Get("Content-Type") != "application/zip" &&
-				project.Header.Get("Content-Type") != "application/x-zip" &&
-				project.Header.Get("Content-Type") != "application/x-zip-compressed" {
-				return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
-					Success: false,
-					Reason:  "Project must be compressed (zipped).",
-				})
-			}
-
-			// If the file size is greater than 50MB, return error
-			if project.Size > 50*1024*1024 {
-				return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
-					Success: false,
-					Reason:  "Project must be less than 50MB.",
-				})
-			}
-
-			f, err := project.Open()
+		// Check if user uploaded a project or wants to import a Git repository
+		if read, err := readProjectSource(fbCtx); err != nil || read != nil {
 			if err != nil {
 				return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
 					Success: false,
-					Reason:  "Could not open project file.",
+					Reason:  err.Error(),
 				})
 			}
 
-			// Read file
-			zipBytes := make([]byte, project.Size)
-			lenZipBytes, err := f.Read(zipBytes)
+			// Transpile the files in the background. The progress is sent to the websocket of the job.
+			userId, err := sdkP.Postgres.Auth.Client.GetUserId(fbCtx.Locals("idToken").(string))
 			if err != nil {
 				return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
 					Success: false,
-					Reason:  "Could not read project file.",
+					Reason:  err.Error(),
 				})
 			}
 
-			f.Close()
-
-			zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(lenZipBytes))
+			job, err := startImport(sdkP, userId, fbCtx.FormValue("layout"), read, createDiagram(sdkP, userId, projectId))
 			if err != nil {
-				return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
-					Success: false,
-					Reason:  "Could not read project file.",
-				})
-			}
-
-			var files []types.File
-
-			// Read all the files from zip archive
-			for _, zipFile := range zipReader.File {
-				lastSlashIndex := strings.LastIndexByte(zipFile.Name, '/')
-
-				// Get the file extension
-				fileNameWithExtension := zipFile.Name[lastSlashIndex+1:]
-				periodIndex := strings.IndexByte(fileNameWithExtension, '.')
-				if periodIndex == -1 {
-					continue
-				}
-
-				unzippedFileBytes, err := readZipFile(zipFile)
-				if err != nil {
-					continue
-				}
-
-				files = append(files, types.File{
-					Name:      fileNameWithExtension[:periodIndex],
-					Extension: fileNameWithExtension[periodIndex+1:],
-					Code:      unzippedFileBytes,
-				})
-			}
-
-			// Transpile files
-			transpiledProject, err2 := transpiler.Transpile(sdkP, files)
-			if err2 != nil {
-				return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
-					Success: false,
-					Reason:  err2.Error(),
-				})
-			}
-
-			// Create a new diagram
-			diagramId, err2 := sdkP.Postgres.Diagram.Create(fbCtx.Locals("idToken").(string), projectId, &transpiledProject)
-			if err2 != nil {
 				return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
 					Success: false,
 					Reason:  err.Error(),
 				})
 			}
 
-			return fbCtx.Status(fiber.StatusOK).JSON(httpTypes.Status{
+			return fbCtx.Status(fiber.StatusAccepted).JSON(httpTypes.Status{
 				Success:  true,
-				Response: diagramId,
+				Response: job,
 			})
 		}
 
@@ -129,7 +66,7 @@ func Post(sdkP *sdk.SDK) fiber.Handler {
 			}
 
 			// Create a new diagram
-			diagramId, err := sdkP.Postgres.Diagram.Create(fbCtx.Locals("idToken").(string), projectId, template)
+			diagramId, err := sdkP.Postgres.Diagram.Create(fbCtx.Locals("idToken").(string), projectId, template, nil, "")
 			if err != nil {
 				return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
 					Success: false,
@@ -160,7 +97,7 @@ func Post(sdkP *sdk.SDK) fiber.Handler {
 		}
 
 		// Create a new diagram
-		diagramId, err := sdkP.Postgres.Diagram.Create(fbCtx.Locals("idToken").(string), projectId, nil)
+		diagramId, err := sdkP.Postgres.Diagram.Create(fbCtx.Locals("idToken").(string), projectId, nil, nil, "")
 		if err != nil {
 			return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
 				Success: false,
@@ -176,11 +113,109 @@ func Post(sdkP *sdk.SDK) fiber.Handler {
 	}
 }
 
-func readZipFile(zf *zip.File) ([]byte, error) {
-	f, err := zf.Open()
-	if err != nil {
-		return nil, err
+// Reads the source files that a request imports from one of these form values:
+//   - project: a zip, tar or tar.gz archive of the project, or a single source file
+//   - files: source files that are uploaded one by one, with their paths in the paths values in the same order
+//   - bundle: a Git bundle, checked out at ref
+//   - repository: the path of a Git repository on the server, checked out at ref. Only allowed when self-hosted.
+//
+// Uploaded files are read right away, because they are removed when the request ends. Git repositories are read by the import job,
+// because checking out a commit can take long. Returns nil when the request does not import a project.
+func readProjectSource(fbCtx *fiber.Ctx) (importReader, *httpTypes.WrappedError) {
+	if project, err := fbCtx.FormFile("project"); err == nil {
+		if project.Size > maxProjectSize {
+			return nil, httpTypes.Wrap(errors.New("project too large"), httpTypes.ErrProjectTooLarge)
+		}
+
+		f, err := project.Open()
+		if err != nil {
+			return nil, httpTypes.Wrap(err, httpTypes.ErrCouldNotOpenProject)
+		}
+
+		defer f.Close()
+
+		snapshot, err2 := source.ReadProject(f, project.Size, project.Filename)
+		if err2 != nil {
+			return nil, err2
+		}
+
+		return readSnapshot(snapshot), nil
+	}
+
+	if form, err := fbCtx.MultipartForm(); err == nil && len(form.File["files"]) != 0 {
+		snapshot, err := readUploadedFiles(form.File["files"], form.Value["paths"])
+		if err != nil {
+			return nil, err
+		}
+
+		return readSnapshot(snapshot), nil
 	}
-	defer f.Close()
-	return io.ReadAll(f)
+
+	// The ref is copied, because the values of a request are reused once it ends
+	ref := utils.CopyString(fbCtx.FormValue("ref"))
+
+	if bundle, err := fbCtx.FormFile("bundle"); err == nil {
+		if bundle.Size > maxProjectSize {
+			return nil, httpTypes.Wrap(errors.New("bundle too large"), httpTypes.ErrBundleTooLarge)
+		}
+
+		f, err := bundle.Open()
+		if err != nil {
+			return nil, httpTypes.Wrap(err, httpTypes.ErrGitInvalidBundle)
+		}
+
+		defer f.Close()
+
+		bundleData, err := io.ReadAll(f)
+		if err != nil {
+			return nil, httpTypes.Wrap(err, httpTypes.ErrGitInvalidBundle)
+		}
+
+		return func(ctx context.Context) (*source.Snapshot, *httpTypes.WrappedError) {
+			return source.ReadGitBundle(ctx, bytes.NewReader(bundleData), ref)
+		}, nil
+	}
+
+	if repository := fbCtx.FormValue("repository"); repository != "" {
+		repositoryPath, err := source.ResolveGitRepositoryPath(utils.CopyString(repository))
+		if err != nil {
+			return nil, err
+		}
+
+		return func(ctx context.Context) (*source.Snapshot, *httpTypes.WrappedError) {
+			return source.ReadGitRepository(ctx, repositoryPath, ref)
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// Reads files that were uploaded one by one. Browsers only send the name of a file, so its path is sent separately.
+func readUploadedFiles(files []*multipart.FileHeader, paths []string) (*source.Snapshot, *httpTypes.WrappedError) {
+	var (
+		entries []source.ArchiveEntry
+		size    int64
+	)
+
+	for i, file := range files {
+		file := file
+		if size += file.Size; size > maxProjectSize {
+			return nil, httpTypes.Wrap(errors.New("project too large"), httpTypes.ErrProjectTooLarge)
+		}
+
+		filePath := file.Filename
+		if i < len(paths) && paths[i] != "" {
+			filePath = paths[i]
+		}
+
+		entries = append(entries, source.ArchiveEntry{
+			Path: filePath,
+			Size: file.Size,
+			Open: func() (io.ReadCloser, error) {
+				return file.Open()
+			},
+		})
+	}
+
+	return source.ReadFiles(entries)
 }
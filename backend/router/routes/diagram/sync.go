@@ -0,0 +1,93 @@
+package diagram
+
+import (
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/junioryono/ProUML/backend/sdk"
+	"github.com/junioryono/ProUML/backend/transpiler"
+	httpTypes "github.com/junioryono/ProUML/backend/types"
+)
+
+// Re-imports an uploaded project or a Git repository into an existing diagram, see readProjectSource. The cells of the diagram keep their layout and styling,
+// see transpiler.SyncDiagram. Classes that are not in the project anymore are flagged, or removed with removeMissing=true.
+func Sync(sdkP *sdk.SDK) fiber.Handler {
+	return func(fbCtx *fiber.Ctx) error {
+		diagramId := fbCtx.FormValue("id")
+		if diagramId == "" {
+			return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
+				Success: false,
+				Reason:  httpTypes.ErrInvalidRequest,
+			})
+		}
+
+		userId, err := sdkP.Postgres.Auth.Client.GetUserId(fbCtx.Locals("idToken").(string))
+		if err != nil {
+			return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
+				Success: false,
+				Reason:  err.Error(),
+			})
+		}
+
+		// Check the permission before the upload is transpiled, the diagram checks it again when it is saved
+		if hasPermission, err := sdkP.Postgres.Diagram.UserHasDiagramEdittingPermissions(diagramId, userId); err != nil || !hasPermission {
+			return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
+				Success: false,
+				Reason:  httpTypes.ErrNotOwnerOrEditor,
+			})
+		}
+
+		read, err := readProjectSource(fbCtx)
+		if err != nil {
+			return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
+				Success: false,
+				Reason:  err.Error(),
+			})
+		}
+
+		if read == nil {
+			return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
+				Success: false,
+				Reason:  httpTypes.ErrInvalidRequest,
+			})
+		}
+
+		job, err := startImport(sdkP, userId, fbCtx.FormValue("layout"), read, syncDiagram(sdkP, userId, diagramId, fbCtx.FormValue("removeMissing") == "true"))
+		if err != nil {
+			return fbCtx.Status(fiber.StatusBadRequest).JSON(httpTypes.Status{
+				Success: false,
+				Reason:  err.Error(),
+			})
+		}
+
+		return fbCtx.Status(fiber.StatusAccepted).JSON(httpTypes.Status{
+			Success:  true,
+			Response: job,
+		})
+	}
+}
+
+// Merges the transpiled project into the diagram and tells the users that have the diagram open to reload it
+func syncDiagram(sdkP *sdk.SDK, userId, diagramId string, removeMissing bool) importSaver {
+	return func(job *httpTypes.ImportJob, transpiledProject []any) *httpTypes.WrappedError {
+		job.DiagramId = diagramId
+
+		if err := sdkP.Postgres.Diagram.SyncContent(diagramId, userId, job.Diagnostics, job.Commit, func(content []map[string]any) ([]any, *httpTypes.WrappedError) {
+			syncedContent, summary, err := transpiler.SyncDiagram(content, transpiledProject, removeMissing)
+			if err != nil {
+				return nil, httpTypes.Wrap(err, httpTypes.ErrInternalServerError)
+			}
+
+			job.Sync = &summary
+			return syncedContent, nil
+		}); err != nil {
+			return err
+		}
+
+		if message, err := json.Marshal(httpTypes.WebSocketBody{Events: "sync"}); err == nil {
+			sdkP.Redis.Broadcast(diagramId, message)
+		}
+
+		return nil
+	}
+}
@@ -124,6 +124,28 @@ func WebSocketDiagramHandler(sdkP *sdk.SDK) fiber.Handler {
 	})
 }
 
+// Sends the progress of an import job to the user who started it
+func WebSocketImportHandler(sdkP *sdk.SDK) fiber.Handler {
+	return websocket.New(func(wc *websocket.Conn) {
+		idToken := wc.Cookies(auth.IdTokenCookieName)
+		jobId := wc.Params("jobId")
+
+		// Get the user model from the id token
+		userModel, err := sdkP.Postgres.Auth.Client.GetUser(idToken)
+		if err != nil {
+			return
+		}
+
+		// Only the user who started the import can follow it
+		job, err2 := sdkP.Redis.GetImportJob(jobId)
+		if err2 != nil || job.UserId != userModel.ID {
+			return
+		}
+
+		sdkP.Redis.SubscribeImportJob(jobId, wc)
+	})
+}
+
 func sliceContains(slice []string, contains string) bool {
 	for _, value := range slice {
 		if value == contains {
@@ -10,6 +10,7 @@ import (
 	"github.com/junioryono/ProUML/backend/sdk/postgres/diagram/issues"
 	"github.com/junioryono/ProUML/backend/sdk/postgres/diagram/users"
 	"github.com/junioryono/ProUML/backend/sdk/postgres/models"
+	transpilerTypes "github.com/junioryono/ProUML/backend/transpiler/types"
 	"github.com/junioryono/ProUML/backend/types"
 	"gorm.io/gorm"
 )
@@ -32,19 +33,26 @@ func Init(getDb func() *gorm.DB, Auth *auth.Auth_SDK) *Diagram_SDK {
 	}
 }
 
-func (d *Diagram_SDK) Create(idToken, projectId string, diagramContent *[]any) (string, *types.WrappedError) {
+func (d *Diagram_SDK) Create(idToken, projectId string, diagramContent *[]any, diagnostics []transpilerTypes.Diagnostic, sourceCommit string) (string, *types.WrappedError) {
 	// Get the user id from the id token
 	userId, err := d.auth.Client.GetUserId(idToken)
 	if err != nil {
 		return "", err
 	}
 
+	return d.CreateForUser(userId, projectId, diagramContent, diagnostics, sourceCommit)
+}
+
+// Creates a diagram owned by the user with the id, for imports that run after the id token of their request expired
+func (d *Diagram_SDK) CreateForUser(userId, projectId string, diagramContent *[]any, diagnostics []transpilerTypes.Diagnostic, sourceCommit string) (string, *types.WrappedError) {
 	diagramId := uuid.New().String()
 
 	// Create the diagram
 	diagram := models.DiagramModel{
-		ID:        uuid.New().String(),
-		ProjectID: projectId,
+		ID:           uuid.New().String(),
+		ProjectID:    projectId,
+		Diagnostics:  diagnostics,
+		SourceCommit: sourceCommit,
 		UserRoles: []models.DiagramUserRoleModel{
 			{
 				UserID:    userId,
@@ -111,6 +119,8 @@ func (d *Diagram_SDK) Duplicate(idToken, projectId, duplicateDiagramId string) (
 		Name:            duplicateDiagram.Name + " (copy)",
 		Image:           duplicateDiagram.Image,
 		Content:         duplicateDiagram.Content,
+		Diagnostics:     duplicateDiagram.Diagnostics,
+		SourceCommit:    duplicateDiagram.SourceCommit,
 		BackgroundColor: duplicateDiagram.BackgroundColor,
 		ShowGrid:        duplicateDiagram.ShowGrid,
 		ProjectID:       projectId,
@@ -431,6 +441,72 @@ func (d *Diagram_SDK) UpdateContentRemoveCell(diagramId, idToken string, cell ma
 	return nil
 }
 
+// Replaces the content of a diagram with the content that sync returns for it, and saves the diagnostics and the commit of its source.
+// The diagram is locked while sync runs, so that cells that are changed at the same time are not lost.
+// Syncs run in import jobs after the id token of their request may have expired, so the user is given by id.
+func (d *Diagram_SDK) SyncContent(diagramId, userId string, diagnostics []transpilerTypes.Diagnostic, sourceCommit string, sync func(content []map[string]any) ([]any, *types.WrappedError)) *types.WrappedError {
+	hasPermission, err := d.UserHasDiagramEdittingPermissions(diagramId, userId)
+	if err != nil {
+		return err
+	}
+
+	if !hasPermission {
+		return types.Wrap(errors.New("user does not have permission to edit the diagram"), types.ErrInvalidRequest)
+	}
+
+	tx := d.getDb().Begin()
+
+	var diagram models.DiagramModel
+	result := tx.Raw("SELECT content FROM diagram_models WHERE id = ? FOR UPDATE;", diagramId).Scan(&diagram)
+	if result.Error != nil {
+		tx.Rollback()
+		return types.Wrap(result.Error, types.ErrInternalServerError)
+	}
+
+	// Scan does not fail when no row was found, unlike First
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return types.Wrap(errors.New("diagram not found"), types.ErrDiagramNotFound)
+	}
+
+	var content []map[string]any
+	if len(diagram.Content) != 0 {
+		if err := json.Unmarshal(diagram.Content, &content); err != nil {
+			tx.Rollback()
+			return types.Wrap(err, types.ErrInternalServerError)
+		}
+	}
+
+	syncedContent, err := sync(content)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	syncedContentJson, err2 := json.Marshal(syncedContent)
+	if err2 != nil {
+		tx.Rollback()
+		return types.Wrap(err2, types.ErrCouldNotMarshalJSON)
+	}
+
+	if err := tx.Model(&models.DiagramModel{}).
+		Where("id = ?", diagramId).
+		Updates(map[string]any{
+			"content":       models.DiagramContent(syncedContentJson),
+			"diagnostics":   models.DiagramDiagnostics(diagnostics),
+			"source_commit": sourceCommit,
+		}).Error; err != nil {
+		tx.Rollback()
+		return types.Wrap(err, types.ErrInternalServerError)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return types.Wrap(err, types.ErrInternalServerError)
+	}
+
+	return nil
+}
+
 func (d *Diagram_SDK) UpdateImage(diagramId, idToken string, image string) *types.WrappedError {
 	// Get the user id from the id token
 	userId, err := d.auth.Client.GetUserId(idToken)
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	transpilerTypes "github.com/junioryono/ProUML/backend/transpiler/types"
 	"github.com/lib/pq"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
@@ -63,6 +64,8 @@ type DiagramModel struct {
 	Name                   string                 `gorm:"default:'Untitled Diagram'" json:"name"`
 	Image                  string                 `json:"image,omitempty"`
 	Content                DiagramContent         `gorm:"type:jsonb;default:'[]';not null" json:"content"`
+	Diagnostics            DiagramDiagnostics     `gorm:"type:jsonb;default:'[]';not null" json:"diagnostics"`
+	SourceCommit           string                 `json:"source_commit,omitempty"`
 	ProjectID              string                 `gorm:"default:'default'" json:"project_id,omitempty"`
 	Project                *ProjectModel          `gorm:"foreignKey:ProjectID;references:ID" json:"project,omitempty"`
 	BackgroundColor        string                 `gorm:"default:FFFFFF" json:"background_color"`
@@ -107,6 +110,31 @@ func (DiagramContent) GormDBDataType(db *gorm.DB, field *schema.Field) string {
 	return "jsonb"
 }
 
+// Problems found while transpiling the project that the diagram was imported from
+type DiagramDiagnostics []transpilerTypes.Diagnostic
+
+// Scan scan value into Jsonb, implements sql.Scanner interface
+func (d *DiagramDiagnostics) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal JSONB value: %v", errors.New("type assertion .([]byte) failed"))
+	}
+
+	return json.Unmarshal(bytes, (*[]transpilerTypes.Diagnostic)(d))
+}
+
+// Value return json value, implement driver.Valuer interface
+func (d DiagramDiagnostics) Value() (driver.Value, error) {
+	if d == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]transpilerTypes.Diagnostic(d))
+}
+
+func (DiagramDiagnostics) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return "jsonb"
+}
+
 type DiagramUserRoleModel struct {
 	UserID    string       `gorm:"primaryKey" json:"-"`
 	DiagramID string       `gorm:"primaryKey" json:"-"`
@@ -0,0 +1,122 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/websocket/v2"
+	"github.com/junioryono/ProUML/backend/types"
+)
+
+// Import jobs are removed from Redis after this long, so that their status can still be read after they finish
+const importJobExpiration = 24 * time.Hour
+
+func getImportJobKey(jobId string) string {
+	return fmt.Sprintf("import:%s", jobId)
+}
+
+func getImportJobChannel(jobId string) string {
+	return fmt.Sprintf("import:%s:events", jobId)
+}
+
+// Save an import job and publish it to the websockets that listen to the job
+func (r *Redis_SDK) SetImportJob(job *types.ImportJob) error {
+	job.UpdatedAt = time.Now()
+
+	b, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	if err := r.client.Set(r.context, getImportJobKey(job.Id), b, importJobExpiration).Err(); err != nil {
+		return err
+	}
+
+	message, err := json.Marshal(types.WebSocketBody{
+		Events: "import_progress",
+		Import: job,
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.client.Publish(r.context, getImportJobChannel(job.Id), message).Err()
+}
+
+// Get an import job. Returns an error if the job does not exist or has expired.
+func (r *Redis_SDK) GetImportJob(jobId string) (*types.ImportJob, error) {
+	b, err := r.client.Get(r.context, getImportJobKey(jobId)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var job types.ImportJob
+	if err := json.Unmarshal(b, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// Send the current state of an import job and every update of it to the websocket.
+// Returns once the job is finished or the websocket is closed.
+func (r *Redis_SDK) SubscribeImportJob(jobId string, ws *websocket.Conn) {
+	ps := r.client.Subscribe(r.context, getImportJobChannel(jobId))
+	defer ps.Close()
+
+	// Wait for confirmation that subscription is created, so that no update is missed after reading the job
+	if _, err := ps.Receive(r.context); err != nil {
+		return
+	}
+
+	job, err := r.GetImportJob(jobId)
+	if err != nil {
+		return
+	}
+
+	if err := ws.WriteJSON(types.WebSocketBody{Events: "import_progress", Import: job}); err != nil || job.Finished() {
+		return
+	}
+
+	// The client does not send anything, reading only tells when the websocket is closed
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// Every message is written from this loop, since a websocket only supports one writer at a time
+	ping := time.NewTicker(30 * time.Second)
+	defer ping.Stop()
+
+	messages := ps.Channel()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ping.C:
+			if err := ws.WriteMessage(websocket.TextMessage, []byte("ping")); err != nil {
+				return
+			}
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			payload := types.WebSocketBody{}
+			if err := json.Unmarshal([]byte(msg.Payload), &payload); err != nil || payload.Import == nil {
+				continue
+			}
+
+			if err := ws.WriteJSON(payload); err != nil || payload.Import.Finished() {
+				return
+			}
+		}
+	}
+}
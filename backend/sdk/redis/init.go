@@ -181,3 +181,8 @@ func (r *Redis_SDK) Publish(channelId string, message interface{}) error {
 		return channel.publish(message)
 	}
 }
+
+// Publish a message to a channel, also when this server has no connections to it
+func (r *Redis_SDK) Broadcast(channelId string, message interface{}) error {
+	return r.client.Publish(r.context, channelId, message).Err()
+}
@@ -0,0 +1,180 @@
+package source
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+
+	httpTypes "github.com/junioryono/ProUML/backend/types"
+)
+
+// An archive format that projects can be uploaded in
+type Archive interface {
+	// Returns true when the upload is in this format. The header holds the first bytes of the upload.
+	Match(name string, header []byte) bool
+
+	// Calls visit for every entry of the archive in order. An entry can only be opened while visit runs.
+	// Walking stops at the first error that visit returns. An archive may be walked more than once.
+	// Archives fail with errUncompressedTooLarge once a walk uncompresses more than maxUncompressedSize bytes,
+	// and with ErrTooManyFiles when they have more than maxProjectFiles entries.
+	Walk(r io.ReaderAt, size int64, visit func(entry ArchiveEntry) *httpTypes.WrappedError) *httpTypes.WrappedError
+}
+
+// A file or directory of an archive. Symbolic links and other special files have their type in Mode.
+type ArchiveEntry struct {
+	Path string
+	Mode fs.FileMode
+	Size int64
+	Open func() (io.ReadCloser, error)
+}
+
+// The archive formats that uploaded projects are read with, in the order that they are matched
+var Archives = []Archive{
+	zipArchive{},
+	tarArchive{gzip: true},
+	tarArchive{},
+}
+
+type zipArchive struct{}
+
+func (zipArchive) Match(name string, header []byte) bool {
+	// An empty archive only has the end of the central directory
+	return bytes.HasPrefix(header, []byte("PK\x03\x04")) || bytes.HasPrefix(header, []byte("PK\x05\x06"))
+}
+
+func (zipArchive) Walk(r io.ReaderAt, size int64, visit func(entry ArchiveEntry) *httpTypes.WrappedError) *httpTypes.WrappedError {
+	// Entries are read from the upload when they are needed, instead of reading the whole archive into memory
+	zipReader, err := zip.NewReader(r, size)
+	if err != nil {
+		return httpTypes.Wrap(err, httpTypes.ErrInvalidArchive)
+	}
+
+	if len(zipReader.File) > maxProjectFiles {
+		return httpTypes.Wrap(errors.New("too many files"), httpTypes.ErrTooManyFiles)
+	}
+
+	// The sizes in the headers of a zip archive can be wrong, so the entries share a limit of uncompressed bytes like a gzip stream
+	remaining := maxUncompressedSize
+
+	for _, zipFile := range zipReader.File {
+		zipFile := zipFile
+		if err := visit(ArchiveEntry{
+			Path: zipFile.Name,
+			Mode: zipFile.Mode(),
+			Size: int64(zipFile.UncompressedSize64),
+			Open: func() (io.ReadCloser, error) {
+				f, err := zipFile.Open()
+				if err != nil {
+					return nil, err
+				}
+
+				return uncompressedReadCloser{&uncompressedReader{reader: f, remaining: &remaining}, f}, nil
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reads tar archives, and tar archives that are compressed with gzip when gzip is set
+type tarArchive struct {
+	gzip bool
+}
+
+func (archive tarArchive) Match(name string, header []byte) bool {
+	if archive.gzip {
+		return bytes.HasPrefix(header, []byte("\x1f\x8b"))
+	}
+
+	// Archives of old versions of tar do not have the magic of the ustar format
+	return (len(header) >= 262 && string(header[257:262]) == "ustar") || strings.HasSuffix(strings.ToLower(name), ".tar")
+}
+
+func (archive tarArchive) Walk(r io.ReaderAt, size int64, visit func(entry ArchiveEntry) *httpTypes.WrappedError) *httpTypes.WrappedError {
+	var reader io.Reader = io.NewSectionReader(r, 0, size)
+
+	if archive.gzip {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return httpTypes.Wrap(err, httpTypes.ErrInvalidArchive)
+		}
+
+		defer gzipReader.Close()
+
+		// A small archive can uncompress to a lot of data, so every byte that is uncompressed is counted
+		remaining := maxUncompressedSize
+		reader = &uncompressedReader{reader: gzipReader, remaining: &remaining}
+	}
+
+	tarReader := tar.NewReader(reader)
+	for files := 1; ; files++ {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		// The entries of a tar archive are only known once they are walked, unlike the central directory of a zip archive
+		if files > maxProjectFiles {
+			return httpTypes.Wrap(errors.New("too many files"), httpTypes.ErrTooManyFiles)
+		}
+
+		if errors.Is(err, errUncompressedTooLarge) {
+			return httpTypes.Wrap(err, httpTypes.ErrUncompressedTooLarge)
+		}
+
+		if err != nil {
+			return httpTypes.Wrap(err, httpTypes.ErrInvalidArchive)
+		}
+
+		// Hard links and the headers of extended attributes do not have a file type
+		mode := header.FileInfo().Mode()
+		if mode.IsRegular() && header.Typeflag != tar.TypeReg {
+			mode |= fs.ModeIrregular
+		}
+
+		if err := visit(ArchiveEntry{
+			Path: header.Name,
+			Mode: mode,
+			Size: header.Size,
+			Open: func() (io.ReadCloser, error) {
+				return io.NopCloser(tarReader), nil
+			},
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// Fails once more than the remaining bytes are read. Readers can share the remaining bytes.
+type uncompressedReader struct {
+	reader    io.Reader
+	remaining *int64
+}
+
+func (r *uncompressedReader) Read(p []byte) (int, error) {
+	if *r.remaining <= 0 {
+		return 0, errUncompressedTooLarge
+	}
+
+	if int64(len(p)) > *r.remaining {
+		p = p[:*r.remaining]
+	}
+
+	n, err := r.reader.Read(p)
+	*r.remaining -= int64(n)
+
+	return n, err
+}
+
+// Counts the bytes that are read from an entry of a zip archive
+type uncompressedReadCloser struct {
+	*uncompressedReader
+	io.Closer
+}
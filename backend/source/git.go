@@ -0,0 +1,278 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler"
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+	httpTypes "github.com/junioryono/ProUML/backend/types"
+)
+
+type gitTreeEntry struct {
+	path   string
+	object string
+	size   int64
+}
+
+// Returns the path of a repository on the server. Repositories can only be read from inside of the directory
+// that GIT_REPOSITORIES_ROOT points to, which is only set when ProUML is self-hosted.
+func ResolveGitRepositoryPath(path string) (string, *httpTypes.WrappedError) {
+	root := os.Getenv("GIT_REPOSITORIES_ROOT")
+	if root == "" {
+		return "", httpTypes.Wrap(errors.New("GIT_REPOSITORIES_ROOT is empty"), httpTypes.ErrGitPathNotAllowed)
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+
+	// Symbolic links are resolved first, so that they cannot point outside of the root
+	root, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", httpTypes.Wrap(err, httpTypes.ErrGitPathNotAllowed)
+	}
+
+	path, err = filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", httpTypes.Wrap(err, httpTypes.ErrGitRepositoryNotFound)
+	}
+
+	relativePath, err := filepath.Rel(root, path)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return "", httpTypes.Wrap(errors.New("repository is outside of GIT_REPOSITORIES_ROOT"), httpTypes.ErrGitPathNotAllowed)
+	}
+
+	return path, nil
+}
+
+// Reads the files of the commit that a ref points to. The ref can be a branch, a tag or a commit, and is HEAD when empty.
+// Files are read from the objects of the repository, so its working tree is never changed or read.
+// Symbolic links, submodules and IgnoredDirectories are skipped, and files that are not source files are returned without their code.
+func ReadGitRepository(ctx context.Context, repositoryPath, ref string) (*Snapshot, *httpTypes.WrappedError) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	// A ref that starts with a dash would be read as an option
+	if strings.HasPrefix(ref, "-") {
+		return nil, httpTypes.Wrap(errors.New("invalid ref "+ref), httpTypes.ErrGitRefNotFound)
+	}
+
+	output, err := runGit(ctx, repositoryPath, nil, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		if _, err := runGit(ctx, repositoryPath, nil, "rev-parse", "--git-dir"); err != nil {
+			return nil, httpTypes.Wrap(err, httpTypes.ErrGitRepositoryNotFound)
+		}
+
+		return nil, httpTypes.Wrap(err, httpTypes.ErrGitRefNotFound)
+	}
+
+	snapshot := Snapshot{Commit: strings.TrimSpace(string(output))}
+
+	entries, err := getGitTree(ctx, repositoryPath, snapshot.Commit)
+	if err != nil {
+		return nil, httpTypes.Wrap(err, httpTypes.ErrGitRepositoryNotFound)
+	}
+
+	var (
+		sourceEntries []gitTreeEntry
+		sourceFiles   []int
+		sourceSize    int64
+	)
+
+	for _, entry := range entries {
+		// Dependencies and build output that were committed are skipped like in an uploaded project
+		file, ok := types.NewFile(entry.path)
+		if !ok || isInIgnoredDirectory(entry.path) {
+			continue
+		}
+
+		// Files that are not parsed, such as images, are only counted
+		if !transpiler.IsSupportedFile(file) {
+			snapshot.Files = append(snapshot.Files, file)
+			continue
+		}
+
+		if entry.size > transpiler.MaxSourceFileSize {
+			snapshot.Diagnostics = append(snapshot.Diagnostics, types.Diagnostic{
+				Severity: types.DiagnosticWarning,
+				Path:     entry.path,
+				Message:  "file is larger than 4MB and was not parsed",
+			})
+			continue
+		}
+
+		if sourceSize += entry.size; sourceSize > maxSourceSize {
+			snapshot.Diagnostics = append(snapshot.Diagnostics, types.Diagnostic{
+				Severity: types.DiagnosticWarning,
+				Path:     entry.path,
+				Message:  "the source files of the repository are larger than 100MB, this file and the files after it were not parsed",
+			})
+			break
+		}
+
+		sourceEntries = append(sourceEntries, entry)
+		sourceFiles = append(sourceFiles, len(snapshot.Files))
+		snapshot.Files = append(snapshot.Files, file)
+	}
+
+	code, err := readGitObjects(ctx, repositoryPath, sourceEntries)
+	if err != nil {
+		return nil, httpTypes.Wrap(err, httpTypes.ErrGitRepositoryNotFound)
+	}
+
+	for i, fileIndex := range sourceFiles {
+		snapshot.Files[fileIndex].Code = code[i]
+	}
+
+	return &snapshot, nil
+}
+
+// Reads a commit of a Git bundle, see ReadGitRepository. The bundle is cloned into a temporary repository,
+// so it must contain the whole history of the commit.
+func ReadGitBundle(ctx context.Context, bundle io.Reader, ref string) (*Snapshot, *httpTypes.WrappedError) {
+	dir, err := os.MkdirTemp("", "prouml-bundle-")
+	if err != nil {
+		return nil, httpTypes.Wrap(err, httpTypes.ErrInternalServerError)
+	}
+
+	defer os.RemoveAll(dir)
+
+	bundlePath := filepath.Join(dir, "project.bundle")
+	f, err := os.Create(bundlePath)
+	if err != nil {
+		return nil, httpTypes.Wrap(err, httpTypes.ErrInternalServerError)
+	}
+
+	_, err = io.Copy(f, bundle)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return nil, httpTypes.Wrap(err, httpTypes.ErrInternalServerError)
+	}
+
+	repositoryPath := filepath.Join(dir, "repository")
+	if _, err := runGit(ctx, dir, nil, "clone", "--mirror", "--quiet", bundlePath, repositoryPath); err != nil {
+		return nil, httpTypes.Wrap(err, httpTypes.ErrGitInvalidBundle)
+	}
+
+	return ReadGitRepository(ctx, repositoryPath, ref)
+}
+
+// Runs a Git command in the directory. The error holds what Git wrote to stderr.
+func runGit(ctx context.Context, dir string, stdin io.Reader, args ...string) ([]byte, error) {
+	// Repositories on the server can belong to another user than the one that runs ProUML
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-c", "safe.directory=*"}, args...)...)
+	cmd.Dir = dir
+	cmd.Stdin = stdin
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_CONFIG_NOSYSTEM=1")
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], message)
+		}
+
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+
+	return output, nil
+}
+
+// Lists the files of a commit with their size
+func getGitTree(ctx context.Context, repositoryPath, commit string) ([]gitTreeEntry, error) {
+	output, err := runGit(ctx, repositoryPath, nil, "ls-tree", "-r", "-z", "--long", "--full-tree", commit)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []gitTreeEntry
+	for _, line := range bytes.Split(output, []byte{0}) {
+		// <mode> <type> <object> <size>\t<path>
+		info, path, found := bytes.Cut(line, []byte("\t"))
+		fields := bytes.Fields(info)
+		if !found || len(fields) != 4 {
+			continue
+		}
+
+		// Symbolic links are blobs too, submodules are commits
+		if string(fields[1]) != "blob" || string(fields[0]) == "120000" {
+			continue
+		}
+
+		size, err := strconv.ParseInt(string(fields[3]), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, gitTreeEntry{path: string(path), object: string(fields[2]), size: size})
+	}
+
+	return entries, nil
+}
+
+// Reads the content of the files with one Git process
+func readGitObjects(ctx context.Context, repositoryPath string, entries []gitTreeEntry) ([][]byte, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	var objects strings.Builder
+	for _, entry := range entries {
+		objects.WriteString(entry.object + "\n")
+	}
+
+	output, err := runGit(ctx, repositoryPath, strings.NewReader(objects.String()), "cat-file", "--batch")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		response = make([][]byte, len(entries))
+		reader   = bufio.NewReader(bytes.NewReader(output))
+	)
+
+	// Every object is written as "<object> <type> <size>\n<content>\n"
+	for i := range entries {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			return nil, errors.New("git cat-file: " + strings.TrimSpace(header))
+		}
+
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, err
+		}
+
+		response[i] = make([]byte, size)
+		if _, err := io.ReadFull(reader, response[i]); err != nil {
+			return nil, err
+		}
+
+		if _, err := reader.Discard(1); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,242 @@
+package source
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	httpTypes "github.com/junioryono/ProUML/backend/types"
+)
+
+// Runs a Git command in a test repository and returns what it printed
+func git(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", append([]string{"-c", "user.name=ProUML", "-c", "user.email=test@prouml.com", "-c", "init.defaultBranch=main"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_CONFIG_NOSYSTEM=1", "GIT_CONFIG_GLOBAL="+os.DevNull)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %s\n%s", strings.Join(args, " "), err.Error(), output)
+	}
+
+	return strings.TrimSpace(string(output))
+}
+
+// Writes the files into the repository and commits them
+func commit(t *testing.T, dir string, files map[string]string) string {
+	for path, code := range files {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git(t, dir, "add", "-A")
+	git(t, dir, "commit", "--quiet", "-m", "Update")
+
+	return git(t, dir, "rev-parse", "HEAD")
+}
+
+// Creates a repository with a few commits on main and a tag on the first one
+func createTestRepository(t *testing.T) (dir string, first string, second string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir = t.TempDir()
+	git(t, dir, "init", "--quiet")
+
+	first = commit(t, dir, map[string]string{
+		"src/shop/Order.java": "package shop;\n\npublic class Order {\n}\n",
+		"README.md":           "# Shop\n",
+	})
+	git(t, dir, "tag", "v1")
+
+	second = commit(t, dir, map[string]string{
+		"src/shop/Invoice.java": "package shop;\n\npublic class Invoice {\n}\n",
+	})
+
+	if err := os.Symlink("Order.java", filepath.Join(dir, "src/shop/Link.java")); err != nil {
+		t.Fatal(err)
+	}
+
+	second = commit(t, dir, nil)
+	return dir, first, second
+}
+
+func describeSnapshot(snapshot *Snapshot) string {
+	var files []string
+	for _, file := range snapshot.Files {
+		description := file.Path
+		if file.Code != nil {
+			description += " " + strconv.Itoa(len(file.Code))
+		}
+
+		files = append(files, description)
+	}
+
+	sort.Strings(files)
+	return strings.Join(files, ", ")
+}
+
+func TestReadGitRepository(t *testing.T) {
+	dir, first, second := createTestRepository(t)
+
+	// Changes that are not committed are not read
+	if err := os.WriteFile(filepath.Join(dir, "src/shop/Order.java"), []byte("package shop;\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		ref      string
+		commit   string
+		expected string
+		err      string
+	}{
+		{
+			ref:      "",
+			commit:   second,
+			expected: "README.md, src/shop/Invoice.java 40, src/shop/Order.java 38",
+		},
+		{
+			ref:      "main",
+			commit:   second,
+			expected: "README.md, src/shop/Invoice.java 40, src/shop/Order.java 38",
+		},
+		{
+			ref:      "v1",
+			commit:   first,
+			expected: "README.md, src/shop/Order.java 38",
+		},
+		{
+			ref:      first,
+			commit:   first,
+			expected: "README.md, src/shop/Order.java 38",
+		},
+		{
+			ref: "missing",
+			err: httpTypes.ErrGitRefNotFound,
+		},
+		{
+			ref: "--output=/tmp/prouml",
+			err: httpTypes.ErrGitRefNotFound,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run("Test index "+strconv.Itoa(i), func(subtest *testing.T) {
+			snapshot, err := ReadGitRepository(context.Background(), dir, test.ref)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					subtest.Errorf("incorrect error.\nexpected:\n%s\ngot:\n%v\n", test.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				subtest.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if snapshot.Commit != test.commit {
+				subtest.Errorf("incorrect commit.\nexpected:\n%s\ngot:\n%s\n", test.commit, snapshot.Commit)
+			}
+
+			if actual := describeSnapshot(snapshot); actual != test.expected {
+				subtest.Errorf("incorrect files.\nexpected:\n%s\ngot:\n%s\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestReadGitBundle(t *testing.T) {
+	dir, first, _ := createTestRepository(t)
+
+	bundlePath := filepath.Join(t.TempDir(), "project.bundle")
+	git(t, dir, "bundle", "create", "--quiet", bundlePath, "--all")
+
+	bundle, err := os.Open(bundlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer bundle.Close()
+
+	snapshot, err2 := ReadGitBundle(context.Background(), bundle, "v1")
+	if err2 != nil {
+		t.Fatalf("unexpected error: %s", err2.Error())
+	}
+
+	if snapshot.Commit != first {
+		t.Errorf("incorrect commit.\nexpected:\n%s\ngot:\n%s\n", first, snapshot.Commit)
+	}
+
+	if actual, expected := describeSnapshot(snapshot), "README.md, src/shop/Order.java 38"; actual != expected {
+		t.Errorf("incorrect files.\nexpected:\n%s\ngot:\n%s\n", expected, actual)
+	}
+
+	if _, err := ReadGitBundle(context.Background(), strings.NewReader("not a bundle"), ""); err == nil || err.Error() != httpTypes.ErrGitInvalidBundle {
+		t.Errorf("incorrect error.\nexpected:\n%s\ngot:\n%v\n", httpTypes.ErrGitInvalidBundle, err)
+	}
+}
+
+func TestResolveGitRepositoryPath(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+
+	if err := os.Mkdir(filepath.Join(root, "shop"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ResolveGitRepositoryPath("shop"); err == nil || err.Error() != httpTypes.ErrGitPathNotAllowed {
+		t.Errorf("repository path was allowed without GIT_REPOSITORIES_ROOT")
+	}
+
+	t.Setenv("GIT_REPOSITORIES_ROOT", root)
+
+	tests := []struct {
+		path string
+		err  string
+	}{
+		{path: "shop"},
+		{path: filepath.Join(root, "shop")},
+		{path: "../" + filepath.Base(outside), err: httpTypes.ErrGitPathNotAllowed},
+		{path: outside, err: httpTypes.ErrGitPathNotAllowed},
+		{path: "link", err: httpTypes.ErrGitPathNotAllowed},
+		{path: "missing", err: httpTypes.ErrGitRepositoryNotFound},
+	}
+
+	for i, test := range tests {
+		t.Run("Test index "+strconv.Itoa(i), func(subtest *testing.T) {
+			path, err := ResolveGitRepositoryPath(test.path)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					subtest.Errorf("incorrect error.\nexpected:\n%s\ngot:\n%v\n", test.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				subtest.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if expected, _ := filepath.EvalSymlinks(filepath.Join(root, "shop")); path != expected {
+				subtest.Errorf("incorrect path.\nexpected:\n%s\ngot:\n%s\n", expected, path)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package source
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Directories that hold dependencies or build output. Their files are never imported, even without a .gitignore file.
+var IgnoredDirectories = []string{
+	".git", ".gradle", ".idea", ".vs", "__pycache__",
+	"bin", "build", "dist", "node_modules", "obj", "out", "target", "vendor",
+}
+
+// Returns true when one of the parent directories of the path is in IgnoredDirectories
+func isInIgnoredDirectory(filePath string) bool {
+	directories := strings.Split(filePath, "/")
+	for _, directory := range directories[:len(directories)-1] {
+		for _, ignoredDirectory := range IgnoredDirectories {
+			if directory == ignoredDirectory {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// The patterns of the .gitignore files of a project
+type ignoreRules []ignoreRule
+
+type ignoreRule struct {
+	base     string // Directory of the .gitignore file, empty for the root of the project
+	pattern  *regexp.Regexp
+	negate   bool
+	dirOnly  bool
+	anchored bool // Anchored patterns are matched against the path from the base, others against the name only
+}
+
+// Adds the patterns of a .gitignore file in the base directory
+func (rules *ignoreRules) add(base string, gitignore []byte) {
+	if base == "." {
+		base = ""
+	}
+
+	for _, line := range strings.Split(string(gitignore), "\n") {
+		line = strings.TrimRight(line, "\r ")
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		rule := ignoreRule{base: base}
+		if line[0] == '!' {
+			rule.negate = true
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+
+		// A slash at the start or in the middle anchors the pattern to the directory of the .gitignore file
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+
+		if line == "" {
+			continue
+		}
+
+		pattern, err := regexp.Compile("^" + globToRegexp(line) + "$")
+		if err != nil {
+			continue
+		}
+
+		rule.pattern = pattern
+		*rules = append(*rules, rule)
+	}
+
+	// The patterns of a .gitignore file in a subdirectory take precedence over the ones of its parents
+	sort.SliceStable(*rules, func(i, j int) bool {
+		return getPathDepth((*rules)[i].base) < getPathDepth((*rules)[j].base)
+	})
+}
+
+// Returns true when the file or one of its parent directories is ignored.
+// Like Git, a file cannot be included again by a negated pattern once its directory is ignored.
+func (rules ignoreRules) matches(filePath string) bool {
+	if len(rules) == 0 {
+		return false
+	}
+
+	elements := strings.Split(filePath, "/")
+	for i := range elements {
+		if rules.matchesPath(strings.Join(elements[:i+1], "/"), i < len(elements)-1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The last pattern that matches the path decides whether it is ignored
+func (rules ignoreRules) matchesPath(filePath string, isDir bool) bool {
+	ignored := false
+	for _, rule := range rules {
+		relativePath := filePath
+		if rule.base != "" {
+			if !strings.HasPrefix(filePath, rule.base+"/") {
+				continue
+			}
+
+			relativePath = filePath[len(rule.base)+1:]
+		}
+
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		if !rule.anchored {
+			relativePath = path.Base(relativePath)
+		}
+
+		if rule.pattern.MatchString(relativePath) {
+			ignored = !rule.negate
+		}
+	}
+
+	return ignored
+}
+
+func getPathDepth(filePath string) int {
+	if filePath == "" {
+		return 0
+	}
+
+	return strings.Count(filePath, "/") + 1
+}
+
+// Converts a .gitignore pattern to a regular expression
+func globToRegexp(glob string) string {
+	var response strings.Builder
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			switch {
+			case strings.HasPrefix(glob[i:], "**/"):
+				// Zero or more directories
+				response.WriteString("(?:.*/)?")
+				i += 2
+			case glob[i:] == "**":
+				response.WriteString(".*")
+				i++
+			default:
+				response.WriteString("[^/]*")
+			}
+		case '?':
+			response.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end == -1 {
+				response.WriteString(`\[`)
+				continue
+			}
+
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			response.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				response.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+			}
+		default:
+			response.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return response.String()
+}
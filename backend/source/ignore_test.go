@@ -0,0 +1,44 @@
+package source
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestIgnoreRules(t *testing.T) {
+	var rules ignoreRules
+	rules.add(".", []byte("# Build output\n*.class\n/generated/\nlogs/\n!logs/keep.java\ndocs/**/*.java\ncache?\n\n"))
+	rules.add("app", []byte("*.gen.ts\n!Keep.gen.ts\n/local.ts\n"))
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "Main.java", expected: false},
+		{path: "Main.class", expected: true},
+		{path: "shop/Order.class", expected: true},
+		{path: "generated/Api.java", expected: true},
+		{path: "shop/generated/Api.java", expected: false},
+		{path: "logs/Log.java", expected: true},
+		{path: "shop/logs/Log.java", expected: true},
+		{path: "logs/keep.java", expected: true}, // Its directory is ignored
+		{path: "docs/Guide.java", expected: true},
+		{path: "docs/a/b/Guide.java", expected: true},
+		{path: "cache1/Cache.java", expected: true},
+		{path: "cache12/Cache.java", expected: false},
+		{path: "app/api.gen.ts", expected: true},
+		{path: "app/src/api.gen.ts", expected: true},
+		{path: "app/Keep.gen.ts", expected: false},
+		{path: "api.gen.ts", expected: false},
+		{path: "app/local.ts", expected: true},
+		{path: "app/src/local.ts", expected: false},
+	}
+
+	for i, test := range tests {
+		t.Run("Test index "+strconv.Itoa(i), func(subtest *testing.T) {
+			if actual := rules.matches(test.path); actual != test.expected {
+				subtest.Errorf("incorrect match for %s.\nexpected:\n%t\ngot:\n%t\n", test.path, test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package source
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler"
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+	httpTypes "github.com/junioryono/ProUML/backend/types"
+)
+
+// The source files of a project are not read beyond this size
+const maxSourceSize = 100 * 1024 * 1024
+
+var (
+	// Archives are not uncompressed beyond this size, so that a small upload cannot fill the memory of the server
+	maxUncompressedSize int64 = 1024 * 1024 * 1024
+
+	// Projects with more files than this are rejected
+	maxProjectFiles = 100000
+
+	errUncompressedTooLarge = errors.New("archive is too large once it is uncompressed")
+)
+
+// The files of an imported project. Commit is set when the files were read from a Git repository.
+type Snapshot struct {
+	Commit      string
+	Files       []types.File
+	Diagnostics []types.Diagnostic
+}
+
+// Reads an uploaded project. The project is read with the first archive format in Archives that matches it,
+// or as a single source file when none does.
+func ReadProject(r io.ReaderAt, size int64, name string) (*Snapshot, *httpTypes.WrappedError) {
+	header := make([]byte, 512)
+	n, err := r.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return nil, httpTypes.Wrap(err, httpTypes.ErrInvalidArchive)
+	}
+
+	header = header[:n]
+
+	for _, archive := range Archives {
+		if !archive.Match(name, header) {
+			continue
+		}
+
+		// The .gitignore files are read first, so that the files that they ignore are never read
+		var builder snapshotBuilder
+		if err := archive.Walk(r, size, builder.addIgnores); err != nil {
+			return nil, err
+		}
+
+		if err := archive.Walk(r, size, builder.add); err != nil {
+			return nil, err
+		}
+
+		return &builder.snapshot, nil
+	}
+
+	if file, ok := types.NewFile(name); !ok || !transpiler.IsSupportedExtension(file.Extension) {
+		return nil, httpTypes.Wrap(errors.New("unsupported project "+name), httpTypes.ErrUnsupportedProject)
+	}
+
+	return ReadFiles([]ArchiveEntry{{
+		Path: name,
+		Size: size,
+		Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(io.NewSectionReader(r, 0, size)), nil
+		},
+	}})
+}
+
+// Reads files that were uploaded one by one, as if they were the entries of an archive
+func ReadFiles(files []ArchiveEntry) (*Snapshot, *httpTypes.WrappedError) {
+	var builder snapshotBuilder
+	for _, file := range files {
+		if err := builder.addIgnores(file); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range files {
+		if err := builder.add(file); err != nil {
+			return nil, err
+		}
+	}
+
+	return &builder.snapshot, nil
+}
+
+// Collects the files of a project while they are read
+type snapshotBuilder struct {
+	snapshot   Snapshot
+	ignores    ignoreRules
+	files      int
+	sourceSize int64
+	full       bool // Set once the source files are larger than maxSourceSize
+}
+
+// Reads the rules of an entry that is a .gitignore file. The rules must be known before the other entries are added.
+func (builder *snapshotBuilder) addIgnores(entry ArchiveEntry) *httpTypes.WrappedError {
+	filePath, ok := cleanPath(entry.Path)
+	if !ok || !entry.Mode.IsRegular() || path.Base(filePath) != ".gitignore" || isInIgnoredDirectory(filePath) {
+		return nil
+	}
+
+	gitignore, err := readEntry(entry)
+	if err != nil {
+		return builder.addReadError(filePath, err)
+	}
+
+	builder.ignores.add(path.Dir(filePath), gitignore)
+	return nil
+}
+
+// Adds an entry of the project. Returns an error when the project must be rejected.
+// Entries that cannot be imported, like symbolic links, are skipped with a diagnostic.
+// Entries that the .gitignore files of the project ignore are skipped before they are read or counted.
+func (builder *snapshotBuilder) add(entry ArchiveEntry) *httpTypes.WrappedError {
+	filePath, ok := cleanPath(entry.Path)
+	if !ok {
+		return httpTypes.Wrap(errors.New("unsafe path "+entry.Path), httpTypes.ErrUnsafeArchivePath)
+	}
+
+	if builder.files++; builder.files > maxProjectFiles {
+		return httpTypes.Wrap(errors.New("too many files"), httpTypes.ErrTooManyFiles)
+	}
+
+	if entry.Mode.IsDir() || filePath == "" || isInIgnoredDirectory(filePath) || builder.ignores.matches(filePath) {
+		return nil
+	}
+
+	if !entry.Mode.IsRegular() {
+		builder.snapshot.Diagnostics = append(builder.snapshot.Diagnostics, types.Diagnostic{
+			Severity: types.DiagnosticWarning,
+			Path:     filePath,
+			Message:  "symbolic links and special files are not imported",
+		})
+		return nil
+	}
+
+	// The rules of .gitignore files were read by addIgnores
+	if path.Base(filePath) == ".gitignore" {
+		return nil
+	}
+
+	file, ok := types.NewFile(filePath)
+	if !ok {
+		return nil
+	}
+
+	// Files that are not parsed, such as images, are only counted
+	if !transpiler.IsSupportedFile(file) {
+		builder.snapshot.Files = append(builder.snapshot.Files, file)
+		return nil
+	}
+
+	if entry.Size > transpiler.MaxSourceFileSize {
+		builder.snapshot.Diagnostics = append(builder.snapshot.Diagnostics, types.Diagnostic{
+			Severity: types.DiagnosticWarning,
+			Path:     filePath,
+			Message:  "file is larger than 4MB and was not parsed",
+		})
+		return nil
+	}
+
+	if builder.full {
+		return nil
+	}
+
+	if builder.sourceSize+entry.Size > maxSourceSize {
+		builder.full = true
+		builder.snapshot.Diagnostics = append(builder.snapshot.Diagnostics, types.Diagnostic{
+			Severity: types.DiagnosticWarning,
+			Path:     filePath,
+			Message:  "the source files of the project are larger than 100MB, this file and the files after it were not parsed",
+		})
+		return nil
+	}
+
+	code, err := readEntry(entry)
+	if err != nil {
+		return builder.addReadError(filePath, err)
+	}
+
+	// The size of an entry can be wrong, so the code that was read is counted
+	builder.sourceSize += int64(len(code))
+	file.Code = code
+	builder.snapshot.Files = append(builder.snapshot.Files, file)
+
+	return nil
+}
+
+// Files that cannot be read are reported, unless the whole archive is too large
+func (builder *snapshotBuilder) addReadError(filePath string, err error) *httpTypes.WrappedError {
+	if errors.Is(err, errUncompressedTooLarge) {
+		return httpTypes.Wrap(err, httpTypes.ErrUncompressedTooLarge)
+	}
+
+	builder.snapshot.Diagnostics = append(builder.snapshot.Diagnostics, types.Diagnostic{
+		Severity: types.DiagnosticError,
+		Path:     filePath,
+		Message:  "could not read file: " + err.Error(),
+	})
+
+	return nil
+}
+
+func readEntry(entry ArchiveEntry) ([]byte, error) {
+	f, err := entry.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	// The size in the header of an entry can be wrong, so the reader stops after the limit too
+	code, err := io.ReadAll(io.LimitReader(f, transpiler.MaxSourceFileSize+1))
+	if err == nil && len(code) > transpiler.MaxSourceFileSize {
+		return nil, errors.New("file is larger than 4MB")
+	}
+
+	return code, err
+}
+
+// Returns the path of an entry relative to the root of the project.
+// Returns false when the path is absolute or leaves the root, so that no entry can pretend to be outside of the project.
+func cleanPath(name string) (string, bool) {
+	// Archives that were created on Windows can use backslashes
+	name = strings.ReplaceAll(name, `\`, "/")
+
+	if strings.HasPrefix(name, "/") || (len(name) >= 2 && name[1] == ':') {
+		return "", false
+	}
+
+	for _, element := range strings.Split(name, "/") {
+		if element == ".." {
+			return "", false
+		}
+	}
+
+	name = path.Clean(name)
+	if name == "." {
+		return "", true
+	}
+
+	return name, fs.ValidPath(name)
+}
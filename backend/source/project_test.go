@@ -0,0 +1,285 @@
+package source
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	httpTypes "github.com/junioryono/ProUML/backend/types"
+)
+
+type testEntry struct {
+	path    string
+	code    string
+	symlink bool
+}
+
+var testProject = []testEntry{
+	{path: "shop/"},
+	{path: "shop/.gitignore", code: "*.gen.java\n"},
+	{path: "shop/src/Order.java", code: "package shop;\n"},
+	{path: "shop/src/Order.gen.java", code: "package shop;\n"},
+	{path: "shop/logo.png", code: "png"},
+	{path: "shop/node_modules/lib/index.js", code: "module.exports = {};\n"},
+	{path: "shop/target/classes/Order.java", code: "package shop;\n"},
+}
+
+func createTestZip(t *testing.T, entries []testEntry) []byte {
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.path, Method: zip.Deflate}
+		if entry.symlink {
+			header.SetMode(fs.ModeSymlink | 0o777)
+		}
+
+		w, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w.Write([]byte(entry.code))
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+func createTestTar(t *testing.T, entries []testEntry, compress bool) []byte {
+	var buffer bytes.Buffer
+
+	var gzipWriter *gzip.Writer
+	tarWriter := tar.NewWriter(&buffer)
+	if compress {
+		gzipWriter = gzip.NewWriter(&buffer)
+		tarWriter = tar.NewWriter(gzipWriter)
+	}
+
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.path, Mode: 0o644, Size: int64(len(entry.code)), Typeflag: tar.TypeReg}
+		switch {
+		case entry.symlink:
+			header.Typeflag, header.Linkname, header.Size = tar.TypeSymlink, entry.code, 0
+		case strings.HasSuffix(entry.path, "/"):
+			header.Typeflag = tar.TypeDir
+		}
+
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+
+		if header.Typeflag == tar.TypeReg {
+			tarWriter.Write([]byte(entry.code))
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if compress {
+		if err := gzipWriter.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return buffer.Bytes()
+}
+
+func describeProject(snapshot *Snapshot) string {
+	var files []string
+	for _, file := range snapshot.Files {
+		description := file.Path
+		if file.Code != nil {
+			description += " " + strconv.Itoa(len(file.Code))
+		}
+
+		files = append(files, description)
+	}
+
+	for _, diagnostic := range snapshot.Diagnostics {
+		files = append(files, diagnostic.Path+": "+diagnostic.Message)
+	}
+
+	sort.Strings(files)
+	return strings.Join(files, ", ")
+}
+
+func TestReadProject(t *testing.T) {
+	unsafeProject := []testEntry{{path: "shop/Order.java", code: "package shop;\n"}, {path: "../Order.java", code: "package shop;\n"}}
+	linkProject := []testEntry{{path: "Order.java", code: "package shop;\n"}, {path: "Link.java", code: "/etc/passwd", symlink: true}}
+
+	tests := []struct {
+		name     string
+		project  []byte
+		expected string
+		err      string
+	}{
+		{
+			name:     "shop.zip",
+			project:  createTestZip(t, testProject),
+			expected: "shop/logo.png, shop/src/Order.java 14",
+		},
+		{
+			name:     "shop.tar",
+			project:  createTestTar(t, testProject, false),
+			expected: "shop/logo.png, shop/src/Order.java 14",
+		},
+		{
+			name:     "shop.tar.gz",
+			project:  createTestTar(t, testProject, true),
+			expected: "shop/logo.png, shop/src/Order.java 14",
+		},
+		{
+			// Browsers do not know every archive type, so the name does not matter
+			name:     "shop",
+			project:  createTestTar(t, testProject, true),
+			expected: "shop/logo.png, shop/src/Order.java 14",
+		},
+		{
+			name:     "Order.java",
+			project:  []byte("package shop;\n"),
+			expected: "Order.java 14",
+		},
+		{
+			name:    "shop.rar",
+			project: []byte("Rar!\x1a\x07\x00"),
+			err:     httpTypes.ErrUnsupportedProject,
+		},
+		{
+			name:    "shop.zip",
+			project: createTestZip(t, unsafeProject),
+			err:     httpTypes.ErrUnsafeArchivePath,
+		},
+		{
+			name:    "shop.tar.gz",
+			project: createTestTar(t, unsafeProject, true),
+			err:     httpTypes.ErrUnsafeArchivePath,
+		},
+		{
+			name:    "shop.zip",
+			project: createTestZip(t, []testEntry{{path: "/etc/Order.java", code: "package shop;\n"}}),
+			err:     httpTypes.ErrUnsafeArchivePath,
+		},
+		{
+			name:    "shop.zip",
+			project: createTestZip(t, []testEntry{{path: `..\Order.java`, code: "package shop;\n"}}),
+			err:     httpTypes.ErrUnsafeArchivePath,
+		},
+		{
+			name:     "shop.zip",
+			project:  createTestZip(t, linkProject),
+			expected: "Link.java: symbolic links and special files are not imported, Order.java 14",
+		},
+		{
+			name:     "shop.tgz",
+			project:  createTestTar(t, linkProject, true),
+			expected: "Link.java: symbolic links and special files are not imported, Order.java 14",
+		},
+		{
+			name:    "shop.tgz",
+			project: []byte("\x1f\x8bnot gzip"),
+			err:     httpTypes.ErrInvalidArchive,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run("Test index "+strconv.Itoa(i), func(subtest *testing.T) {
+			snapshot, err := ReadProject(bytes.NewReader(test.project), int64(len(test.project)), test.name)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					subtest.Errorf("incorrect error.\nexpected:\n%s\ngot:\n%v\n", test.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				subtest.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			if actual := describeProject(snapshot); actual != test.expected {
+				subtest.Errorf("incorrect files.\nexpected:\n%s\ngot:\n%s\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestReadProjectDecompressionBomb(t *testing.T) {
+	defer func(size int64) { maxUncompressedSize = size }(maxUncompressedSize)
+	maxUncompressedSize = 64 * 1024
+
+	// Zeros compress very well, so the archives are much smaller than the limit.
+	// Only the source files of a zip archive are uncompressed, so the zeros are a source file.
+	entries := []testEntry{
+		{path: "Order.java", code: "package shop;\n"},
+		{path: "Zeros.java", code: string(make([]byte, 1024*1024))},
+	}
+
+	tests := []struct {
+		name    string
+		project []byte
+	}{
+		{name: "shop.tar.gz", project: createTestTar(t, entries, true)},
+		{name: "shop.zip", project: createTestZip(t, entries)},
+	}
+
+	for i, test := range tests {
+		t.Run("Test index "+strconv.Itoa(i), func(subtest *testing.T) {
+			if len(test.project) >= int(maxUncompressedSize) {
+				subtest.Fatalf("archive is not smaller than the limit: %d bytes", len(test.project))
+			}
+
+			if _, err := ReadProject(bytes.NewReader(test.project), int64(len(test.project)), test.name); err == nil || err.Error() != httpTypes.ErrUncompressedTooLarge {
+				subtest.Errorf("incorrect error.\nexpected:\n%s\ngot:\n%v\n", httpTypes.ErrUncompressedTooLarge, err)
+			}
+		})
+	}
+}
+
+func TestReadFilesIgnoresBeforeReading(t *testing.T) {
+	var opened []string
+	entry := func(filePath, code string, mode fs.FileMode) ArchiveEntry {
+		return ArchiveEntry{
+			Path: filePath,
+			Mode: mode,
+			Size: int64(len(code)),
+			Open: func() (io.ReadCloser, error) {
+				opened = append(opened, filePath)
+				return io.NopCloser(strings.NewReader(code)), nil
+			},
+		}
+	}
+
+	// The .gitignore file comes after the files that it ignores
+	snapshot, err := ReadFiles([]ArchiveEntry{
+		entry("shop/Order.java", "package shop;\n", 0),
+		entry("shop/Order.gen.java", "package shop;\n", 0),
+		entry("shop/generated/Invoice.java", "package shop;\n", 0),
+		entry("shop/generated/Link.java", "", fs.ModeSymlink),
+		entry("shop/.gitignore", "*.gen.java\ngenerated/\n", 0),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if actual := describeProject(snapshot); actual != "shop/Order.java 14" {
+		t.Errorf("incorrect files.\nexpected:\n%s\ngot:\n%s\n", "shop/Order.java 14", actual)
+	}
+
+	sort.Strings(opened)
+	if actual := strings.Join(opened, ", "); actual != "shop/.gitignore, shop/Order.java" {
+		t.Errorf("incorrect opened files.\nexpected:\n%s\ngot:\n%s\n", "shop/.gitignore, shop/Order.java", actual)
+	}
+}
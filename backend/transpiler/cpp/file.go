@@ -0,0 +1,1066 @@
+package cpp
+
+import (
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+// Classes declared outside of a namespace belong to the global namespace
+const globalNamespace = "default"
+
+type fileResponse struct {
+	Usings       []string          // Namespaces imported with using directives
+	Declarations map[string]string // using zoo::Dog; The key is the name and the value is the qualified name
+	Data         []any             // Holds [](JavaAbstract | JavaClass | JavaEnum)
+	Definitions  []definition      // Member functions defined outside of their class
+}
+
+// A member function defined outside of its class: void zoo::Dog::bark() { ... }
+type definition struct {
+	Namespace  string
+	Class      string // The qualified class name without template arguments
+	Name       string
+	Parameters int
+	Body       []byte
+}
+
+var memberModifiers = map[string]struct{}{
+	"virtual": {}, "static": {}, "inline": {}, "explicit": {}, "constexpr": {}, "consteval": {}, "constinit": {},
+	"mutable": {}, "extern": {}, "thread_local": {}, "register": {},
+}
+
+var builtinTypes = map[string]struct{}{
+	"void": {}, "bool": {}, "char": {}, "wchar_t": {}, "char8_t": {}, "char16_t": {}, "char32_t": {}, "short": {}, "int": {},
+	"long": {}, "float": {}, "double": {}, "signed": {}, "unsigned": {}, "auto": {},
+}
+
+var classKeys = map[string]struct{}{
+	"class": {}, "struct": {}, "union": {}, "enum": {},
+}
+
+func parseFile(file types.File) fileResponse {
+	response := fileResponse{Declarations: make(map[string]string)}
+
+	parseScope(tokenize(file.Code), "", &response)
+
+	return response
+}
+
+// Parses the namespaces, classes and function definitions of the global scope or of a namespace
+func parseScope(tokens []token, namespace string, response *fileResponse) {
+	var (
+		templateParameters string
+		isTemplate         bool
+	)
+
+	for i := 0; i < len(tokens); {
+		text := tokens[i].Text
+
+		switch {
+		case text == ";":
+			i++
+			continue
+		case text == "template" && isText(tokens, i+1, "<"):
+			parameters, end := getAngles(tokens, i+1)
+			templateParameters = getTemplateParameters(parameters)
+			isTemplate = true
+			i = end
+			continue
+		case text == "[":
+			i = skipBalanced(tokens, i)
+			continue
+		case text == "namespace" || text == "inline" && isText(tokens, i+1, "namespace"):
+			if text == "inline" {
+				i++
+			}
+
+			j := i + 1
+			for isKind(tokens, j, identifierToken) || isText(tokens, j, "::") {
+				j++
+			}
+
+			// Namespace aliases: namespace fs = std::filesystem;
+			if !isText(tokens, j, "{") {
+				i = indexOf(tokens, j, ";") + 1
+				break
+			}
+
+			// Anonymous namespaces belong to the enclosing namespace
+			body, end := getBalanced(tokens, j)
+			parseScope(body, joinNamespace(namespace, joinTokens(removeText(tokens[i+1:j], "inline"))), response)
+			i = end
+		case text == "using":
+			end := indexOf(tokens, i, ";")
+			directive := tokens[i+1 : end]
+
+			if len(directive) > 1 && directive[0].Text == "namespace" {
+				response.Usings = append(response.Usings, strings.TrimPrefix(joinTokens(directive[1:]), "::"))
+			} else if len(directive) > 2 && !containsText(directive, "=") && isKind(directive, len(directive)-1, identifierToken) {
+				name := strings.TrimPrefix(joinTokens(directive), "::")
+				response.Declarations[directive[len(directive)-1].Text] = name
+			}
+
+			i = end + 1
+		case text == "extern" && isKind(tokens, i+1, stringToken) && isText(tokens, i+2, "{"):
+			body, end := getBalanced(tokens, i+2)
+			parseScope(body, namespace, response)
+			i = end
+		case isMacro(tokens, i):
+			i = skipMacro(tokens, i)
+		default:
+			if key := findClassKey(tokens, i); key != -1 {
+				if classes, end, ok := parseClass(tokens, key, namespace, "", templateParameters, isTemplate); ok {
+					response.Data = append(response.Data, classes...)
+					i = indexOf(tokens, end, ";") + 1
+					break
+				}
+			}
+
+			end := findStatementEnd(tokens, i)
+			if !isText(tokens, end, "{") {
+				i = end + 1
+				break
+			}
+
+			bodyEnd := skipBalanced(tokens, end)
+			if d, ok := getDefinition(tokens[i:bodyEnd], end-i, namespace); ok {
+				response.Definitions = append(response.Definitions, d)
+			}
+
+			i = bodyEnd
+		}
+
+		templateParameters = ""
+		isTemplate = false
+	}
+}
+
+// Returns the index of the class key that starts a statement, or -1: typedef struct, EXPORT class
+func findClassKey(tokens []token, start int) int {
+	for i := start; i < len(tokens) && tokens[i].Kind == identifierToken; i++ {
+		if _, ok := classKeys[tokens[i].Text]; ok {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Parses the class, struct, union or enum whose key is at index, and every class nested inside of it.
+// Returns false when the key does not start a definition, such as in a forward declaration.
+func parseClass(tokens []token, index int, namespace, definedWithin, templateParameters string, isTemplate bool) ([]any, int, bool) {
+	var (
+		key  = tokens[index].Text
+		i    = index + 1
+		name string
+		pkg  = types.CustomByteSlice(namespace)
+	)
+
+	if namespace == "" {
+		pkg = types.CustomByteSlice(globalNamespace)
+	}
+
+	if key == "enum" && (isText(tokens, i, "class") || isText(tokens, i, "struct")) {
+		i++
+	}
+
+	// Attributes and export macros may come before the name: class alignas(16) EXPORT Vector final
+	for i < len(tokens) {
+		if isText(tokens, i, "[") {
+			i = skipBalanced(tokens, i)
+		} else if isKind(tokens, i, identifierToken) && isText(tokens, i+1, "(") {
+			i = skipBalanced(tokens, i+1)
+		} else if tokens[i].Text == "final" && (isText(tokens, i+1, ":") || isText(tokens, i+1, "{")) {
+			i++
+		} else if isKind(tokens, i, identifierToken) {
+			name = tokens[i].Text
+			i++
+		} else if isText(tokens, i, "::") {
+			i++
+		} else {
+			break
+		}
+	}
+
+	// Explicit specializations show their template arguments: template <> class Vector<bool>
+	if isText(tokens, i, "<") {
+		end := skipAngles(tokens, i)
+		templateParameters = joinTokens(tokens[i:end])
+		i = end
+
+		if isText(tokens, i, "final") {
+			i++
+		}
+	}
+
+	if !isTemplate {
+		templateParameters = ""
+	}
+
+	var bases []types.CustomByteSlice
+
+	if key == "enum" && isText(tokens, i, ":") {
+		for i < len(tokens) && !isText(tokens, i, "{") && !isText(tokens, i, ";") {
+			i++
+		}
+	} else if isText(tokens, i, ":") {
+		// Every base is a class, whether it is inherited publicly, protectedly, privately or virtually
+		end := i + 1
+		for end < len(tokens) && !isText(tokens, end, "{") && !isText(tokens, end, ";") {
+			if isText(tokens, end, "<") {
+				end = skipAngles(tokens, end)
+				continue
+			}
+
+			end++
+		}
+
+		for _, part := range splitTopLevel(tokens[i+1:end], ",") {
+			if baseName := getBaseName(part); baseName != "" {
+				bases = append(bases, types.CustomByteSlice(baseName))
+			}
+		}
+
+		i = end
+	}
+
+	if !isText(tokens, i, "{") {
+		return nil, i, false
+	}
+
+	body, end := getBalanced(tokens, i)
+
+	// typedef struct { ... } Point;
+	if name == "" && tokens[findStatementStart(tokens, index)].Text == "typedef" {
+		if closing := indexOf(tokens, end, ";"); isKind(tokens, closing-1, identifierToken) {
+			name = tokens[closing-1].Text
+		}
+	}
+
+	// Anonymous classes and enums are members of the enclosing class, they are not shown
+	if name == "" {
+		return nil, end, true
+	}
+
+	if key == "enum" {
+		return []any{types.JavaEnum{
+			DefinedWithin: types.CustomByteSlice(definedWithin),
+			Package:       pkg,
+			Name:          types.CustomByteSlice(name),
+			Declarations:  getEnumDeclarations(body),
+		}}, end, true
+	}
+
+	// Members of a class are private by default, members of a struct or a union are public
+	accessModifier := "private"
+	if key != "class" {
+		accessModifier = "public"
+	}
+
+	fullName := name + templateParameters
+	variables, methods, nested := parseClassBody(body, namespace, name, fullName, accessModifier)
+
+	// A pure virtual method makes the class abstract
+	isAbstract := false
+	for _, method := range methods {
+		isAbstract = isAbstract || method.Abstract
+	}
+
+	var class any
+	if isAbstract {
+		class = types.JavaAbstract{
+			DefinedWithin: types.CustomByteSlice(definedWithin),
+			Package:       pkg,
+			Name:          types.CustomByteSlice(fullName),
+			Extends:       bases,
+			Variables:     variables,
+			Methods:       methods,
+		}
+	} else {
+		class = types.JavaClass{
+			DefinedWithin: types.CustomByteSlice(definedWithin),
+			Package:       pkg,
+			Name:          types.CustomByteSlice(fullName),
+			Extends:       bases,
+			Variables:     variables,
+			Methods:       methods,
+		}
+	}
+
+	return append([]any{class}, nested...), end, true
+}
+
+// Parses the members of a class body, grouped by their access sections
+func parseClassBody(tokens []token, namespace, className, fullName, accessModifier string) ([]types.JavaVariable, []types.JavaMethod, []any) {
+	var (
+		variables          []types.JavaVariable
+		methods            []types.JavaMethod
+		nested             []any
+		templateParameters string
+		isTemplate         bool
+	)
+
+	for i := 0; i < len(tokens); {
+		text := tokens[i].Text
+
+		switch {
+		case text == ";":
+			i++
+			continue
+		case (text == "public" || text == "protected" || text == "private") && (isText(tokens, i+1, ":") || isText(tokens, i+2, ":")):
+			// Qt sections such as "public slots:" share the access of their specifier
+			accessModifier = text
+			i = indexOf(tokens, i, ":") + 1
+			continue
+		case (text == "signals" || text == "Q_SIGNALS") && isText(tokens, i+1, ":"):
+			accessModifier = "public"
+			i += 2
+			continue
+		case text == "template" && isText(tokens, i+1, "<"):
+			parameters, end := getAngles(tokens, i+1)
+			templateParameters = getTemplateParameters(parameters)
+			isTemplate = true
+			i = end
+			continue
+		case text == "[":
+			i = skipBalanced(tokens, i)
+			continue
+		case isMacro(tokens, i):
+			i = skipMacro(tokens, i)
+			continue
+		case text == "friend" || text == "using" || text == "typedef" || text == "static_assert":
+			i = skipStatement(tokens, i)
+		default:
+			if key := findClassKey(tokens, i); key != -1 {
+				if classes, end, ok := parseClass(tokens, key, namespace, fullName, templateParameters, isTemplate); ok {
+					nested = append(nested, classes...)
+					i = indexOf(tokens, end, ";") + 1
+					break
+				}
+			}
+
+			end := findStatementEnd(tokens, i)
+			next := end + 1
+			statement := tokens[i:end]
+
+			if isText(tokens, end, "{") {
+				next = skipBalanced(tokens, end)
+				statement = tokens[i:next]
+			}
+
+			memberVariables, method := parseMember(statement, className, accessModifier)
+			variables = append(variables, memberVariables...)
+			if method != nil {
+				methods = append(methods, *method)
+			}
+
+			i = next
+		}
+
+		templateParameters = ""
+		isTemplate = false
+	}
+
+	return variables, methods, nested
+}
+
+// Parses a member declaration or an inline member definition. Operators and conversions are not shown.
+func parseMember(tokens []token, className, accessModifier string) ([]types.JavaVariable, *types.JavaMethod) {
+	var (
+		modifiers = make(map[string]struct{})
+		i         int
+	)
+
+	for i < len(tokens) {
+		if isText(tokens, i, "[") {
+			i = skipBalanced(tokens, i)
+		} else if text := tokens[i].Text; (text == "alignas" || text == "__declspec" || text == "__attribute__") && isText(tokens, i+1, "(") {
+			i = skipBalanced(tokens, i+1)
+		} else if _, ok := memberModifiers[text]; ok && tokens[i].Kind == identifierToken {
+			modifiers[text] = struct{}{}
+			i++
+		} else {
+			break
+		}
+	}
+
+	if i >= len(tokens) {
+		return nil, nil
+	}
+
+	_, isStatic := modifiers["static"]
+
+	var (
+		name          string
+		returnType    string
+		openParameter int
+	)
+
+	switch {
+	case isText(tokens, i, "~") && isText(tokens, i+2, "("):
+		name = "~" + tokens[i+1].Text
+		openParameter = i + 2
+	case tokens[i].Text == className && isText(tokens, i+1, "("):
+		name = className
+		openParameter = i + 1
+	case tokens[i].Text == "operator":
+		return nil, nil
+	default:
+		typeEnd := readType(tokens, i)
+		if typeEnd == i || isText(tokens, typeEnd, "operator") {
+			return nil, nil
+		}
+
+		// Function pointers: void (*callback)(int);
+		if isText(tokens, typeEnd, "(") && isText(tokens, typeEnd+1, "*") && isKind(tokens, typeEnd+2, identifierToken) {
+			end := findStatementEnd(tokens, typeEnd)
+			return []types.JavaVariable{{
+				Type:           types.CustomByteSlice(joinTokens(removeText(tokens[i:end], tokens[typeEnd+2].Text))),
+				Name:           types.CustomByteSlice(tokens[typeEnd+2].Text),
+				AccessModifier: types.CustomByteSlice(accessModifier),
+				Static:         isStatic,
+			}}, nil
+		}
+
+		if !isKind(tokens, typeEnd, identifierToken) {
+			return nil, nil
+		}
+
+		if !isText(tokens, typeEnd+1, "(") {
+			return getVariables(tokens[i:], typeEnd-i, accessModifier, modifiers), nil
+		}
+
+		name = tokens[typeEnd].Text
+		returnType = joinTokens(tokens[i:typeEnd])
+		openParameter = typeEnd + 1
+	}
+
+	parameters, closeParameter := getBalanced(tokens, openParameter)
+	method := types.JavaMethod{
+		Type:           types.CustomByteSlice(returnType),
+		Name:           types.CustomByteSlice(name),
+		AccessModifier: types.CustomByteSlice(accessModifier),
+		Parameters:     parseParameters(parameters),
+		Static:         isStatic,
+	}
+
+	// Specifiers after the parameters: const, noexcept, override, final, = 0, -> ReturnType
+	for j := closeParameter; j < len(tokens) && !isText(tokens, j, "{") && !isText(tokens, j, ":"); j++ {
+		switch tokens[j].Text {
+		case "final":
+			method.Final = true
+		case "=":
+			method.Abstract = isText(tokens, j+1, "0")
+		case "->":
+			end := readType(tokens, j+1)
+			if string(method.Type) == "auto" {
+				method.Type = types.CustomByteSlice(joinTokens(tokens[j+1 : end]))
+			}
+
+			j = end - 1
+		case "(":
+			j = skipBalanced(tokens, j) - 1
+		}
+	}
+
+	method.Functionality = []byte(joinTokens(removeStrings(tokens[closeParameter:])))
+
+	return nil, &method
+}
+
+// Returns the variables of a member declaration that can declare several names: int *a, b[4] = {1};
+func getVariables(tokens []token, typeEnd int, accessModifier string, modifiers map[string]struct{}) []types.JavaVariable {
+	var response []types.JavaVariable
+
+	// The pointer and reference declarators belong to the first name only
+	baseEnd := typeEnd
+	for baseEnd > 0 && (tokens[baseEnd-1].Text == "*" || tokens[baseEnd-1].Text == "&" || tokens[baseEnd-1].Text == "&&") {
+		baseEnd--
+	}
+
+	baseType := tokens[:baseEnd]
+	_, isStatic := modifiers["static"]
+	_, isConstexpr := modifiers["constexpr"]
+
+	declarators := splitTopLevel(tokens[typeEnd:], ",")
+	for index, declarator := range declarators {
+		var pointers []token
+		if index == 0 {
+			pointers = tokens[baseEnd:typeEnd]
+		}
+
+		j := 0
+		for j < len(declarator) && (declarator[j].Text == "*" || declarator[j].Text == "&" || declarator[j].Text == "&&") {
+			pointers = append(pointers, declarator[j])
+			j++
+		}
+
+		if !isKind(declarator, j, identifierToken) {
+			continue
+		}
+
+		variableType := joinTokens(append(append([]token{}, baseType...), pointers...))
+		variable := types.JavaVariable{
+			Name:           types.CustomByteSlice(declarator[j].Text),
+			AccessModifier: types.CustomByteSlice(accessModifier),
+			Static:         isStatic,
+			Final:          isConstexpr || len(baseType) > 0 && baseType[0].Text == "const" && len(pointers) == 0,
+		}
+
+		for j++; j < len(declarator); j++ {
+			switch declarator[j].Text {
+			case "[":
+				end := skipBalanced(declarator, j)
+				variableType += joinTokens(declarator[j:end])
+				j = end - 1
+			case "=":
+				variable.Value = types.CustomByteSlice(joinTokens(declarator[j+1:]))
+				j = len(declarator)
+			case "{":
+				variable.Value = types.CustomByteSlice(joinTokens(declarator[j:]))
+				j = len(declarator)
+			case ":":
+				// Bit fields: unsigned flag : 1;
+				j = len(declarator)
+			}
+		}
+
+		variable.Type = types.CustomByteSlice(variableType)
+		response = append(response, variable)
+	}
+
+	return response
+}
+
+// Parses a parameter list such as: (const std::vector<Dog>& dogs, int count = 0, void (*callback)(int))
+func parseParameters(tokens []token) []types.JavaMethodParameter {
+	var response []types.JavaMethodParameter
+
+	parts := splitTopLevel(tokens, ",")
+	if len(parts) == 1 && len(parts[0]) == 1 && parts[0][0].Text == "void" {
+		return nil
+	}
+
+	for _, part := range parts {
+		// Default arguments are not shown
+		if index := indexOf(part, 0, "="); index < len(part) {
+			part = part[:index]
+		}
+
+		i := 0
+		for isText(part, i, "[") {
+			i = skipBalanced(part, i)
+		}
+
+		typeEnd := readType(part, i)
+		if typeEnd == i {
+			continue
+		}
+
+		switch {
+		case typeEnd == len(part):
+			// Unnamed parameters: void bark(int);
+			response = append(response, types.JavaMethodParameter{Type: types.CustomByteSlice(joinTokens(part[i:]))})
+		case isText(part, typeEnd, "(") && isText(part, typeEnd+1, "*") && isKind(part, typeEnd+2, identifierToken):
+			response = append(response, types.JavaMethodParameter{
+				Type: types.CustomByteSlice(joinTokens(removeText(part[i:], part[typeEnd+2].Text))),
+				Name: types.CustomByteSlice(part[typeEnd+2].Text),
+			})
+		case isKind(part, typeEnd, identifierToken):
+			// Array parameters: int values[]
+			response = append(response, types.JavaMethodParameter{
+				Type: types.CustomByteSlice(joinTokens(part[i:typeEnd]) + joinTokens(part[typeEnd+1:])),
+				Name: types.CustomByteSlice(part[typeEnd].Text),
+			})
+		}
+	}
+
+	return response
+}
+
+// Returns the names of template parameters in their generic form: <typename T, int N = 3> -> <T, N>
+func getTemplateParameters(tokens []token) string {
+	var names []string
+
+	for _, part := range splitTopLevel(tokens, ",") {
+		if index := indexOf(part, 0, "="); index < len(part) {
+			part = part[:index]
+		}
+
+		if len(part) > 1 && part[len(part)-1].Kind == identifierToken {
+			names = append(names, part[len(part)-1].Text)
+		}
+	}
+
+	if len(names) == 0 {
+		return ""
+	}
+
+	return "<" + strings.Join(names, ", ") + ">"
+}
+
+// Returns the definition of a member function outside of its class: Type zoo::Dog::bark(int times) const { ... }
+func getDefinition(tokens []token, bodyStart int, namespace string) (definition, bool) {
+	// The name is in front of the first parenthesis that is not inside of template arguments
+	open := -1
+	for i := 0; i < bodyStart; i++ {
+		if isText(tokens, i, "<") && isKind(tokens, i-1, identifierToken) && tokens[i-1].Text != "operator" {
+			i = skipAngles(tokens, i) - 1
+		} else if isText(tokens, i, "(") {
+			open = i
+			break
+		}
+	}
+
+	if open < 2 || !isKind(tokens, open-1, identifierToken) {
+		return definition{}, false
+	}
+
+	name := tokens[open-1].Text
+	qualifierEnd := open - 2
+	if isText(tokens, qualifierEnd, "~") {
+		name = "~" + name
+		qualifierEnd--
+	}
+
+	if !isText(tokens, qualifierEnd, "::") {
+		return definition{}, false
+	}
+
+	// Walk back over the qualifier: zoo::Vector<T>::
+	var components []string
+	for i := qualifierEnd - 1; i >= 0; i-- {
+		if isText(tokens, i, ">") {
+			depth := 0
+			for ; i >= 0; i-- {
+				if tokens[i].Text == ">" {
+					depth++
+				} else if tokens[i].Text == "<" {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+
+			continue
+		}
+
+		if !isKind(tokens, i, identifierToken) {
+			break
+		}
+
+		components = append([]string{tokens[i].Text}, components...)
+		if !isText(tokens, i-1, "::") {
+			break
+		}
+
+		i--
+	}
+
+	if len(components) == 0 {
+		return definition{}, false
+	}
+
+	parameters, closeParameter := getBalanced(tokens, open)
+
+	return definition{
+		Namespace:  namespace,
+		Class:      strings.Join(components, "::"),
+		Name:       name,
+		Parameters: len(parseParameters(parameters)),
+		Body:       []byte(joinTokens(removeStrings(tokens[closeParameter:]))),
+	}, true
+}
+
+func getEnumDeclarations(tokens []token) []types.CustomByteSlice {
+	var response []types.CustomByteSlice
+
+	for _, part := range splitTopLevel(tokens, ",") {
+		i := 0
+		for isText(part, i, "[") {
+			i = skipBalanced(part, i)
+		}
+
+		if isKind(part, i, identifierToken) {
+			response = append(response, types.CustomByteSlice(part[i].Text))
+		}
+	}
+
+	return response
+}
+
+// Returns the name of a base class without its access specifier or template arguments: public virtual zoo::Base<T> -> zoo::Base
+func getBaseName(tokens []token) string {
+	var builder strings.Builder
+
+	for _, t := range tokens {
+		switch t.Text {
+		case "public", "protected", "private", "virtual":
+			continue
+		}
+
+		if t.Kind != identifierToken && t.Text != "::" {
+			break
+		}
+
+		builder.WriteString(t.Text)
+	}
+
+	return strings.TrimPrefix(builder.String(), "::")
+}
+
+// Returns the index after the type that starts at start, or start if there is no type
+func readType(tokens []token, start int) int {
+	i := start
+
+	for isText(tokens, i, "const") || isText(tokens, i, "volatile") || isText(tokens, i, "typename") || isText(tokens, i, "struct") ||
+		isText(tokens, i, "class") || isText(tokens, i, "union") || isText(tokens, i, "enum") {
+		i++
+	}
+
+	switch {
+	case isBuiltinType(tokens, i):
+		// unsigned long long int
+		for isBuiltinType(tokens, i) {
+			i++
+		}
+	case isText(tokens, i, "decltype") && isText(tokens, i+1, "("):
+		i = skipBalanced(tokens, i+1)
+	case isKind(tokens, i, identifierToken) || isText(tokens, i, "::") && isKind(tokens, i+1, identifierToken):
+		if isText(tokens, i, "::") {
+			i++
+		}
+
+		i++
+		for {
+			if isText(tokens, i, "::") && isKind(tokens, i+1, identifierToken) {
+				i += 2
+			} else if isText(tokens, i, "<") {
+				i = skipAngles(tokens, i)
+			} else {
+				break
+			}
+		}
+	default:
+		return start
+	}
+
+	// Qualifiers, pointers, references and parameter packs
+	for isText(tokens, i, "const") || isText(tokens, i, "volatile") || isText(tokens, i, "*") || isText(tokens, i, "&") ||
+		isText(tokens, i, "&&") || isText(tokens, i, "...") {
+		i++
+	}
+
+	return i
+}
+
+func isBuiltinType(tokens []token, index int) bool {
+	if !isKind(tokens, index, identifierToken) {
+		return false
+	}
+
+	_, ok := builtinTypes[tokens[index].Text]
+	return ok
+}
+
+// Macros such as Q_OBJECT or DISALLOW_COPY(Dog) stand on their own line without a semicolon
+func isMacro(tokens []token, index int) bool {
+	text := tokens[index].Text
+	if tokens[index].Kind != identifierToken || len(text) < 2 || strings.ToUpper(text) != text || strings.ToLower(text) == text {
+		return false
+	}
+
+	end := index + 1
+	if isText(tokens, end, "(") {
+		end = skipBalanced(tokens, end)
+	}
+
+	return end >= len(tokens) || tokens[end].NewlineBefore && tokens[end].Text != ";" && tokens[end].Text != "{" && tokens[end].Text != ":"
+}
+
+func skipMacro(tokens []token, index int) int {
+	if isText(tokens, index+1, "(") {
+		return skipBalanced(tokens, index+1)
+	}
+
+	return index + 1
+}
+
+// Returns the index of the semicolon that ends the statement at start, or of the brace that opens its body.
+// Brace initializers are part of the statement: int values{1, 2}; Dog() : name{"Rex"} { ... }
+func findStatementEnd(tokens []token, start int) int {
+	var (
+		sawParameters bool
+		sawAssignment bool
+		inInitializer bool
+	)
+
+	for i := start; i < len(tokens); i++ {
+		if tokens[i].Kind != punctuationToken {
+			continue
+		}
+
+		switch tokens[i].Text {
+		case "(":
+			sawParameters = true
+			i = skipBalanced(tokens, i) - 1
+		case "[":
+			i = skipBalanced(tokens, i) - 1
+		case "<":
+			if !sawAssignment && isKind(tokens, i-1, identifierToken) && tokens[i-1].Text != "operator" {
+				i = skipAngles(tokens, i) - 1
+			}
+		case "=":
+			sawAssignment = true
+		case ":":
+			inInitializer = inInitializer || isText(tokens, i-1, ")")
+		case "{":
+			if i == start {
+				return i
+			}
+
+			previous := tokens[i-1]
+			isInitializer := sawAssignment ||
+				inInitializer && (previous.Kind == identifierToken || previous.Text == ">") ||
+				!sawParameters && (previous.Kind == identifierToken || previous.Text == ">" || previous.Text == "]")
+
+			if !isInitializer {
+				return i
+			}
+
+			i = skipBalanced(tokens, i) - 1
+		case ";", "}":
+			return i
+		}
+	}
+
+	return len(tokens)
+}
+
+// Returns the index after the statement at start, including its body
+func skipStatement(tokens []token, start int) int {
+	end := findStatementEnd(tokens, start)
+	if isText(tokens, end, "{") {
+		return skipBalanced(tokens, end)
+	}
+
+	return end + 1
+}
+
+// Returns the index where the statement that contains index starts
+func findStatementStart(tokens []token, index int) int {
+	for i := index - 1; i >= 0; i-- {
+		if text := tokens[i].Text; text == ";" || text == "{" || text == "}" || text == ":" {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// Returns the index of the first text at the top level after start, or the number of tokens
+func indexOf(tokens []token, start int, text string) int {
+	for i := start; i < len(tokens); i++ {
+		if tokens[i].Text == text {
+			return i
+		}
+
+		if isOpeningBracket(tokens[i]) {
+			i = skipBalanced(tokens, i) - 1
+		}
+	}
+
+	return len(tokens)
+}
+
+// Returns the index after the bracket that closes the one at index
+func skipBalanced(tokens []token, index int) int {
+	_, end := getBalanced(tokens, index)
+	return end
+}
+
+// Returns the tokens inside of the bracket at index and the index after the bracket that closes it.
+// A bracket that is never closed holds the rest of the tokens.
+func getBalanced(tokens []token, index int) ([]token, int) {
+	depth := 0
+
+	for i := index; i < len(tokens); i++ {
+		if tokens[i].Kind != punctuationToken {
+			continue
+		}
+
+		switch tokens[i].Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return tokens[index+1 : i], i + 1
+			}
+		}
+	}
+
+	if index+1 >= len(tokens) {
+		return nil, len(tokens)
+	}
+
+	return tokens[index+1:], len(tokens)
+}
+
+// Returns the index after the angle bracket that closes the one at index
+func skipAngles(tokens []token, index int) int {
+	depth := 0
+
+	for i := index; i < len(tokens); i++ {
+		switch tokens[i].Text {
+		case "(", "[":
+			i = skipBalanced(tokens, i) - 1
+		case "<":
+			depth++
+		case ">":
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case ";", "{", "}":
+			return i
+		}
+	}
+
+	return len(tokens)
+}
+
+// Returns the tokens inside of the angle bracket at index and the index after the angle bracket that closes it.
+// An angle bracket that is not closed before the end of the statement holds the tokens until the end of the statement.
+func getAngles(tokens []token, index int) ([]token, int) {
+	end := skipAngles(tokens, index)
+
+	closing := end
+	if isText(tokens, end-1, ">") {
+		closing--
+	}
+
+	if closing <= index+1 {
+		return nil, end
+	}
+
+	return tokens[index+1 : closing], end
+}
+
+// Splits tokens on every separator that is not inside of brackets or template arguments
+func splitTopLevel(tokens []token, separator string) [][]token {
+	var (
+		response [][]token
+		start    int
+		depth    int
+	)
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].Kind != punctuationToken {
+			continue
+		}
+
+		switch tokens[i].Text {
+		case "(", "[", "{", "<":
+			depth++
+		case ")", "]", "}", ">":
+			depth--
+		case separator:
+			if depth != 0 {
+				continue
+			}
+
+			if i > start {
+				response = append(response, tokens[start:i])
+			}
+
+			start = i + 1
+		}
+	}
+
+	if start < len(tokens) {
+		response = append(response, tokens[start:])
+	}
+
+	return response
+}
+
+// Removes string literals so that words inside of them are not mistaken for names
+func removeStrings(tokens []token) []token {
+	var response []token
+
+	for _, t := range tokens {
+		if t.Kind != stringToken {
+			response = append(response, t)
+		}
+	}
+
+	return response
+}
+
+func removeText(tokens []token, text string) []token {
+	var response []token
+
+	for _, t := range tokens {
+		if t.Text != text {
+			response = append(response, t)
+		}
+	}
+
+	return response
+}
+
+// Joins tokens into readable code: const std::map<std::string, Dog*>&
+func joinTokens(tokens []token) string {
+	var builder strings.Builder
+
+	for i, t := range tokens {
+		if i > 0 {
+			previous := tokens[i-1]
+			if previous.Kind != punctuationToken && t.Kind != punctuationToken || previous.Text == "," || previous.Text == "=" || t.Text == "=" ||
+				t.Kind == identifierToken && (previous.Text == "*" || previous.Text == "&" || previous.Text == "&&") {
+				builder.WriteByte(' ')
+			}
+		}
+
+		builder.WriteString(t.Text)
+	}
+
+	return builder.String()
+}
+
+func joinNamespace(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+
+	if name == "" {
+		return parent
+	}
+
+	return parent + "::" + name
+}
+
+func containsText(tokens []token, text string) bool {
+	for _, t := range tokens {
+		if t.Text == text {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isText(tokens []token, index int, text string) bool {
+	return index >= 0 && index < len(tokens) && tokens[index].Text == text
+}
+
+func isKind(tokens []token, index int, kind tokenKind) bool {
+	return index >= 0 && index < len(tokens) && tokens[index].Kind == kind
+}
+
+func isOpeningBracket(t token) bool {
+	return t.Kind == punctuationToken && (t.Text == "(" || t.Text == "[" || t.Text == "{")
+}
@@ -0,0 +1,256 @@
+package cpp
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestParseFile(t *testing.T) {
+	file := types.File{
+		Name:      "animal",
+		Extension: "hpp",
+		Code: []byte(`
+#pragma once
+#include <string>
+
+namespace zoo {
+namespace models {
+
+using namespace std;
+
+enum class Diet : uint8_t { Herbivore = 1, Carnivore };
+
+class EXPORT Animal : public Named, protected virtual Tracked {
+	Q_OBJECT
+
+public:
+	explicit Animal(const std::string& name, int age = 0);
+	virtual ~Animal() = default;
+
+	virtual std::string speak() const = 0;
+	virtual void feed(Food* food, int amount) { food->eat(amount); }
+	static int count;
+	bool operator==(const Animal& other) const;
+
+protected:
+	Diet diet;
+	std::vector<Keeper*> keepers;
+
+private:
+	const int id;
+	int *legs, eyes[2];
+	static constexpr double kWeight = 1.5;
+	void (*callback)(int);
+
+	struct Collar {
+		std::string color;
+	};
+};
+
+template <typename T, int N = 4>
+struct Pack final : Animal {
+	T members[N];
+	T lead() const override;
+	auto size() -> std::size_t { return N; }
+};
+
+template <>
+class Pack<Dog, 2> : public Animal {};
+
+} // namespace models
+} // namespace zoo
+
+typedef struct {
+	int x, y;
+} Point;
+
+class Forward;
+`),
+	}
+
+	response := parseFile(file)
+
+	if len(response.Usings) != 1 || response.Usings[0] != "std" {
+		t.Errorf("incorrect usings: %s", response.Usings)
+	}
+
+	if len(response.Data) != 6 {
+		t.Fatalf("incorrect number of classes.\nexpected: 6\ngot: %d\n", len(response.Data))
+	}
+
+	diet, ok := response.Data[0].(types.JavaEnum)
+	if !ok || string(diet.Package) != "zoo::models" || len(diet.Declarations) != 2 || string(diet.Declarations[1]) != "Carnivore" {
+		t.Errorf("incorrect enum")
+	}
+
+	animal, ok := response.Data[1].(types.JavaAbstract)
+	if !ok {
+		t.Fatalf("Animal is not abstract")
+	}
+
+	if string(animal.Name) != "Animal" || len(animal.Extends) != 2 || string(animal.Extends[0]) != "Named" || string(animal.Extends[1]) != "Tracked" {
+		t.Errorf("incorrect bases: %s", animal.Extends)
+	}
+
+	expectedVariables := []types.JavaVariable{
+		{Name: []byte("count"), Type: []byte("int"), AccessModifier: []byte("public"), Static: true},
+		{Name: []byte("diet"), Type: []byte("Diet"), AccessModifier: []byte("protected")},
+		{Name: []byte("keepers"), Type: []byte("std::vector<Keeper*>"), AccessModifier: []byte("protected")},
+		{Name: []byte("id"), Type: []byte("const int"), AccessModifier: []byte("private"), Final: true},
+		{Name: []byte("legs"), Type: []byte("int*"), AccessModifier: []byte("private")},
+		{Name: []byte("eyes"), Type: []byte("int[2]"), AccessModifier: []byte("private")},
+		{Name: []byte("kWeight"), Type: []byte("double"), Value: []byte("1.5"), AccessModifier: []byte("private"), Static: true, Final: true},
+		{Name: []byte("callback"), Type: []byte("void(*)(int)"), AccessModifier: []byte("private")},
+	}
+
+	if len(animal.Variables) != len(expectedVariables) {
+		t.Fatalf("incorrect number of variables.\nexpected: %d\ngot: %d\n", len(expectedVariables), len(animal.Variables))
+	}
+
+	for index, expected := range expectedVariables {
+		actual := animal.Variables[index]
+		if string(actual.Name) != string(expected.Name) || string(actual.Type) != string(expected.Type) || string(actual.Value) != string(expected.Value) ||
+			string(actual.AccessModifier) != string(expected.AccessModifier) || actual.Static != expected.Static || actual.Final != expected.Final {
+			t.Errorf("incorrect variable.\nexpected:\n%s %s %s %s\ngot:\n%s %s %s %s\n",
+				expected.AccessModifier, expected.Name, expected.Type, expected.Value,
+				actual.AccessModifier, actual.Name, actual.Type, actual.Value)
+		}
+	}
+
+	expectedMethods := []types.JavaMethod{
+		{Name: []byte("Animal"), AccessModifier: []byte("public")},
+		{Name: []byte("~Animal"), AccessModifier: []byte("public")},
+		{Name: []byte("speak"), Type: []byte("std::string"), AccessModifier: []byte("public"), Abstract: true},
+		{Name: []byte("feed"), Type: []byte("void"), AccessModifier: []byte("public")},
+	}
+
+	if len(animal.Methods) != len(expectedMethods) {
+		t.Fatalf("incorrect number of methods.\nexpected: %d\ngot: %d\n", len(expectedMethods), len(animal.Methods))
+	}
+
+	for index, expected := range expectedMethods {
+		actual := animal.Methods[index]
+		if string(actual.Name) != string(expected.Name) || string(actual.Type) != string(expected.Type) ||
+			string(actual.AccessModifier) != string(expected.AccessModifier) || actual.Abstract != expected.Abstract {
+			t.Errorf("incorrect method.\nexpected:\n%s %s %s\ngot:\n%s %s %s\n", expected.AccessModifier, expected.Name, expected.Type, actual.AccessModifier, actual.Name, actual.Type)
+		}
+	}
+
+	if constructor := animal.Methods[0]; len(constructor.Parameters) != 2 || string(constructor.Parameters[0].Type) != "const std::string&" || string(constructor.Parameters[1].Name) != "age" {
+		t.Errorf("incorrect constructor parameters")
+	}
+
+	collar, ok := response.Data[2].(types.JavaClass)
+	if !ok || string(collar.DefinedWithin) != "Animal" || len(collar.Variables) != 1 || string(collar.Variables[0].AccessModifier) != "public" {
+		t.Errorf("incorrect nested struct")
+	}
+
+	pack, ok := response.Data[3].(types.JavaClass)
+	if !ok || string(pack.Name) != "Pack<T, N>" || len(pack.Extends) != 1 {
+		t.Fatalf("incorrect class template")
+	}
+
+	if len(pack.Variables) != 1 || string(pack.Variables[0].Type) != "T[N]" || string(pack.Variables[0].AccessModifier) != "public" {
+		t.Errorf("incorrect class template variables")
+	}
+
+	if len(pack.Methods) != 2 || string(pack.Methods[1].Type) != "std::size_t" {
+		t.Errorf("incorrect class template methods")
+	}
+
+	if specialization, ok := response.Data[4].(types.JavaClass); !ok || string(specialization.Name) != "Pack<Dog, 2>" {
+		t.Errorf("incorrect template specialization")
+	}
+
+	if point, ok := response.Data[5].(types.JavaClass); !ok || string(point.Name) != "Point" || string(point.Package) != globalNamespace || len(point.Variables) != 2 {
+		t.Errorf("incorrect typedef struct")
+	}
+}
+
+func TestParseFileDefinitions(t *testing.T) {
+	response := parseFile(types.File{
+		Name:      "animal",
+		Extension: "cpp",
+		Code: []byte(`
+#include "animal.hpp"
+
+namespace zoo::models {
+
+Animal::Animal(const std::string& name, int age) : Named(name), age_{age} {
+	tracker_ = new Tracker();
+}
+
+std::string Animal::speak() const { return "..."; }
+
+template <typename T, int N>
+T Pack<T, N>::lead() const { return members[0]; }
+
+int Animal::count = 0;
+
+static void helper() {}
+
+}
+`),
+	})
+
+	expected := []definition{
+		{Namespace: "zoo::models", Class: "Animal", Name: "Animal", Parameters: 2},
+		{Namespace: "zoo::models", Class: "Animal", Name: "speak"},
+		{Namespace: "zoo::models", Class: "Pack", Name: "lead"},
+	}
+
+	if len(response.Definitions) != len(expected) {
+		t.Fatalf("incorrect number of definitions.\nexpected: %d\ngot: %d\n", len(expected), len(response.Definitions))
+	}
+
+	for index, e := range expected {
+		actual := response.Definitions[index]
+		if actual.Namespace != e.Namespace || actual.Class != e.Class || actual.Name != e.Name || actual.Parameters != e.Parameters {
+			t.Errorf("incorrect definition.\nexpected:\n%+v\ngot:\n%+v\n", e, actual)
+		}
+	}
+
+	if body := string(response.Definitions[0].Body); body != ":Named(name), age_{age}{tracker_ = new Tracker();}" {
+		t.Errorf("incorrect constructor body: %s", body)
+	}
+}
+
+func TestParseFileUnbalanced(t *testing.T) {
+	var tests = []struct {
+		Input  string
+		Output []string
+	}{
+		{Input: "namespace zoo {", Output: nil},
+		{Input: `extern "C" {`, Output: nil},
+		{Input: "namespace zoo {\nclass Dog {", Output: []string{"zoo.Dog"}},
+		{Input: "enum class Size {", Output: []string{"default.Size"}},
+		{Input: "class Dog {\n\tvoid bark(", Output: []string{"default.Dog"}},
+		{Input: "class Dog {\n\ttemplate <", Output: []string{"default.Dog"}},
+		{Input: "template <", Output: nil},
+		{Input: "template <;", Output: nil},
+		{Input: "void Dog::bark(", Output: nil},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := parseFile(types.File{Name: "dog", Extension: "cpp", Code: []byte(tt.Input)})
+
+			var output []string
+			for _, class := range response.Data {
+				switch c := class.(type) {
+				case types.JavaClass:
+					output = append(output, string(c.Package)+"."+string(c.Name))
+				case types.JavaEnum:
+					output = append(output, string(c.Package)+"."+string(c.Name))
+				}
+			}
+
+			if fmt.Sprint(output) != fmt.Sprint(tt.Output) {
+				subtest.Errorf("incorrect classes.\nexpected:\n%s\ngot:\n%s\n", tt.Output, output)
+			}
+		})
+	}
+}
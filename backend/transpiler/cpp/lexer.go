@@ -0,0 +1,175 @@
+package cpp
+
+import "strings"
+
+type tokenKind int
+
+const (
+	identifierToken tokenKind = iota
+	punctuationToken
+	stringToken
+	numberToken
+)
+
+type token struct {
+	Kind          tokenKind
+	Text          string
+	NewlineBefore bool // True when a line break separates this token from the previous one
+}
+
+// Punctuation made of more than one character that matters to the parser.
+// Angle brackets are kept apart so that nested template arguments close one by one: vector<vector<int>>
+var multiCharPunctuation = []string{"::", "->", "...", "==", "!=", "<=", "&&", "||"}
+
+// Splits code into tokens. Comments, whitespace and preprocessor directives are removed.
+func tokenize(code []byte) []token {
+	var (
+		response      []token
+		text          = string(code)
+		newlineBefore = true
+	)
+
+	appendToken := func(kind tokenKind, start, end int) {
+		response = append(response, token{Kind: kind, Text: text[start:end], NewlineBefore: newlineBefore})
+		newlineBefore = false
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+
+		switch {
+		case c == '\n':
+			newlineBefore = true
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			i++
+		case c == '\\' && i+1 < len(text) && (text[i+1] == '\n' || text[i+1] == '\r'):
+			// Line continuation
+			i++
+		case c == '#' && newlineBefore:
+			// Preprocessor directives span the rest of the line, including continued lines
+			for i < len(text) && text[i] != '\n' {
+				if text[i] == '\\' && i+1 < len(text) && text[i+1] == '\n' {
+					i++
+				} else if strings.HasPrefix(text[i:], "/*") {
+					if end := strings.Index(text[i+2:], "*/"); end != -1 {
+						i += end + 3
+					}
+				}
+
+				i++
+			}
+		case strings.HasPrefix(text[i:], "//"):
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end == -1 {
+				i = len(text)
+				break
+			}
+
+			i += end + 4
+		case c == '"' || c == '\'':
+			end := skipString(text, i, 0)
+			appendToken(stringToken, i, end)
+			i = end
+		case isIdentifierStart(c):
+			end := i + 1
+			for end < len(text) && isIdentifierPart(text[end]) {
+				end++
+			}
+
+			// String prefixes: u8"text", L'c', R"(raw)"
+			if end < len(text) && (text[end] == '"' || text[end] == '\'') && isStringPrefix(text[i:end]) {
+				stringEnd := skipString(text, end, strings.IndexByte(text[i:end], 'R')+1)
+				appendToken(stringToken, i, stringEnd)
+				i = stringEnd
+				break
+			}
+
+			appendToken(identifierToken, i, end)
+			i = end
+		case c >= '0' && c <= '9' || c == '.' && i+1 < len(text) && text[i+1] >= '0' && text[i+1] <= '9':
+			// Digit separators and exponents: 1'000'000, 1.5e-3f
+			end := i + 1
+			for end < len(text) {
+				if isIdentifierPart(text[end]) || text[end] == '.' || text[end] == '\'' && end+1 < len(text) && isIdentifierPart(text[end+1]) {
+					end++
+				} else if (text[end] == '+' || text[end] == '-') && (text[end-1] == 'e' || text[end-1] == 'E' || text[end-1] == 'p' || text[end-1] == 'P') {
+					end++
+				} else {
+					break
+				}
+			}
+
+			appendToken(numberToken, i, end)
+			i = end
+		default:
+			end := i + 1
+			for _, punctuation := range multiCharPunctuation {
+				if strings.HasPrefix(text[i:], punctuation) {
+					end = i + len(punctuation)
+					break
+				}
+			}
+
+			appendToken(punctuationToken, i, end)
+			i = end
+		}
+	}
+
+	return response
+}
+
+// Returns the index after the string or character literal that starts at start.
+// Raw strings are passed with raw greater than zero: R"delimiter(...)delimiter"
+func skipString(text string, start int, raw int) int {
+	quote := text[start]
+
+	if raw > 0 && quote == '"' {
+		open := strings.IndexByte(text[start:], '(')
+		if open == -1 {
+			return len(text)
+		}
+
+		closing := ")" + text[start+1:start+open] + `"`
+		end := strings.Index(text[start+open:], closing)
+		if end == -1 {
+			return len(text)
+		}
+
+		return start + open + end + len(closing)
+	}
+
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		case '\n':
+			return i
+		}
+	}
+
+	return len(text)
+}
+
+func isStringPrefix(prefix string) bool {
+	switch prefix {
+	case "L", "u", "U", "u8", "R", "LR", "uR", "UR", "u8R":
+		return true
+	}
+
+	return false
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '$' || c >= 0x80
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,46 @@
+package cpp
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	type TokenizeTest struct {
+		Input  string
+		Output []string
+	}
+
+	var tests = []TokenizeTest{
+		{
+			Input:  "#include <vector>\n#define MAX(a, b) \\\n\t((a) > (b) ? (a) : (b))\nint x;",
+			Output: []string{"int", "x", ";"},
+		},
+		{
+			Input:  `std::map<int, std::vector<Dog*>> dogs; // comment`,
+			Output: []string{"std", "::", "map", "<", "int", ",", "std", "::", "vector", "<", "Dog", "*", ">", ">", "dogs", ";"},
+		},
+		{
+			Input:  `auto s = R"json({"a": "}"})json" + u8"text" + 'c';`,
+			Output: []string{"auto", "s", "=", `R"json({"a": "}"})json"`, "+", `u8"text"`, "+", `'c'`, ";"},
+		},
+		{
+			Input:  `long n = 1'000'000; /* block */ double d = 1.5e-3;`,
+			Output: []string{"long", "n", "=", "1'000'000", ";", "double", "d", "=", "1.5e-3", ";"},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			var actualOutput []string
+			for _, token := range tokenize([]byte(tt.Input)) {
+				actualOutput = append(actualOutput, token.Text)
+			}
+
+			if strings.Join(actualOutput, " ") != strings.Join(tt.Output, " ") {
+				subtest.Errorf("incorrect tokens.\nexpected:\n%s\ngot:\n%s\n", tt.Output, actualOutput)
+			}
+		})
+	}
+}
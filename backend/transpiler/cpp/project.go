@@ -0,0 +1,302 @@
+package cpp
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+var identifierRegex = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(::[A-Za-z_][A-Za-z0-9_]*)*`)
+
+// The names that are visible inside of a class or a function definition
+type scope struct {
+	Namespace    string
+	Usings       []string
+	Declarations map[string]string
+}
+
+func ParseProject(ctx context.Context, files []types.File) *types.Project {
+	var (
+		response   types.Project
+		nodes      []any
+		nodeScopes []scope
+	)
+
+	parsedFiles, diagnostics := types.ParseFiles(ctx, files, parseFile)
+	response.Diagnostics = diagnostics
+
+	// The key is the qualified name of a class without template arguments and the value is the index of its node.
+	// Template specializations and classes defined by more than one file, such as platform specific headers,
+	// keep their first definition.
+	classes := make(map[string]int)
+
+	for _, parsedFile := range parsedFiles {
+		for _, parsedClass := range parsedFile.Data {
+			pkg, name := getClassPackageAndName(parsedClass)
+			key := joinNamespace(getNamespace(pkg), removeTemplateArguments(name))
+
+			if _, ok := classes[key]; ok {
+				continue
+			}
+
+			classes[key] = len(nodes)
+			nodes = append(nodes, parsedClass)
+			nodeScopes = append(nodeScopes, getScope(parsedFile, getNamespace(pkg)))
+		}
+	}
+
+	// Member functions defined in source files add their bodies to the methods declared in headers.
+	// The names in a body are resolved with the using directives of its own file.
+	definitionDependencies := make(map[int]map[string]struct{})
+
+	for _, parsedFile := range parsedFiles {
+		for _, d := range parsedFile.Definitions {
+			s := getScope(parsedFile, d.Namespace)
+			index, ok := resolveClass(classes, nodes, s, d.Class)
+			if !ok {
+				continue
+			}
+
+			if _, ok := definitionDependencies[index]; !ok {
+				definitionDependencies[index] = make(map[string]struct{})
+			}
+
+			addReferences(definitionDependencies[index], classes, nodes, s, d.Body)
+
+			switch class := nodes[index].(type) {
+			case types.JavaAbstract:
+				addDefinition(class.Methods, d)
+			case types.JavaClass:
+				addDefinition(class.Methods, d)
+			}
+		}
+	}
+
+	for index, node := range nodes {
+		s := nodeScopes[index]
+
+		switch class := node.(type) {
+		case types.JavaAbstract:
+			class.Extends = resolveBases(classes, nodes, s, class.Extends)
+			class.Associations, class.Dependencies = getClassAssociationsAndDependencies(classes, nodes, s, class.Variables, class.Methods, definitionDependencies[index])
+			response.Nodes = append(response.Nodes, class)
+			addClassRelations(&response, class.Package, class.Name, class.DefinedWithin, class.Extends, class.Associations, class.Dependencies)
+		case types.JavaClass:
+			class.Extends = resolveBases(classes, nodes, s, class.Extends)
+			class.Associations, class.Dependencies = getClassAssociationsAndDependencies(classes, nodes, s, class.Variables, class.Methods, definitionDependencies[index])
+			response.Nodes = append(response.Nodes, class)
+			addClassRelations(&response, class.Package, class.Name, class.DefinedWithin, class.Extends, class.Associations, class.Dependencies)
+		case types.JavaEnum:
+			response.Nodes = append(response.Nodes, class)
+			addClassRelations(&response, class.Package, class.Name, class.DefinedWithin, nil, nil, nil)
+		}
+	}
+
+	return &response
+}
+
+func getScope(parsedFile fileResponse, namespace string) scope {
+	return scope{
+		Namespace:    namespace,
+		Usings:       parsedFile.Usings,
+		Declarations: parsedFile.Declarations,
+	}
+}
+
+func getNamespace(pkg []byte) string {
+	if string(pkg) == globalNamespace {
+		return ""
+	}
+
+	return string(pkg)
+}
+
+// Adds the body of a member function to the declared method with the same name and number of parameters
+func addDefinition(methods []types.JavaMethod, d definition) {
+	for i := range methods {
+		if string(methods[i].Name) == d.Name && len(methods[i].Parameters) == d.Parameters {
+			methods[i].Functionality = append(methods[i].Functionality, d.Body...)
+			return
+		}
+	}
+}
+
+// Resolves a qualified name such as "Dog", "zoo::Dog" or "Outer::Inner" to the index of its node
+func resolveClass(classes map[string]int, nodes []any, s scope, name string) (int, bool) {
+	name = strings.TrimPrefix(name, "::")
+	components := strings.Split(name, "::")
+
+	if declaration, ok := s.Declarations[components[0]]; ok {
+		components = append(strings.Split(declaration, "::"), components[1:]...)
+		name = strings.Join(components, "::")
+	}
+
+	// Enclosing namespaces, from the innermost to the global namespace
+	for namespace := s.Namespace; ; {
+		if index, ok := classes[joinNamespace(namespace, name)]; ok {
+			return index, true
+		}
+
+		if namespace == "" {
+			break
+		}
+
+		if index := strings.LastIndex(namespace, "::"); index != -1 {
+			namespace = namespace[:index]
+		} else {
+			namespace = ""
+		}
+	}
+
+	for _, using := range s.Usings {
+		if index, ok := classes[joinNamespace(using, name)]; ok {
+			return index, true
+		}
+	}
+
+	// Nested classes share the namespace of the class they are declared in: Outer::Inner
+	if len(components) > 1 {
+		if outer, ok := resolveClass(classes, nodes, s, strings.Join(components[:len(components)-1], "::")); ok {
+			pkg, _ := getClassPackageAndName(nodes[outer])
+			if index, ok := classes[joinNamespace(getNamespace(pkg), components[len(components)-1])]; ok {
+				return index, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// Resolves a name such as "Dog::create" or "zoo::Dog" to a class id, or returns an empty string.
+// The longest prefix that names a class wins.
+func resolveName(classes map[string]int, nodes []any, s scope, name string) string {
+	components := strings.Split(name, "::")
+
+	for end := len(components); end > 0; end-- {
+		if index, ok := resolveClass(classes, nodes, s, strings.Join(components[:end], "::")); ok {
+			return getClassId(nodes[index])
+		}
+	}
+
+	return ""
+}
+
+func resolveBases(classes map[string]int, nodes []any, s scope, bases []types.CustomByteSlice) []types.CustomByteSlice {
+	var response []types.CustomByteSlice
+
+	for _, base := range bases {
+		if index, ok := resolveClass(classes, nodes, s, string(base)); ok {
+			response = append(response, types.CustomByteSlice(getClassId(nodes[index])))
+		}
+	}
+
+	return response
+}
+
+// Returns associations and dependencies. Member types are associations, parameter types and the
+// classes used by method bodies are dependencies.
+func getClassAssociationsAndDependencies(classes map[string]int, nodes []any, s scope, variables []types.JavaVariable, methods []types.JavaMethod, definitionDependencies map[string]struct{}) ([]types.CustomByteSlice, []types.CustomByteSlice) {
+	var (
+		associationsMap = make(map[string]struct{})
+		dependenciesMap = make(map[string]struct{})
+	)
+
+	for _, variable := range variables {
+		addReferences(associationsMap, classes, nodes, s, variable.Type)
+		addReferences(associationsMap, classes, nodes, s, variable.Value)
+	}
+
+	for _, method := range methods {
+		for _, parameter := range method.Parameters {
+			addReferences(dependenciesMap, classes, nodes, s, parameter.Type)
+		}
+
+		addReferences(dependenciesMap, classes, nodes, s, method.Functionality)
+	}
+
+	for classId := range definitionDependencies {
+		dependenciesMap[classId] = struct{}{}
+	}
+
+	// Association is a stronger form of a dependency
+	for classId := range associationsMap {
+		delete(dependenciesMap, classId)
+	}
+
+	return sortedKeys(associationsMap), sortedKeys(dependenciesMap)
+}
+
+// Adds the class id of every name in the text that resolves to a class
+func addReferences(m map[string]struct{}, classes map[string]int, nodes []any, s scope, text []byte) {
+	for _, name := range identifierRegex.FindAllString(string(text), -1) {
+		if classId := resolveName(classes, nodes, s, name); classId != "" {
+			m[classId] = struct{}{}
+		}
+	}
+}
+
+func addClassRelations(project *types.Project, packageName, name, definedWithin []byte, extends, associations, dependencies []types.CustomByteSlice) {
+	fromClassId := []byte(string(packageName) + "." + string(name))
+
+	for _, extend := range extends {
+		project.AddRelation(fromClassId, extend, &types.Generalization{})
+	}
+
+	if len(definedWithin) != 0 {
+		project.AddRelation(fromClassId, []byte(string(packageName)+"."+string(definedWithin)), &types.NestedOwnership{})
+	}
+
+	for _, association := range associations {
+		project.AddRelation(fromClassId, association, &types.Association{})
+	}
+
+	for _, dependency := range dependencies {
+		project.AddRelation(fromClassId, dependency, &types.Dependency{})
+	}
+}
+
+// Vector<T> -> Vector
+func removeTemplateArguments(name string) string {
+	if index := strings.IndexByte(name, '<'); index != -1 {
+		return name[:index]
+	}
+
+	return name
+}
+
+func getClassPackageAndName(class any) ([]byte, string) {
+	switch c := class.(type) {
+	case types.JavaAbstract:
+		return c.Package, string(c.Name)
+	case types.JavaClass:
+		return c.Package, string(c.Name)
+	case types.JavaEnum:
+		return c.Package, string(c.Name)
+	}
+
+	return nil, ""
+}
+
+func getClassId(class any) string {
+	pkg, name := getClassPackageAndName(class)
+	return string(pkg) + "." + name
+}
+
+func sortedKeys(m map[string]struct{}) []types.CustomByteSlice {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var response []types.CustomByteSlice
+	for _, key := range keys {
+		response = append(response, []byte(key))
+	}
+
+	return response
+}
@@ -0,0 +1,180 @@
+package cpp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func relationToString(relation types.Relation) string {
+	return fmt.Sprintf("%s -> %s %T from:%t to:%t", relation.FromClassId, relation.ToClassId, relation.Type, relation.Type.GetFromArrow(), relation.Type.GetToArrow())
+}
+
+func TestParseProject(t *testing.T) {
+	type Output struct {
+		Nodes []string
+		Edges []types.Relation
+	}
+
+	type ParseProjectTest struct {
+		Input  []types.File
+		Output Output
+	}
+
+	var tests = []ParseProjectTest{
+		{
+			Input: []types.File{
+				{
+					Name:      "animal",
+					Extension: "h",
+					Code: []byte(`
+#pragma once
+
+namespace zoo {
+
+class Speaker {
+public:
+	virtual ~Speaker() = default;
+	virtual std::string speak() const = 0;
+};
+
+class Walker {
+public:
+	virtual void walk(int steps) = 0;
+};
+
+class Animal : public Speaker {
+protected:
+	Keeper* keeper;
+};
+
+}
+`),
+				},
+				{
+					Name:      "dog",
+					Extension: "hpp",
+					Code: []byte(`
+#pragma once
+#include "animal.h"
+
+namespace zoo {
+namespace pets {
+
+template <typename T>
+class Leash {
+	T* owner;
+};
+
+class Dog : public Animal, private Walker {
+public:
+	std::string speak() const override;
+	void walk(int steps) override;
+	void learn(const Trick& trick);
+
+	struct Collar { };
+
+private:
+	Leash<Dog> leash;
+	std::vector<Collar> collars;
+};
+
+}
+}
+`),
+				},
+				{
+					Name:      "dog",
+					Extension: "cpp",
+					Code: []byte(`
+#include "dog.hpp"
+
+using zoo::staff::Schedule;
+
+namespace zoo::pets {
+
+std::string Dog::speak() const { return "Woof"; }
+
+void Dog::walk(int steps) {
+	auto today = Schedule::today();
+}
+
+}
+`),
+				},
+				{
+					Name:      "keeper",
+					Extension: "h",
+					Code: []byte(`
+namespace zoo {
+struct Keeper { std::string name; };
+struct Trick { };
+
+namespace staff {
+class Schedule {
+public:
+	static Schedule today();
+};
+}
+}
+`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{"zoo.Speaker", "zoo.Walker", "zoo.Animal", "zoo::pets.Leash<T>", "zoo::pets.Dog", "zoo::pets.Collar", "zoo.Keeper", "zoo.Trick", "zoo::staff.Schedule"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("zoo.Animal"), ToClassId: []byte("zoo.Speaker"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("zoo.Animal"), ToClassId: []byte("zoo.Keeper"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("zoo::pets.Dog"), ToClassId: []byte("zoo.Animal"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("zoo::pets.Dog"), ToClassId: []byte("zoo.Walker"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("zoo::pets.Dog"), ToClassId: []byte("zoo::pets.Leash<T>"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("zoo::pets.Dog"), ToClassId: []byte("zoo::pets.Collar"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("zoo::pets.Dog"), ToClassId: []byte("zoo.Trick"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("zoo::pets.Dog"), ToClassId: []byte("zoo::staff.Schedule"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("zoo::pets.Collar"), ToClassId: []byte("zoo::pets.Dog"), Type: &types.NestedOwnership{ToArrow: true}},
+				},
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := ParseProject(context.Background(), tt.Input)
+
+			var nodes []string
+			for _, node := range response.Nodes {
+				nodes = append(nodes, getClassId(node))
+			}
+
+			if fmt.Sprint(nodes) != fmt.Sprint(tt.Output.Nodes) {
+				subtest.Errorf("incorrect nodes.\nexpected:\n%s\ngot:\n%s\n", tt.Output.Nodes, nodes)
+			}
+
+			var expectedEdges, actualEdges []string
+			for _, edge := range tt.Output.Edges {
+				expectedEdges = append(expectedEdges, relationToString(edge))
+			}
+
+			for _, edge := range response.Edges {
+				actualEdges = append(actualEdges, relationToString(edge))
+			}
+
+			sort.Strings(expectedEdges)
+			sort.Strings(actualEdges)
+
+			if fmt.Sprint(expectedEdges) != fmt.Sprint(actualEdges) {
+				subtest.Errorf("incorrect edges.\nexpected:\n%s\ngot:\n%s\n", expectedEdges, actualEdges)
+			}
+
+			for _, node := range response.Nodes {
+				if speaker, ok := node.(types.JavaAbstract); ok && string(speaker.Name) != "Speaker" && string(speaker.Name) != "Walker" {
+					subtest.Errorf("%s should not be abstract", speaker.Name)
+				}
+			}
+		})
+	}
+}
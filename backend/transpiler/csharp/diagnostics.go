@@ -0,0 +1,53 @@
+package csharp
+
+import (
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+var openingBrackets = map[string]string{")": "(", "]": "[", "}": "{"}
+
+// Get the brackets that are never closed or closed by the wrong bracket, because the declarations after them are lost
+func getSyntaxDiagnostics(tokens []token) []types.Diagnostic {
+	var (
+		response     []types.Diagnostic
+		openedTokens []token
+	)
+
+	for _, t := range tokens {
+		if t.Kind != punctuationToken {
+			continue
+		}
+
+		if isOpeningBracket(t.Text) {
+			openedTokens = append(openedTokens, t)
+			continue
+		}
+
+		opening, ok := openingBrackets[t.Text]
+		if !ok {
+			continue
+		}
+
+		if len(openedTokens) == 0 || openedTokens[len(openedTokens)-1].Text != opening {
+			return append(response, types.Diagnostic{
+				Severity: types.DiagnosticError,
+				Line:     t.Line,
+				Message:  "closing bracket " + t.Text + " does not match an opening bracket",
+			})
+		}
+
+		openedTokens = openedTokens[:len(openedTokens)-1]
+	}
+
+	// Only the innermost bracket that is still open is reported
+	if len(openedTokens) != 0 {
+		t := openedTokens[len(openedTokens)-1]
+		response = append(response, types.Diagnostic{
+			Severity: types.DiagnosticError,
+			Line:     t.Line,
+			Message:  "opening bracket " + t.Text + " is never closed",
+		})
+	}
+
+	return response
+}
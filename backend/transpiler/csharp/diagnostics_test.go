@@ -0,0 +1,78 @@
+package csharp
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestGetDiagnostics(t *testing.T) {
+	type GetDiagnosticsTest struct {
+		Input  []byte
+		Output []string
+	}
+
+	var tests = []GetDiagnosticsTest{
+		{
+			Input: []byte(`namespace Zoo;
+
+public class Keeper
+{
+	private string note = "{ (";
+}
+`),
+			Output: nil,
+		},
+		{
+			Input:  []byte("using System;\n\nnamespace Zoo"),
+			Output: []string{"error Zoo/Keeper.cs:3 namespace Zoo is not followed by a body or a semicolon"},
+		},
+		{
+			Input: []byte(`namespace Zoo
+{
+	public class Keeper
+	{
+		public void Feed(Animal animal
+	}
+}
+`),
+			Output: []string{"error Zoo/Keeper.cs:6 closing bracket } does not match an opening bracket"},
+		},
+		{
+			Input:  []byte("namespace Zoo {\n\tpublic class Keeper {"),
+			Output: []string{"error Zoo/Keeper.cs:2 opening bracket { is never closed"},
+		},
+		{
+			Input:  []byte("public enum Size {"),
+			Output: []string{"error Zoo/Keeper.cs:1 opening bracket { is never closed"},
+		},
+		{
+			Input: []byte("public class Keeper {\n\tpublic event"),
+			Output: []string{
+				"error Zoo/Keeper.cs:2 event in Keeper is not followed by a declaration",
+				"error Zoo/Keeper.cs:1 opening bracket { is never closed",
+			},
+		},
+		{
+			Input:  []byte("public record Keeper("),
+			Output: []string{"error Zoo/Keeper.cs:1 opening bracket ( is never closed"},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := parseFile(types.File{Path: "Zoo/Keeper.cs", Name: "Keeper", Extension: "cs", Code: tt.Input})
+
+			var got []string
+			for _, diagnostic := range response.Diagnostics {
+				got = append(got, fmt.Sprintf("%s %s:%d %s", diagnostic.Severity, diagnostic.Path, diagnostic.Line, diagnostic.Message))
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.Output) {
+				subtest.Errorf("incorrect diagnostics.\nexpected:\n%v\ngot:\n%v\n", tt.Output, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,802 @@
+package csharp
+
+import (
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+// Classes declared outside of a namespace belong to the global namespace
+const globalNamespace = "default"
+
+type fileResponse struct {
+	Usings      []string          // Namespaces imported with using directives
+	Aliases     map[string]string // using Alias = Namespace.Type;
+	Data        []any             // Holds [](JavaAbstract | JavaClass | JavaInterface | JavaEnum)
+	Diagnostics []types.Diagnostic
+}
+
+var modifiers = map[string]struct{}{
+	"public": {}, "private": {}, "protected": {}, "internal": {}, "static": {}, "abstract": {}, "sealed": {}, "partial": {},
+	"readonly": {}, "const": {}, "virtual": {}, "override": {}, "new": {}, "extern": {}, "unsafe": {}, "volatile": {},
+	"async": {}, "required": {}, "file": {}, "fixed": {},
+}
+
+var parameterModifiers = map[string]struct{}{
+	"ref": {}, "out": {}, "in": {}, "params": {}, "this": {}, "scoped": {}, "readonly": {},
+}
+
+var typeKeywords = map[string]struct{}{
+	"class": {}, "struct": {}, "interface": {}, "record": {}, "enum": {},
+}
+
+func parseFile(file types.File) fileResponse {
+	var (
+		response = fileResponse{Aliases: make(map[string]string)}
+		tokens   = tokenize(file.Code)
+	)
+
+	if len(tokens) == 0 {
+		return response
+	}
+
+	parseDeclarations(tokens, "", &response)
+	response.Diagnostics = append(response.Diagnostics, getSyntaxDiagnostics(tokens)...)
+	for i := range response.Diagnostics {
+		response.Diagnostics[i].Path = file.GetPath()
+	}
+
+	return response
+}
+
+// Parses the using directives, namespaces and types declared at the top level or inside of a namespace
+func parseDeclarations(tokens []token, namespace string, response *fileResponse) {
+	memberModifiers := make(map[string]struct{})
+
+	for i := 0; i < len(tokens); {
+		text := tokens[i].Text
+
+		if _, ok := modifiers[text]; ok && tokens[i].Kind == identifierToken {
+			memberModifiers[text] = struct{}{}
+			i++
+			continue
+		}
+
+		switch {
+		case text == "global" && isText(tokens, i+1, "using"):
+			i++
+		case text == "using":
+			end := indexOf(tokens, i, ";")
+			directive := tokens[i+1 : end]
+
+			if len(directive) > 0 && directive[0].Text == "static" {
+				// Static usings import members, not types
+			} else if len(directive) > 2 && directive[1].Text == "=" {
+				response.Aliases[directive[0].Text] = joinTokens(directive[2:])
+			} else if len(directive) > 0 {
+				response.Usings = append(response.Usings, joinTokens(directive))
+			}
+
+			i = end + 1
+		case text == "namespace":
+			j := i + 1
+			for isKind(tokens, j, identifierToken) || isText(tokens, j, ".") {
+				j++
+			}
+
+			name := joinNamespace(namespace, joinTokens(tokens[i+1:j]))
+
+			if isText(tokens, j, "{") {
+				body, end := getBalanced(tokens, j)
+				parseDeclarations(body, name, response)
+				i = end
+				continue
+			}
+
+			if !isText(tokens, j, ";") {
+				response.Diagnostics = append(response.Diagnostics, types.Diagnostic{
+					Severity: types.DiagnosticError,
+					Line:     tokens[i].Line,
+					Message:  "namespace " + name + " is not followed by a body or a semicolon",
+				})
+
+				i = j
+				continue
+			}
+
+			// File scoped namespaces contain the rest of the file
+			parseDeclarations(tokens[j+1:], name, response)
+			return
+		case text == "[" || text == "{":
+			i = skipBalanced(tokens, i)
+			continue
+		case isTypeDeclaration(tokens, i):
+			classes, end := parseType(tokens, i, namespace, nil, memberModifiers, response)
+			response.Data = append(response.Data, classes...)
+			i = end
+		case text == "delegate":
+			i = indexOf(tokens, i, ";") + 1
+		default:
+			i++
+		}
+
+		memberModifiers = make(map[string]struct{})
+	}
+}
+
+// Reports whether the token at index starts a class, struct, interface, record or enum declaration
+func isTypeDeclaration(tokens []token, index int) bool {
+	if _, ok := typeKeywords[tokens[index].Text]; !ok || tokens[index].Kind != identifierToken {
+		return false
+	}
+
+	// record class Name, record struct Name
+	if tokens[index].Text == "record" && (isText(tokens, index+1, "class") || isText(tokens, index+1, "struct")) {
+		return isKind(tokens, index+2, identifierToken)
+	}
+
+	return isKind(tokens, index+1, identifierToken)
+}
+
+// Parses the type declared at index and every type nested inside of it.
+// Returns the types and the index after the declaration.
+func parseType(tokens []token, index int, namespace string, definedWithin []byte, typeModifiers map[string]struct{}, response *fileResponse) ([]any, int) {
+	var (
+		keyword = tokens[index].Text
+		i       = index + 1
+		pkg     = types.CustomByteSlice(namespace)
+	)
+
+	if namespace == "" {
+		pkg = types.CustomByteSlice(globalNamespace)
+	}
+
+	if keyword == "record" && (isText(tokens, i, "class") || isText(tokens, i, "struct")) {
+		i++
+	}
+
+	name := tokens[i].Text
+	i++
+
+	if keyword == "enum" {
+		for i < len(tokens) && !isText(tokens, i, "{") && !isText(tokens, i, ";") {
+			i++
+		}
+
+		enum := types.JavaEnum{
+			DefinedWithin: definedWithin,
+			Package:       pkg,
+			Name:          types.CustomByteSlice(name),
+		}
+
+		if !isText(tokens, i, "{") {
+			return []any{enum}, i + 1
+		}
+
+		body, end := getBalanced(tokens, i)
+		enum.Declarations = getEnumDeclarations(body)
+
+		return []any{enum}, end
+	}
+
+	if isText(tokens, i, "<") {
+		i = skipAngles(tokens, i)
+	}
+
+	var (
+		variables []types.JavaVariable
+		methods   []types.JavaMethod
+		nested    []any
+		bases     []types.CustomByteSlice
+	)
+
+	// Positional records declare properties, other types declare a primary constructor
+	if isText(tokens, i, "(") {
+		parameterTokens, end := getBalanced(tokens, i)
+		parameters := parseParameters(parameterTokens)
+
+		if keyword == "record" {
+			for _, parameter := range parameters {
+				variables = append(variables, types.JavaVariable{
+					Type:           parameter.Type,
+					Name:           parameter.Name,
+					AccessModifier: []byte("public"),
+					Final:          true,
+				})
+			}
+		} else {
+			methods = append(methods, types.JavaMethod{
+				Name:           types.CustomByteSlice(name),
+				AccessModifier: []byte("public"),
+				Parameters:     parameters,
+			})
+		}
+
+		i = end
+	}
+
+	// The base list holds the base class and the implemented interfaces in any order of preference,
+	// they are told apart once every type in the project is known
+	if isText(tokens, i, ":") {
+		end := i + 1
+		for end < len(tokens) && !isText(tokens, end, "{") && !isText(tokens, end, ";") && !isText(tokens, end, "where") {
+			if isText(tokens, end, "(") {
+				end = skipBalanced(tokens, end)
+				continue
+			}
+
+			end++
+		}
+
+		for _, part := range splitTopLevel(tokens[i+1:end], ",") {
+			if typeName := getTypeName(part); typeName != "" {
+				bases = append(bases, types.CustomByteSlice(typeName))
+			}
+		}
+
+		i = end
+	}
+
+	// Skip generic constraints
+	for i < len(tokens) && !isText(tokens, i, "{") && !isText(tokens, i, ";") {
+		i++
+	}
+
+	end := i + 1
+	if isText(tokens, i, "{") {
+		var body []token
+		body, end = getBalanced(tokens, i)
+
+		var bodyVariables []types.JavaVariable
+		var bodyMethods []types.JavaMethod
+		bodyVariables, bodyMethods, nested = parseMembers(body, namespace, name, keyword == "interface", response)
+		variables = append(variables, bodyVariables...)
+		methods = append(methods, bodyMethods...)
+	}
+
+	var class any
+	_, isAbstract := typeModifiers["abstract"]
+
+	switch {
+	case keyword == "interface":
+		class = types.JavaInterface{
+			DefinedWithin: definedWithin,
+			Package:       pkg,
+			Name:          types.CustomByteSlice(name),
+			Extends:       bases,
+			Variables:     variables,
+			Methods:       methods,
+		}
+	case isAbstract:
+		class = types.JavaAbstract{
+			DefinedWithin: definedWithin,
+			Package:       pkg,
+			Name:          types.CustomByteSlice(name),
+			Extends:       bases,
+			Variables:     variables,
+			Methods:       methods,
+		}
+	default:
+		class = types.JavaClass{
+			DefinedWithin: definedWithin,
+			Package:       pkg,
+			Name:          types.CustomByteSlice(name),
+			Extends:       bases,
+			Variables:     variables,
+			Methods:       methods,
+		}
+	}
+
+	return append([]any{class}, nested...), end
+}
+
+// Parses the fields, properties, methods and nested types of a type body
+func parseMembers(tokens []token, namespace, className string, isInterface bool, response *fileResponse) ([]types.JavaVariable, []types.JavaMethod, []any) {
+	var (
+		variables []types.JavaVariable
+		methods   []types.JavaMethod
+		nested    []any
+	)
+
+	defaultAccessModifier := types.CustomByteSlice("private")
+	if isInterface {
+		defaultAccessModifier = types.CustomByteSlice("public")
+	}
+
+	for i := 0; i < len(tokens); {
+		if tokens[i].Text == ";" {
+			i++
+			continue
+		}
+
+		// Skip attributes
+		for isText(tokens, i, "[") {
+			i = skipBalanced(tokens, i)
+		}
+
+		memberModifiers := make(map[string]struct{})
+		for i < len(tokens) && tokens[i].Kind == identifierToken {
+			if _, ok := modifiers[tokens[i].Text]; !ok {
+				break
+			}
+
+			memberModifiers[tokens[i].Text] = struct{}{}
+			i++
+		}
+
+		if i >= len(tokens) {
+			break
+		}
+
+		if isTypeDeclaration(tokens, i) {
+			classes, end := parseType(tokens, i, namespace, types.CustomByteSlice(className), memberModifiers, response)
+			nested = append(nested, classes...)
+			i = end
+			continue
+		}
+
+		// Delegates, destructors, operators and conversions are not shown
+		if text := tokens[i].Text; text == "delegate" || text == "~" || text == "implicit" || text == "explicit" || text == "operator" {
+			i = skipMember(tokens, i)
+			continue
+		}
+
+		// Events are shown as fields
+		if tokens[i].Text == "event" {
+			i++
+
+			if i >= len(tokens) {
+				response.Diagnostics = append(response.Diagnostics, types.Diagnostic{
+					Severity: types.DiagnosticError,
+					Line:     tokens[i-1].Line,
+					Message:  "event in " + className + " is not followed by a declaration",
+				})
+
+				break
+			}
+		}
+
+		accessModifier := getAccessModifier(memberModifiers, defaultAccessModifier)
+		_, isStatic := memberModifiers["static"]
+		_, isConst := memberModifiers["const"]
+		_, isReadonly := memberModifiers["readonly"]
+		_, isAbstract := memberModifiers["abstract"]
+
+		// Constructors
+		if tokens[i].Text == className && isText(tokens, i+1, "(") {
+			parameterTokens, end := getBalanced(tokens, i+1)
+			method := types.JavaMethod{
+				Name:           types.CustomByteSlice(className),
+				AccessModifier: accessModifier,
+				Parameters:     parseParameters(parameterTokens),
+				Static:         isStatic,
+			}
+
+			bodyStart := end
+			i = skipMember(tokens, end)
+			method.Functionality = []byte(joinTokens(removeLiterals(tokens[bodyStart:i])))
+			methods = append(methods, method)
+			continue
+		}
+
+		typeEnd := readType(tokens, i)
+		if typeEnd == i {
+			i = skipMember(tokens, i)
+			continue
+		}
+
+		memberType := joinTokens(tokens[i:typeEnd])
+		i = typeEnd
+
+		// Indexers: public T this[int index] { get; }
+		if isText(tokens, i, "this") || isText(tokens, i, "operator") {
+			i = skipMember(tokens, i)
+			continue
+		}
+
+		if !isKind(tokens, i, identifierToken) {
+			i = skipMember(tokens, i)
+			continue
+		}
+
+		// Explicit interface implementations: void IDisposable.Dispose()
+		name := tokens[i].Text
+		for i++; isText(tokens, i, ".") && isKind(tokens, i+1, identifierToken) || isText(tokens, i, "<") && isText(tokens, skipAngles(tokens, i), "."); {
+			if isText(tokens, i, "<") {
+				i = skipAngles(tokens, i)
+				continue
+			}
+
+			name = tokens[i+1].Text
+			i += 2
+		}
+
+		switch {
+		case isText(tokens, i, "(") || isText(tokens, i, "<"):
+			if isText(tokens, i, "<") {
+				i = skipAngles(tokens, i)
+			}
+
+			parameterTokens, end := getBalanced(tokens, i)
+			method := types.JavaMethod{
+				Type:           types.CustomByteSlice(memberType),
+				Name:           types.CustomByteSlice(name),
+				AccessModifier: accessModifier,
+				Parameters:     parseParameters(parameterTokens),
+				Abstract:       isAbstract,
+				Static:         isStatic,
+			}
+
+			bodyStart := end
+			i = skipMember(tokens, end)
+			method.Functionality = []byte(joinTokens(removeLiterals(tokens[bodyStart:i])))
+			methods = append(methods, method)
+		case isText(tokens, i, "{"):
+			// Properties are read only unless they have a set accessor
+			accessors, end := getBalanced(tokens, i)
+			variable := types.JavaVariable{
+				Type:           types.CustomByteSlice(memberType),
+				Name:           types.CustomByteSlice(name),
+				AccessModifier: accessModifier,
+				Static:         isStatic,
+				Final:          !hasSetAccessor(accessors),
+			}
+
+			i = end
+			if isText(tokens, i, "=") {
+				valueEnd := indexOf(tokens, i, ";")
+				variable.Value = []byte(joinTokens(tokens[i+1 : valueEnd]))
+				i = valueEnd + 1
+			}
+
+			variables = append(variables, variable)
+		case isText(tokens, i, "=>"):
+			// Expression bodied properties only have a get accessor
+			variables = append(variables, types.JavaVariable{
+				Type:           types.CustomByteSlice(memberType),
+				Name:           types.CustomByteSlice(name),
+				AccessModifier: accessModifier,
+				Static:         isStatic,
+				Final:          true,
+			})
+
+			i = indexOf(tokens, i, ";") + 1
+		default:
+			// Fields, possibly with several declarators: int a = 1, b;
+			end := indexOf(tokens, i, ";")
+			for _, declarator := range splitTopLevel(tokens[i-1:end], ",") {
+				variable := types.JavaVariable{
+					Type:           types.CustomByteSlice(memberType),
+					Name:           types.CustomByteSlice(declarator[0].Text),
+					AccessModifier: accessModifier,
+					Static:         isStatic || isConst,
+					Final:          isConst || isReadonly,
+				}
+
+				if len(declarator) > 2 && declarator[1].Text == "=" {
+					variable.Value = []byte(joinTokens(declarator[2:]))
+				}
+
+				variables = append(variables, variable)
+			}
+
+			i = end + 1
+		}
+	}
+
+	return variables, methods, nested
+}
+
+// Parses a parameter list such as: (this string value, ref int count, params object[] args, int limit = 10)
+func parseParameters(tokens []token) []types.JavaMethodParameter {
+	var response []types.JavaMethodParameter
+
+	for _, part := range splitTopLevel(tokens, ",") {
+		i := 0
+		for isText(part, i, "[") {
+			i = skipBalanced(part, i)
+		}
+
+		for i < len(part) {
+			if _, ok := parameterModifiers[part[i].Text]; !ok {
+				break
+			}
+
+			i++
+		}
+
+		typeEnd := readType(part, i)
+		if typeEnd == i || !isKind(part, typeEnd, identifierToken) {
+			continue
+		}
+
+		response = append(response, types.JavaMethodParameter{
+			Type: types.CustomByteSlice(joinTokens(part[i:typeEnd])),
+			Name: types.CustomByteSlice(part[typeEnd].Text),
+		})
+	}
+
+	return response
+}
+
+func hasSetAccessor(tokens []token) bool {
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i].Text {
+		case "{", "(", "[":
+			i = skipBalanced(tokens, i) - 1
+		case "set":
+			return true
+		}
+	}
+
+	return false
+}
+
+func getEnumDeclarations(tokens []token) []types.CustomByteSlice {
+	var response []types.CustomByteSlice
+
+	for _, part := range splitTopLevel(tokens, ",") {
+		i := 0
+		for isText(part, i, "[") {
+			i = skipBalanced(part, i)
+		}
+
+		if isKind(part, i, identifierToken) {
+			response = append(response, types.CustomByteSlice(part[i].Text))
+		}
+	}
+
+	return response
+}
+
+func getAccessModifier(memberModifiers map[string]struct{}, defaultAccessModifier types.CustomByteSlice) types.CustomByteSlice {
+	_, isPublic := memberModifiers["public"]
+	_, isInternal := memberModifiers["internal"]
+	_, isProtected := memberModifiers["protected"]
+	_, isPrivate := memberModifiers["private"]
+
+	// private protected is narrower than protected, protected internal is wider
+	switch {
+	case isPublic || isInternal && !isProtected:
+		return []byte("public")
+	case isProtected && !isPrivate || isProtected && isInternal:
+		return []byte("protected")
+	case isPrivate:
+		return []byte("private")
+	}
+
+	return defaultAccessModifier
+}
+
+// Returns the index after the type that starts at start, or start if there is no type
+func readType(tokens []token, start int) int {
+	i := start
+
+	switch {
+	case isText(tokens, i, "("):
+		// Tuples: (int Id, string Name)
+		i = skipBalanced(tokens, i)
+	case isKind(tokens, i, identifierToken):
+		i++
+		for {
+			if (isText(tokens, i, ".") || isText(tokens, i, "::")) && isKind(tokens, i+1, identifierToken) {
+				i += 2
+			} else if isText(tokens, i, "<") {
+				i = skipAngles(tokens, i)
+			} else {
+				break
+			}
+		}
+	default:
+		return start
+	}
+
+	// Nullable, array and pointer suffixes
+	for {
+		if isText(tokens, i, "?") || isText(tokens, i, "*") {
+			i++
+		} else if isText(tokens, i, "[") && (isText(tokens, i+1, "]") || isText(tokens, i+1, ",")) {
+			i = skipBalanced(tokens, i)
+		} else {
+			return i
+		}
+	}
+}
+
+// Returns the name of a base type without type arguments or constructor arguments: Base<T>(value) -> Base
+func getTypeName(tokens []token) string {
+	var builder strings.Builder
+
+	for _, t := range tokens {
+		if t.Kind != identifierToken && t.Text != "." {
+			break
+		}
+
+		builder.WriteString(t.Text)
+	}
+
+	return builder.String()
+}
+
+// Returns the index after the body or the semicolon that ends the member at start
+func skipMember(tokens []token, start int) int {
+	for i := start; i < len(tokens); i++ {
+		switch tokens[i].Text {
+		case "{":
+			end := skipBalanced(tokens, i)
+
+			// Property initializers follow the accessors: public int X { get; } = 1;
+			if isText(tokens, end, "=") {
+				return indexOf(tokens, end, ";") + 1
+			}
+
+			return end
+		case "(", "[":
+			i = skipBalanced(tokens, i) - 1
+		case "=>":
+			return indexOf(tokens, i, ";") + 1
+		case ";":
+			return i + 1
+		}
+	}
+
+	return len(tokens)
+}
+
+// Returns the index of the first text at the top level after start, or the number of tokens
+func indexOf(tokens []token, start int, text string) int {
+	for i := start; i < len(tokens); i++ {
+		if tokens[i].Text == text {
+			return i
+		}
+
+		if isOpeningBracket(tokens[i].Text) {
+			i = skipBalanced(tokens, i) - 1
+		}
+	}
+
+	return len(tokens)
+}
+
+// Returns the index after the bracket that closes the one at index
+func skipBalanced(tokens []token, index int) int {
+	_, end := getBalanced(tokens, index)
+	return end
+}
+
+// Returns the tokens inside of the bracket at index and the index after the bracket that closes it.
+// When the bracket is never closed, the tokens until the end are returned.
+func getBalanced(tokens []token, index int) ([]token, int) {
+	depth := 0
+
+	for i := index; i < len(tokens); i++ {
+		if tokens[i].Kind != punctuationToken {
+			continue
+		}
+
+		switch tokens[i].Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return tokens[index+1 : i], i + 1
+			}
+		}
+	}
+
+	if index+1 >= len(tokens) {
+		return nil, len(tokens)
+	}
+
+	return tokens[index+1:], len(tokens)
+}
+
+// Returns the index after the angle bracket that closes the one at index
+func skipAngles(tokens []token, index int) int {
+	depth := 0
+
+	for i := index; i < len(tokens); i++ {
+		switch tokens[i].Text {
+		case "(", "[":
+			i = skipBalanced(tokens, i) - 1
+		case "<":
+			depth++
+		case ">":
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case ";", "{", "}":
+			return i
+		}
+	}
+
+	return len(tokens)
+}
+
+// Splits tokens on every separator that is not inside of brackets
+func splitTopLevel(tokens []token, separator string) [][]token {
+	var (
+		response [][]token
+		start    int
+		depth    int
+	)
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].Kind != punctuationToken {
+			continue
+		}
+
+		switch tokens[i].Text {
+		case "(", "[", "{", "<":
+			depth++
+		case ")", "]", "}", ">":
+			depth--
+		case separator:
+			if depth != 0 {
+				continue
+			}
+
+			if i > start {
+				response = append(response, tokens[start:i])
+			}
+
+			start = i + 1
+		}
+	}
+
+	if start < len(tokens) {
+		response = append(response, tokens[start:])
+	}
+
+	return response
+}
+
+// Removes string literals so that words inside of them are not mistaken for names
+func removeLiterals(tokens []token) []token {
+	var response []token
+
+	for _, t := range tokens {
+		if t.Kind != stringToken {
+			response = append(response, t)
+		}
+	}
+
+	return response
+}
+
+// Joins tokens into readable code: Dictionary<string, List<int>>
+func joinTokens(tokens []token) string {
+	var builder strings.Builder
+
+	for i, t := range tokens {
+		if i > 0 && (tokens[i-1].Kind != punctuationToken && t.Kind != punctuationToken || tokens[i-1].Text == "," || tokens[i-1].Text == "=" || t.Text == "=") {
+			builder.WriteByte(' ')
+		}
+
+		builder.WriteString(t.Text)
+	}
+
+	return builder.String()
+}
+
+func joinNamespace(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+
+	return parent + "." + name
+}
+
+func isText(tokens []token, index int, text string) bool {
+	return index >= 0 && index < len(tokens) && tokens[index].Text == text
+}
+
+func isKind(tokens []token, index int, kind tokenKind) bool {
+	return index >= 0 && index < len(tokens) && tokens[index].Kind == kind
+}
+
+func isOpeningBracket(text string) bool {
+	return text == "(" || text == "[" || text == "{"
+}
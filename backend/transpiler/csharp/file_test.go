@@ -0,0 +1,324 @@
+package csharp
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestParseFileUsings(t *testing.T) {
+	type UsingsTest struct {
+		Input   []byte
+		Usings  []string
+		Aliases map[string]string
+	}
+
+	var tests = []UsingsTest{
+		{
+			Input: []byte(`
+				using System;
+				using Zoo.Models;
+				global using Zoo.Services;
+				using static System.Math;
+				using Keeper = Zoo.Staff.Keeper;`),
+			Usings:  []string{"System", "Zoo.Models", "Zoo.Services"},
+			Aliases: map[string]string{"Keeper": "Zoo.Staff.Keeper"},
+		},
+		{
+			Input: []byte(`
+				// using Commented.Out;
+				#if DEBUG
+				using Zoo.Debug;
+				#endif
+				namespace Zoo { using Zoo.Inner; }`),
+			Usings:  []string{"Zoo.Debug", "Zoo.Inner"},
+			Aliases: map[string]string{},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := parseFile(types.File{Name: "test", Extension: "cs", Code: tt.Input})
+
+			if len(tt.Usings) != len(response.Usings) {
+				subtest.Errorf("incorrect usings.\nexpected:\n%s\ngot:\n%s\n", tt.Usings, response.Usings)
+				subtest.FailNow()
+			}
+
+			for index, expected := range tt.Usings {
+				if expected != response.Usings[index] {
+					subtest.Errorf("incorrect using.\nexpected:\n%s\ngot:\n%s\n", expected, response.Usings[index])
+				}
+			}
+
+			if len(tt.Aliases) != len(response.Aliases) {
+				subtest.Errorf("incorrect aliases.\nexpected:\n%v\ngot:\n%v\n", tt.Aliases, response.Aliases)
+			}
+
+			for alias, expected := range tt.Aliases {
+				if response.Aliases[alias] != expected {
+					subtest.Errorf("incorrect alias %s.\nexpected:\n%s\ngot:\n%s\n", alias, expected, response.Aliases[alias])
+				}
+			}
+		})
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	file := types.File{
+		Name:      "Animal",
+		Extension: "cs",
+		Code: []byte(`
+using System.Collections.Generic;
+
+namespace Zoo.Models
+{
+	public enum Diet : byte
+	{
+		[Description("plants")]
+		Herbivore = 1,
+		Carnivore,
+	}
+
+	public interface INamed<T> where T : class
+	{
+		string Name { get; }
+		void Rename(string name);
+	}
+
+	[Serializable]
+	public abstract class Animal : Base<Animal>, INamed<Animal>, IComparable
+	{
+		public const int MaxAge = 100, MinAge = 0;
+		private static readonly List<Keeper> keepers = new();
+		protected Diet diet;
+
+		public string Name { get; private set; } = "unknown";
+		public int Age { get; init; }
+		internal bool IsOld => Age > 50;
+		public event EventHandler Fed;
+
+		public Animal(string name, params object[] tags) : base(name)
+		{
+			Name = name;
+		}
+
+		public abstract string Speak();
+
+		public virtual async Task<Dictionary<string, int>> FeedAsync(Food food, int amount = 1)
+		{
+			var text = $"{Name} eats {food}";
+			return new Dictionary<string, int>();
+		}
+
+		void IComparable.CompareTo(object other) { }
+
+		public int this[int index] => index;
+
+		public static Animal operator +(Animal a, Animal b) => a;
+
+		~Animal() { }
+
+		private class Collar
+		{
+			string color;
+		}
+	}
+
+	public record Point(int X, int Y);
+
+	public readonly record struct Size(double Width, double Height) : IShape;
+
+	public struct Cage : IDisposable
+	{
+		public int Width;
+		public void Dispose() { }
+	}
+}
+`),
+	}
+
+	response := parseFile(file)
+
+	if len(response.Data) != 7 {
+		t.Fatalf("incorrect number of classes.\nexpected: 7\ngot: %d\n", len(response.Data))
+	}
+
+	diet, ok := response.Data[0].(types.JavaEnum)
+	if !ok || string(diet.Package) != "Zoo.Models" || len(diet.Declarations) != 2 || string(diet.Declarations[1]) != "Carnivore" {
+		t.Errorf("incorrect enum")
+	}
+
+	named, ok := response.Data[1].(types.JavaInterface)
+	if !ok {
+		t.Fatalf("INamed is not an interface")
+	}
+
+	if len(named.Variables) != 1 || !named.Variables[0].Final || string(named.Variables[0].AccessModifier) != "public" {
+		t.Errorf("incorrect interface property")
+	}
+
+	if len(named.Methods) != 1 || string(named.Methods[0].Name) != "Rename" || string(named.Methods[0].AccessModifier) != "public" {
+		t.Errorf("incorrect interface methods")
+	}
+
+	animal, ok := response.Data[2].(types.JavaAbstract)
+	if !ok {
+		t.Fatalf("Animal is not abstract")
+	}
+
+	if len(animal.Extends) != 3 || string(animal.Extends[0]) != "Base" || string(animal.Extends[1]) != "INamed" {
+		t.Errorf("incorrect base list: %s", animal.Extends)
+	}
+
+	expectedVariables := []types.JavaVariable{
+		{Name: []byte("MaxAge"), Type: []byte("int"), Value: []byte("100"), AccessModifier: []byte("public"), Static: true, Final: true},
+		{Name: []byte("MinAge"), Type: []byte("int"), Value: []byte("0"), AccessModifier: []byte("public"), Static: true, Final: true},
+		{Name: []byte("keepers"), Type: []byte("List<Keeper>"), Value: []byte("new()"), AccessModifier: []byte("private"), Static: true, Final: true},
+		{Name: []byte("diet"), Type: []byte("Diet"), AccessModifier: []byte("protected")},
+		{Name: []byte("Name"), Type: []byte("string"), Value: []byte(`"unknown"`), AccessModifier: []byte("public")},
+		{Name: []byte("Age"), Type: []byte("int"), AccessModifier: []byte("public"), Final: true},
+		{Name: []byte("IsOld"), Type: []byte("bool"), AccessModifier: []byte("public"), Final: true},
+		{Name: []byte("Fed"), Type: []byte("EventHandler"), AccessModifier: []byte("public")},
+	}
+
+	if len(animal.Variables) != len(expectedVariables) {
+		t.Fatalf("incorrect number of variables.\nexpected: %d\ngot: %d\n", len(expectedVariables), len(animal.Variables))
+	}
+
+	for index, expected := range expectedVariables {
+		actual := animal.Variables[index]
+		if string(actual.Name) != string(expected.Name) || string(actual.Type) != string(expected.Type) || string(actual.Value) != string(expected.Value) ||
+			string(actual.AccessModifier) != string(expected.AccessModifier) || actual.Static != expected.Static || actual.Final != expected.Final {
+			t.Errorf("incorrect variable.\nexpected:\n%s %s %s %s\ngot:\n%s %s %s %s\n",
+				expected.AccessModifier, expected.Name, expected.Type, expected.Value,
+				actual.AccessModifier, actual.Name, actual.Type, actual.Value)
+		}
+	}
+
+	expectedMethods := []types.JavaMethod{
+		{Name: []byte("Animal"), AccessModifier: []byte("public")},
+		{Name: []byte("Speak"), Type: []byte("string"), AccessModifier: []byte("public"), Abstract: true},
+		{Name: []byte("FeedAsync"), Type: []byte("Task<Dictionary<string, int>>"), AccessModifier: []byte("public")},
+		{Name: []byte("CompareTo"), Type: []byte("void"), AccessModifier: []byte("private")},
+	}
+
+	if len(animal.Methods) != len(expectedMethods) {
+		t.Fatalf("incorrect number of methods.\nexpected: %d\ngot: %d\n", len(expectedMethods), len(animal.Methods))
+	}
+
+	for index, expected := range expectedMethods {
+		actual := animal.Methods[index]
+		if string(actual.Name) != string(expected.Name) || string(actual.Type) != string(expected.Type) ||
+			string(actual.AccessModifier) != string(expected.AccessModifier) || actual.Abstract != expected.Abstract {
+			t.Errorf("incorrect method.\nexpected:\n%s %s %s\ngot:\n%s %s %s\n", expected.AccessModifier, expected.Name, expected.Type, actual.AccessModifier, actual.Name, actual.Type)
+		}
+	}
+
+	if constructor := animal.Methods[0]; len(constructor.Parameters) != 2 || string(constructor.Parameters[1].Type) != "object[]" {
+		t.Errorf("incorrect constructor parameters")
+	}
+
+	if feed := animal.Methods[2]; len(feed.Parameters) != 2 || string(feed.Parameters[0].Type) != "Food" || string(feed.Parameters[1].Name) != "amount" {
+		t.Errorf("incorrect feed parameters")
+	}
+
+	collar, ok := response.Data[3].(types.JavaClass)
+	if !ok || string(collar.DefinedWithin) != "Animal" || string(collar.Package) != "Zoo.Models" || len(collar.Variables) != 1 {
+		t.Errorf("incorrect nested class")
+	}
+
+	point, ok := response.Data[4].(types.JavaClass)
+	if !ok || len(point.Variables) != 2 || string(point.Variables[1].Name) != "Y" || string(point.Variables[1].AccessModifier) != "public" {
+		t.Errorf("incorrect positional record")
+	}
+
+	size, ok := response.Data[5].(types.JavaClass)
+	if !ok || string(size.Name) != "Size" || len(size.Variables) != 2 || len(size.Extends) != 1 {
+		t.Errorf("incorrect record struct")
+	}
+
+	cage, ok := response.Data[6].(types.JavaClass)
+	if !ok || len(cage.Variables) != 1 || len(cage.Methods) != 1 || len(cage.Extends) != 1 {
+		t.Errorf("incorrect struct")
+	}
+}
+
+func TestParseFileScopedNamespace(t *testing.T) {
+	response := parseFile(types.File{
+		Name:      "Keeper",
+		Extension: "cs",
+		Code: []byte(`
+namespace Zoo.Staff;
+
+public sealed class Keeper
+{
+	private readonly string name;
+}
+
+file class Helper { }
+`),
+	})
+
+	if len(response.Data) != 2 {
+		t.Fatalf("incorrect number of classes.\nexpected: 2\ngot: %d\n", len(response.Data))
+	}
+
+	for _, class := range response.Data {
+		if c, ok := class.(types.JavaClass); !ok || string(c.Package) != "Zoo.Staff" {
+			t.Errorf("incorrect namespace")
+		}
+	}
+
+	if response := parseFile(types.File{Name: "Global", Extension: "cs", Code: []byte(`class Global { }`)}); string(response.Data[0].(types.JavaClass).Package) != globalNamespace {
+		t.Errorf("incorrect global namespace")
+	}
+}
+
+func TestParseFileTruncated(t *testing.T) {
+	type ParseFileTruncatedTest struct {
+		Input  []byte
+		Output []string
+	}
+
+	// Files that are cut off keep the types that were declared before the cut
+	var tests = []ParseFileTruncatedTest{
+		{
+			Input:  []byte("using System;\n\nnamespace Zoo"),
+			Output: nil,
+		},
+		{
+			Input:  []byte("namespace Zoo {\n\tpublic class Keeper {"),
+			Output: []string{"Zoo.Keeper"},
+		},
+		{
+			Input:  []byte("public enum Size {"),
+			Output: []string{"default.Size"},
+		},
+		{
+			Input:  []byte("public class Keeper {\n\tpublic event"),
+			Output: []string{"default.Keeper"},
+		},
+		{
+			Input:  []byte("public record Keeper("),
+			Output: []string{"default.Keeper"},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := parseFile(types.File{Name: "Keeper", Extension: "cs", Code: tt.Input})
+
+			var got []string
+			for _, class := range response.Data {
+				got = append(got, getClassId(class))
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.Output) {
+				subtest.Errorf("incorrect classes.\nexpected:\n%v\ngot:\n%v\n", tt.Output, got)
+			}
+		})
+	}
+}
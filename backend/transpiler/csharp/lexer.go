@@ -0,0 +1,182 @@
+package csharp
+
+import "strings"
+
+type tokenKind int
+
+const (
+	identifierToken tokenKind = iota
+	punctuationToken
+	stringToken
+	numberToken
+)
+
+type token struct {
+	Kind tokenKind
+	Text string
+	Line int // The line that the token starts on, starting at 1
+}
+
+// Punctuation made of more than one character that matters to the parser
+var multiCharPunctuation = []string{"=>", "::", "?.", "??", "=="}
+
+// Splits code into tokens. Comments, whitespace and preprocessor directives are removed.
+func tokenize(code []byte) []token {
+	var (
+		response    []token
+		text        = string(code)
+		atLineStart = true
+		line        = 1
+		lineIndex   int // The index that line was counted up to
+	)
+
+	appendToken := func(kind tokenKind, start, end int) {
+		line += strings.Count(text[lineIndex:start], "\n")
+		lineIndex = start
+
+		response = append(response, token{Kind: kind, Text: text[start:end], Line: line})
+		atLineStart = false
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+
+		switch {
+		case c == '\n':
+			atLineStart = true
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '#' && atLineStart:
+			// Preprocessor directives such as #region and #if span the rest of the line
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(text[i:], "//"):
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end == -1 {
+				i = len(text)
+				break
+			}
+
+			i += end + 4
+		case c == '"' || c == '\'' || ((c == '@' || c == '$') && i+1 < len(text) && (text[i+1] == '"' || text[i+1] == '$' || text[i+1] == '@')):
+			end := skipString(text, i)
+			appendToken(stringToken, i, end)
+			i = end
+		case isIdentifierStart(c) || (c == '@' && i+1 < len(text) && isIdentifierStart(text[i+1])):
+			// Verbatim identifiers such as @class are written without the @
+			start := i
+			if c == '@' {
+				start++
+			}
+
+			end := start + 1
+			for end < len(text) && isIdentifierPart(text[end]) {
+				end++
+			}
+
+			appendToken(identifierToken, start, end)
+			i = end
+		case c >= '0' && c <= '9':
+			end := i + 1
+			for end < len(text) && (isIdentifierPart(text[end]) || text[end] == '.' && end+1 < len(text) && text[end+1] >= '0' && text[end+1] <= '9') {
+				end++
+			}
+
+			appendToken(numberToken, i, end)
+			i = end
+		default:
+			end := i + 1
+			for _, punctuation := range multiCharPunctuation {
+				if strings.HasPrefix(text[i:], punctuation) {
+					end = i + len(punctuation)
+					break
+				}
+			}
+
+			appendToken(punctuationToken, i, end)
+			i = end
+		}
+	}
+
+	return response
+}
+
+// Returns the index after the string or character literal that starts at start. Handles verbatim (@"..."),
+// interpolated ($"...{value}...") and raw ("""...""") strings.
+func skipString(text string, start int) int {
+	var (
+		i            = start
+		verbatim     bool
+		interpolated bool
+	)
+
+	for i < len(text) && (text[i] == '@' || text[i] == '$') {
+		verbatim = verbatim || text[i] == '@'
+		interpolated = interpolated || text[i] == '$'
+		i++
+	}
+
+	// Raw string literals end with at least as many quotes as they start with
+	if strings.HasPrefix(text[i:], `"""`) {
+		quotes := 0
+		for i < len(text) && text[i] == '"' {
+			quotes++
+			i++
+		}
+
+		end := strings.Index(text[i:], strings.Repeat(`"`, quotes))
+		if end == -1 {
+			return len(text)
+		}
+
+		return i + end + quotes
+	}
+
+	quote := text[i]
+	depth := 0
+
+	for i++; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case depth > 0:
+			if c == '"' || c == '\'' {
+				i = skipString(text, i) - 1
+			} else if c == '{' {
+				depth++
+			} else if c == '}' {
+				depth--
+			}
+		case c == '\\' && !verbatim:
+			i++
+		case c == quote && verbatim && i+1 < len(text) && text[i+1] == quote:
+			i++
+		case c == quote:
+			return i + 1
+		case c == '{' && interpolated:
+			if i+1 < len(text) && text[i+1] == '{' {
+				i++
+			} else {
+				depth++
+			}
+		case c == '\n' && !verbatim:
+			return i
+		}
+	}
+
+	return len(text)
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,415 @@
+package csharp
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+var identifierRegex = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*`)
+
+// What the project knows about a declared type
+type classInfo struct {
+	Package     string
+	IsInterface bool
+}
+
+// The names that are visible inside of a type declaration
+type scope struct {
+	Namespace string
+	Usings    []string
+	Aliases   map[string]string
+}
+
+func ParseProject(ctx context.Context, files []types.File) *types.Project {
+	var (
+		response types.Project
+		classIds []string
+		merged   = make(map[string]any)
+	)
+
+	parsedFiles, diagnostics := types.ParseFiles(ctx, files, parseFile)
+	response.Diagnostics = diagnostics
+
+	classes := getProjectClasses(parsedFiles)
+
+	// Partial classes may be split across files, every part is resolved with the usings of its own file
+	for _, parsedFile := range parsedFiles {
+		response.Diagnostics = append(response.Diagnostics, parsedFile.Diagnostics...)
+
+		for _, parsedClass := range parsedFile.Data {
+			class := resolveClass(classes, parsedFile, parsedClass)
+			classId := getClassId(class)
+
+			if existing, ok := merged[classId]; ok {
+				merged[classId] = mergeClasses(existing, class)
+				continue
+			}
+
+			merged[classId] = class
+			classIds = append(classIds, classId)
+		}
+	}
+
+	for _, classId := range classIds {
+		switch class := merged[classId].(type) {
+		case types.JavaAbstract:
+			class.Dependencies = removeClassIds(class.Dependencies, class.Associations)
+			response.Nodes = append(response.Nodes, class)
+			addClassRelations(&response, class.Package, class.Name, class.DefinedWithin, class.Implements, class.Extends, class.Associations, class.Dependencies)
+		case types.JavaClass:
+			class.Dependencies = removeClassIds(class.Dependencies, class.Associations)
+			response.Nodes = append(response.Nodes, class)
+			addClassRelations(&response, class.Package, class.Name, class.DefinedWithin, class.Implements, class.Extends, class.Associations, class.Dependencies)
+		case types.JavaInterface:
+			class.Dependencies = removeClassIds(class.Dependencies, class.Associations)
+			response.Nodes = append(response.Nodes, class)
+			addClassRelations(&response, class.Package, class.Name, class.DefinedWithin, nil, class.Extends, class.Associations, class.Dependencies)
+		case types.JavaEnum:
+			response.Nodes = append(response.Nodes, class)
+			addClassRelations(&response, class.Package, class.Name, class.DefinedWithin, nil, nil, nil, nil)
+		}
+	}
+
+	return &response
+}
+
+// Returns every type declared in the project keyed by class id
+func getProjectClasses(parsedFiles []fileResponse) map[string]classInfo {
+	response := make(map[string]classInfo)
+
+	for _, parsedFile := range parsedFiles {
+		for _, parsedClass := range parsedFile.Data {
+			_, isInterface := parsedClass.(types.JavaInterface)
+			classId := getClassId(parsedClass)
+
+			response[classId] = classInfo{
+				Package:     classId[:strings.LastIndexByte(classId, '.')],
+				IsInterface: isInterface || response[classId].IsInterface,
+			}
+		}
+	}
+
+	return response
+}
+
+// Resolves the base list, associations and dependencies of a parsed type to class ids
+func resolveClass(classes map[string]classInfo, parsedFile fileResponse, parsedClass any) any {
+	switch class := parsedClass.(type) {
+	case types.JavaAbstract:
+		s := getScope(parsedFile, class.Package)
+		class.Implements, class.Extends = resolveBaseList(classes, s, class.Extends, false)
+		class.Associations, class.Dependencies = getClassAssociationsAndDependencies(classes, s, class.Variables, class.Methods)
+		return class
+	case types.JavaClass:
+		s := getScope(parsedFile, class.Package)
+		class.Implements, class.Extends = resolveBaseList(classes, s, class.Extends, false)
+		class.Associations, class.Dependencies = getClassAssociationsAndDependencies(classes, s, class.Variables, class.Methods)
+		return class
+	case types.JavaInterface:
+		s := getScope(parsedFile, class.Package)
+		_, class.Extends = resolveBaseList(classes, s, class.Extends, true)
+		class.Associations, class.Dependencies = getClassAssociationsAndDependencies(classes, s, class.Variables, class.Methods)
+		return class
+	}
+
+	return parsedClass
+}
+
+func getScope(parsedFile fileResponse, pkg []byte) scope {
+	namespace := string(pkg)
+	if namespace == globalNamespace {
+		namespace = ""
+	}
+
+	return scope{
+		Namespace: namespace,
+		Usings:    parsedFile.Usings,
+		Aliases:   parsedFile.Aliases,
+	}
+}
+
+// A base list names the base class and the interfaces together, they are told apart by what the project declares.
+// Interfaces extend every entry, so every entry of an interface is returned as extended.
+func resolveBaseList(classes map[string]classInfo, s scope, bases []types.CustomByteSlice, isInterface bool) ([]types.CustomByteSlice, []types.CustomByteSlice) {
+	var implements, extends []types.CustomByteSlice
+
+	for _, base := range bases {
+		classId := resolveName(classes, s, string(base))
+		if classId == "" {
+			continue
+		}
+
+		if classes[classId].IsInterface && !isInterface {
+			implements = append(implements, types.CustomByteSlice(classId))
+		} else {
+			extends = append(extends, types.CustomByteSlice(classId))
+		}
+	}
+
+	return implements, extends
+}
+
+// Resolves a name such as "Dog", "Models.Dog" or "Dog.Create" to a class id, or returns an empty string
+func resolveName(classes map[string]classInfo, s scope, name string) string {
+	components := strings.Split(name, ".")
+
+	// The longest prefix that names a type wins: Models.Dog.Create -> Models.Dog
+	for end := len(components); end > 0; end-- {
+		if classId := resolveTypeName(classes, s, components[:end]); classId != "" {
+			return classId
+		}
+	}
+
+	return ""
+}
+
+func resolveTypeName(classes map[string]classInfo, s scope, components []string) string {
+	name := strings.Join(components, ".")
+
+	// Enclosing namespaces, from the innermost to the global namespace
+	for namespace := s.Namespace; ; {
+		if classId := getExistingClassId(classes, namespace, name); classId != "" {
+			return classId
+		}
+
+		if namespace == "" {
+			break
+		}
+
+		if index := strings.LastIndexByte(namespace, '.'); index != -1 {
+			namespace = namespace[:index]
+		} else {
+			namespace = ""
+		}
+	}
+
+	if alias, ok := s.Aliases[components[0]]; ok {
+		if classId := getExistingClassId(classes, "", strings.Join(append([]string{alias}, components[1:]...), ".")); classId != "" {
+			return classId
+		}
+	}
+
+	if len(components) == 1 {
+		for _, using := range s.Usings {
+			if classId := getExistingClassId(classes, using, name); classId != "" {
+				return classId
+			}
+		}
+	}
+
+	// Nested types share the namespace of the type they are declared in: Outer.Inner
+	if len(components) > 1 {
+		if outerClassId := resolveTypeName(classes, s, components[:len(components)-1]); outerClassId != "" {
+			return getExistingClassId(classes, classes[outerClassId].Package, components[len(components)-1])
+		}
+	}
+
+	return ""
+}
+
+// Returns the class id of the name inside of the namespace if the project declares it. Names looked up
+// in the global namespace may also be fully qualified.
+func getExistingClassId(classes map[string]classInfo, namespace, name string) string {
+	candidates := []string{namespace + "." + name}
+	if namespace == "" {
+		candidates = []string{globalNamespace + "." + name, name}
+	}
+
+	for _, classId := range candidates {
+		if _, ok := classes[classId]; ok {
+			return classId
+		}
+	}
+
+	return ""
+}
+
+// Returns associations and dependencies
+func getClassAssociationsAndDependencies(classes map[string]classInfo, s scope, variables []types.JavaVariable, methods []types.JavaMethod) ([]types.CustomByteSlice, []types.CustomByteSlice) {
+	var (
+		associationsMap = make(map[string]struct{})
+		dependenciesMap = make(map[string]struct{})
+	)
+
+	addReferences := func(m map[string]struct{}, text []byte) {
+		for _, name := range identifierRegex.FindAllString(string(text), -1) {
+			if classId := resolveName(classes, s, name); classId != "" {
+				m[classId] = struct{}{}
+			}
+		}
+	}
+
+	for _, variable := range variables {
+		addReferences(associationsMap, variable.Type)
+		addReferences(associationsMap, variable.Value)
+	}
+
+	for _, method := range methods {
+		for _, parameter := range method.Parameters {
+			addReferences(dependenciesMap, parameter.Type)
+		}
+
+		addReferences(dependenciesMap, method.Functionality)
+	}
+
+	// Association is a stronger form of a dependency
+	for classId := range associationsMap {
+		delete(dependenciesMap, classId)
+	}
+
+	return sortedKeys(associationsMap), sortedKeys(dependenciesMap)
+}
+
+// Merges the parts of a partial class. The class is abstract if any part is abstract.
+func mergeClasses(existing, part any) any {
+	switch e := existing.(type) {
+	case types.JavaClass:
+		switch p := part.(type) {
+		case types.JavaClass:
+			e.Implements = appendClassIds(e.Implements, p.Implements)
+			e.Extends = appendClassIds(e.Extends, p.Extends)
+			e.Variables = append(e.Variables, p.Variables...)
+			e.Methods = append(e.Methods, p.Methods...)
+			e.Associations = appendClassIds(e.Associations, p.Associations)
+			e.Dependencies = appendClassIds(e.Dependencies, p.Dependencies)
+			return e
+		case types.JavaAbstract:
+			return mergeClasses(types.JavaAbstract{
+				DefinedWithin: e.DefinedWithin,
+				Package:       e.Package,
+				Name:          e.Name,
+				Implements:    e.Implements,
+				Extends:       e.Extends,
+				Variables:     e.Variables,
+				Methods:       e.Methods,
+				Associations:  e.Associations,
+				Dependencies:  e.Dependencies,
+			}, p)
+		}
+	case types.JavaAbstract:
+		switch p := part.(type) {
+		case types.JavaClass:
+			return mergeClasses(e, types.JavaAbstract{
+				Implements:   p.Implements,
+				Extends:      p.Extends,
+				Variables:    p.Variables,
+				Methods:      p.Methods,
+				Associations: p.Associations,
+				Dependencies: p.Dependencies,
+			})
+		case types.JavaAbstract:
+			e.Implements = appendClassIds(e.Implements, p.Implements)
+			e.Extends = appendClassIds(e.Extends, p.Extends)
+			e.Variables = append(e.Variables, p.Variables...)
+			e.Methods = append(e.Methods, p.Methods...)
+			e.Associations = appendClassIds(e.Associations, p.Associations)
+			e.Dependencies = appendClassIds(e.Dependencies, p.Dependencies)
+			return e
+		}
+	case types.JavaInterface:
+		if p, ok := part.(types.JavaInterface); ok {
+			e.Extends = appendClassIds(e.Extends, p.Extends)
+			e.Variables = append(e.Variables, p.Variables...)
+			e.Methods = append(e.Methods, p.Methods...)
+			e.Associations = appendClassIds(e.Associations, p.Associations)
+			e.Dependencies = appendClassIds(e.Dependencies, p.Dependencies)
+			return e
+		}
+	}
+
+	// Only classes, structs and interfaces can be partial
+	return existing
+}
+
+// Appends the class ids that are not in the slice yet
+func appendClassIds(classIds, others []types.CustomByteSlice) []types.CustomByteSlice {
+	for _, other := range others {
+		if !containsClassId(classIds, string(other)) {
+			classIds = append(classIds, other)
+		}
+	}
+
+	return classIds
+}
+
+// Association is a stronger form of a dependency
+func removeClassIds(dependencies, associations []types.CustomByteSlice) []types.CustomByteSlice {
+	var response []types.CustomByteSlice
+
+	for _, dependency := range dependencies {
+		if !containsClassId(associations, string(dependency)) {
+			response = append(response, dependency)
+		}
+	}
+
+	return response
+}
+
+func containsClassId(classIds []types.CustomByteSlice, classId string) bool {
+	for _, existing := range classIds {
+		if string(existing) == classId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func addClassRelations(project *types.Project, packageName, name, definedWithin []byte, implements, extends, associations, dependencies []types.CustomByteSlice) {
+	fromClassId := []byte(string(packageName) + "." + string(name))
+
+	for _, implement := range implements {
+		project.AddRelation(fromClassId, implement, &types.Realization{})
+	}
+
+	for _, extend := range extends {
+		project.AddRelation(fromClassId, extend, &types.Generalization{})
+	}
+
+	if definedWithin != nil {
+		project.AddRelation(fromClassId, []byte(string(packageName)+"."+string(definedWithin)), &types.NestedOwnership{})
+	}
+
+	for _, association := range associations {
+		project.AddRelation(fromClassId, association, &types.Association{})
+	}
+
+	for _, dependency := range dependencies {
+		project.AddRelation(fromClassId, dependency, &types.Dependency{})
+	}
+}
+
+func getClassId(class any) string {
+	switch c := class.(type) {
+	case types.JavaAbstract:
+		return string(c.Package) + "." + string(c.Name)
+	case types.JavaClass:
+		return string(c.Package) + "." + string(c.Name)
+	case types.JavaInterface:
+		return string(c.Package) + "." + string(c.Name)
+	case types.JavaEnum:
+		return string(c.Package) + "." + string(c.Name)
+	}
+
+	return ""
+}
+
+func sortedKeys(m map[string]struct{}) []types.CustomByteSlice {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var response []types.CustomByteSlice
+	for _, key := range keys {
+		response = append(response, []byte(key))
+	}
+
+	return response
+}
@@ -0,0 +1,223 @@
+package csharp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func relationToString(relation types.Relation) string {
+	return fmt.Sprintf("%s -> %s %T from:%t to:%t", relation.FromClassId, relation.ToClassId, relation.Type, relation.Type.GetFromArrow(), relation.Type.GetToArrow())
+}
+
+func getNodeId(node any) string {
+	switch class := node.(type) {
+	case types.JavaAbstract:
+		return string(class.Package) + "." + string(class.Name)
+	case types.JavaClass:
+		return string(class.Package) + "." + string(class.Name)
+	case types.JavaInterface:
+		return string(class.Package) + "." + string(class.Name)
+	case types.JavaEnum:
+		return string(class.Package) + "." + string(class.Name)
+	}
+
+	return ""
+}
+
+func TestParseProject(t *testing.T) {
+	type Output struct {
+		Nodes []string
+		Edges []types.Relation
+	}
+
+	type ParseProjectTest struct {
+		Input  []types.File
+		Output Output
+	}
+
+	var tests = []ParseProjectTest{
+		{
+			Input: []types.File{
+				{
+					Name:      "Animal",
+					Extension: "cs",
+					Code: []byte(`
+using Zoo.Staff;
+
+namespace Zoo.Models
+{
+	public interface ISpeaker { string Speak(); }
+
+	public interface IWalker : ISpeaker { }
+
+	public abstract class Animal : ISpeaker
+	{
+		protected Keeper keeper;
+		public abstract string Speak();
+	}
+}
+`),
+				},
+				{
+					Name:      "Dog",
+					Extension: "cs",
+					Code: []byte(`
+using System;
+using Boss = Zoo.Staff.Keeper;
+
+namespace Zoo.Models.Pets;
+
+// The base class is listed after an interface, the parser decides which is which
+public class Dog : IWalker, Animal, IDisposable
+{
+	public Collar Collar { get; set; }
+
+	public string Speak() => "Woof";
+
+	public void Dispose() { }
+
+	public void Report(Boss boss)
+	{
+		var schedule = Zoo.Staff.Schedule.Today();
+	}
+
+	public class Collar { }
+}
+`),
+				},
+				{
+					Name:      "Keeper",
+					Extension: "cs",
+					Code: []byte(`
+namespace Zoo.Staff
+{
+	public class Keeper { }
+
+	public class Schedule
+	{
+		public static Schedule Today() => new Schedule();
+	}
+}
+`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{"Zoo.Models.ISpeaker", "Zoo.Models.IWalker", "Zoo.Models.Animal", "Zoo.Models.Pets.Dog", "Zoo.Models.Pets.Collar", "Zoo.Staff.Keeper", "Zoo.Staff.Schedule"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("Zoo.Models.IWalker"), ToClassId: []byte("Zoo.Models.ISpeaker"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("Zoo.Models.Animal"), ToClassId: []byte("Zoo.Models.ISpeaker"), Type: &types.Realization{ToArrow: true}},
+					{FromClassId: []byte("Zoo.Models.Animal"), ToClassId: []byte("Zoo.Staff.Keeper"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("Zoo.Models.Pets.Dog"), ToClassId: []byte("Zoo.Models.IWalker"), Type: &types.Realization{ToArrow: true}},
+					{FromClassId: []byte("Zoo.Models.Pets.Dog"), ToClassId: []byte("Zoo.Models.Animal"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("Zoo.Models.Pets.Dog"), ToClassId: []byte("Zoo.Models.Pets.Collar"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("Zoo.Models.Pets.Dog"), ToClassId: []byte("Zoo.Staff.Keeper"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("Zoo.Models.Pets.Dog"), ToClassId: []byte("Zoo.Staff.Schedule"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("Zoo.Models.Pets.Collar"), ToClassId: []byte("Zoo.Models.Pets.Dog"), Type: &types.NestedOwnership{ToArrow: true}},
+				},
+			},
+		},
+		{
+			// Partial classes are merged into one node
+			Input: []types.File{
+				{
+					Name:      "Order",
+					Extension: "cs",
+					Code: []byte(`
+namespace Shop
+{
+	public partial class Order : Entity
+	{
+		public Customer Customer { get; set; }
+	}
+}
+`),
+				},
+				{
+					Name:      "Order.Generated",
+					Extension: "cs",
+					Code: []byte(`
+namespace Shop
+{
+	public abstract partial class Order : IValidatable
+	{
+		private int total;
+
+		public bool Validate(Rules rules) => true;
+	}
+
+	public class Entity { }
+	public class Customer { }
+	public class Rules { }
+	public interface IValidatable { }
+}
+`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{"Shop.Order", "Shop.Entity", "Shop.Customer", "Shop.Rules", "Shop.IValidatable"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("Shop.Order"), ToClassId: []byte("Shop.Entity"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("Shop.Order"), ToClassId: []byte("Shop.IValidatable"), Type: &types.Realization{ToArrow: true}},
+					{FromClassId: []byte("Shop.Order"), ToClassId: []byte("Shop.Customer"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("Shop.Order"), ToClassId: []byte("Shop.Rules"), Type: &types.Dependency{ToArrow: true}},
+				},
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := ParseProject(context.Background(), tt.Input)
+
+			var nodes []string
+			for _, node := range response.Nodes {
+				nodes = append(nodes, getNodeId(node))
+			}
+
+			if fmt.Sprint(nodes) != fmt.Sprint(tt.Output.Nodes) {
+				subtest.Errorf("incorrect nodes.\nexpected:\n%s\ngot:\n%s\n", tt.Output.Nodes, nodes)
+			}
+
+			var expectedEdges, actualEdges []string
+			for _, edge := range tt.Output.Edges {
+				expectedEdges = append(expectedEdges, relationToString(edge))
+			}
+
+			for _, edge := range response.Edges {
+				actualEdges = append(actualEdges, relationToString(edge))
+			}
+
+			sort.Strings(expectedEdges)
+			sort.Strings(actualEdges)
+
+			if fmt.Sprint(expectedEdges) != fmt.Sprint(actualEdges) {
+				subtest.Errorf("incorrect edges.\nexpected:\n%s\ngot:\n%s\n", expectedEdges, actualEdges)
+			}
+		})
+	}
+}
+
+func TestParseProjectPartialClass(t *testing.T) {
+	response := ParseProject(context.Background(), []types.File{
+		{Name: "A", Extension: "cs", Code: []byte(`partial class Widget { int width; void Draw() { } }`)},
+		{Name: "B", Extension: "cs", Code: []byte(`abstract partial class Widget { int height; abstract void Resize(); }`)},
+	})
+
+	if len(response.Nodes) != 1 {
+		t.Fatalf("incorrect number of nodes.\nexpected: 1\ngot: %d\n", len(response.Nodes))
+	}
+
+	widget, ok := response.Nodes[0].(types.JavaAbstract)
+	if !ok {
+		t.Fatalf("Widget is not abstract")
+	}
+
+	if string(widget.Package) != globalNamespace || len(widget.Variables) != 2 || len(widget.Methods) != 2 || string(widget.Variables[1].Name) != "height" {
+		t.Errorf("incorrect merged members")
+	}
+}
@@ -0,0 +1,139 @@
+package transpiler
+
+import (
+	"github.com/google/uuid"
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+const edgeZIndex = 11
+
+// Markers mirror the ones registered by the frontend in components/diagram/x6/graph/shapes/edges.ts
+var (
+	classicMarker = types.EdgeMarker{
+		Type: "classic",
+		Size: new(float64),
+	}
+
+	associationMarker = types.EdgeMarker{
+		Type:    "association",
+		Name:    "path",
+		D:       "M 6 10 L 18 4 C 14.3333 6 10.6667 8 7 10 L 18 16 z",
+		Fill:    "black",
+		OffsetX: -5,
+	}
+
+	generalizationMarker = types.EdgeMarker{
+		Type:        "generalization",
+		Name:        "path",
+		D:           "M 6 10 L 18 4 L 18 5 L 8 10 L 18 15 L 18 16 z",
+		StrokeWidth: 1.5,
+		Fill:        "black",
+		OffsetX:     -5,
+	}
+
+	realizationMarker = types.EdgeMarker{
+		Type:    "realization",
+		Name:    "path",
+		D:       "M 20 0 L 0 10 L 20 20 z",
+		Fill:    "white",
+		OffsetX: -10,
+	}
+)
+
+// Converts every relation into an X6 edge cell.
+// Relations whose classes are not part of the diagram are skipped.
+func generateDiagramEdges(nodes []types.DiagramNode, relations []types.Relation) []any {
+	var response []any
+
+	for _, relation := range relations {
+		if relation.Type == nil {
+			continue
+		}
+
+		sourceIndex := getNodeIndexById(nodes, relation.FromClassId)
+		targetIndex := getNodeIndexById(nodes, relation.ToClassId)
+		if sourceIndex == -1 || targetIndex == -1 {
+			continue
+		}
+
+		response = append(response, generateEdge(nodes[sourceIndex], nodes[targetIndex], relation.Type))
+	}
+
+	return response
+}
+
+func generateEdge(sourceNode, targetNode types.DiagramNode, relation types.RelationData) types.Edge {
+	source, target := sourceNode.GetDiagramNode(), targetNode.GetDiagramNode()
+	sourcePort, targetPort := selectPort(
+		source.Position.X+source.Width/2,
+		source.Position.Y+source.Height/2,
+		target.Position.X+target.Width/2,
+		target.Position.Y+target.Height/2,
+	)
+	edgeType, marker, dashed := getEdgeStyle(relation.GetType())
+
+	edge := types.Edge{
+		EdgeType: edgeType,
+		ID:       uuid.New().String(),
+		Shape:    "edge",
+		ZIndex:   edgeZIndex,
+		Attrs: types.EdgeAttrs{
+			Line: types.EdgeLine{
+				Stroke:      "#000000",
+				StrokeWidth: 2,
+			},
+		},
+		Source: types.EdgeNodeConnection{
+			CellId: source.ID,
+			Port:   sourcePort,
+			ConnectionPoint: types.EdgeConnectionPoint{
+				Name: "anchor",
+			},
+		},
+		Target: types.EdgeNodeConnection{
+			CellId: target.ID,
+			Port:   targetPort,
+			ConnectionPoint: types.EdgeConnectionPoint{
+				Name: "anchor",
+			},
+		},
+		Data: &types.EdgeData{Relation: relation.GetType()},
+	}
+
+	if dashed {
+		edge.Attrs.Line.StrokeDasharray = "3,3"
+	}
+
+	// Each edge gets its own copy of the marker so that the frontend can mutate them independently
+	if relation.GetFromArrow() {
+		sourceMarker := marker
+		edge.Attrs.Line.SourceMarker = &sourceMarker
+	}
+
+	if relation.GetToArrow() {
+		targetMarker := marker
+		edge.Attrs.Line.TargetMarker = &targetMarker
+	}
+
+	return edge
+}
+
+// Returns the frontend edge type, the arrow marker, and whether the line is dashed for a relation type
+func getEdgeStyle(relationType string) (string, types.EdgeMarker, bool) {
+	switch relationType {
+	case "association":
+		return "association", associationMarker, false
+	case "dependency":
+		return "classic", classicMarker, true
+	case "realization":
+		return "realization", realizationMarker, true
+	case "generalization":
+		return "generalization", generalizationMarker, false
+	case "uses":
+		// Trait uses are drawn like a dependency on the trait
+		return "classic", classicMarker, true
+	default:
+		// Nested ownership has no dedicated shape in the frontend yet
+		return "classic", classicMarker, false
+	}
+}
@@ -0,0 +1,159 @@
+package transpiler
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestGenerateDiagramEdges(t *testing.T) {
+	type GenerateDiagramEdgesTest struct {
+		Input        types.Relation
+		EdgeType     string
+		SourceMarker string
+		TargetMarker string
+		Dashed       bool
+	}
+
+	nodes := []types.DiagramNode{
+		types.JavaClass{
+			Package:         []byte("default"),
+			Name:            []byte("Child"),
+			JavaDiagramNode: types.JavaDiagramNode{ID: "child-id", Position: types.Position{X: 0, Y: 300}},
+		},
+		types.JavaAbstract{
+			Package:         []byte("default"),
+			Name:            []byte("Parent"),
+			JavaDiagramNode: types.JavaDiagramNode{ID: "parent-id", Position: types.Position{X: 0, Y: 0}},
+		},
+	}
+
+	var tests = []GenerateDiagramEdgesTest{
+		{
+			Input: types.Relation{
+				FromClassId: []byte("default.Child"),
+				ToClassId:   []byte("default.Parent"),
+				Type:        &types.Generalization{ToArrow: true},
+			},
+			EdgeType:     "generalization",
+			TargetMarker: "generalization",
+		},
+		{
+			Input: types.Relation{
+				FromClassId: []byte("default.Child"),
+				ToClassId:   []byte("default.Parent"),
+				Type:        &types.Realization{ToArrow: true},
+			},
+			EdgeType:     "realization",
+			TargetMarker: "realization",
+			Dashed:       true,
+		},
+		{
+			Input: types.Relation{
+				FromClassId: []byte("default.Child"),
+				ToClassId:   []byte("default.Parent"),
+				Type:        &types.Association{FromArrow: true, ToArrow: true},
+			},
+			EdgeType:     "association",
+			SourceMarker: "association",
+			TargetMarker: "association",
+		},
+		{
+			Input: types.Relation{
+				FromClassId: []byte("default.Child"),
+				ToClassId:   []byte("default.Parent"),
+				Type:        &types.Dependency{ToArrow: true},
+			},
+			EdgeType:     "classic",
+			TargetMarker: "classic",
+			Dashed:       true,
+		},
+		{
+			Input: types.Relation{
+				FromClassId: []byte("default.Child"),
+				ToClassId:   []byte("default.Parent"),
+				Type:        &types.NestedOwnership{ToArrow: true},
+			},
+			EdgeType:     "classic",
+			TargetMarker: "classic",
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := generateDiagramEdges(nodes, []types.Relation{tt.Input})
+			if len(response) != 1 {
+				subtest.Fatalf("incorrect length.\nexpected: 1\ngot: %d\n", len(response))
+			}
+
+			edge, ok := response[0].(types.Edge)
+			if !ok {
+				subtest.Fatalf("incorrect response type")
+			}
+
+			if edge.ID == "" {
+				subtest.Errorf("edge id is empty")
+			}
+
+			if edge.EdgeType != tt.EdgeType {
+				subtest.Errorf("incorrect edge type.\nexpected: %s\ngot: %s\n", tt.EdgeType, edge.EdgeType)
+			}
+
+			if edge.Source.CellId != "child-id" || edge.Target.CellId != "parent-id" {
+				subtest.Errorf("incorrect cells.\nexpected: child-id -> parent-id\ngot: %s -> %s\n", edge.Source.CellId, edge.Target.CellId)
+			}
+
+			if edge.Source.Port != "top-middle" || edge.Target.Port != "bottom-middle" {
+				subtest.Errorf("incorrect ports.\nexpected: top-middle -> bottom-middle\ngot: %s -> %s\n", edge.Source.Port, edge.Target.Port)
+			}
+
+			if (edge.Attrs.Line.StrokeDasharray != "") != tt.Dashed {
+				subtest.Errorf("incorrect dash.\nexpected: %t\ngot: %s\n", tt.Dashed, edge.Attrs.Line.StrokeDasharray)
+			}
+
+			checkMarker := func(name string, marker *types.EdgeMarker, expected string) {
+				if expected == "" && marker != nil {
+					subtest.Errorf("unexpected %s marker %s", name, marker.Type)
+				} else if expected != "" && (marker == nil || marker.Type != expected) {
+					subtest.Errorf("incorrect %s marker.\nexpected: %s\ngot: %v\n", name, expected, marker)
+				}
+			}
+
+			checkMarker("source", edge.Attrs.Line.SourceMarker, tt.SourceMarker)
+			checkMarker("target", edge.Attrs.Line.TargetMarker, tt.TargetMarker)
+		})
+	}
+}
+
+func TestGenerateDiagramEdgesSkipsUnknownClasses(t *testing.T) {
+	nodes := []types.DiagramNode{
+		types.JavaClass{Package: []byte("default"), Name: []byte("Known")},
+	}
+
+	response := generateDiagramEdges(nodes, []types.Relation{
+		{
+			FromClassId: []byte("default.Known"),
+			ToClassId:   []byte("default.Unknown"),
+			Type:        &types.Dependency{ToArrow: true},
+		},
+	})
+
+	if len(response) != 0 {
+		t.Errorf("incorrect length.\nexpected: 0\ngot: %d\n", len(response))
+	}
+}
+
+func TestClassicMarkerMatchesFrontend(t *testing.T) {
+	expected := `{"type":"classic","size":0}`
+
+	response, err := json.Marshal(classicMarker)
+	if err != nil {
+		t.Fatalf("could not marshal marker: %v", err)
+	}
+
+	if string(response) != expected {
+		t.Errorf("incorrect marker.\nexpected:\n%s\ngot:\n%s\n", expected, response)
+	}
+}
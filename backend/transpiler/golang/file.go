@@ -0,0 +1,408 @@
+package golang
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	gotypes "go/types"
+	"path"
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+// The files of a Go package and the result of type checking them
+type goPackage struct {
+	Path     string // The import path, or the directory of the files when they are not inside of a module
+	Name     string
+	InModule bool
+	Files    []*ast.File
+	Types    *gotypes.Package
+	Info     *gotypes.Info
+}
+
+// A parsed file and its syntax errors
+type parsedGoFile struct {
+	Dir         string
+	File        *ast.File
+	Diagnostics []types.Diagnostic
+}
+
+// Parses every file and groups the files by the import path of their directory, see getImportPath.
+// Test files are skipped. Syntax errors are returned as diagnostics.
+func parseFiles(ctx context.Context, fileSet *token.FileSet, files []types.File) (map[string]*goPackage, []types.Diagnostic) {
+	var (
+		response = make(map[string]*goPackage)
+		modules  = make(map[string]string)
+		goFiles  []types.File
+	)
+
+	for _, file := range files {
+		if filePath := file.GetPath(); path.Base(filePath) == "go.mod" {
+			modules[path.Dir(filePath)] = getModulePath(file.Code)
+		} else if file.Extension == "go" {
+			goFiles = append(goFiles, file)
+		}
+	}
+
+	parsedFiles, diagnostics := types.ParseFiles(ctx, goFiles, func(file types.File) parsedGoFile {
+		if strings.HasSuffix(file.Name, "_test") {
+			return parsedGoFile{}
+		}
+
+		// A file with syntax errors still returns the declarations that could be parsed
+		parsedFile, err := parser.ParseFile(fileSet, file.GetPath(), file.Code, parser.SkipObjectResolution)
+		return parsedGoFile{Dir: path.Dir(file.GetPath()), File: parsedFile, Diagnostics: getSyntaxDiagnostics(file.GetPath(), err)}
+	})
+
+	for _, parsedFile := range parsedFiles {
+		diagnostics = append(diagnostics, parsedFile.Diagnostics...)
+
+		if parsedFile.File == nil || parsedFile.File.Name == nil || parsedFile.File.Name.Name == "" || parsedFile.File.Name.Name == "_" {
+			continue
+		}
+
+		importPath, inModule := getImportPath(modules, parsedFile.Dir, parsedFile.File.Name.Name)
+		if _, ok := response[importPath]; !ok {
+			response[importPath] = &goPackage{Path: importPath, Name: parsedFile.File.Name.Name, InModule: inModule}
+		}
+
+		response[importPath].Files = append(response[importPath].Files, parsedFile.File)
+	}
+
+	return response, diagnostics
+}
+
+// Returns the module path declared in a go.mod file, or an empty string
+func getModulePath(code []byte) string {
+	for _, line := range strings.Split(string(code), "\n") {
+		if index := strings.Index(line, "//"); index != -1 {
+			line = line[:index]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], "\"`")
+		}
+	}
+
+	return ""
+}
+
+// Returns the import path of the package in the directory, and whether the directory is inside of a module.
+// The modules hold the module path of every directory with a go.mod file. Packages outside of a module are
+// identified by their directory, or by their name when they are at the root of the project.
+func getImportPath(modules map[string]string, dir, name string) (string, bool) {
+	for moduleDir := dir; ; moduleDir = path.Dir(moduleDir) {
+		if modulePath := modules[moduleDir]; modulePath != "" {
+			if moduleDir == "." {
+				return path.Join(modulePath, dir), true
+			}
+
+			return path.Join(modulePath, strings.TrimPrefix(dir, moduleDir)), true
+		}
+
+		if moduleDir == "." || moduleDir == "/" {
+			break
+		}
+	}
+
+	if dir == "." {
+		return name, false
+	}
+
+	return dir, false
+}
+
+// The parser stops reporting after the first ten errors of a file
+func getSyntaxDiagnostics(path string, err error) []types.Diagnostic {
+	var response []types.Diagnostic
+
+	if err == nil {
+		return response
+	}
+
+	errorList, ok := err.(scanner.ErrorList)
+	if !ok {
+		return append(response, types.Diagnostic{Severity: types.DiagnosticError, Path: path, Message: err.Error()})
+	}
+
+	for _, e := range errorList {
+		response = append(response, types.Diagnostic{
+			Severity: types.DiagnosticError,
+			Path:     path,
+			Line:     e.Pos.Line,
+			Message:  e.Msg,
+		})
+	}
+
+	return response
+}
+
+// Resolves imports while type checking. Imports of project packages are resolved by their import path,
+// every other import is an empty package.
+type packageImporter struct {
+	fileSet  *token.FileSet
+	packages map[string]*goPackage
+	checking map[string]struct{}
+	external map[string]*gotypes.Package
+}
+
+// Type checks the project packages. Type errors, such as uses of external packages, are ignored.
+func checkPackages(fileSet *token.FileSet, packages map[string]*goPackage) {
+	importer := &packageImporter{
+		fileSet:  fileSet,
+		packages: packages,
+		checking: make(map[string]struct{}),
+		external: make(map[string]*gotypes.Package),
+	}
+
+	for _, importPath := range getSortedPackagePaths(packages) {
+		importer.check(packages[importPath])
+	}
+}
+
+func (importer *packageImporter) Import(importPath string) (*gotypes.Package, error) {
+	// Import cycles are not valid Go, the package that closes the cycle is treated as external
+	if pkg := importer.getPackage(importPath); pkg != nil {
+		if _, ok := importer.checking[pkg.Path]; !ok {
+			importer.check(pkg)
+			return pkg.Types, nil
+		}
+	}
+
+	if pkg, ok := importer.external[importPath]; ok {
+		return pkg, nil
+	}
+
+	pkg := gotypes.NewPackage(importPath, getPackageName(importPath))
+	pkg.MarkComplete()
+	importer.external[importPath] = pkg
+
+	return pkg, nil
+}
+
+// Returns the project package with the import path. A project that was uploaded without its go.mod file has packages
+// that are identified by their directory, so they are found by the one directory that the import path ends with.
+func (importer *packageImporter) getPackage(importPath string) *goPackage {
+	if pkg, ok := importer.packages[importPath]; ok {
+		return pkg
+	}
+
+	var response *goPackage
+	for directory, pkg := range importer.packages {
+		if pkg.InModule || !strings.HasSuffix(importPath, "/"+directory) {
+			continue
+		}
+
+		if response != nil {
+			return nil
+		}
+
+		response = pkg
+	}
+
+	return response
+}
+
+func (importer *packageImporter) check(pkg *goPackage) {
+	if pkg.Types != nil {
+		return
+	}
+
+	importer.checking[pkg.Path] = struct{}{}
+	defer delete(importer.checking, pkg.Path)
+
+	pkg.Info = &gotypes.Info{
+		Types: make(map[ast.Expr]gotypes.TypeAndValue),
+		Defs:  make(map[*ast.Ident]gotypes.Object),
+		Uses:  make(map[*ast.Ident]gotypes.Object),
+	}
+
+	config := gotypes.Config{
+		Importer:    importer,
+		Error:       func(err error) {},
+		FakeImportC: true,
+	}
+
+	pkg.Types, _ = config.Check(pkg.Path, importer.fileSet, pkg.Files, pkg.Info)
+}
+
+// Returns the default package name of an import path: gopkg.in/yaml.v3 -> yaml, github.com/a/b/v2 -> b
+func getPackageName(importPath string) string {
+	name := path.Base(importPath)
+	if isMajorVersion(name) {
+		name = path.Base(path.Dir(importPath))
+	}
+
+	if index := strings.IndexByte(name, '.'); index != -1 {
+		name = name[:index]
+	}
+
+	return name
+}
+
+func isMajorVersion(name string) bool {
+	if len(name) < 2 || name[0] != 'v' {
+		return false
+	}
+
+	for _, c := range name[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the methods declared in the package, grouped by the name of their receiver type
+func getPackageMethods(pkg *goPackage) map[string][]*ast.FuncDecl {
+	response := make(map[string][]*ast.FuncDecl)
+
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+				continue
+			}
+
+			if receiver := getReceiverTypeName(funcDecl.Recv.List[0].Type); receiver != "" {
+				response[receiver] = append(response[receiver], funcDecl)
+			}
+		}
+	}
+
+	return response
+}
+
+// Returns the type name of a method receiver: *List[T] -> List
+func getReceiverTypeName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return getReceiverTypeName(e.X)
+	case *ast.ParenExpr:
+		return getReceiverTypeName(e.X)
+	case *ast.IndexExpr:
+		return getReceiverTypeName(e.X)
+	case *ast.IndexListExpr:
+		return getReceiverTypeName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+
+	return ""
+}
+
+// Returns the constants declared with each named type, in declaration order
+func getPackageConstants(pkg *goPackage) map[*gotypes.TypeName][]types.CustomByteSlice {
+	response := make(map[*gotypes.TypeName][]types.CustomByteSlice)
+
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.CONST {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				for _, name := range spec.(*ast.ValueSpec).Names {
+					constant, ok := pkg.Info.Defs[name].(*gotypes.Const)
+					if !ok || name.Name == "_" {
+						continue
+					}
+
+					if named, ok := constant.Type().(*gotypes.Named); ok && named.Obj().Pkg() == pkg.Types {
+						response[named.Obj()] = append(response[named.Obj()], types.CustomByteSlice(name.Name))
+					}
+				}
+			}
+		}
+	}
+
+	return response
+}
+
+// Converts struct fields to variables. Embedded fields are returned separately.
+func getStructVariables(structType *ast.StructType) ([]types.JavaVariable, []*ast.Field) {
+	var (
+		variables []types.JavaVariable
+		embedded  []*ast.Field
+	)
+
+	for _, field := range structType.Fields.List {
+		if len(field.Names) == 0 {
+			embedded = append(embedded, field)
+			continue
+		}
+
+		for _, name := range field.Names {
+			variables = append(variables, types.JavaVariable{
+				Type:           types.CustomByteSlice(gotypes.ExprString(field.Type)),
+				Name:           types.CustomByteSlice(name.Name),
+				AccessModifier: getAccessModifier(name.Name),
+			})
+		}
+	}
+
+	return variables, embedded
+}
+
+// Converts a function declaration or an interface method to a method
+func getMethod(name string, funcType *ast.FuncType) types.JavaMethod {
+	method := types.JavaMethod{
+		Name:           types.CustomByteSlice(name),
+		AccessModifier: getAccessModifier(name),
+	}
+
+	if funcType.Params != nil {
+		for _, field := range funcType.Params.List {
+			parameterType := types.CustomByteSlice(gotypes.ExprString(field.Type))
+
+			if len(field.Names) == 0 {
+				method.Parameters = append(method.Parameters, types.JavaMethodParameter{Type: parameterType})
+				continue
+			}
+
+			for _, parameterName := range field.Names {
+				method.Parameters = append(method.Parameters, types.JavaMethodParameter{
+					Type: parameterType,
+					Name: types.CustomByteSlice(parameterName.Name),
+				})
+			}
+		}
+	}
+
+	if funcType.Results != nil {
+		var results []string
+		for _, field := range funcType.Results.List {
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+
+			for i := 0; i < count; i++ {
+				results = append(results, gotypes.ExprString(field.Type))
+			}
+		}
+
+		if len(results) == 1 {
+			method.Type = types.CustomByteSlice(results[0])
+		} else if len(results) > 1 {
+			method.Type = types.CustomByteSlice("(" + strings.Join(results, ", ") + ")")
+		}
+	}
+
+	return method
+}
+
+// Exported identifiers are public, every other identifier is private to its package
+func getAccessModifier(name string) types.CustomByteSlice {
+	if token.IsExported(name) {
+		return []byte("public")
+	}
+
+	return []byte("private")
+}
@@ -0,0 +1,118 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestGetModulePath(t *testing.T) {
+	var tests = map[string]string{
+		"module github.com/example/zoo\n\ngo 1.19\n":         "github.com/example/zoo",
+		"// Zoo\nmodule \"example.com/zoo\" // comment\n":    "example.com/zoo",
+		"go 1.19\n\nrequire github.com/google/uuid v1.3.0\n": "",
+	}
+
+	for input, expected := range tests {
+		t.Run(expected, func(subtest *testing.T) {
+			if actual := getModulePath([]byte(input)); actual != expected {
+				subtest.Errorf("incorrect module path.\nexpected: %s\ngot: %s\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetImportPath(t *testing.T) {
+	modules := map[string]string{
+		".":        "example.com/shop",
+		"tools":    "example.com/tools",
+		"tools/v2": "",
+	}
+
+	var tests = []struct {
+		Dir      string
+		Name     string
+		Output   string
+		InModule bool
+	}{
+		{Dir: ".", Name: "shop", Output: "example.com/shop", InModule: true},
+		{Dir: "accounts/models", Name: "models", Output: "example.com/shop/accounts/models", InModule: true},
+		{Dir: "tools", Name: "main", Output: "example.com/tools", InModule: true},
+		{Dir: "tools/lint", Name: "lint", Output: "example.com/tools/lint", InModule: true},
+		{Dir: "tools/v2/lint", Name: "lint", Output: "example.com/tools/v2/lint", InModule: true},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			output, inModule := getImportPath(modules, tt.Dir, tt.Name)
+			if output != tt.Output || inModule != tt.InModule {
+				subtest.Errorf("incorrect import path.\nexpected: %s %t\ngot: %s %t\n", tt.Output, tt.InModule, output, inModule)
+			}
+		})
+	}
+
+	// Without a go.mod file, packages are identified by their directory
+	if output, inModule := getImportPath(nil, "accounts/models", "models"); output != "accounts/models" || inModule {
+		t.Errorf("incorrect import path.\nexpected: accounts/models false\ngot: %s %t\n", output, inModule)
+	}
+
+	if output, inModule := getImportPath(nil, ".", "shop"); output != "shop" || inModule {
+		t.Errorf("incorrect import path.\nexpected: shop false\ngot: %s %t\n", output, inModule)
+	}
+}
+
+func TestParseFiles(t *testing.T) {
+	var tests = []struct {
+		Input  []types.File
+		Output []string
+	}{
+		{
+			// Packages with the same name in different directories are different packages
+			Input: []types.File{
+				{Path: "go.mod", Name: "go", Extension: "mod", Code: []byte("module example.com/shop\n")},
+				{Path: "accounts/models/user.go", Name: "user", Extension: "go", Code: []byte("package models\n")},
+				{Path: "accounts/models/role.go", Name: "role", Extension: "go", Code: []byte("package models\n")},
+				{Path: "billing/models/user.go", Name: "user", Extension: "go", Code: []byte("package models\n")},
+				{Path: "billing/models/user_test.go", Name: "user_test", Extension: "go", Code: []byte("package models\n")},
+				{Path: "main.go", Name: "main", Extension: "go", Code: []byte("package main\n")},
+			},
+			Output: []string{
+				"example.com/shop main 1",
+				"example.com/shop/accounts/models models 2",
+				"example.com/shop/billing/models models 1",
+			},
+		},
+		{
+			Input: []types.File{
+				{Path: "accounts/models/user.go", Name: "user", Extension: "go", Code: []byte("package models\n")},
+				{Path: "billing/models/user.go", Name: "user", Extension: "go", Code: []byte("package models\n")},
+			},
+			Output: []string{
+				"accounts/models models 1",
+				"billing/models models 1",
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			packages, _ := parseFiles(context.Background(), token.NewFileSet(), tt.Input)
+
+			var output []string
+			for importPath, pkg := range packages {
+				output = append(output, fmt.Sprintf("%s %s %d", importPath, pkg.Name, len(pkg.Files)))
+			}
+
+			sort.Strings(output)
+
+			if fmt.Sprint(output) != fmt.Sprint(tt.Output) {
+				subtest.Errorf("incorrect packages.\nexpected:\n%s\ngot:\n%s\n", tt.Output, output)
+			}
+		})
+	}
+}
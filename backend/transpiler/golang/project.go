@@ -0,0 +1,336 @@
+package golang
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	gotypes "go/types"
+	"sort"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func ParseProject(ctx context.Context, files []types.File) *types.Project {
+	var (
+		response     types.Project
+		fileSet      = token.NewFileSet()
+		implementers []*gotypes.TypeName
+		interfaces   []*gotypes.TypeName
+	)
+
+	packages, diagnostics := parseFiles(ctx, fileSet, files)
+	response.Diagnostics = diagnostics
+
+	checkPackages(fileSet, packages)
+
+	for _, packagePath := range getSortedPackagePaths(packages) {
+		var (
+			pkg       = packages[packagePath]
+			methods   = getPackageMethods(pkg)
+			constants = getPackageConstants(pkg)
+		)
+
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range genDecl.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+
+					// Type aliases do not declare a new type
+					if typeSpec.Assign.IsValid() {
+						continue
+					}
+
+					typeName, ok := pkg.Info.Defs[typeSpec.Name].(*gotypes.TypeName)
+					if !ok {
+						continue
+					}
+
+					switch t := typeSpec.Type.(type) {
+					case *ast.StructType:
+						class := types.JavaClass{
+							Package: types.CustomByteSlice(packagePath),
+							Name:    types.CustomByteSlice(typeSpec.Name.Name),
+						}
+
+						var embedded []*ast.Field
+						class.Variables, embedded = getStructVariables(t)
+						class.Extends = getEmbeddedClassIds(packages, pkg.Info, embedded)
+						class.Methods, class.Dependencies = getMethodsAndDependencies(packages, pkg.Info, methods[typeSpec.Name.Name])
+						class.Associations = getFieldClassIds(packages, pkg.Info, t.Fields)
+						class.Dependencies = removeClassIds(class.Dependencies, class.Associations)
+
+						response.Nodes = append(response.Nodes, class)
+						addClassRelations(&response, class.Package, class.Name, class.Extends, class.Associations, class.Dependencies)
+						implementers = append(implementers, typeName)
+					case *ast.InterfaceType:
+						class := types.JavaInterface{
+							Package: types.CustomByteSlice(packagePath),
+							Name:    types.CustomByteSlice(typeSpec.Name.Name),
+						}
+
+						var embedded []*ast.Field
+						for _, field := range t.Methods.List {
+							funcType, ok := field.Type.(*ast.FuncType)
+							if len(field.Names) == 0 || !ok {
+								embedded = append(embedded, field)
+								continue
+							}
+
+							for _, name := range field.Names {
+								class.Methods = append(class.Methods, getMethod(name.Name, funcType))
+							}
+
+							class.Dependencies = appendClassIds(class.Dependencies, packages, pkg.Info.TypeOf(funcType))
+						}
+
+						class.Extends = getEmbeddedClassIds(packages, pkg.Info, embedded)
+
+						response.Nodes = append(response.Nodes, class)
+						addClassRelations(&response, class.Package, class.Name, class.Extends, nil, class.Dependencies)
+						interfaces = append(interfaces, typeName)
+					default:
+						// Named types with constants are enums: type Color int; const ( Red Color = iota )
+						if declarations, ok := constants[typeName]; ok {
+							response.Nodes = append(response.Nodes, types.JavaEnum{
+								Package:      types.CustomByteSlice(packagePath),
+								Name:         types.CustomByteSlice(typeSpec.Name.Name),
+								Declarations: declarations,
+							})
+
+							continue
+						}
+
+						// Other named types are only shown when they have methods: type HandlerFunc func()
+						if len(methods[typeSpec.Name.Name]) == 0 {
+							continue
+						}
+
+						class := types.JavaClass{
+							Package: types.CustomByteSlice(packagePath),
+							Name:    types.CustomByteSlice(typeSpec.Name.Name),
+						}
+
+						class.Methods, class.Dependencies = getMethodsAndDependencies(packages, pkg.Info, methods[typeSpec.Name.Name])
+
+						response.Nodes = append(response.Nodes, class)
+						addClassRelations(&response, class.Package, class.Name, nil, nil, class.Dependencies)
+						implementers = append(implementers, typeName)
+					}
+				}
+			}
+		}
+	}
+
+	addImplementedInterfaces(&response, implementers, interfaces)
+
+	return &response
+}
+
+func getSortedPackagePaths(packages map[string]*goPackage) []string {
+	var response []string
+	for importPath := range packages {
+		response = append(response, importPath)
+	}
+
+	sort.Strings(response)
+
+	return response
+}
+
+// Returns the class id of a type declared at the package level of a project package, or an empty string
+func getClassId(packages map[string]*goPackage, typeName *gotypes.TypeName) string {
+	if typeName == nil || typeName.Pkg() == nil || typeName.Parent() != typeName.Pkg().Scope() {
+		return ""
+	}
+
+	pkg, ok := packages[typeName.Pkg().Path()]
+	if !ok || pkg.Types != typeName.Pkg() {
+		return ""
+	}
+
+	return pkg.Path + "." + typeName.Name()
+}
+
+// Appends the class ids of every project type that the type is built from: map[string][]*Dog -> Dog
+func appendClassIds(classIds []types.CustomByteSlice, packages map[string]*goPackage, t gotypes.Type) []types.CustomByteSlice {
+	switch t := t.(type) {
+	case *gotypes.Named:
+		if classId := getClassId(packages, t.Obj()); classId != "" && !containsClassId(classIds, classId) {
+			classIds = append(classIds, types.CustomByteSlice(classId))
+		}
+
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			classIds = appendClassIds(classIds, packages, t.TypeArgs().At(i))
+		}
+	case *gotypes.Pointer:
+		classIds = appendClassIds(classIds, packages, t.Elem())
+	case *gotypes.Slice:
+		classIds = appendClassIds(classIds, packages, t.Elem())
+	case *gotypes.Array:
+		classIds = appendClassIds(classIds, packages, t.Elem())
+	case *gotypes.Chan:
+		classIds = appendClassIds(classIds, packages, t.Elem())
+	case *gotypes.Map:
+		classIds = appendClassIds(classIds, packages, t.Key())
+		classIds = appendClassIds(classIds, packages, t.Elem())
+	case *gotypes.Signature:
+		for i := 0; i < t.Params().Len(); i++ {
+			classIds = appendClassIds(classIds, packages, t.Params().At(i).Type())
+		}
+
+		for i := 0; i < t.Results().Len(); i++ {
+			classIds = appendClassIds(classIds, packages, t.Results().At(i).Type())
+		}
+	case *gotypes.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			classIds = appendClassIds(classIds, packages, t.Field(i).Type())
+		}
+	}
+
+	return classIds
+}
+
+// Returns the class ids of the embedded types
+func getEmbeddedClassIds(packages map[string]*goPackage, info *gotypes.Info, embedded []*ast.Field) []types.CustomByteSlice {
+	var response []types.CustomByteSlice
+
+	for _, field := range embedded {
+		t := info.TypeOf(field.Type)
+		if pointer, ok := t.(*gotypes.Pointer); ok {
+			t = pointer.Elem()
+		}
+
+		if named, ok := t.(*gotypes.Named); ok {
+			if classId := getClassId(packages, named.Obj()); classId != "" {
+				response = append(response, types.CustomByteSlice(classId))
+			}
+		}
+	}
+
+	return response
+}
+
+// Returns the class ids of the types used by the named fields
+func getFieldClassIds(packages map[string]*goPackage, info *gotypes.Info, fields *ast.FieldList) []types.CustomByteSlice {
+	var response []types.CustomByteSlice
+
+	for _, field := range fields.List {
+		if len(field.Names) != 0 {
+			response = appendClassIds(response, packages, info.TypeOf(field.Type))
+		}
+	}
+
+	return response
+}
+
+// Converts method declarations to methods. Returns the methods and the class ids of the
+// project types used by their parameters and bodies.
+func getMethodsAndDependencies(packages map[string]*goPackage, info *gotypes.Info, funcDecls []*ast.FuncDecl) ([]types.JavaMethod, []types.CustomByteSlice) {
+	var (
+		methods      []types.JavaMethod
+		dependencies []types.CustomByteSlice
+	)
+
+	for _, funcDecl := range funcDecls {
+		methods = append(methods, getMethod(funcDecl.Name.Name, funcDecl.Type))
+
+		if funcDecl.Type.Params != nil {
+			for _, field := range funcDecl.Type.Params.List {
+				dependencies = appendClassIds(dependencies, packages, info.TypeOf(field.Type))
+			}
+		}
+
+		if funcDecl.Body == nil {
+			continue
+		}
+
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			if ident, ok := node.(*ast.Ident); ok {
+				if typeName, ok := info.Uses[ident].(*gotypes.TypeName); ok {
+					if classId := getClassId(packages, typeName); classId != "" && !containsClassId(dependencies, classId) {
+						dependencies = append(dependencies, types.CustomByteSlice(classId))
+					}
+				}
+			}
+
+			return true
+		})
+	}
+
+	return methods, dependencies
+}
+
+// Association is a stronger form of a dependency
+func removeClassIds(dependencies, associations []types.CustomByteSlice) []types.CustomByteSlice {
+	var response []types.CustomByteSlice
+
+	for _, dependency := range dependencies {
+		if !containsClassId(associations, string(dependency)) {
+			response = append(response, dependency)
+		}
+	}
+
+	return response
+}
+
+func containsClassId(classIds []types.CustomByteSlice, classId string) bool {
+	for _, existing := range classIds {
+		if string(existing) == classId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func addClassRelations(project *types.Project, packageName, name []byte, extends, associations, dependencies []types.CustomByteSlice) {
+	fromClassId := []byte(string(packageName) + "." + string(name))
+
+	for _, extend := range extends {
+		project.AddRelation(fromClassId, extend, &types.Generalization{})
+	}
+
+	for _, association := range associations {
+		project.AddRelation(fromClassId, association, &types.Association{})
+	}
+
+	for _, dependency := range dependencies {
+		project.AddRelation(fromClassId, dependency, &types.Dependency{})
+	}
+}
+
+// Go interfaces are satisfied implicitly. Adds a realization from every type whose value or pointer
+// implements a project interface. Empty interfaces, constraints and generic types are skipped.
+func addImplementedInterfaces(project *types.Project, implementers, interfaces []*gotypes.TypeName) {
+	for _, implementer := range implementers {
+		named, ok := implementer.Type().(*gotypes.Named)
+		if !ok || named.TypeParams().Len() != 0 {
+			continue
+		}
+
+		for _, iface := range interfaces {
+			namedInterface, ok := iface.Type().(*gotypes.Named)
+			if !ok || namedInterface.TypeParams().Len() != 0 {
+				continue
+			}
+
+			underlying, ok := namedInterface.Underlying().(*gotypes.Interface)
+			if !ok || underlying.Empty() || !underlying.IsMethodSet() {
+				continue
+			}
+
+			if gotypes.Implements(named, underlying) || gotypes.Implements(gotypes.NewPointer(named), underlying) {
+				project.AddRelation(
+					[]byte(implementer.Pkg().Path()+"."+implementer.Name()),
+					[]byte(iface.Pkg().Path()+"."+iface.Name()),
+					&types.Realization{},
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package golang
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func relationToString(relation types.Relation) string {
+	return fmt.Sprintf("%s -> %s %T from:%t to:%t", relation.FromClassId, relation.ToClassId, relation.Type, relation.Type.GetFromArrow(), relation.Type.GetToArrow())
+}
+
+func TestParseProject(t *testing.T) {
+	type Output struct {
+		Nodes []string
+		Edges []types.Relation
+	}
+
+	type ParseProjectTest struct {
+		Input  []types.File
+		Output Output
+	}
+
+	var tests = []ParseProjectTest{
+		{
+			Input: []types.File{
+				{
+					Name:      "animal",
+					Extension: "go",
+					Code: []byte(`
+package zoo
+
+import "fmt"
+
+type Speaker interface {
+	Speak() string
+}
+
+type Walker interface {
+	Speaker
+	Walk(steps int)
+}
+
+type Animal struct {
+	Name string
+	age  int
+}
+
+func (a Animal) Speak() string {
+	return fmt.Sprintf("%s speaks", a.Name)
+}
+`),
+				},
+				{
+					Name:      "dog",
+					Extension: "go",
+					Code: []byte(`
+package zoo
+
+import "github.com/example/zoo/keeper"
+
+type Dog struct {
+	*Animal
+	Owner  *keeper.Keeper
+	Tricks map[string][]Trick
+}
+
+func (d *Dog) Walk(steps int) {}
+
+func (d *Dog) Learn(name string) {
+	_ = Trick{Name: name}
+	_ = keeper.Schedule{}
+}
+
+type Trick struct {
+	Name string
+}
+
+type Size int
+
+const (
+	Small Size = iota
+	Large
+)
+`),
+				},
+				{
+					Name:      "keeper",
+					Extension: "go",
+					Code: []byte(`
+package keeper
+
+type Keeper struct {
+	Name     string
+	schedule *Schedule
+}
+
+type Schedule struct{}
+
+func (s Schedule) Speak() string { return "" }
+`),
+				},
+				{
+					Name:      "keeper_test",
+					Extension: "go",
+					Code: []byte(`
+package keeper
+
+type Fake struct{}
+`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{"keeper.Keeper", "keeper.Schedule", "zoo.Speaker", "zoo.Walker", "zoo.Animal", "zoo.Dog", "zoo.Trick", "zoo.Size"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("keeper.Keeper"), ToClassId: []byte("keeper.Schedule"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("keeper.Schedule"), ToClassId: []byte("zoo.Speaker"), Type: &types.Realization{ToArrow: true}},
+					{FromClassId: []byte("zoo.Walker"), ToClassId: []byte("zoo.Speaker"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("zoo.Animal"), ToClassId: []byte("zoo.Speaker"), Type: &types.Realization{ToArrow: true}},
+					{FromClassId: []byte("zoo.Dog"), ToClassId: []byte("zoo.Animal"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("zoo.Dog"), ToClassId: []byte("keeper.Keeper"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("zoo.Dog"), ToClassId: []byte("zoo.Trick"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("zoo.Dog"), ToClassId: []byte("keeper.Schedule"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("zoo.Dog"), ToClassId: []byte("zoo.Speaker"), Type: &types.Realization{ToArrow: true}},
+					{FromClassId: []byte("zoo.Dog"), ToClassId: []byte("zoo.Walker"), Type: &types.Realization{ToArrow: true}},
+				},
+			},
+		},
+		{
+			// Packages are identified by their import path, not by their name
+			Input: []types.File{
+				{Path: "go.mod", Name: "go", Extension: "mod", Code: []byte("module example.com/shop\n\ngo 1.19\n")},
+				{
+					Path:      "accounts/models/user.go",
+					Name:      "user",
+					Extension: "go",
+					Code: []byte(`
+package models
+
+type User struct {
+	Name string
+}
+`),
+				},
+				{
+					Path:      "billing/models/user.go",
+					Name:      "user",
+					Extension: "go",
+					Code: []byte(`
+package models
+
+import (
+	accounts "example.com/shop/accounts/models"
+	other "example.com/other/models"
+)
+
+type User struct {
+	Account *accounts.User
+	Other   *other.User
+}
+`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{"example.com/shop/accounts/models.User", "example.com/shop/billing/models.User"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("example.com/shop/billing/models.User"), ToClassId: []byte("example.com/shop/accounts/models.User"), Type: &types.Association{ToArrow: true}},
+				},
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := ParseProject(context.Background(), tt.Input)
+
+			var nodes []string
+			for _, node := range response.Nodes {
+				switch class := node.(type) {
+				case types.JavaClass:
+					nodes = append(nodes, string(class.Package)+"."+string(class.Name))
+				case types.JavaInterface:
+					nodes = append(nodes, string(class.Package)+"."+string(class.Name))
+				case types.JavaEnum:
+					nodes = append(nodes, string(class.Package)+"."+string(class.Name))
+				}
+			}
+
+			if fmt.Sprint(nodes) != fmt.Sprint(tt.Output.Nodes) {
+				subtest.Errorf("incorrect nodes.\nexpected:\n%s\ngot:\n%s\n", tt.Output.Nodes, nodes)
+			}
+
+			var expectedEdges, actualEdges []string
+			for _, edge := range tt.Output.Edges {
+				expectedEdges = append(expectedEdges, relationToString(edge))
+			}
+
+			for _, edge := range response.Edges {
+				actualEdges = append(actualEdges, relationToString(edge))
+			}
+
+			sort.Strings(expectedEdges)
+			sort.Strings(actualEdges)
+
+			if fmt.Sprint(expectedEdges) != fmt.Sprint(actualEdges) {
+				subtest.Errorf("incorrect edges.\nexpected:\n%s\ngot:\n%s\n", expectedEdges, actualEdges)
+			}
+		})
+	}
+}
+
+func TestParseProjectMembers(t *testing.T) {
+	response := ParseProject(context.Background(), []types.File{
+		{
+			Name:      "store",
+			Extension: "go",
+			Code: []byte(`
+package store
+
+import "context"
+
+type Store struct {
+	db, cache string
+	Items     []*Item
+}
+
+func (s *Store) Get(ctx context.Context, ids ...int) (*Item, error) {
+	return nil, nil
+}
+
+func (s Store) count() int { return 0 }
+
+type Item struct{}
+
+type Color string
+
+const (
+	Red   Color = "red"
+	Green Color = "green"
+)
+`),
+		},
+	})
+
+	if len(response.Nodes) != 3 {
+		t.Fatalf("incorrect number of nodes.\nexpected: 3\ngot: %d\n", len(response.Nodes))
+	}
+
+	store := response.Nodes[0].(types.JavaClass)
+
+	expectedVariables := []string{"private db string", "private cache string", "public Items []*Item"}
+	for index, variable := range store.Variables {
+		if actual := fmt.Sprintf("%s %s %s", variable.AccessModifier, variable.Name, variable.Type); actual != expectedVariables[index] {
+			t.Errorf("incorrect variable.\nexpected: %s\ngot: %s\n", expectedVariables[index], actual)
+		}
+	}
+
+	if len(store.Methods) != 2 {
+		t.Fatalf("incorrect number of methods.\nexpected: 2\ngot: %d\n", len(store.Methods))
+	}
+
+	get := store.Methods[0]
+	if string(get.AccessModifier) != "public" || string(get.Type) != "(*Item, error)" || len(get.Parameters) != 2 || string(get.Parameters[1].Type) != "...int" {
+		t.Errorf("incorrect method %s", get.Name)
+	}
+
+	if count := store.Methods[1]; string(count.AccessModifier) != "private" || string(count.Type) != "int" {
+		t.Errorf("incorrect method %s", count.Name)
+	}
+
+	color, ok := response.Nodes[2].(types.JavaEnum)
+	if !ok || len(color.Declarations) != 2 || string(color.Declarations[1]) != "Green" {
+		t.Errorf("Color is not an enum")
+	}
+}
+
+func TestGetPackageName(t *testing.T) {
+	var tests = map[string]string{
+		"fmt":                          "fmt",
+		"github.com/example/zoo":       "zoo",
+		"github.com/go-redis/redis/v8": "redis",
+		"gopkg.in/yaml.v3":             "yaml",
+	}
+
+	for input, expected := range tests {
+		t.Run(input, func(subtest *testing.T) {
+			if actual := getPackageName(input); actual != expected {
+				subtest.Errorf("incorrect package name.\nexpected: %s\ngot: %s\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseProjectDiagnostics(t *testing.T) {
+	response := ParseProject(context.Background(), []types.File{
+		{
+			Path:      "zoo/animal.go",
+			Name:      "animal",
+			Extension: "go",
+			Code: []byte(`package zoo
+
+type Animal struct {
+	Name string
+}
+
+func (a *Animal) Speak() string {
+	return a.Name +
+}
+`),
+		},
+		{
+			Path:      "zoo/keeper.go",
+			Name:      "keeper",
+			Extension: "go",
+			Code:      []byte("package zoo\n\ntype Keeper struct{}\n"),
+		},
+	})
+
+	if len(response.Nodes) != 2 {
+		t.Errorf("incorrect number of nodes.\nexpected: 2\ngot: %d\n", len(response.Nodes))
+	}
+
+	if len(response.Diagnostics) != 1 {
+		t.Fatalf("incorrect number of diagnostics.\nexpected: 1\ngot: %d\n", len(response.Diagnostics))
+	}
+
+	if diagnostic := response.Diagnostics[0]; diagnostic.Severity != types.DiagnosticError || diagnostic.Path != "zoo/animal.go" || diagnostic.Line != 9 {
+		t.Errorf("incorrect diagnostic.\nexpected: error zoo/animal.go:9\ngot: %s %s:%d %s\n", diagnostic.Severity, diagnostic.Path, diagnostic.Line, diagnostic.Message)
+	}
+}
@@ -0,0 +1,526 @@
+package transpiler
+
+import (
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+const (
+	hierarchicalNodeSeparation  = 40
+	hierarchicalRankSeparation  = 80
+	hierarchicalMaxRowWidth     = 2000
+	hierarchicalCrossingSweeps  = 8
+	hierarchicalGroupPadding    = 20
+	hierarchicalGroupHeader     = 24
+	hierarchicalGroupZIndex     = 1
+	hierarchicalGroupLabelSize  = 12
+	hierarchicalGroupLabelColor = "#616161"
+)
+
+// A box placed by the layered layout. Positions are relative to the top left corner of the layout.
+type layeredItem struct {
+	id     string
+	width  float64
+	height float64
+	x      float64
+	y      float64
+}
+
+// A connection between two layered items.
+// If inheritance is true, the item "to" is ranked above the item "from".
+type layeredLink struct {
+	from        string
+	to          string
+	inheritance bool
+}
+
+// Positions nodes in layers so that superclasses and interfaces sit above their implementers.
+// Nodes of the same package are laid out together and bounded by a group cell when the project has more than one package.
+// The result only depends on the class ids and relations, so the same project always produces the same picture.
+func layoutNodesHierarchically(nodes []types.DiagramNode, relations []types.Relation) []any {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	var (
+		packageNames []string
+		packageNodes = make(map[string][]int)  // The key is the package name and the value holds node indexes
+		nodePackages = make(map[string]string) // The key is the node's class id and the value is its package name
+	)
+
+	for i, node := range nodes {
+		packageName := string(node.GetPackage())
+		if _, ok := packageNodes[packageName]; !ok {
+			packageNames = append(packageNames, packageName)
+		}
+
+		packageNodes[packageName] = append(packageNodes[packageName], i)
+		nodePackages[string(getNodeClassId(node))] = packageName
+	}
+
+	sort.Strings(packageNames)
+
+	var (
+		useGroups     = len(packageNames) > 1
+		packageItems  []*layeredItem
+		packageLinks  []layeredLink
+		packageLayout = make(map[string][]*layeredItem)
+	)
+
+	// Lay out the classes of every package on their own
+	for _, packageName := range packageNames {
+		var (
+			items   []*layeredItem
+			links   []layeredLink
+			classes = make(map[string]struct{})
+		)
+
+		for _, nodeIndex := range packageNodes[packageName] {
+			classId := string(getNodeClassId(nodes[nodeIndex]))
+			classes[classId] = struct{}{}
+			items = append(items, &layeredItem{
+				id:     classId,
+				width:  nodes[nodeIndex].GetDiagramNode().Width,
+				height: nodes[nodeIndex].GetDiagramNode().Height,
+			})
+		}
+
+		for _, relation := range relations {
+			_, fromOk := classes[string(relation.FromClassId)]
+			_, toOk := classes[string(relation.ToClassId)]
+			if relation.Type == nil || !fromOk || !toOk {
+				continue
+			}
+
+			links = append(links, getLayeredLink(string(relation.FromClassId), string(relation.ToClassId), relation.Type))
+		}
+
+		width, height := layoutLayeredItems(items, links)
+		if useGroups {
+			width += 2 * hierarchicalGroupPadding
+			height += 2*hierarchicalGroupPadding + hierarchicalGroupHeader
+		}
+
+		packageLayout[packageName] = items
+		packageItems = append(packageItems, &layeredItem{
+			id:     packageName,
+			width:  width,
+			height: height,
+		})
+	}
+
+	// Lay out the packages relative to each other
+	for _, relation := range relations {
+		fromPackage, fromOk := nodePackages[string(relation.FromClassId)]
+		toPackage, toOk := nodePackages[string(relation.ToClassId)]
+		if relation.Type == nil || !fromOk || !toOk || fromPackage == toPackage {
+			continue
+		}
+
+		packageLinks = append(packageLinks, getLayeredLink(fromPackage, toPackage, relation.Type))
+	}
+
+	layoutLayeredItems(packageItems, packageLinks)
+
+	var (
+		groups        []types.Group
+		classPosition = make(map[string]types.Position)
+		classGroup    = make(map[string]int) // The key is the node's class id and the value is the index of its group
+	)
+
+	for _, packageItem := range packageItems {
+		offsetX, offsetY := packageItem.x, packageItem.y
+
+		if useGroups {
+			groups = append(groups, newPackageGroup(packageItem))
+			offsetX += hierarchicalGroupPadding
+			offsetY += hierarchicalGroupPadding + hierarchicalGroupHeader
+		}
+
+		for _, item := range packageLayout[packageItem.id] {
+			classPosition[item.id] = types.Position{X: offsetX + item.x, Y: offsetY + item.y}
+			if useGroups {
+				classGroup[item.id] = len(groups) - 1
+			}
+		}
+	}
+
+	for i, node := range nodes {
+		classId := string(getNodeClassId(node))
+		groupIndex, inGroup := classGroup[classId]
+
+		nodes[i] = types.UpdateDiagramNode(node, func(diagramNode *types.JavaDiagramNode) {
+			diagramNode.Position = classPosition[classId]
+			if inGroup {
+				diagramNode.Parent = groups[groupIndex].ID
+			}
+		})
+
+		if inGroup {
+			groups[groupIndex].Children = append(groups[groupIndex].Children, node.GetDiagramNode().ID)
+		}
+	}
+
+	var response []any
+	for _, group := range groups {
+		response = append(response, group)
+	}
+
+	return response
+}
+
+func newPackageGroup(packageItem *layeredItem) types.Group {
+	return types.Group{
+		ID:      uuid.New().String(),
+		Shape:   "rect",
+		ZIndex:  hierarchicalGroupZIndex,
+		Package: types.CustomByteSlice(packageItem.id),
+		Attrs: types.GroupAttrs{
+			Body: types.GroupBody{
+				Fill:            "transparent",
+				Stroke:          "#9E9E9E",
+				StrokeWidth:     1,
+				StrokeDasharray: "5,5",
+				Rx:              6,
+				Ry:              6,
+			},
+			Label: types.GroupLabel{
+				Text:               packageItem.id,
+				FontSize:           hierarchicalGroupLabelSize,
+				Fill:               hierarchicalGroupLabelColor,
+				RefX:               hierarchicalGroupPadding / 2,
+				RefY:               hierarchicalGroupPadding / 2,
+				TextAnchor:         "start",
+				TextVerticalAnchor: "top",
+			},
+		},
+		Position: types.Position{X: packageItem.x, Y: packageItem.y},
+		Size:     types.Size{Width: packageItem.width, Height: packageItem.height},
+	}
+}
+
+func getLayeredLink(fromClassId, toClassId string, relation types.RelationData) layeredLink {
+	return layeredLink{
+		from:        fromClassId,
+		to:          toClassId,
+		inheritance: relation.GetType() == "generalization" || relation.GetType() == "realization",
+	}
+}
+
+// Positions items in ranks and returns the width and height of the whole layout.
+// Ranks are assigned from inheritance links, crossings are reduced with barycenter sweeps,
+// and items without any links are packed below the layered items.
+func layoutLayeredItems(items []*layeredItem, links []layeredLink) (float64, float64) {
+	if len(items) == 0 {
+		return 0, 0
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].id < items[j].id
+	})
+
+	var (
+		itemsById  = make(map[string]*layeredItem, len(items))
+		parents    = make(map[string][]string) // Inheritance links from child to parent
+		neighbors  = make(map[string][]string) // All links, in both directions
+		seenLinks  = make(map[[2]string]struct{})
+		isolated   []*layeredItem
+		ranks      = make(map[string]int)
+		rankStates = make(map[string]int) // 0 = unvisited, 1 = visiting, 2 = done
+	)
+
+	for _, item := range items {
+		itemsById[item.id] = item
+	}
+
+	for _, link := range links {
+		if link.from == link.to {
+			continue
+		}
+
+		if _, ok := itemsById[link.from]; !ok {
+			continue
+		}
+
+		if _, ok := itemsById[link.to]; !ok {
+			continue
+		}
+
+		if link.inheritance {
+			parents[link.from] = append(parents[link.from], link.to)
+		}
+
+		key := [2]string{link.from, link.to}
+		if link.to < link.from {
+			key = [2]string{link.to, link.from}
+		}
+
+		if _, ok := seenLinks[key]; ok {
+			continue
+		}
+
+		seenLinks[key] = struct{}{}
+		neighbors[link.from] = append(neighbors[link.from], link.to)
+		neighbors[link.to] = append(neighbors[link.to], link.from)
+	}
+
+	for id := range parents {
+		sort.Strings(parents[id])
+	}
+
+	for id := range neighbors {
+		sort.Strings(neighbors[id])
+	}
+
+	// An item is ranked one below its lowest parent. Links that close a cycle are ignored.
+	var getRank func(id string) int
+	getRank = func(id string) int {
+		if rankStates[id] == 2 {
+			return ranks[id]
+		}
+
+		rankStates[id] = 1
+
+		rank := 0
+		for _, parent := range parents[id] {
+			if rankStates[parent] == 1 {
+				continue
+			}
+
+			if parentRank := getRank(parent) + 1; parentRank > rank {
+				rank = parentRank
+			}
+		}
+
+		ranks[id] = rank
+		rankStates[id] = 2
+		return rank
+	}
+
+	var layers [][]*layeredItem
+	for _, item := range items {
+		if len(neighbors[item.id]) == 0 {
+			isolated = append(isolated, item)
+			continue
+		}
+
+		rank := getRank(item.id)
+		for len(layers) <= rank {
+			layers = append(layers, nil)
+		}
+
+		layers[rank] = append(layers[rank], item)
+	}
+
+	orderLayers(layers, neighbors)
+
+	var (
+		y     float64
+		rows  [][]*layeredItem
+		width float64
+	)
+
+	// Split layers that are too wide into several rows
+	for _, layer := range layers {
+		rows = append(rows, splitRow(layer)...)
+	}
+
+	if len(isolated) > 0 {
+		rows = append(rows, splitRow(isolated)...)
+	}
+
+	for _, row := range rows {
+		if rowWidth := getRowWidth(row); rowWidth > width {
+			width = rowWidth
+		}
+	}
+
+	// Center every row below the previous one
+	for _, row := range rows {
+		var (
+			x         = (width - getRowWidth(row)) / 2
+			rowHeight float64
+		)
+
+		for _, item := range row {
+			item.x = x
+			item.y = y
+			x += item.width + hierarchicalNodeSeparation
+
+			if item.height > rowHeight {
+				rowHeight = item.height
+			}
+		}
+
+		y += rowHeight + hierarchicalRankSeparation
+	}
+
+	return width, math.Max(0, y-hierarchicalRankSeparation)
+}
+
+// Reorders the items of every layer to reduce the number of crossing links.
+// Runs alternating downward and upward barycenter sweeps and keeps the best ordering found.
+func orderLayers(layers [][]*layeredItem, neighbors map[string][]string) {
+	if len(layers) < 2 {
+		return
+	}
+
+	var (
+		best          = copyLayers(layers)
+		bestCrossings = countCrossings(layers, neighbors)
+	)
+
+	for sweep := 0; sweep < hierarchicalCrossingSweeps && bestCrossings > 0; sweep++ {
+		if sweep%2 == 0 {
+			for i := 1; i < len(layers); i++ {
+				sortByBarycenter(layers[i], layers[i-1], neighbors)
+			}
+		} else {
+			for i := len(layers) - 2; i >= 0; i-- {
+				sortByBarycenter(layers[i], layers[i+1], neighbors)
+			}
+		}
+
+		if crossings := countCrossings(layers, neighbors); crossings < bestCrossings {
+			best = copyLayers(layers)
+			bestCrossings = crossings
+		}
+	}
+
+	for i := range layers {
+		copy(layers[i], best[i])
+	}
+}
+
+// Sorts layer by the average index of each item's neighbors in the fixed layer.
+// Items without neighbors in the fixed layer keep their current index.
+func sortByBarycenter(layer, fixed []*layeredItem, neighbors map[string][]string) {
+	var (
+		fixedIndex  = make(map[string]int, len(fixed))
+		barycenters = make(map[string]float64, len(layer))
+		indexes     = make(map[string]int, len(layer))
+	)
+
+	for i, item := range fixed {
+		fixedIndex[item.id] = i
+	}
+
+	for i, item := range layer {
+		var (
+			sum   int
+			count int
+		)
+
+		for _, neighbor := range neighbors[item.id] {
+			if index, ok := fixedIndex[neighbor]; ok {
+				sum += index
+				count++
+			}
+		}
+
+		indexes[item.id] = i
+		if count == 0 {
+			barycenters[item.id] = float64(i)
+			continue
+		}
+
+		barycenters[item.id] = float64(sum) / float64(count)
+	}
+
+	sort.SliceStable(layer, func(i, j int) bool {
+		if barycenters[layer[i].id] != barycenters[layer[j].id] {
+			return barycenters[layer[i].id] < barycenters[layer[j].id]
+		}
+
+		return indexes[layer[i].id] < indexes[layer[j].id]
+	})
+}
+
+// Counts the links between adjacent layers that cross each other
+func countCrossings(layers [][]*layeredItem, neighbors map[string][]string) int {
+	var crossings int
+
+	for i := 0; i+1 < len(layers); i++ {
+		var (
+			lowerIndex = make(map[string]int, len(layers[i+1]))
+			segments   [][2]int
+		)
+
+		for j, item := range layers[i+1] {
+			lowerIndex[item.id] = j
+		}
+
+		for j, item := range layers[i] {
+			for _, neighbor := range neighbors[item.id] {
+				if k, ok := lowerIndex[neighbor]; ok {
+					segments = append(segments, [2]int{j, k})
+				}
+			}
+		}
+
+		for a := 0; a < len(segments); a++ {
+			for b := a + 1; b < len(segments); b++ {
+				if (segments[a][0]-segments[b][0])*(segments[a][1]-segments[b][1]) < 0 {
+					crossings++
+				}
+			}
+		}
+	}
+
+	return crossings
+}
+
+func copyLayers(layers [][]*layeredItem) [][]*layeredItem {
+	response := make([][]*layeredItem, len(layers))
+	for i, layer := range layers {
+		response[i] = append([]*layeredItem(nil), layer...)
+	}
+
+	return response
+}
+
+// Splits items into rows that are at most hierarchicalMaxRowWidth wide
+func splitRow(items []*layeredItem) [][]*layeredItem {
+	var (
+		rows  [][]*layeredItem
+		row   []*layeredItem
+		width float64
+	)
+
+	for _, item := range items {
+		if len(row) > 0 && width+hierarchicalNodeSeparation+item.width > hierarchicalMaxRowWidth {
+			rows = append(rows, row)
+			row = nil
+			width = 0
+		}
+
+		if len(row) > 0 {
+			width += hierarchicalNodeSeparation
+		}
+
+		row = append(row, item)
+		width += item.width
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func getRowWidth(row []*layeredItem) float64 {
+	var width float64
+	for i, item := range row {
+		if i > 0 {
+			width += hierarchicalNodeSeparation
+		}
+
+		width += item.width
+	}
+
+	return width
+}
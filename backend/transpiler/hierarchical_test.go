@@ -0,0 +1,149 @@
+package transpiler
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func getHierarchicalTestProject() ([]types.DiagramNode, []types.Relation) {
+	size := types.Size{Width: 150, Height: 80}
+	newClass := func(packageName, name, id string) types.JavaClass {
+		return types.JavaClass{
+			Package:         []byte(packageName),
+			Name:            []byte(name),
+			JavaDiagramNode: types.JavaDiagramNode{ID: id, Size: size},
+		}
+	}
+
+	nodes := []types.DiagramNode{
+		newClass("com.app.model", "Dog", "dog"),
+		newClass("com.app.model", "Cat", "cat"),
+		types.JavaInterface{Package: []byte("com.app.api"), Name: []byte("Pet"), JavaDiagramNode: types.JavaDiagramNode{ID: "pet", Size: size}},
+		types.JavaAbstract{Package: []byte("com.app.model"), Name: []byte("Animal"), JavaDiagramNode: types.JavaDiagramNode{ID: "animal", Size: size}},
+		newClass("com.app.service", "Shelter", "shelter"),
+		newClass("com.app.service", "Logger", "logger"),
+	}
+
+	edges := []types.Relation{
+		{FromClassId: []byte("com.app.model.Dog"), ToClassId: []byte("com.app.model.Animal"), Type: &types.Generalization{ToArrow: true}},
+		{FromClassId: []byte("com.app.model.Cat"), ToClassId: []byte("com.app.model.Animal"), Type: &types.Generalization{ToArrow: true}},
+		{FromClassId: []byte("com.app.model.Animal"), ToClassId: []byte("com.app.api.Pet"), Type: &types.Realization{ToArrow: true}},
+		{FromClassId: []byte("com.app.service.Shelter"), ToClassId: []byte("com.app.api.Pet"), Type: &types.Association{ToArrow: true}},
+	}
+
+	return nodes, edges
+}
+
+func TestLayoutNodesHierarchically(t *testing.T) {
+	nodes, edges := getHierarchicalTestProject()
+	groups := layoutNodesHierarchically(nodes, edges)
+
+	if len(groups) != 3 {
+		t.Fatalf("incorrect number of groups.\nexpected: 3\ngot: %d\n", len(groups))
+	}
+
+	nodesById := make(map[string]types.DiagramNode)
+	for _, node := range nodes {
+		nodesById[node.GetDiagramNode().ID] = node
+	}
+
+	// Every node is inside the bounds of its package group
+	for _, g := range groups {
+		group, ok := g.(types.Group)
+		if !ok {
+			t.Fatalf("incorrect group type")
+		}
+
+		for _, childId := range group.Children {
+			child := nodesById[childId]
+
+			if string(child.GetPackage()) != string(group.Package) {
+				t.Errorf("node %s is in group %s", childId, group.Package)
+			}
+
+			x, y := child.GetDiagramNode().Position.X, child.GetDiagramNode().Position.Y
+
+			if x < group.X || y < group.Y || x+child.GetDiagramNode().Width > group.X+group.Width || y+child.GetDiagramNode().Height > group.Y+group.Height {
+				t.Errorf("node %s is outside of group %s", childId, group.Package)
+			}
+		}
+	}
+
+	// Groups do not overlap
+	for i := 0; i < len(groups); i++ {
+		for j := i + 1; j < len(groups); j++ {
+			a, b := groups[i].(types.Group), groups[j].(types.Group)
+			if a.X < b.X+b.Width && b.X < a.X+a.Width && a.Y < b.Y+b.Height && b.Y < a.Y+a.Height {
+				t.Errorf("groups %s and %s overlap", a.Package, b.Package)
+			}
+		}
+	}
+
+	// Superclasses and interfaces are placed above their implementers
+	dog, animal, pet := nodesById["dog"], nodesById["animal"], nodesById["pet"]
+	if animal.GetDiagramNode().Position.Y >= dog.GetDiagramNode().Position.Y || pet.GetDiagramNode().Position.Y >= animal.GetDiagramNode().Position.Y {
+		t.Errorf("parents are not placed above their children")
+	}
+}
+
+func TestLayoutNodesHierarchicallyIsDeterministic(t *testing.T) {
+	expectedNodes, expectedEdges := getHierarchicalTestProject()
+	layoutNodesHierarchically(expectedNodes, expectedEdges)
+
+	for run := 0; run < 5; run++ {
+		t.Run("Test index "+strconv.Itoa(run), func(subtest *testing.T) {
+			nodes, edges := getHierarchicalTestProject()
+
+			// Reverse the input order, the result must not change
+			for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+				nodes[i], nodes[j] = nodes[j], nodes[i]
+			}
+
+			layoutNodesHierarchically(nodes, edges)
+
+			for _, expected := range expectedNodes {
+				for _, node := range nodes {
+					if node.GetDiagramNode().ID != expected.GetDiagramNode().ID {
+						continue
+					}
+
+					if node.GetDiagramNode().Position.X != expected.GetDiagramNode().Position.X || node.GetDiagramNode().Position.Y != expected.GetDiagramNode().Position.Y {
+						subtest.Errorf("node %s moved", node.GetDiagramNode().ID)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestOrderLayers(t *testing.T) {
+	newItems := func(ids ...string) []*layeredItem {
+		var items []*layeredItem
+		for _, id := range ids {
+			items = append(items, &layeredItem{id: id, width: 100, height: 50})
+		}
+
+		return items
+	}
+
+	// a-d and b-c cross when both layers are in alphabetical order
+	layers := [][]*layeredItem{newItems("a", "b"), newItems("c", "d")}
+	neighbors := map[string][]string{
+		"a": {"d"},
+		"b": {"c"},
+		"c": {"b"},
+		"d": {"a"},
+	}
+
+	if crossings := countCrossings(layers, neighbors); crossings != 1 {
+		t.Fatalf("incorrect crossings.\nexpected: 1\ngot: %d\n", crossings)
+	}
+
+	orderLayers(layers, neighbors)
+
+	if crossings := countCrossings(layers, neighbors); crossings != 0 {
+		t.Errorf("incorrect crossings.\nexpected: 0\ngot: %d\n", crossings)
+	}
+}
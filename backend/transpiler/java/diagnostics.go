@@ -0,0 +1,87 @@
+package java
+
+import (
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+// Get the errors that make the parser lose declarations: comments that are never closed and braces that do not match.
+// The text has its comments and quotations blanked out already.
+func getSyntaxDiagnostics(text []byte, unclosedIndex int, getLine func(int) int) []types.Diagnostic {
+	var (
+		response         []types.Diagnostic
+		openCurlyIndexes []int
+	)
+
+	if unclosedIndex != -1 {
+		response = append(response, types.Diagnostic{
+			Severity: types.DiagnosticError,
+			Line:     getLine(unclosedIndex),
+			Message:  "comment or text block is never closed",
+		})
+	}
+
+	for i, b := range text {
+		if b == OpenCurly {
+			openCurlyIndexes = append(openCurlyIndexes, i)
+		} else if b == ClosedCurly {
+			if len(openCurlyIndexes) == 0 {
+				return append(response, types.Diagnostic{
+					Severity: types.DiagnosticError,
+					Line:     getLine(i),
+					Message:  "closing brace does not match an opening brace",
+				})
+			}
+
+			openCurlyIndexes = openCurlyIndexes[:len(openCurlyIndexes)-1]
+		}
+	}
+
+	// Only the innermost brace that is still open is reported
+	if len(openCurlyIndexes) != 0 {
+		response = append(response, types.Diagnostic{
+			Severity: types.DiagnosticError,
+			Line:     getLine(openCurlyIndexes[len(openCurlyIndexes)-1]),
+			Message:  "opening brace is never closed",
+		})
+	}
+
+	return response
+}
+
+// Get a warning for every declaration of the code that the parser did not return, because it is missing from the diagram.
+// Members are only checked for classes whose members are parsed, enum constants are not.
+func getMissingDeclarationDiagnostics(declarations []sourceDeclaration, className []byte) []types.Diagnostic {
+	var response []types.Diagnostic
+
+	for _, declaration := range declarations {
+		switch {
+		case declaration.Kind == sourceClass && !declaration.matched:
+			response = append(response, types.Diagnostic{
+				Severity: types.DiagnosticWarning,
+				Line:     declaration.Span.StartLine,
+				Message:  "could not parse class " + string(declaration.Names[0]),
+			})
+		case declaration.Kind == sourceClass:
+			response = append(response, getMissingDeclarationDiagnostics(declaration.Members, declaration.Names[0])...)
+		case declaration.used == nil:
+			continue
+		default:
+			kind := "variable"
+			if declaration.Kind == sourceMethod {
+				kind = "method"
+			}
+
+			for i, name := range declaration.Names {
+				if !declaration.used[i] {
+					response = append(response, types.Diagnostic{
+						Severity: types.DiagnosticWarning,
+						Line:     declaration.Span.StartLine,
+						Message:  "could not parse " + kind + " " + string(name) + " of class " + string(className),
+					})
+				}
+			}
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,95 @@
+package java
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestGetDiagnostics(t *testing.T) {
+	type GetDiagnosticsTest struct {
+		Input  []byte
+		Output []string
+	}
+
+	var tests = []GetDiagnosticsTest{
+		{
+			Input: []byte(`package com.shop;
+
+public class Order {
+	private String note = "{ /* }";
+	private char open = '{';
+
+	public void add() {}
+}
+`),
+			Output: nil,
+		},
+		{
+			Input: []byte(`package com.shop;
+
+public class Order {
+	/* Never closed
+	private int count;
+}
+`),
+			Output: []string{
+				"error src/Order.java:4 comment or text block is never closed",
+				"error src/Order.java:3 opening brace is never closed",
+			},
+		},
+		{
+			Input: []byte(`public class Order {
+	public void add() {
+		count++;
+
+	public void remove() {}
+}
+`),
+			Output: []string{
+				"error src/Order.java:1 opening brace is never closed",
+				"warning src/Order.java:1 could not parse class Order",
+			},
+		},
+		{
+			Input: []byte(`public class Order {
+	public void add() {}
+	}
+
+	public void remove() {}
+}
+`),
+			Output: []string{
+				"error src/Order.java:6 closing brace does not match an opening brace",
+			},
+		},
+		{
+			Input: []byte(`public class Order {
+	private int[] lines, totals[];
+
+	public void add() {}
+}
+`),
+			Output: []string{
+				"warning src/Order.java:2 could not parse variable totals of class Order",
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := ParseFile(types.File{Path: "src/Order.java", Name: "Order", Extension: "java", Code: tt.Input})
+
+			var got []string
+			for _, diagnostic := range response.Diagnostics {
+				got = append(got, fmt.Sprintf("%s %s:%d %s", diagnostic.Severity, diagnostic.Path, diagnostic.Line, diagnostic.Message))
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.Output) {
+				subtest.Errorf("incorrect diagnostics.\nexpected:\n%v\ngot:\n%v\n", tt.Output, got)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package java
 
 import (
 	"bytes"
-	"regexp"
 
 	"github.com/junioryono/ProUML/backend/transpiler/types"
 )
@@ -43,7 +42,7 @@ const (
 	Tab               byte = '\t'
 )
 
-func parseFile(file types.File) types.FileResponse {
+func ParseFile(file types.File) types.FileResponse {
 	var (
 		response   = types.FileResponse{}
 		parsedText = file.Code
@@ -53,13 +52,15 @@ func parseFile(file types.File) types.FileResponse {
 		return response
 	}
 
+	parsedText, javadocs := cutJavadocs(parsedText)
 	parsedText = removeComments(parsedText)
 	parsedText = removeSpacing(parsedText)
-	parsedText = removeAnnotations(parsedText)
+	parsedText = simplifyAnnotations(parsedText)
 
 	response.Package = getPackageName(parsedText)
 	response.Imports = getPackageImports(parsedText)
-	response.Data = append(response.Data, getFileClasses(parsedText, response.Package)...)
+	response.Data = append(response.Data, attachJavadocs(getFileClasses(parsedText, response.Package), javadocs)...)
+	response.Data, response.Diagnostics = attachSourceSpans(response.Data, file)
 
 	return response
 }
@@ -105,8 +106,7 @@ func removeComments(text []byte) []byte {
 // Remove all extra spacing from code
 func removeSpacing(text []byte) []byte {
 	// Replace all \r. Needed for Windows files
-	REGEX_NewLine := regexp.MustCompile(`\r`)
-	text = REGEX_NewLine.ReplaceAll(text, []byte(""))
+	text = bytes.ReplaceAll(text, []byte("\r"), nil)
 
 	needsSpace := func(b byte) bool {
 		if b == EqualSign || b == AndCondition || b == OrCondition || b == Colon {
@@ -195,13 +195,12 @@ func removeSpacing(text []byte) []byte {
 		return false
 	}
 
+	// Remove a word between two spaces and the space before it. This runs for every byte, so it does not allocate.
 	removeWord := func(i *int, s string) bool {
-		b := []byte(s)
-		b = append([]byte{Space}, b...)
-		b = append(b, Space)
+		endIndex := *i + len(s) + 1
 
-		if *i+len(b)-1 < len(text) && bytes.Equal(text[*i:*i+len(b)], b) {
-			text = append(text[:*i], text[*i+len(b)-1:]...)
+		if endIndex < len(text) && text[*i] == Space && text[endIndex] == Space && string(text[*i+1:endIndex]) == s {
+			text = append(text[:*i], text[endIndex:]...)
 			return true
 		}
 
@@ -221,21 +220,6 @@ func removeSpacing(text []byte) []byte {
 		return false
 	}
 
-	// Remove all exception throws: public void writeList()throws IOException,IndexOutOfBoundsException{
-	removeMethodThrowsExceptions := func(i *int) bool {
-		if *i+1 < len(text) && *i > 5 && text[*i-1] == ClosedParenthesis && text[*i] == 't' && text[*i+1] == 'h' && text[*i+2] == 'r' && text[*i+3] == 'o' && text[*i+4] == 'w' && text[*i+5] == 's' && text[*i+6] == Space {
-
-			for closingCurlyIndex := *i + 7; closingCurlyIndex < len(text); closingCurlyIndex++ {
-				if text[closingCurlyIndex] == OpenCurly || text[closingCurlyIndex] == SemiColon {
-					text = append(text[:*i], text[closingCurlyIndex:]...)
-					break
-				}
-			}
-		}
-
-		return false
-	}
-
 	for i, f := 0, ignoreQuotes(&text); i < len(text); i++ {
 		isInsideQuotation := f(i)
 		if isInsideQuotation {
@@ -451,8 +435,6 @@ func removeSpacing(text []byte) []byte {
 		if ok {
 			continue
 		}
-
-		removeMethodThrowsExceptions(&i)
 	}
 
 	// Trim left and right spacing
@@ -509,6 +491,151 @@ func removeAnnotations(text []byte) []byte {
 	return text
 }
 
+// Rewrite every annotation as its simple name followed by a space, so that declarations can keep them:
+// @javax.persistence.Table(name="orders")public class Order becomes @Table public class Order.
+// Annotations of type arguments, such as List<@NonNull String>, are removed.
+func simplifyAnnotations(text []byte) []byte {
+	var (
+		response   []byte
+		arrowScope int = 0
+	)
+
+	isNamePart := func(b byte) bool {
+		return b == '_' || b == '$' || b == Period || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+	}
+
+	for i, f := 0, ignoreQuotes(&text); i < len(text); i++ {
+		isInsideQuotation := f(i)
+		if isInsideQuotation || text[i] != Asperand {
+			if !isInsideQuotation {
+				switch text[i] {
+				case LeftArrow:
+					arrowScope++
+				case RightArrow:
+					if arrowScope > 0 {
+						arrowScope--
+					}
+				case SemiColon, OpenCurly, ClosedCurly, OpenParenthesis, ClosedParenthesis:
+					arrowScope = 0
+				}
+			}
+
+			response = append(response, text[i])
+			continue
+		}
+
+		nameEndIndex := i + 1
+		for nameEndIndex < len(text) && isNamePart(text[nameEndIndex]) {
+			nameEndIndex++
+		}
+
+		name := text[i+1 : nameEndIndex]
+		if periodIndex := bytes.LastIndexByte(name, Period); periodIndex != -1 {
+			name = name[periodIndex+1:]
+		}
+
+		// Skip the elements of the annotation: @SuppressWarnings({"unchecked","rawtypes"})
+		endIndex := nameEndIndex
+		if endIndex < len(text) && (text[endIndex] == OpenParenthesis || text[endIndex] == OpenCurly) {
+			var (
+				scope        int  = 0
+				currentQuote byte = NoQuote
+			)
+
+			for ; endIndex < len(text); endIndex++ {
+				if currentQuote != NoQuote {
+					if text[endIndex] == currentQuote && text[endIndex-1] != Backslash {
+						currentQuote = NoQuote
+					}
+				} else if text[endIndex] == DoubleQuote || text[endIndex] == SingleQuote {
+					currentQuote = text[endIndex]
+				} else if text[endIndex] == OpenParenthesis || text[endIndex] == OpenCurly {
+					scope++
+				} else if text[endIndex] == ClosedParenthesis || text[endIndex] == ClosedCurly {
+					scope--
+
+					if scope == 0 {
+						endIndex++
+						break
+					}
+				}
+			}
+		}
+
+		if endIndex < len(text) && text[endIndex] == Space {
+			endIndex++
+		}
+
+		if arrowScope == 0 && len(name) != 0 {
+			if len(response) != 0 {
+				switch response[len(response)-1] {
+				case Space, OpenParenthesis, Comma, SemiColon, OpenCurly, ClosedCurly:
+				default:
+					response = append(response, Space)
+				}
+			}
+
+			response = append(response, Asperand)
+			response = append(response, name...)
+			response = append(response, Space)
+		}
+
+		i = endIndex - 1
+	}
+
+	return response
+}
+
+// Cut the annotations off of the declaration at the start of text: @Id @Column private Long id=0;
+// Annotations in the value or the body of the declaration are left alone.
+func cutAnnotations(text []byte) ([]types.CustomByteSlice, []byte) {
+	if bytes.IndexByte(text, Asperand) == -1 {
+		return nil, text
+	}
+
+	declarationEndIndex := bytes.IndexAny(text, "=({;")
+	if declarationEndIndex == -1 {
+		declarationEndIndex = len(text)
+	}
+
+	var (
+		annotations []types.CustomByteSlice
+		words       [][]byte
+	)
+
+	for _, word := range bytes.Split(text[:declarationEndIndex], []byte(" ")) {
+		if len(word) > 1 && word[0] == Asperand && !bytes.Equal(word, []byte("@interface")) {
+			annotations = append(annotations, word[1:])
+			continue
+		}
+
+		// Spacing around removed annotations and Javadoc markers must not become part of the declaration
+		if len(word) == 0 {
+			continue
+		}
+
+		words = append(words, word)
+	}
+
+	if len(annotations) == 0 {
+		return nil, text
+	}
+
+	response := bytes.Join(words, []byte(" "))
+	response = append(response, text[declarationEndIndex:]...)
+
+	return annotations, response
+}
+
+// Remove annotations from a copy of code that belongs to a body or a value
+func removeBodyAnnotations(text []byte) []byte {
+	if bytes.IndexByte(text, Asperand) == -1 {
+		return text
+	}
+
+	return removeAnnotations(append([]byte{}, text...))
+}
+
 // Get all package imports declare in file
 func getPackageImports(text []byte) [][]byte {
 	var (
@@ -590,12 +717,50 @@ func getFileClasses(text []byte, packageName []byte) []any {
 	}
 
 	for i := 0; i < len(classesText); i++ {
-		textSplit := bytes.Split(classesText[i].Outside, []byte(" "))
+		var (
+			textSplit    [][]byte
+			Stereotypes  []types.CustomByteSlice
+			isDeclared   bool = false
+			declarations      = [][]byte{[]byte("class"), []byte("interface"), []byte("enum"), []byte("record"), []byte("@interface")}
+		)
+
+		outside, TypeParameters := cutTypeParameters(classesText[i].Outside)
+
+		// Annotations before the declaration belong to the class, annotations after it belong to the types it uses
+		for _, word := range bytes.Split(outside, []byte(" ")) {
+			if len(word) > 1 && word[0] == Asperand && !bytes.Equal(word, []byte("@interface")) {
+				if !isDeclared {
+					Stereotypes = append(Stereotypes, word[1:])
+				}
+
+				continue
+			}
+
+			if findIndex(string(word), declarations) != -1 {
+				isDeclared = true
+			}
+
+			textSplit = append(textSplit, word)
+		}
+
+		if annotationIndex := findIndex("@interface", textSplit); annotationIndex != -1 && annotationIndex+1 < len(textSplit) {
+			classesStruct = append(classesStruct, getAnnotationType(classesText[i], packageName, textSplit[annotationIndex+1], Stereotypes))
+			continue
+		}
 
 		abstractIndex := findIndex("abstract", textSplit)
 		classIndex := findIndex("class", textSplit)
 		interfaceIndex := findIndex("interface", textSplit)
 		enumIndex := findIndex("enum", textSplit)
+		recordIndex := findIndex("record", textSplit)
+
+		if recordIndex != -1 && classIndex == -1 && interfaceIndex == -1 && enumIndex == -1 {
+			record := getRecord(types.JavaClassText{DefinedWithin: classesText[i].DefinedWithin, Outside: outside, Inside: classesText[i].Inside}, packageName)
+			record.TypeParameters = TypeParameters
+			record.Stereotypes = Stereotypes
+			classesStruct = append(classesStruct, record)
+			continue
+		}
 
 		if (classIndex != -1 && (interfaceIndex != -1 || enumIndex != -1)) ||
 			(interfaceIndex != -1 && enumIndex != -1) ||
@@ -604,11 +769,18 @@ func getFileClasses(text []byte, packageName []byte) []any {
 		}
 
 		if enumIndex != -1 {
+			var Declarations []types.CustomByteSlice
+			for _, declaration := range getEnumDeclarations(classesText[i].Inside) {
+				_, declaration := cutAnnotations(declaration)
+				Declarations = append(Declarations, declaration)
+			}
+
 			classesStruct = append(classesStruct, types.JavaEnum{
 				DefinedWithin: classesText[i].DefinedWithin,
 				Package:       packageName,
 				Name:          textSplit[enumIndex+1],
-				Declarations:  getEnumDeclarations(classesText[i].Inside),
+				Stereotypes:   Stereotypes,
+				Declarations:  Declarations,
 			})
 			continue
 		}
@@ -618,64 +790,154 @@ func getFileClasses(text []byte, packageName []byte) []any {
 		}
 
 		Variables, Methods := getVariablesAndMethods(classesText[i].Inside)
+		if classIndex != -1 {
+			Methods = markConstructors(Methods, textSplit[classIndex+1])
+		}
 
-		var ExtendsTemp [][]byte
-		var ExtendsCustom []types.CustomByteSlice
-		extendsIndex := findIndex("extends", textSplit)
-		if extendsIndex != -1 {
-			ExtendsTemp = bytes.Split(textSplit[extendsIndex+1], []byte(","))
-			for _, v := range ExtendsTemp {
-				ExtendsCustom = append(ExtendsCustom, bytes.Trim(v, " "))
-			}
+		if findIndex("sealed", textSplit) != -1 {
+			Stereotypes = append(Stereotypes, []byte("sealed"))
 		}
 
+		header := bytes.Join(textSplit, []byte(" "))
+		ExtendsCustom := getDeclarationTypes(header, "extends")
+		PermitsCustom := getDeclarationTypes(header, "permits")
+
 		if interfaceIndex != -1 {
 			classesStruct = append(classesStruct, types.JavaInterface{
-				DefinedWithin: classesText[i].DefinedWithin,
-				Package:       packageName,
-				Name:          textSplit[interfaceIndex+1],
-				Extends:       ExtendsCustom,
-				Variables:     Variables,
-				Methods:       Methods,
+				DefinedWithin:  classesText[i].DefinedWithin,
+				Package:        packageName,
+				Name:           textSplit[interfaceIndex+1],
+				TypeParameters: TypeParameters,
+				Stereotypes:    Stereotypes,
+				Extends:        ExtendsCustom,
+				Permits:        PermitsCustom,
+				Variables:      Variables,
+				Methods:        Methods,
 			})
 			continue
 		}
 
-		var implementsTemp [][]byte
-		var ImplementsCustom []types.CustomByteSlice
-		implementsIndex := findIndex("implements", textSplit)
-		if implementsIndex != -1 {
-			implementsTemp = bytes.Split(textSplit[implementsIndex+1], []byte(","))
-			for _, v := range implementsTemp {
-				ImplementsCustom = append(ImplementsCustom, types.CustomByteSlice(v))
-			}
-		}
+		ImplementsCustom := getDeclarationTypes(header, "implements")
 
 		if abstractIndex != -1 {
 			classesStruct = append(classesStruct, types.JavaAbstract{
-				DefinedWithin: classesText[i].DefinedWithin,
-				Package:       packageName,
-				Name:          textSplit[classIndex+1],
-				Implements:    ImplementsCustom,
-				Extends:       ExtendsCustom,
-				Variables:     Variables,
-				Methods:       Methods,
+				DefinedWithin:  classesText[i].DefinedWithin,
+				Package:        packageName,
+				Name:           textSplit[classIndex+1],
+				TypeParameters: TypeParameters,
+				Stereotypes:    Stereotypes,
+				Implements:     ImplementsCustom,
+				Extends:        ExtendsCustom,
+				Permits:        PermitsCustom,
+				Variables:      Variables,
+				Methods:        Methods,
 			})
 			continue
 		}
 
 		classesStruct = append(classesStruct, types.JavaClass{
-			DefinedWithin: classesText[i].DefinedWithin,
-			Package:       packageName,
-			Name:          textSplit[classIndex+1],
-			Implements:    ImplementsCustom,
-			Extends:       ExtendsCustom,
-			Variables:     Variables,
-			Methods:       Methods,
+			DefinedWithin:  classesText[i].DefinedWithin,
+			Package:        packageName,
+			Name:           textSplit[classIndex+1],
+			TypeParameters: TypeParameters,
+			Stereotypes:    Stereotypes,
+			Implements:     ImplementsCustom,
+			Extends:        ExtendsCustom,
+			Permits:        PermitsCustom,
+			Variables:      Variables,
+			Methods:        Methods,
 		})
 	}
 
-	return classesStruct
+	return addImplicitPermits(classesStruct)
+}
+
+// Sealed classes without a permits clause permit the subclasses declared in the same file
+func addImplicitPermits(classes []any) []any {
+	getSupertypeNames := func(class any) []types.CustomByteSlice {
+		switch c := class.(type) {
+		case types.JavaAbstract:
+			return append(append([]types.CustomByteSlice{}, c.Extends...), c.Implements...)
+		case types.JavaClass:
+			return append(append([]types.CustomByteSlice{}, c.Extends...), c.Implements...)
+		case types.JavaInterface:
+			return c.Extends
+		case types.JavaEnum:
+			return c.Implements
+		case types.JavaRecord:
+			return c.Implements
+		}
+
+		return nil
+	}
+
+	getPermits := func(name []byte) []types.CustomByteSlice {
+		var response []types.CustomByteSlice
+
+		for _, class := range classes {
+			for _, supertype := range getSupertypeNames(class) {
+				// Type arguments do not change the supertype: Shape<T>
+				supertype, _, _ := bytes.Cut(supertype, []byte("<"))
+				if bytes.Equal(supertype, name) {
+					response = append(response, getClassName(class))
+					break
+				}
+			}
+		}
+
+		return response
+	}
+
+	for i, class := range classes {
+		switch c := class.(type) {
+		case types.JavaAbstract:
+			if isSealed(c.Stereotypes) && len(c.Permits) == 0 {
+				c.Permits = getPermits(c.Name)
+				classes[i] = c
+			}
+		case types.JavaClass:
+			if isSealed(c.Stereotypes) && len(c.Permits) == 0 {
+				c.Permits = getPermits(c.Name)
+				classes[i] = c
+			}
+		case types.JavaInterface:
+			if isSealed(c.Stereotypes) && len(c.Permits) == 0 {
+				c.Permits = getPermits(c.Name)
+				classes[i] = c
+			}
+		}
+	}
+
+	return classes
+}
+
+func isSealed(stereotypes []types.CustomByteSlice) bool {
+	for _, stereotype := range stereotypes {
+		if bytes.Equal(stereotype, []byte("sealed")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getClassName(class any) []byte {
+	switch c := class.(type) {
+	case types.JavaAbstract:
+		return c.Name
+	case types.JavaClass:
+		return c.Name
+	case types.JavaInterface:
+		return c.Name
+	case types.JavaEnum:
+		return c.Name
+	case types.JavaRecord:
+		return c.Name
+	case types.JavaAnnotation:
+		return c.Name
+	}
+
+	return nil
 }
 
 // Get all nested classes declared in file | ..Recursive..
@@ -687,7 +949,7 @@ func getNestedClasses(text []byte, previousClass *types.JavaClassText) []types.J
 	)
 
 	isClassDeclaration := func(word1, word2 []byte) bool {
-		return (bytes.Equal(word1, []byte("abstract")) && bytes.Equal(word2, []byte("class"))) || bytes.Equal(word1, []byte("class")) || bytes.Equal(word1, []byte("interface")) || bytes.Equal(word1, []byte("enum"))
+		return (bytes.Equal(word1, []byte("abstract")) && bytes.Equal(word2, []byte("class"))) || bytes.Equal(word1, []byte("class")) || bytes.Equal(word1, []byte("interface")) || bytes.Equal(word1, []byte("enum")) || bytes.Equal(word1, []byte("record")) || bytes.Equal(word1, []byte("@interface"))
 	}
 
 	for i, f := 0, ignoreQuotes(&text); i < len(text); i++ {
@@ -729,7 +991,14 @@ func getNestedClasses(text []byte, previousClass *types.JavaClassText) []types.J
 			)
 
 			outerText = append(outerText, text[j:startScopeIndex]...)
-			outerTextSplit := bytes.Split(outerText, []byte(" "))
+			var outerTextSplit [][]byte
+			for _, word := range bytes.Split(outerText, []byte(" ")) {
+				if len(word) > 1 && word[0] == Asperand && !bytes.Equal(word, []byte("@interface")) {
+					continue
+				}
+
+				outerTextSplit = append(outerTextSplit, word)
+			}
 			innerText = append(innerText, text[startScopeIndex+1:i]...)
 
 			if !(len(outerTextSplit) > 5 && isClassDeclaration(outerTextSplit[4], outerTextSplit[5]) ||
@@ -769,7 +1038,7 @@ func getNestedClasses(text []byte, previousClass *types.JavaClassText) []types.J
 				endingIndex      int = len(previousClass.Inside) - 1
 			)
 
-			for i2, f2 := 0, ignoreQuotes(&previousClass.Inside); i2 < len(previousClass.Inside); i2++ {
+			for i2, f2 := index, ignoreQuotes(&previousClass.Inside); i2 < len(previousClass.Inside); i2++ {
 				isInsideQuotation2 := f2(i2)
 				if isInsideQuotation2 {
 					continue
@@ -791,11 +1060,9 @@ func getNestedClasses(text []byte, previousClass *types.JavaClassText) []types.J
 				}
 			}
 
-			previousClass.Inside = append(previousClass.Inside[0:index], previousClass.Inside[endingIndex+1:]...)
-			previousOuterTextSplit := bytes.Split(previousClass.Outside, []byte(" "))
-			definedWithin := previousOuterTextSplit[len(previousOuterTextSplit)-1]
-
-			addCurrentAndCheckForNext(definedWithin, outerText, innerText)
+			// The inside of the previous class is the text that is being read, so the nested class is removed from a copy
+			previousClass.Inside = append(append([]byte{}, previousClass.Inside[0:index]...), previousClass.Inside[endingIndex+1:]...)
+			addCurrentAndCheckForNext(getDeclaredName(previousClass.Outside), outerText, innerText)
 			break
 		}
 
@@ -805,6 +1072,395 @@ func getNestedClasses(text []byte, previousClass *types.JavaClassText) []types.J
 	return classes
 }
 
+// Cut the type parameters off of the name of a class: public class Repository<T extends Entity&Serializable,ID>extends Base
+func cutTypeParameters(outside []byte) ([]byte, []types.JavaTypeParameter) {
+	var nameStartIndex int = -1
+
+	for _, keyword := range []string{"class ", "interface ", "enum ", "record ", "@interface "} {
+		for i := 0; i+len(keyword) <= len(outside); i++ {
+			if (i == 0 || outside[i-1] == Space) && bytes.HasPrefix(outside[i:], []byte(keyword)) {
+				nameStartIndex = i + len(keyword)
+				break
+			}
+		}
+
+		if nameStartIndex != -1 {
+			break
+		}
+	}
+
+	if nameStartIndex == -1 {
+		return outside, nil
+	}
+
+	openArrowIndex := nameStartIndex
+	for openArrowIndex < len(outside) && outside[openArrowIndex] != LeftArrow && outside[openArrowIndex] != Space && outside[openArrowIndex] != OpenParenthesis {
+		openArrowIndex++
+	}
+
+	if openArrowIndex == len(outside) || outside[openArrowIndex] != LeftArrow {
+		return outside, nil
+	}
+
+	closedArrowIndex := -1
+	for i, arrowScope := openArrowIndex, 0; i < len(outside); i++ {
+		if outside[i] == LeftArrow {
+			arrowScope++
+		} else if outside[i] == RightArrow {
+			arrowScope--
+
+			if arrowScope == 0 {
+				closedArrowIndex = i
+				break
+			}
+		}
+	}
+
+	if closedArrowIndex == -1 {
+		return outside, nil
+	}
+
+	var typeParameters []types.JavaTypeParameter
+	for _, typeParameter := range splitOutsideArrows(outside[openArrowIndex+1:closedArrowIndex], Comma) {
+		name, bounds, found := bytes.Cut(typeParameter, []byte(" extends "))
+
+		current := types.JavaTypeParameter{Name: name}
+		if found {
+			for _, bound := range splitOutsideArrows(bounds, AndCondition) {
+				current.Bounds = append(current.Bounds, bound)
+			}
+		}
+
+		typeParameters = append(typeParameters, current)
+	}
+
+	// Keep a space between the name and the next keyword: Repository<T>extends Base
+	response := append([]byte{}, outside[:openArrowIndex]...)
+	if rest := outside[closedArrowIndex+1:]; len(rest) != 0 {
+		if rest[0] != Space && rest[0] != OpenParenthesis {
+			response = append(response, Space)
+		}
+
+		response = append(response, rest...)
+	}
+
+	return response, typeParameters
+}
+
+// Get the types listed after a keyword of a class declaration: extends Base<Map<K,V>>,Other implements Shape
+func getDeclarationTypes(header []byte, keyword string) []types.CustomByteSlice {
+	var (
+		response   []types.CustomByteSlice
+		startIndex int = -1
+		arrowScope int = 0
+	)
+
+	for i := 0; i < len(header); i++ {
+		if header[i] == LeftArrow {
+			arrowScope++
+		} else if header[i] == RightArrow {
+			arrowScope--
+		} else if arrowScope == 0 && (i == 0 || header[i-1] == Space || header[i-1] == RightArrow) && bytes.HasPrefix(header[i:], []byte(keyword+" ")) {
+			startIndex = i + len(keyword) + 1
+			break
+		}
+	}
+
+	if startIndex == -1 {
+		return response
+	}
+
+	// Types are only separated by commas, spaces are left inside of their type arguments: List<? extends Shape>.
+	// The spacing around arrows is removed, so the next keyword can follow the last arrow: Base<User>implements Shape
+	endIndex := len(header)
+	for i := startIndex; i < len(header); i++ {
+		if header[i] == LeftArrow {
+			arrowScope++
+		} else if header[i] == RightArrow {
+			arrowScope--
+
+			if arrowScope == 0 && i+1 < len(header) && header[i+1] != Comma && header[i+1] != Space {
+				endIndex = i + 1
+				break
+			}
+		} else if header[i] == Space && arrowScope == 0 {
+			endIndex = i
+			break
+		}
+	}
+
+	for _, declarationType := range splitOutsideArrows(header[startIndex:endIndex], Comma) {
+		if len(declarationType) != 0 {
+			response = append(response, declarationType)
+		}
+	}
+
+	return response
+}
+
+// Get the name of the class declared by the text outside of its body: public class Dog extends Animal
+func getDeclaredName(outside []byte) []byte {
+	textSplit := bytes.Split(outside, []byte(" "))
+
+	for i := 0; i+1 < len(textSplit); i++ {
+		if bytes.Equal(textSplit[i], []byte("class")) ||
+			bytes.Equal(textSplit[i], []byte("interface")) ||
+			bytes.Equal(textSplit[i], []byte("enum")) ||
+			bytes.Equal(textSplit[i], []byte("record")) ||
+			bytes.Equal(textSplit[i], []byte("@interface")) {
+			name, _, _ := bytes.Cut(textSplit[i+1], []byte("("))
+			name, _, _ = bytes.Cut(name, []byte("<"))
+			return name
+		}
+	}
+
+	return textSplit[len(textSplit)-1]
+}
+
+// Get a record from its declaration: public record Point(int x,int y)implements Shape{...}
+// Components become private final variables with public accessor methods, unless the record
+// declares its own accessor. A compact constructor takes the components as its parameters.
+func getRecord(classText types.JavaClassText, packageName []byte) types.JavaRecord {
+	var (
+		response   types.JavaRecord
+		header     = classText.Outside[bytes.Index(classText.Outside, []byte("record "))+len("record "):]
+		components []types.JavaMethodParameter
+	)
+
+	response.DefinedWithin = classText.DefinedWithin
+	response.Package = packageName
+
+	openParenthesisIndex := bytes.IndexByte(header, OpenParenthesis)
+	if openParenthesisIndex == -1 {
+		response.Name = header
+		response.Variables, response.Methods = getVariablesAndMethods(classText.Inside)
+		response.Methods = markConstructors(response.Methods, response.Name)
+		return response
+	}
+
+	response.Name = header[:openParenthesisIndex]
+
+	closedParenthesisIndex := len(header)
+	for i, parenthesisScope := openParenthesisIndex, 0; i < len(header); i++ {
+		if header[i] == OpenParenthesis {
+			parenthesisScope++
+		} else if header[i] == ClosedParenthesis {
+			parenthesisScope--
+
+			if parenthesisScope == 0 {
+				closedParenthesisIndex = i
+				break
+			}
+		}
+	}
+
+	for _, component := range splitOutsideArrows(header[openParenthesisIndex+1:closedParenthesisIndex], Comma) {
+		if component, ok := getMethodParameter(component); ok {
+			components = append(components, component)
+		}
+	}
+
+	if closedParenthesisIndex < len(header) {
+		if implements := header[closedParenthesisIndex+1:]; bytes.HasPrefix(implements, []byte("implements ")) {
+			for _, implement := range splitOutsideArrows(implements[len("implements "):], Comma) {
+				response.Implements = append(response.Implements, implement)
+			}
+		}
+	}
+
+	var (
+		variables []types.JavaVariable
+		methods   []types.JavaMethod
+	)
+
+	for _, line := range splitVariablesAndMethods(classText.Inside) {
+		if method, ok := getCompactConstructor(line, response.Name, components); ok {
+			methods = append(methods, method)
+			continue
+		}
+
+		v, m := getVariablesOrMethod(line)
+		variables = append(variables, v...)
+
+		if !bytes.Equal(m.Name, []byte("")) {
+			methods = append(methods, m)
+		}
+	}
+
+	methods = markConstructors(methods, response.Name)
+
+	hasAccessor := func(name []byte) bool {
+		for _, method := range methods {
+			if bytes.Equal(method.Name, name) && len(method.Parameters) == 0 {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, component := range components {
+		// Variable arity components are stored as arrays: String...names
+		Type := component.Type
+		if component.Varargs {
+			Type = append(append([]byte{}, Type...), []byte("[]")...)
+		}
+
+		response.Variables = append(response.Variables, types.JavaVariable{
+			Annotations:    component.Annotations,
+			Type:           Type,
+			Name:           component.Name,
+			AccessModifier: []byte("private"),
+			Final:          true,
+		})
+
+		if !hasAccessor(component.Name) {
+			response.Methods = append(response.Methods, types.JavaMethod{
+				Type:           Type,
+				Name:           component.Name,
+				AccessModifier: []byte("public"),
+			})
+		}
+	}
+
+	response.Variables = append(response.Variables, variables...)
+	response.Methods = append(response.Methods, methods...)
+
+	return response
+}
+
+// Get an annotation type: public @interface Audited{String value()default "";int MAX=5;}
+// The default values of elements are not part of the diagram.
+func getAnnotationType(classText types.JavaClassText, packageName, name []byte, stereotypes []types.CustomByteSlice) types.JavaAnnotation {
+	response := types.JavaAnnotation{
+		DefinedWithin: classText.DefinedWithin,
+		Package:       packageName,
+		Name:          name,
+		Stereotypes:   stereotypes,
+	}
+
+	for _, line := range splitVariablesAndMethods(classText.Inside) {
+		if defaultIndex := bytes.Index(line, []byte(")default ")); defaultIndex != -1 {
+			line = append(append([]byte{}, line[:defaultIndex+1]...), SemiColon)
+		}
+
+		v, m := getVariablesOrMethod(line)
+		response.Variables = append(response.Variables, v...)
+
+		if !bytes.Equal(m.Name, []byte("")) {
+			// Elements are implicitly public and abstract
+			if len(m.AccessModifier) == 0 {
+				m.AccessModifier = []byte("public")
+			}
+
+			m.Abstract = true
+			response.Methods = append(response.Methods, m)
+		}
+	}
+
+	return response
+}
+
+// Get the compact constructor of a record if the line declares one: public Point{...}
+func getCompactConstructor(text, name []byte, components []types.JavaMethodParameter) (types.JavaMethod, bool) {
+	var method types.JavaMethod
+
+	method.Annotations, text = cutAnnotations(text)
+
+	for _, accessModifier := range []string{"public", "protected", "private"} {
+		if bytes.HasPrefix(text, []byte(accessModifier+" ")) {
+			method.AccessModifier = []byte(accessModifier)
+			text = text[len(accessModifier)+1:]
+			break
+		}
+	}
+
+	if !bytes.HasPrefix(text, append(append([]byte{}, name...), OpenCurly)) {
+		return method, false
+	}
+
+	method.Name = name
+	method.IsConstructor = true
+	method.Parameters = components
+	method.Functionality = removeBodyAnnotations(bytes.TrimSuffix(bytes.TrimSuffix(text[len(name)+1:], []byte(";")), []byte("}")))
+
+	return method, true
+}
+
+// Split text on the separators that are not inside of angle brackets: Map<K,V>a,int b
+func splitOutsideArrows(text []byte, separator byte) [][]byte {
+	var (
+		response   [][]byte
+		arrowScope int = 0
+		startIndex int = 0
+	)
+
+	if len(text) == 0 {
+		return response
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == LeftArrow {
+			arrowScope++
+		} else if text[i] == RightArrow {
+			arrowScope--
+		} else if text[i] == separator && arrowScope == 0 {
+			response = append(response, text[startIndex:i])
+			startIndex = i + 1
+		}
+	}
+
+	return append(response, text[startIndex:])
+}
+
+// Get a parameter of a method or a component of a record: @NotNull String...names
+func getMethodParameter(text []byte) (types.JavaMethodParameter, bool) {
+	Annotations, text := cutAnnotations(text)
+
+	Type, Name := getTypeAndName(text)
+	if len(Name) == 0 {
+		return types.JavaMethodParameter{}, false
+	}
+
+	return types.JavaMethodParameter{
+		Annotations: Annotations,
+		Type:        bytes.TrimSuffix(Type, []byte("...")),
+		Name:        Name,
+		Varargs:     bytes.HasSuffix(Type, []byte("...")),
+	}, true
+}
+
+// Constructors are declared with the name of their class and without a return type: public Order(String id)
+func markConstructors(methods []types.JavaMethod, className []byte) []types.JavaMethod {
+	for i, method := range methods {
+		if !bytes.Equal(method.Name, className) || (len(method.Type) != 0 && !bytes.Equal(method.Type, method.AccessModifier)) {
+			continue
+		}
+
+		methods[i].Type = nil
+		methods[i].IsConstructor = true
+	}
+
+	return methods
+}
+
+// Get the type and the name of a declaration: int x, List<? extends Shape>shapes, String...names
+func getTypeAndName(text []byte) ([]byte, []byte) {
+	if split := splitOutsideArrows(text, Space); len(split) > 1 {
+		return split[0], bytes.Join(split[1:], []byte(" "))
+	}
+
+	if index := bytes.LastIndex(text, []byte("...")); index != -1 {
+		return text[:index+3], text[index+3:]
+	}
+
+	if index := bytes.LastIndexByte(text, RightArrow); index != -1 {
+		return text[:index+1], text[index+1:]
+	}
+
+	return text, nil
+}
+
 // Get all enumeration constant types
 func getEnumDeclarations(text []byte) []types.CustomByteSlice {
 	var (
@@ -930,10 +1586,13 @@ func splitVariablesAndMethods(text []byte) [][]byte {
 // Get all variables in one line of execution, or one method
 func getVariablesOrMethod(text []byte) ([]types.JavaVariable, types.JavaMethod) {
 	var (
-		variables []types.JavaVariable
-		method    types.JavaMethod
+		variables   []types.JavaVariable
+		method      types.JavaMethod
+		Annotations []types.CustomByteSlice
 	)
 
+	Annotations, text = cutAnnotations(text)
+
 	// Determine whether the line of text is a variable or method.
 	// If it is a method, return OpenParenthesis index
 	isVariable := func(text []byte) (bool, int) {
@@ -1022,7 +1681,7 @@ func getVariablesOrMethod(text []byte) ([]types.JavaVariable, types.JavaMethod)
 		hasArrow := false
 		numberOfLeftArrows := 0
 		for i := 0; i < len(vSText); i++ {
-			if vSText[i] == Space {
+			if vSText[i] == Space && numberOfLeftArrows == 0 {
 				Type = vSText[:i]
 				break
 			} else if vSText[i] == LeftArrow {
@@ -1078,13 +1737,14 @@ func getVariablesOrMethod(text []byte) ([]types.JavaVariable, types.JavaMethod)
 			if (vSText[i] == Comma && numberOfParenthesis == 0) || vSText[i] == SemiColon {
 				var currentValue []byte
 				if valueStartIndex != 0 {
-					currentValue = vSText[valueStartIndex:i]
+					currentValue = removeBodyAnnotations(vSText[valueStartIndex:i])
 				}
 				if currentlyFindingName {
 					currentName = vSText[nameStartIndex:i]
 				}
 
 				t := types.JavaVariable{
+					Annotations:    Annotations,
 					Type:           Type,
 					Name:           currentName,
 					Value:          currentValue,
@@ -1132,14 +1792,35 @@ func getVariablesOrMethod(text []byte) ([]types.JavaVariable, types.JavaMethod)
 		}
 	}
 
-	if closedParamIndex+2 < len(text) && text[closedParamIndex+1] != SemiColon {
+	// Checked exceptions are declared between the parameters and the body: writeList()throws IOException,ParseException{
+	bodyIndex := closedParamIndex + 1
+	if bytes.HasPrefix(text[bodyIndex:], []byte("throws ")) {
+		throwsEndIndex := bodyIndex + len("throws ")
+		for throwsEndIndex < len(text) && text[throwsEndIndex] != OpenCurly && text[throwsEndIndex] != SemiColon {
+			throwsEndIndex++
+		}
+
+		for _, exception := range splitOutsideArrows(text[bodyIndex+len("throws "):throwsEndIndex], Comma) {
+			if len(exception) != 0 {
+				method.Throws = append(method.Throws, exception)
+			}
+		}
+
+		bodyIndex = throwsEndIndex
+	}
+
+	if bodyIndex+1 < len(text) && text[bodyIndex] != SemiColon {
 		if text[len(text)-1] == SemiColon {
-			method.Functionality = append(method.Functionality, text[closedParamIndex+2:len(text)-2]...)
+			method.Functionality = append(method.Functionality, text[bodyIndex+1:len(text)-2]...)
 		} else {
-			method.Functionality = append(method.Functionality, text[closedParamIndex+2:len(text)-1]...)
+			method.Functionality = append(method.Functionality, text[bodyIndex+1:len(text)-1]...)
 		}
+
+		method.Functionality = removeBodyAnnotations(method.Functionality)
 	}
 
+	method.Annotations = Annotations
+
 	var (
 		allParamsSplit        [][]byte
 		arrowScope            int = 0
@@ -1161,21 +1842,9 @@ func getVariablesOrMethod(text []byte) ([]types.JavaVariable, types.JavaMethod)
 		allParamsSplit = append(allParamsSplit, paramDeclarations[lastCommaPlusOneIndex:])
 
 		for _, param := range allParamsSplit {
-			Type, Name, found := bytes.Cut(param, []byte(" "))
-			if !found {
-				lastRightArrow := bytes.LastIndexByte(param, '>')
-				if lastRightArrow == -1 {
-					continue
-				}
-
-				Type = param[:lastRightArrow+1]
-				Name = param[lastRightArrow+1:]
+			if param, ok := getMethodParameter(param); ok {
+				method.Parameters = append(method.Parameters, param)
 			}
-
-			method.Parameters = append(method.Parameters, types.JavaMethodParameter{
-				Type: Type,
-				Name: Name,
-			})
 		}
 	}
 
@@ -1193,7 +1862,7 @@ func getVariablesOrMethod(text []byte) ([]types.JavaVariable, types.JavaMethod)
 		}
 	}
 
-	declarationSplit := bytes.Split(methodDeclaration, []byte(" "))
+	declarationSplit := splitOutsideArrows(methodDeclaration, Space)
 	if bytes.Equal(declarationSplit[0], []byte("public")) ||
 		bytes.Equal(declarationSplit[0], []byte("protected")) ||
 		bytes.Equal(declarationSplit[0], []byte("private")) {
@@ -3,6 +3,7 @@ package java
 import (
 	"bytes"
 	"strconv"
+	"strings"
 	"testing"
 
 	types "github.com/junioryono/ProUML/backend/transpiler/types"
@@ -560,7 +561,7 @@ func TestParseFile(t *testing.T) {
 
 	for testIndex, tt := range tests {
 		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
-			actualOutput := parseFile(tt.Input)
+			actualOutput := ParseFile(tt.Input)
 
 			if !bytes.Equal(actualOutput.Package, tt.Output.Package) {
 				subtest.Errorf("testIndex: %s. incorrect package.\nexpected: %s\ngot: %s\n", strconv.Itoa(testIndex), string(tt.Output.Package), string(actualOutput.Package))
@@ -1045,7 +1046,7 @@ func TestRemoveSpacing(t *testing.T) {
 		},
 		{
 			Input:  []byte(`public class Test{public void writeList()throws IOException,IndexOutOfBoundsException{System.out.println();};}`),
-			Output: []byte("public class Test{public void writeList(){System.out.println();};}"),
+			Output: []byte("public class Test{public void writeList()throws IOException,IndexOutOfBoundsException{System.out.println();};}"),
 		},
 		{
 			Input: []byte(`
@@ -1317,6 +1318,50 @@ func TestRemoveAnnotations(t *testing.T) {
 	}
 }
 
+func TestSimplifyAnnotations(t *testing.T) {
+	type SimplifyAnnotationsTest struct {
+		Input  []byte
+		Output []byte
+	}
+
+	var tests = []SimplifyAnnotationsTest{
+		{
+			Input:  []byte("@Override public void wordList();"),
+			Output: []byte("@Override public void wordList();"),
+		},
+		{
+			Input:  []byte("@SuppressWarnings(\"unchecked\")static void wordsList();"),
+			Output: []byte("@SuppressWarnings static void wordsList();"),
+		},
+		{
+			Input:  []byte("@javax.persistence.Entity@Table(name=\"orders\")public class Order{}"),
+			Output: []byte("@Entity @Table public class Order{}"),
+		},
+		{
+			Input:  []byte("public@Deprecated void old(@RequestBody(required=false)Order order,@PathVariable(\"id\")Long id){}"),
+			Output: []byte("public @Deprecated void old(@RequestBody Order order,@PathVariable Long id){}"),
+		},
+		{
+			Input:  []byte("@SuppressWarnings({\"a)\",\"b}\"})private List<@NonNull String>names;"),
+			Output: []byte("@SuppressWarnings private List<String>names;"),
+		},
+		{
+			Input:  []byte("public@interface Audited{String value()default \"@Audited\";}"),
+			Output: []byte("public @interface Audited{String value()default \"@Audited\";}"),
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			res := simplifyAnnotations(tt.Input)
+
+			if !bytes.Equal(res, tt.Output) {
+				subtest.Errorf("incorrect response.\nexpected: %s\ngot: %s\n", string(tt.Output), string(res))
+			}
+		})
+	}
+}
+
 func TestGetPackageImports(t *testing.T) {
 	type PackageImportsTest struct {
 		Input  []byte
@@ -2297,3 +2342,526 @@ func TestGetEnumDeclarations(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRecord(t *testing.T) {
+	type RecordTest struct {
+		Input  []byte
+		Output []types.JavaRecord
+	}
+
+	var tests = []RecordTest{
+		{
+			Input: []byte(`
+			package com.shop.dto;
+			public record Point(int x, int y) {}
+			`),
+			Output: []types.JavaRecord{
+				{
+					Package: []byte("com.shop.dto"),
+					Name:    []byte("Point"),
+					Variables: []types.JavaVariable{
+						{Type: []byte("int"), Name: []byte("x"), AccessModifier: []byte("private"), Final: true},
+						{Type: []byte("int"), Name: []byte("y"), AccessModifier: []byte("private"), Final: true},
+					},
+					Methods: []types.JavaMethod{
+						{Type: []byte("int"), Name: []byte("x"), AccessModifier: []byte("public")},
+						{Type: []byte("int"), Name: []byte("y"), AccessModifier: []byte("public")},
+					},
+				},
+			},
+		},
+		{
+			Input: []byte(`
+			public record OrderDto(String id, List<LineDto> lines, String... tags) implements Serializable, Comparable<OrderDto> {
+				public static final String PREFIX = "order-";
+
+				public OrderDto {
+					if (id == null) throw new IllegalArgumentException("id");
+				}
+
+				@Override
+				public String id() {
+					return PREFIX + id;
+				}
+
+				public int compareTo(OrderDto other) {
+					return id.compareTo(other.id);
+				}
+
+				public record LineDto(String sku, int quantity) {}
+			}
+			`),
+			Output: []types.JavaRecord{
+				{
+					Package:    []byte("default"),
+					Name:       []byte("OrderDto"),
+					Implements: []types.CustomByteSlice{[]byte("Serializable"), []byte("Comparable<OrderDto>")},
+					Variables: []types.JavaVariable{
+						{Type: []byte("String"), Name: []byte("id"), AccessModifier: []byte("private"), Final: true},
+						{Type: []byte("List<LineDto>"), Name: []byte("lines"), AccessModifier: []byte("private"), Final: true},
+						{Type: []byte("String[]"), Name: []byte("tags"), AccessModifier: []byte("private"), Final: true},
+						{Type: []byte("String"), Name: []byte("PREFIX"), Value: []byte("\"order-\""), AccessModifier: []byte("public"), Static: true, Final: true},
+					},
+					Methods: []types.JavaMethod{
+						{Type: []byte("List<LineDto>"), Name: []byte("lines"), AccessModifier: []byte("public")},
+						{Type: []byte("String[]"), Name: []byte("tags"), AccessModifier: []byte("public")},
+						{
+							Name:           []byte("OrderDto"),
+							AccessModifier: []byte("public"),
+							IsConstructor:  true,
+							Parameters: []types.JavaMethodParameter{
+								{Type: []byte("String"), Name: []byte("id")},
+								{Type: []byte("List<LineDto>"), Name: []byte("lines")},
+								{Type: []byte("String"), Name: []byte("tags"), Varargs: true},
+							},
+							Functionality: []byte("if(id==null)throw new IllegalArgumentException(\"id\");"),
+						},
+						{Type: []byte("String"), Name: []byte("id"), AccessModifier: []byte("public"), Functionality: []byte("return PREFIX+id;")},
+						{
+							Type:           []byte("int"),
+							Name:           []byte("compareTo"),
+							AccessModifier: []byte("public"),
+							Parameters: []types.JavaMethodParameter{
+								{Type: []byte("OrderDto"), Name: []byte("other")},
+							},
+							Functionality: []byte("return id.compareTo(other.id);"),
+						},
+					},
+				},
+				{
+					DefinedWithin: []byte("OrderDto"),
+					Package:       []byte("default"),
+					Name:          []byte("LineDto"),
+					Variables: []types.JavaVariable{
+						{Type: []byte("String"), Name: []byte("sku"), AccessModifier: []byte("private"), Final: true},
+						{Type: []byte("int"), Name: []byte("quantity"), AccessModifier: []byte("private"), Final: true},
+					},
+					Methods: []types.JavaMethod{
+						{Type: []byte("String"), Name: []byte("sku"), AccessModifier: []byte("public")},
+						{Type: []byte("int"), Name: []byte("quantity"), AccessModifier: []byte("public")},
+					},
+				},
+			},
+		},
+		{
+			Input: []byte("record Pair<A,B>(A first,B second){Pair{}}"),
+			Output: []types.JavaRecord{
+				{
+					Package: []byte("default"),
+					Name:    []byte("Pair"),
+					Variables: []types.JavaVariable{
+						{Type: []byte("A"), Name: []byte("first"), AccessModifier: []byte("private"), Final: true},
+						{Type: []byte("B"), Name: []byte("second"), AccessModifier: []byte("private"), Final: true},
+					},
+					Methods: []types.JavaMethod{
+						{Type: []byte("A"), Name: []byte("first"), AccessModifier: []byte("public")},
+						{Type: []byte("B"), Name: []byte("second"), AccessModifier: []byte("public")},
+						{
+							Name:          []byte("Pair"),
+							IsConstructor: true,
+							Parameters: []types.JavaMethodParameter{
+								{Type: []byte("A"), Name: []byte("first")},
+								{Type: []byte("B"), Name: []byte("second")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := ParseFile(types.File{Name: "Record", Extension: "java", Code: tt.Input})
+
+			if len(response.Data) != len(tt.Output) {
+				subtest.Errorf("incorrect number of classes.\nexpected: %s\ngot: %s\n", strconv.Itoa(len(tt.Output)), strconv.Itoa(len(response.Data)))
+				subtest.FailNow()
+			}
+
+			for i, expected := range tt.Output {
+				record, ok := response.Data[i].(types.JavaRecord)
+				if !ok {
+					subtest.Errorf("incorrect response type on index %s", strconv.Itoa(i))
+					continue
+				}
+
+				if !bytes.Equal(record.Package, expected.Package) || !bytes.Equal(record.Name, expected.Name) || !bytes.Equal(record.DefinedWithin, expected.DefinedWithin) {
+					subtest.Errorf("incorrect record.\nexpected:\n%s %s %s\ngot:\n%s %s %s\n", expected.Package, expected.Name, expected.DefinedWithin, record.Package, record.Name, record.DefinedWithin)
+				}
+
+				if len(record.Implements) != len(expected.Implements) {
+					subtest.Errorf("incorrect implements.\nexpected:\n%s\ngot:\n%s\n", expected.Implements, record.Implements)
+				} else {
+					for index, implement := range expected.Implements {
+						if !bytes.Equal(record.Implements[index], implement) {
+							subtest.Errorf("bytes are not equal.\nexpected:\n%s\ngot:\n%s\n", string(implement), string(record.Implements[index]))
+						}
+					}
+				}
+
+				if len(record.Variables) != len(expected.Variables) {
+					subtest.Errorf("incorrect number of variables.\nexpected: %s\ngot: %s\n", strconv.Itoa(len(expected.Variables)), strconv.Itoa(len(record.Variables)))
+					subtest.FailNow()
+				}
+
+				for index, variable := range expected.Variables {
+					actual := record.Variables[index]
+					if !bytes.Equal(actual.Type, variable.Type) || !bytes.Equal(actual.Name, variable.Name) || !bytes.Equal(actual.Value, variable.Value) ||
+						!bytes.Equal(actual.AccessModifier, variable.AccessModifier) || actual.Static != variable.Static || actual.Final != variable.Final {
+						subtest.Errorf("incorrect variable.\nexpected:\n%s %s %s %s\ngot:\n%s %s %s %s\n",
+							variable.AccessModifier, variable.Type, variable.Name, variable.Value,
+							actual.AccessModifier, actual.Type, actual.Name, actual.Value)
+					}
+				}
+
+				if len(record.Methods) != len(expected.Methods) {
+					subtest.Errorf("incorrect number of methods.\nexpected: %s\ngot: %s\n", strconv.Itoa(len(expected.Methods)), strconv.Itoa(len(record.Methods)))
+					subtest.FailNow()
+				}
+
+				for index, method := range expected.Methods {
+					actual := record.Methods[index]
+					if !bytes.Equal(actual.Type, method.Type) || !bytes.Equal(actual.Name, method.Name) || !bytes.Equal(actual.AccessModifier, method.AccessModifier) ||
+						!bytes.Equal(actual.Functionality, method.Functionality) || len(actual.Parameters) != len(method.Parameters) || actual.IsConstructor != method.IsConstructor {
+						subtest.Errorf("incorrect method.\nexpected:\n%s %s %s %s %t\ngot:\n%s %s %s %s %t\n",
+							method.AccessModifier, method.Type, method.Name, method.Functionality, method.IsConstructor,
+							actual.AccessModifier, actual.Type, actual.Name, actual.Functionality, actual.IsConstructor)
+						continue
+					}
+
+					for indexParam, parameter := range method.Parameters {
+						if !bytes.Equal(actual.Parameters[indexParam].Type, parameter.Type) || !bytes.Equal(actual.Parameters[indexParam].Name, parameter.Name) ||
+							actual.Parameters[indexParam].Varargs != parameter.Varargs {
+							subtest.Errorf("incorrect parameter.\nexpected:\n%s %s %t\ngot:\n%s %s %t\n", parameter.Type, parameter.Name, parameter.Varargs,
+								actual.Parameters[indexParam].Type, actual.Parameters[indexParam].Name, actual.Parameters[indexParam].Varargs)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGetSealedClasses(t *testing.T) {
+	type SealedClass struct {
+		Name        string
+		Stereotypes []string
+		Permits     []string
+	}
+
+	type SealedTest struct {
+		Input  []byte
+		Output []SealedClass
+	}
+
+	var tests = []SealedTest{
+		{
+			Input: []byte("public abstract sealed class Shape permits Circle,Square{}final class Circle extends Shape{}non-sealed class Square extends Shape{}"),
+			Output: []SealedClass{
+				{Name: "Shape", Stereotypes: []string{"sealed"}, Permits: []string{"Circle", "Square"}},
+				{Name: "Circle"},
+				{Name: "Square"},
+			},
+		},
+		{
+			Input: []byte("sealed interface Expr<T>{}record Constant(int value)implements Expr<Integer>{}final class Add implements Expr<Integer>{}class Other{}"),
+			Output: []SealedClass{
+				{Name: "Expr", Stereotypes: []string{"sealed"}, Permits: []string{"Constant", "Add"}},
+				{Name: "Constant"},
+				{Name: "Add"},
+				{Name: "Other"},
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			classes := getFileClasses(tt.Input, getPackageName(tt.Input))
+
+			if len(classes) != len(tt.Output) {
+				subtest.Errorf("incorrect number of classes.\nexpected: %s\ngot: %s\n", strconv.Itoa(len(tt.Output)), strconv.Itoa(len(classes)))
+				subtest.FailNow()
+			}
+
+			for i, class := range classes {
+				var actual SealedClass
+				switch c := class.(type) {
+				case types.JavaAbstract:
+					actual = SealedClass{Name: string(c.Name), Stereotypes: toStrings(c.Stereotypes), Permits: toStrings(c.Permits)}
+				case types.JavaClass:
+					actual = SealedClass{Name: string(c.Name), Stereotypes: toStrings(c.Stereotypes), Permits: toStrings(c.Permits)}
+				case types.JavaInterface:
+					actual = SealedClass{Name: string(c.Name), Stereotypes: toStrings(c.Stereotypes), Permits: toStrings(c.Permits)}
+				case types.JavaRecord:
+					actual = SealedClass{Name: string(c.Name)}
+				}
+
+				expected := tt.Output[i]
+				if actual.Name != expected.Name || strings.Join(actual.Stereotypes, ",") != strings.Join(expected.Stereotypes, ",") || strings.Join(actual.Permits, ",") != strings.Join(expected.Permits, ",") {
+					subtest.Errorf("incorrect class on index %s.\nexpected:\n%v\ngot:\n%v\n", strconv.Itoa(i), expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func toStrings(slices []types.CustomByteSlice) []string {
+	var response []string
+	for _, slice := range slices {
+		response = append(response, string(slice))
+	}
+
+	return response
+}
+
+func TestGetAnnotations(t *testing.T) {
+	input := []byte(`
+	package com.shop;
+	@Entity
+	@Table(name = "orders")
+	public class Order extends @Localized Base {
+		@Id @GeneratedValue(strategy = GenerationType.IDENTITY)
+		private Long id;
+
+		private Runnable task = new Runnable() {
+			@Override
+			public void run() {}
+		};
+
+		@Deprecated
+		public void update(@RequestBody Order order, @PathVariable("id") Long id) {
+			@SuppressWarnings("unused") int count = 0;
+		}
+	}
+
+	@Retention(RetentionPolicy.RUNTIME)
+	public @interface Audited {
+		String value() default "";
+		Level level();
+		int MAX = 5;
+	}
+
+	@Audited
+	public enum Level { @Deprecated LOW, HIGH }
+	`)
+
+	response := ParseFile(types.File{Name: "Order", Extension: "java", Code: input})
+
+	if len(response.Data) != 3 {
+		t.Fatalf("incorrect number of classes.\nexpected: 3\ngot: %s\n", strconv.Itoa(len(response.Data)))
+	}
+
+	order, ok := response.Data[0].(types.JavaClass)
+	if !ok {
+		t.Fatalf("Order is not a class")
+	}
+
+	if joined := strings.Join(toStrings(order.Stereotypes), ","); joined != "Entity,Table" || len(order.Extends) != 1 || string(order.Extends[0]) != "Base" {
+		t.Errorf("incorrect class annotations.\nexpected:\nEntity,Table Base\ngot:\n%s %s\n", joined, order.Extends)
+	}
+
+	if len(order.Variables) != 2 || strings.Join(toStrings(order.Variables[0].Annotations), ",") != "Id,GeneratedValue" ||
+		string(order.Variables[0].Type) != "Long" || string(order.Variables[0].AccessModifier) != "private" || len(order.Variables[1].Annotations) != 0 ||
+		string(order.Variables[1].Value) != "new Runnable(){public void run(){}}" {
+		t.Errorf("incorrect variable annotations")
+	}
+
+	if len(order.Methods) != 1 {
+		t.Fatalf("incorrect number of methods.\nexpected: 1\ngot: %s\n", strconv.Itoa(len(order.Methods)))
+	}
+
+	update := order.Methods[0]
+	if strings.Join(toStrings(update.Annotations), ",") != "Deprecated" || string(update.Type) != "void" || string(update.Functionality) != "int count=0;" {
+		t.Errorf("incorrect method annotations.\ngot:\n%s %s %s\n", update.Annotations, update.Type, update.Functionality)
+	}
+
+	if len(update.Parameters) != 2 || strings.Join(toStrings(update.Parameters[0].Annotations), ",") != "RequestBody" || string(update.Parameters[0].Type) != "Order" ||
+		strings.Join(toStrings(update.Parameters[1].Annotations), ",") != "PathVariable" || string(update.Parameters[1].Name) != "id" {
+		t.Errorf("incorrect parameter annotations")
+	}
+
+	audited, ok := response.Data[1].(types.JavaAnnotation)
+	if !ok {
+		t.Fatalf("Audited is not an annotation type")
+	}
+
+	if string(audited.Name) != "Audited" || strings.Join(toStrings(audited.Stereotypes), ",") != "Retention" || len(audited.Methods) != 2 ||
+		string(audited.Methods[0].Name) != "value" || string(audited.Methods[0].Type) != "String" || string(audited.Methods[1].Type) != "Level" ||
+		len(audited.Variables) != 1 || string(audited.Variables[0].Name) != "MAX" {
+		t.Errorf("incorrect annotation type")
+	}
+
+	for _, element := range audited.Methods {
+		if string(element.AccessModifier) != "public" || !element.Abstract {
+			t.Errorf("incorrect annotation type element %s.\nexpected:\npublic abstract\ngot:\n%s %t\n", element.Name, element.AccessModifier, element.Abstract)
+		}
+	}
+
+	level, ok := response.Data[2].(types.JavaEnum)
+	if !ok || strings.Join(toStrings(level.Stereotypes), ",") != "Audited" || strings.Join(toStrings(level.Declarations), ",") != "LOW,HIGH" {
+		t.Errorf("incorrect enum annotations")
+	}
+}
+
+func TestGetTypeParameters(t *testing.T) {
+	type GenericClass struct {
+		Name           string
+		TypeParameters []string
+		Extends        []string
+		Implements     []string
+	}
+
+	tests := []struct {
+		Input  []byte
+		Output []GenericClass
+	}{
+		{
+			Input: []byte("public class Repository<T extends Entity&Serializable,ID>extends Base<Map<String,T>>implements Store<T,ID>,Closeable{}"),
+			Output: []GenericClass{
+				{
+					Name:           "Repository",
+					TypeParameters: []string{"T extends Entity & Serializable", "ID"},
+					Extends:        []string{"Base<Map<String,T>>"},
+					Implements:     []string{"Store<T,ID>", "Closeable"},
+				},
+			},
+		},
+		{
+			Input: []byte("interface Mapper<S,T extends Comparable<T>>extends Function<S,T>{}abstract class Node<N extends Node<N>>{}"),
+			Output: []GenericClass{
+				{Name: "Mapper", TypeParameters: []string{"S", "T extends Comparable<T>"}, Extends: []string{"Function<S,T>"}},
+				{Name: "Node", TypeParameters: []string{"N extends Node<N>"}},
+			},
+		},
+		{
+			Input: []byte("class Plain extends Base{}"),
+			Output: []GenericClass{
+				{Name: "Plain", Extends: []string{"Base"}},
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			classes := getFileClasses(tt.Input, getPackageName(tt.Input))
+
+			if len(classes) != len(tt.Output) {
+				subtest.Errorf("incorrect number of classes.\nexpected: %s\ngot: %s\n", strconv.Itoa(len(tt.Output)), strconv.Itoa(len(classes)))
+				subtest.FailNow()
+			}
+
+			for i, class := range classes {
+				var (
+					actual         GenericClass
+					typeParameters []types.JavaTypeParameter
+				)
+
+				switch c := class.(type) {
+				case types.JavaAbstract:
+					actual = GenericClass{Name: string(c.Name), Extends: toStrings(c.Extends), Implements: toStrings(c.Implements)}
+					typeParameters = c.TypeParameters
+				case types.JavaClass:
+					actual = GenericClass{Name: string(c.Name), Extends: toStrings(c.Extends), Implements: toStrings(c.Implements)}
+					typeParameters = c.TypeParameters
+				case types.JavaInterface:
+					actual = GenericClass{Name: string(c.Name), Extends: toStrings(c.Extends)}
+					typeParameters = c.TypeParameters
+				}
+
+				for _, typeParameter := range typeParameters {
+					text := string(typeParameter.Name)
+					if len(typeParameter.Bounds) != 0 {
+						text += " extends " + strings.Join(toStrings(typeParameter.Bounds), " & ")
+					}
+
+					actual.TypeParameters = append(actual.TypeParameters, text)
+				}
+
+				expected := tt.Output[i]
+				if actual.Name != expected.Name ||
+					strings.Join(actual.TypeParameters, ",") != strings.Join(expected.TypeParameters, ",") ||
+					strings.Join(actual.Extends, ",") != strings.Join(expected.Extends, ",") ||
+					strings.Join(actual.Implements, ",") != strings.Join(expected.Implements, ",") {
+					subtest.Errorf("incorrect class on index %s.\nexpected:\n%v\ngot:\n%v\n", strconv.Itoa(i), expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestGetConstructorsAndThrows(t *testing.T) {
+	input := []byte(`
+	package com.shop;
+	public abstract class OrderService {
+		private final OrderRepository repository;
+
+		public OrderService(OrderRepository repository) {
+			this.repository = repository;
+		}
+
+		OrderService() {
+			this(null);
+		}
+
+		public Order find(String id) throws OrderNotFoundException, java.io.IOException {
+			return repository.find(id);
+		}
+
+		public Order find(String id, boolean cached) {
+			return null;
+		}
+
+		public void tag(Order order, String... tags) {}
+
+		protected abstract void validate(Order order) throws ValidationException;
+	}
+	`)
+
+	response := ParseFile(types.File{Name: "OrderService", Extension: "java", Code: input})
+	if len(response.Data) != 1 {
+		t.Fatalf("incorrect number of classes.\nexpected: 1\ngot: %s\n", strconv.Itoa(len(response.Data)))
+	}
+
+	class, ok := response.Data[0].(types.JavaAbstract)
+	if !ok {
+		t.Fatalf("incorrect response type: %T", response.Data[0])
+	}
+
+	expected := []string{
+		"public OrderService(OrderRepository repository) constructor",
+		"OrderService() constructor",
+		"public Order find(String id) throws OrderNotFoundException,java.io.IOException",
+		"public Order find(String id,boolean cached)",
+		"public void tag(Order order,String... tags)",
+		"protected void validate(Order order) throws ValidationException",
+	}
+
+	if len(class.Methods) != len(expected) {
+		t.Fatalf("incorrect number of methods.\nexpected: %s\ngot: %s\n", strconv.Itoa(len(expected)), strconv.Itoa(len(class.Methods)))
+	}
+
+	for i, method := range class.Methods {
+		var parameters []string
+		for _, parameter := range method.Parameters {
+			if parameter.Varargs {
+				parameters = append(parameters, string(parameter.Type)+"... "+string(parameter.Name))
+			} else {
+				parameters = append(parameters, string(parameter.Type)+" "+string(parameter.Name))
+			}
+		}
+
+		actual := strings.TrimSpace(string(method.AccessModifier)+" "+string(method.Type)) + " " + string(method.Name) + "(" + strings.Join(parameters, ",") + ")"
+		actual = strings.TrimSpace(actual)
+		if len(method.Throws) != 0 {
+			actual += " throws " + strings.Join(toStrings(method.Throws), ",")
+		}
+		if method.IsConstructor {
+			actual += " constructor"
+		}
+
+		if actual != expected[i] {
+			t.Errorf("incorrect method on index %s.\nexpected:\n%s\ngot:\n%s\n", strconv.Itoa(i), expected[i], actual)
+		}
+	}
+}
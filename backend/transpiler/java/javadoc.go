@@ -0,0 +1,258 @@
+package java
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+// Javadoc comments are replaced by an annotation with this prefix and the index of the comment,
+// so that they follow the declaration they document through the rest of the parser: @Javadoc$0
+const javadocAnnotationPrefix = "Javadoc$"
+
+// Replace every Javadoc comment that is not inside of quotations or other comments with a marker annotation.
+// Javadoc comments before the package or the imports, such as license headers, are removed.
+func cutJavadocs(text []byte) ([]byte, []types.JavaDoc) {
+	var (
+		response     []byte
+		javadocs     []types.JavaDoc
+		currentQuote byte = NoQuote
+	)
+
+	for i := 0; i < len(text); i++ {
+		if currentQuote != NoQuote {
+			if text[i] == currentQuote && text[i-1] != Backslash {
+				currentQuote = NoQuote
+			}
+
+			response = append(response, text[i])
+			continue
+		}
+
+		switch {
+		case text[i] == DoubleQuote || text[i] == SingleQuote:
+			currentQuote = text[i]
+		case bytes.HasPrefix(text[i:], []byte("//")):
+			endIndex := bytes.IndexByte(text[i:], NewLine)
+			if endIndex == -1 {
+				endIndex = len(text) - i
+			}
+
+			response = append(response, text[i:i+endIndex]...)
+			i += endIndex - 1
+			continue
+		case bytes.HasPrefix(text[i:], []byte("/*")):
+			endIndex := bytes.Index(text[i+2:], []byte("*/"))
+			if endIndex == -1 {
+				return append(response, text[i:]...), javadocs
+			}
+
+			comment := text[i : i+2+endIndex+2]
+			i += len(comment) - 1
+
+			// Empty comments, /**/, are not Javadoc comments
+			if !bytes.HasPrefix(comment, []byte("/**")) || len(comment) == 4 {
+				response = append(response, comment...)
+				continue
+			}
+
+			next := bytes.TrimLeft(text[i+1:], " \t\r\n")
+			if bytes.HasPrefix(next, []byte("package ")) || bytes.HasPrefix(next, []byte("import ")) {
+				continue
+			}
+
+			response = append(response, []byte(" @"+javadocAnnotationPrefix+strconv.Itoa(len(javadocs))+" ")...)
+			javadocs = append(javadocs, parseJavadoc(comment[3:len(comment)-2]))
+			continue
+		}
+
+		response = append(response, text[i])
+	}
+
+	return response, javadocs
+}
+
+// Parse the text of a Javadoc comment between /** and */
+func parseJavadoc(text []byte) types.JavaDoc {
+	var (
+		response    types.JavaDoc
+		description [][]byte
+		tags        [][][]byte
+	)
+
+	// Block tags start a line: @param id the id of the order
+	for _, line := range bytes.Split(text, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		line = bytes.TrimSpace(bytes.TrimLeft(line, "*"))
+
+		if len(line) != 0 && line[0] == Asperand {
+			tags = append(tags, [][]byte{line})
+		} else if len(tags) != 0 {
+			tags[len(tags)-1] = append(tags[len(tags)-1], line)
+		} else {
+			description = append(description, line)
+		}
+	}
+
+	response.Summary = getJavadocSummary(simplifyJavadocText(bytes.Join(description, []byte(" "))))
+
+	for _, tag := range tags {
+		name, content, _ := bytes.Cut(simplifyJavadocText(bytes.Join(tag, []byte(" "))), []byte(" "))
+
+		switch string(name) {
+		case "@param":
+			paramName, paramDescription, _ := bytes.Cut(content, []byte(" "))
+			if len(paramName) != 0 {
+				response.Params = append(response.Params, types.JavaDocParam{Name: paramName, Description: paramDescription})
+			}
+		case "@return":
+			response.Return = content
+		case "@deprecated":
+			response.Deprecated = true
+			response.DeprecatedReason = content
+		}
+	}
+
+	return response
+}
+
+// Collapse the spacing of Javadoc text and replace inline tags with their text: {@code id} -> id, {@link Order#id id} -> id
+func simplifyJavadocText(text []byte) []byte {
+	var response []byte
+
+	for i := 0; i < len(text); i++ {
+		if !bytes.HasPrefix(text[i:], []byte("{@")) {
+			response = append(response, text[i])
+			continue
+		}
+
+		endIndex := -1
+		for j, scope := i, 0; j < len(text); j++ {
+			if text[j] == OpenCurly {
+				scope++
+			} else if text[j] == ClosedCurly {
+				scope--
+
+				if scope == 0 {
+					endIndex = j
+					break
+				}
+			}
+		}
+
+		if endIndex == -1 {
+			response = append(response, text[i:]...)
+			break
+		}
+
+		tag, content, _ := bytes.Cut(bytes.TrimSpace(text[i+2:endIndex]), []byte(" "))
+		content = bytes.TrimSpace(content)
+
+		// Links are shown with their label when they have one
+		if bytes.Equal(tag, []byte("link")) || bytes.Equal(tag, []byte("linkplain")) {
+			if _, label, found := bytes.Cut(content, []byte(" ")); found {
+				content = label
+			}
+		}
+
+		response = append(response, content...)
+		i = endIndex
+	}
+
+	return bytes.Join(bytes.Fields(response), []byte(" "))
+}
+
+// Get the first sentence of a description, which ends with a period that is followed by a space or a new paragraph
+func getJavadocSummary(description []byte) []byte {
+	if paragraphIndex := bytes.Index(bytes.ToLower(description), []byte("<p>")); paragraphIndex != -1 {
+		description = bytes.TrimSpace(description[:paragraphIndex])
+	}
+
+	for i := 0; i < len(description); i++ {
+		if description[i] == Period && (i+1 == len(description) || description[i+1] == Space) {
+			return description[:i+1]
+		}
+	}
+
+	if len(description) == 0 {
+		return nil
+	}
+
+	return description
+}
+
+// Move the Javadoc marker annotations of classes and their members to their Javadoc field
+func attachJavadocs(classes []any, javadocs []types.JavaDoc) []any {
+	if len(javadocs) == 0 {
+		return classes
+	}
+
+	attachToMembers := func(variables []types.JavaVariable, methods []types.JavaMethod) {
+		for i := range variables {
+			variables[i].Annotations, variables[i].Javadoc = cutJavadoc(variables[i].Annotations, javadocs)
+		}
+
+		for i := range methods {
+			methods[i].Annotations, methods[i].Javadoc = cutJavadoc(methods[i].Annotations, javadocs)
+
+			for j := range methods[i].Parameters {
+				methods[i].Parameters[j].Annotations, _ = cutJavadoc(methods[i].Parameters[j].Annotations, javadocs)
+			}
+		}
+	}
+
+	for i, class := range classes {
+		switch c := class.(type) {
+		case types.JavaAbstract:
+			c.Stereotypes, c.Javadoc = cutJavadoc(c.Stereotypes, javadocs)
+			attachToMembers(c.Variables, c.Methods)
+			classes[i] = c
+		case types.JavaClass:
+			c.Stereotypes, c.Javadoc = cutJavadoc(c.Stereotypes, javadocs)
+			attachToMembers(c.Variables, c.Methods)
+			classes[i] = c
+		case types.JavaInterface:
+			c.Stereotypes, c.Javadoc = cutJavadoc(c.Stereotypes, javadocs)
+			attachToMembers(c.Variables, c.Methods)
+			classes[i] = c
+		case types.JavaEnum:
+			c.Stereotypes, c.Javadoc = cutJavadoc(c.Stereotypes, javadocs)
+			classes[i] = c
+		case types.JavaRecord:
+			c.Stereotypes, c.Javadoc = cutJavadoc(c.Stereotypes, javadocs)
+			attachToMembers(c.Variables, c.Methods)
+			classes[i] = c
+		case types.JavaAnnotation:
+			c.Stereotypes, c.Javadoc = cutJavadoc(c.Stereotypes, javadocs)
+			attachToMembers(c.Variables, c.Methods)
+			classes[i] = c
+		}
+	}
+
+	return classes
+}
+
+// Remove the Javadoc markers from annotations and return the last Javadoc comment that they point to
+func cutJavadoc(annotations []types.CustomByteSlice, javadocs []types.JavaDoc) ([]types.CustomByteSlice, *types.JavaDoc) {
+	var (
+		response []types.CustomByteSlice
+		javadoc  *types.JavaDoc
+	)
+
+	for _, annotation := range annotations {
+		if !bytes.HasPrefix(annotation, []byte(javadocAnnotationPrefix)) {
+			response = append(response, annotation)
+			continue
+		}
+
+		index, err := strconv.Atoi(string(annotation[len(javadocAnnotationPrefix):]))
+		if err != nil || index >= len(javadocs) {
+			continue
+		}
+
+		javadoc = &javadocs[index]
+	}
+
+	return response, javadoc
+}
@@ -0,0 +1,232 @@
+package java
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestParseJavadoc(t *testing.T) {
+	type ParseJavadocTest struct {
+		Input  []byte
+		Output types.JavaDoc
+	}
+
+	var tests = []ParseJavadocTest{
+		{
+			Input: []byte(`
+			 * Finds an order by its id. Orders that were deleted are not found.
+			 *
+			 * @param id the id of the order
+			 * @param cached whether the cache may
+			 *        be used
+			 * @return the order, or {@code null}
+			 * @throws IOException when the store is unreachable
+			 `),
+			Output: types.JavaDoc{
+				Summary: []byte("Finds an order by its id."),
+				Params: []types.JavaDocParam{
+					{Name: []byte("id"), Description: []byte("the id of the order")},
+					{Name: []byte("cached"), Description: []byte("whether the cache may be used")},
+				},
+				Return: []byte("the order, or null"),
+			},
+		},
+		{
+			Input: []byte(" Use {@link OrderService#find(String) find} instead of e.g. this method<p>It is slow.\n @deprecated "),
+			Output: types.JavaDoc{
+				Summary:    []byte("Use find instead of e.g."),
+				Deprecated: true,
+			},
+		},
+		{
+			Input: []byte(" The version of the {@linkplain Api}\n @deprecated since 2.0, use {@link #id()}"),
+			Output: types.JavaDoc{
+				Summary:          []byte("The version of the Api"),
+				Deprecated:       true,
+				DeprecatedReason: []byte("since 2.0, use #id()"),
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := parseJavadoc(tt.Input)
+
+			if got, expected := javadocString(&response), javadocString(&tt.Output); got != expected {
+				subtest.Errorf("incorrect javadoc.\nexpected:\n%s\ngot:\n%s\n", expected, got)
+			}
+		})
+	}
+}
+
+func TestGetJavadocs(t *testing.T) {
+	input := []byte(`
+	/**
+	 * Copyright (c) ProUML
+	 */
+	package com.shop;
+
+	import java.util.List;
+
+	/**
+	 * A customer order. Orders are immutable.
+	 */
+	@Entity
+	public class Order {
+		/** The id of the order. */
+		@Id
+		private Long id;
+
+		private String note = "/** not a comment */";
+
+		/* A block comment, /** not Javadoc either */
+		private int count;
+
+		/** The total of the order. */ public static int total;
+
+		/**
+		 * Creates an order.
+		 * @param id the id
+		 */
+		public Order(Long id) {
+			/** Javadoc inside of a body is ignored */
+			this.id = id;
+		}
+
+		/**
+		 * The lines of the order.
+		 * @return the lines
+		 * @deprecated use {@link #items()}
+		 */
+		@Deprecated
+		public List<String> lines() {
+			return null;
+		}
+
+		/** An empty order. */ protected static Order empty() {
+			return null;
+		}
+
+		/** Documents nothing */
+	}
+	`)
+
+	response := ParseFile(types.File{Name: "Order", Extension: "java", Code: input})
+
+	if !bytes.Equal(response.Package, []byte("com.shop")) || len(response.Imports) != 1 {
+		t.Fatalf("incorrect package or imports.\nexpected:\ncom.shop [java.util.List]\ngot:\n%s %s\n", response.Package, response.Imports)
+	}
+
+	if len(response.Data) != 1 {
+		t.Fatalf("incorrect number of classes.\nexpected: 1\ngot: %s\n", strconv.Itoa(len(response.Data)))
+	}
+
+	class, ok := response.Data[0].(types.JavaClass)
+	if !ok {
+		t.Fatalf("incorrect response type: %T", response.Data[0])
+	}
+
+	if got := strings.Join(toStrings(class.Stereotypes), ","); got != "Entity" {
+		t.Errorf("incorrect class stereotypes.\nexpected:\nEntity\ngot:\n%s\n", got)
+	}
+
+	if got := javadocString(class.Javadoc); got != "A customer order." {
+		t.Errorf("incorrect class javadoc.\nexpected:\nA customer order.\ngot:\n%s\n", got)
+	}
+
+	expectedVariables := []string{
+		"Id id The id of the order.",
+		"note",
+		"count",
+		"total The total of the order.",
+	}
+
+	if len(class.Variables) != len(expectedVariables) {
+		t.Fatalf("incorrect number of variables.\nexpected: %s\ngot: %s\n", strconv.Itoa(len(expectedVariables)), strconv.Itoa(len(class.Variables)))
+	}
+
+	for i, variable := range class.Variables {
+		got := strings.TrimSpace(strings.Join(toStrings(variable.Annotations), ",") + " " + string(variable.Name) + " " + javadocString(variable.Javadoc))
+		if got != expectedVariables[i] {
+			t.Errorf("incorrect variable on index %s.\nexpected:\n%s\ngot:\n%s\n", strconv.Itoa(i), expectedVariables[i], got)
+		}
+	}
+
+	if !bytes.Equal(class.Variables[1].Value, []byte(`"/** not a comment */"`)) {
+		t.Errorf("incorrect variable value.\nexpected:\n%s\ngot:\n%s\n", `"/** not a comment */"`, class.Variables[1].Value)
+	}
+
+	expectedMethods := []string{
+		"Order Creates an order. id: the id",
+		"Deprecated lines The lines of the order. return: the lines deprecated: use #items()",
+		"empty An empty order.",
+	}
+
+	if len(class.Methods) != len(expectedMethods) {
+		t.Fatalf("incorrect number of methods.\nexpected: %s\ngot: %s\n", strconv.Itoa(len(expectedMethods)), strconv.Itoa(len(class.Methods)))
+	}
+
+	for i, method := range class.Methods {
+		got := strings.TrimSpace(strings.Join(toStrings(method.Annotations), ",") + " " + string(method.Name) + " " + javadocString(method.Javadoc))
+		if got != expectedMethods[i] {
+			t.Errorf("incorrect method on index %s.\nexpected:\n%s\ngot:\n%s\n", strconv.Itoa(i), expectedMethods[i], got)
+		}
+	}
+
+	// Members with a Javadoc but without annotations keep their modifiers and types
+	expectedDeclarations := []string{
+		"private false Long",
+		"private false String",
+		"private false int",
+		"public true int",
+	}
+
+	for i, variable := range class.Variables {
+		got := string(variable.AccessModifier) + " " + strconv.FormatBool(variable.Static) + " " + string(variable.Type)
+		if got != expectedDeclarations[i] {
+			t.Errorf("incorrect variable declaration on index %s.\nexpected:\n%s\ngot:\n%s\n", strconv.Itoa(i), expectedDeclarations[i], got)
+		}
+	}
+
+	expectedDeclarations = []string{
+		"public false  true",
+		"public false List<String> false",
+		"protected true Order false",
+	}
+
+	for i, method := range class.Methods {
+		got := string(method.AccessModifier) + " " + strconv.FormatBool(method.Static) + " " + string(method.Type) + " " + strconv.FormatBool(method.IsConstructor)
+		if got != expectedDeclarations[i] {
+			t.Errorf("incorrect method declaration on index %s.\nexpected:\n%s\ngot:\n%s\n", strconv.Itoa(i), expectedDeclarations[i], got)
+		}
+	}
+
+	if !bytes.Equal(class.Methods[0].Functionality, []byte("this.id=id;")) {
+		t.Errorf("incorrect functionality.\nexpected:\nthis.id=id;\ngot:\n%s\n", class.Methods[0].Functionality)
+	}
+}
+
+func javadocString(javadoc *types.JavaDoc) string {
+	if javadoc == nil {
+		return ""
+	}
+
+	response := string(javadoc.Summary)
+	for _, param := range javadoc.Params {
+		response += " " + string(param.Name) + ": " + string(param.Description)
+	}
+
+	if len(javadoc.Return) != 0 {
+		response += " return: " + string(javadoc.Return)
+	}
+
+	if javadoc.Deprecated {
+		response += " deprecated: " + string(javadoc.DeprecatedReason)
+	}
+
+	return strings.TrimSpace(response)
+}
@@ -2,43 +2,74 @@ package java
 
 import (
 	"bytes"
+	"context"
 	"strings"
 
 	"github.com/junioryono/ProUML/backend/transpiler/types"
 )
 
-func ParseProject(files []types.File) *types.Project {
+func ParseProject(ctx context.Context, files []types.File) *types.Project {
+	parsedFiles, diagnostics := types.ParseFiles(ctx, files, ParseFile)
+
+	response := ParseFileResponses(parsedFiles)
+	response.Diagnostics = append(diagnostics, response.Diagnostics...)
+
+	return response
+}
+
+// Connects the classes of files that are already parsed. Other JVM languages, such as Kotlin,
+// parse their files into Java classes and share the relations of Java.
+func ParseFileResponses(parsedFiles []types.FileResponse) *types.Project {
 	var (
-		response    types.Project
-		parsedFiles []types.FileResponse
+		response         types.Project
+		permitsRelations []types.Relation
 	)
 
-	for _, file := range files {
-		parsedFile := parseFile(file)
-		parsedFiles = append(parsedFiles, parsedFile)
+	// The exports of the classes are grouped by their package, so that the types that a class can use
+	// are found without going through every class of the project
+	packageExports := make(map[string][]types.JavaClassExports)
+	for _, classExports := range getClassExports(parsedFiles) {
+		packageExports[string(classExports.Package)] = append(packageExports[string(classExports.Package)], classExports)
 	}
 
-	allClassExports := getClassExports(parsedFiles)
-
 	for _, parsedFile := range parsedFiles {
+		response.Diagnostics = append(response.Diagnostics, parsedFile.Diagnostics...)
+
 		for _, parsedClass := range parsedFile.Data {
 			switch class := parsedClass.(type) {
 			case types.JavaAbstract:
-				validImportedTypeNames := getValidExternalTypesOfClass(allClassExports, parsedFile.Imports, parsedFile.Package, class.Name)
+				validImportedTypeNames := getValidExternalTypesOfClass(packageExports, parsedFile.Imports, parsedFile.Package, class.Name)
 				class.Associations, class.Dependencies = getClassAssociationsAndDependencies(validImportedTypeNames, class.Variables, class.Methods)
+				class.Dependencies = addGenericDependencies(class.Associations, class.Dependencies, class.TypeParameters, append(class.Extends, class.Implements...))
 				response.Nodes = append(response.Nodes, class)
 				response.Edges = append(response.Edges, getClassRelationConnections(response.Edges, validImportedTypeNames, class)...)
+				permitsRelations = append(permitsRelations, getPermittedSubclassRelations(validImportedTypeNames, class.Package, class.Name, class.Permits)...)
 			case types.JavaClass:
-				validImportedTypeNames := getValidExternalTypesOfClass(allClassExports, parsedFile.Imports, parsedFile.Package, class.Name)
+				validImportedTypeNames := getValidExternalTypesOfClass(packageExports, parsedFile.Imports, parsedFile.Package, class.Name)
 				class.Associations, class.Dependencies = getClassAssociationsAndDependencies(validImportedTypeNames, class.Variables, class.Methods)
+				class.Dependencies = addGenericDependencies(class.Associations, class.Dependencies, class.TypeParameters, append(class.Extends, class.Implements...))
 				response.Nodes = append(response.Nodes, class)
 				response.Edges = append(response.Edges, getClassRelationConnections(response.Edges, validImportedTypeNames, class)...)
+				permitsRelations = append(permitsRelations, getPermittedSubclassRelations(validImportedTypeNames, class.Package, class.Name, class.Permits)...)
 			case types.JavaEnum:
-				validImportedTypeNames := getValidExternalTypesOfClass(allClassExports, parsedFile.Imports, parsedFile.Package, class.Name)
+				validImportedTypeNames := getValidExternalTypesOfClass(packageExports, parsedFile.Imports, parsedFile.Package, class.Name)
 				response.Nodes = append(response.Nodes, class)
 				response.Edges = append(response.Edges, getClassRelationConnections(response.Edges, validImportedTypeNames, class)...)
 			case types.JavaInterface:
-				validImportedTypeNames := getValidExternalTypesOfClass(allClassExports, parsedFile.Imports, parsedFile.Package, class.Name)
+				validImportedTypeNames := getValidExternalTypesOfClass(packageExports, parsedFile.Imports, parsedFile.Package, class.Name)
+				class.Associations, class.Dependencies = getClassAssociationsAndDependencies(validImportedTypeNames, class.Variables, class.Methods)
+				class.Dependencies = addGenericDependencies(class.Associations, class.Dependencies, class.TypeParameters, class.Extends)
+				response.Nodes = append(response.Nodes, class)
+				response.Edges = append(response.Edges, getClassRelationConnections(response.Edges, validImportedTypeNames, class)...)
+				permitsRelations = append(permitsRelations, getPermittedSubclassRelations(validImportedTypeNames, class.Package, class.Name, class.Permits)...)
+			case types.JavaRecord:
+				validImportedTypeNames := getValidExternalTypesOfClass(packageExports, parsedFile.Imports, parsedFile.Package, class.Name)
+				class.Associations, class.Dependencies = getClassAssociationsAndDependencies(validImportedTypeNames, class.Variables, class.Methods)
+				class.Dependencies = addGenericDependencies(class.Associations, class.Dependencies, class.TypeParameters, class.Implements)
+				response.Nodes = append(response.Nodes, class)
+				response.Edges = append(response.Edges, getClassRelationConnections(response.Edges, validImportedTypeNames, class)...)
+			case types.JavaAnnotation:
+				validImportedTypeNames := getValidExternalTypesOfClass(packageExports, parsedFile.Imports, parsedFile.Package, class.Name)
 				class.Associations, class.Dependencies = getClassAssociationsAndDependencies(validImportedTypeNames, class.Variables, class.Methods)
 				response.Nodes = append(response.Nodes, class)
 				response.Edges = append(response.Edges, getClassRelationConnections(response.Edges, validImportedTypeNames, class)...)
@@ -46,9 +77,90 @@ func ParseProject(files []types.File) *types.Project {
 		}
 	}
 
+	// Permitted subclasses usually extend their sealed class already
+	for _, relation := range permitsRelations {
+		if !hasInheritanceRelation(response.Edges, relation.FromClassId, relation.ToClassId) {
+			response.Edges = append(response.Edges, relation)
+		}
+	}
+
 	return &response
 }
 
+// Returns the dependencies with the bounds of the type parameters and the type arguments of the supertypes
+// added: class Repository<T extends Entity> extends Base<User> depends on Entity and User
+func addGenericDependencies(associations, dependencies []types.CustomByteSlice, typeParameters []types.JavaTypeParameter, supertypes []types.CustomByteSlice) []types.CustomByteSlice {
+	existing := make(map[string]struct{})
+	for _, typeName := range append(append([]types.CustomByteSlice{}, associations...), dependencies...) {
+		existing[string(typeName)] = struct{}{}
+	}
+
+	// Type parameters are not types of the project, even when a class with the same name exists
+	for _, typeParameter := range typeParameters {
+		existing[string(typeParameter.Name)] = struct{}{}
+	}
+
+	addTypeNames := func(text []byte) {
+		for _, typeName := range getTypeNames(text) {
+			if _, ok := existing[string(typeName)]; ok {
+				continue
+			}
+
+			existing[string(typeName)] = struct{}{}
+			dependencies = append(dependencies, typeName)
+		}
+	}
+
+	for _, typeParameter := range typeParameters {
+		for _, bound := range typeParameter.Bounds {
+			addTypeNames(bound)
+		}
+	}
+
+	for _, supertype := range supertypes {
+		if openArrowIndex := bytes.IndexByte(supertype, LeftArrow); openArrowIndex != -1 {
+			addTypeNames(supertype[openArrowIndex:])
+		}
+	}
+
+	return dependencies
+}
+
+// Returns the names of the types used by a type: Map<? extends Shape,List<T>[]> uses Map, Shape, List and T
+func getTypeNames(text []byte) []types.CustomByteSlice {
+	var response []types.CustomByteSlice
+
+	words := bytes.FieldsFunc(text, func(r rune) bool {
+		return strings.ContainsRune("<>,&[]? ", r)
+	})
+
+	for _, word := range words {
+		if bytes.Equal(word, []byte("extends")) || bytes.Equal(word, []byte("super")) {
+			continue
+		}
+
+		response = append(response, word)
+	}
+
+	return response
+}
+
+// Returns true when a generalization or a realization already connects the subclass to its supertype
+func hasInheritanceRelation(relations []types.Relation, fromClassId, toClassId []byte) bool {
+	for _, relation := range relations {
+		if !bytes.Equal(relation.FromClassId, fromClassId) || !bytes.Equal(relation.ToClassId, toClassId) {
+			continue
+		}
+
+		switch relation.Type.(type) {
+		case *types.Generalization, *types.Realization:
+			return true
+		}
+	}
+
+	return false
+}
+
 func getClassExports(parsedFiles []types.FileResponse) []types.JavaClassExports {
 	var response []types.JavaClassExports
 
@@ -86,6 +198,12 @@ func getClassExports(parsedFiles []types.FileResponse) []types.JavaClassExports
 		case types.JavaInterface:
 			current.Name = class.Name
 			addExportsHelper(current.Package, class.Name)
+		case types.JavaRecord:
+			current.Name = class.Name
+			addExportsHelper(current.Package, class.Name)
+		case types.JavaAnnotation:
+			current.Name = class.Name
+			addExportsHelper(current.Package, class.Name)
 		}
 
 		for key := range exportsMap {
@@ -106,6 +224,10 @@ func getClassExports(parsedFiles []types.FileResponse) []types.JavaClassExports
 				response = append(response, getAllValidExportedClassTypeNames(parsedFile.Package, class))
 			case types.JavaInterface:
 				response = append(response, getAllValidExportedClassTypeNames(parsedFile.Package, class))
+			case types.JavaRecord:
+				response = append(response, getAllValidExportedClassTypeNames(parsedFile.Package, class))
+			case types.JavaAnnotation:
+				response = append(response, getAllValidExportedClassTypeNames(parsedFile.Package, class))
 			}
 		}
 	}
@@ -113,54 +235,33 @@ func getClassExports(parsedFiles []types.FileResponse) []types.JavaClassExports
 	return response
 }
 
-func getValidExternalTypesOfClass(allClassExports []types.JavaClassExports, imports [][]byte, packageName []byte, className []byte) map[string]struct{} {
+func getValidExternalTypesOfClass(packageExports map[string][]types.JavaClassExports, imports [][]byte, packageName []byte, className []byte) map[string]struct{} {
 	var response = make(map[string]struct{})
 
-	// Need to add all exports that are in the current package and alternative packages if they are imported
-	for _, allClasses := range allClassExports {
-		if bytes.Equal(allClasses.Package, packageName) && bytes.Equal(allClasses.Name, className) {
-			continue
+	addExports := func(classExports types.JavaClassExports) {
+		for _, export := range classExports.Exports {
+			response[string(export)] = struct{}{}
 		}
+	}
 
-		if !bytes.Equal(allClasses.Package, packageName) {
-			isImported := false
-
-			currentClassPackageNameSplit := bytes.Split(allClasses.Package, []byte("."))
-
-			for _, impt := range imports {
-				importSplit := bytes.Split(impt, []byte("."))
-				if len(importSplit) == 0 || len(importSplit) != len(currentClassPackageNameSplit)+1 {
-					continue
-				}
-
-				if len(importSplit) == 1 && bytes.Equal(allClasses.Name, importSplit[0]) {
-					isImported = true
-					break
-				}
-
-				isSamePackage := true
-				for i := 0; i < len(currentClassPackageNameSplit); i++ {
-					if !bytes.Equal(currentClassPackageNameSplit[i], importSplit[i]) {
-						isSamePackage = false
-						break
-					}
-				}
-
-				if !isSamePackage || (!bytes.Equal(importSplit[len(importSplit)-1], []byte("*")) && !bytes.Equal(importSplit[len(importSplit)-1], allClasses.Name)) {
-					continue
-				}
-
-				isImported = true
-				break
-			}
+	// Need to add all exports that are in the current package and alternative packages if they are imported
+	for _, classExports := range packageExports[string(packageName)] {
+		if !bytes.Equal(classExports.Name, className) {
+			addExports(classExports)
+		}
+	}
 
-			if !isImported {
-				continue
-			}
+	for _, impt := range imports {
+		lastPeriodIndex := bytes.LastIndexByte(impt, Period)
+		if lastPeriodIndex == -1 || bytes.Equal(impt[:lastPeriodIndex], packageName) {
+			continue
 		}
 
-		for _, export := range allClasses.Exports {
-			response[string(export)] = struct{}{}
+		importName := impt[lastPeriodIndex+1:]
+		for _, classExports := range packageExports[string(impt[:lastPeriodIndex])] {
+			if bytes.Equal(importName, []byte("*")) || bytes.Equal(importName, classExports.Name) {
+				addExports(classExports)
+			}
 		}
 	}
 
@@ -182,31 +283,8 @@ func getClassAssociationsAndDependencies(importedTypeNames map[string]struct{},
 	}
 
 	getTypesFromType := func(text []byte, relationMap map[string]struct{}) {
-		if len(text) > 0 && text[len(text)-1] != RightArrow {
-			if len(text) > 2 && text[len(text)-2] == OpenBracket && text[len(text)-1] == ClosedBracket {
-				addToResponseMap(text[:len(text)-2], relationMap)
-				return
-			}
-
-			addToResponseMap(text, relationMap)
-			return
-		}
-
-		var (
-			newTypeStartIndex int = 0
-		)
-
-		for i := 0; i < len(text); i++ {
-			if text[i] == LeftArrow || text[i] == RightArrow || text[i] == Comma {
-				wantToPush := text[newTypeStartIndex:i]
-				newTypeStartIndex = i + 1
-
-				if len(wantToPush) == 0 || bytes.ContainsAny(wantToPush, "<>,") {
-					continue
-				}
-
-				addToResponseMap(wantToPush, relationMap)
-			}
+		for _, typeName := range getTypeNames(text) {
+			addToResponseMap(typeName, relationMap)
 		}
 	}
 
@@ -324,6 +402,11 @@ func getClassAssociationsAndDependencies(importedTypeNames map[string]struct{},
 			getTypesFromType(parameter.Type, dependenciesMap)
 		}
 
+		// Checked exceptions are only connected when they belong to the project
+		for _, exception := range method.Throws {
+			getTypesFromType(exception, dependenciesMap)
+		}
+
 		var currentScope int = 1
 
 		for i, f := 0, ignoreQuotes(&method.Functionality); i < len(method.Functionality); i++ {
@@ -372,17 +455,26 @@ func getClassAssociationsAndDependencies(importedTypeNames map[string]struct{},
 				continue
 			}
 
-			// Iterate over importedTypeNames and see if method.Functionality[i...] matches importedTypeNames[k][i...]
-			// If it does match, check if it is a variable name, and if it is add it to currentlyDeclaredVariableNames
+			// Type names are words that can be qualified: com.shop.Order. Every part of the word that ends before a period
+			// or at the end of the word can be an imported type name, so only those are looked up, the longest first.
+			// If one does match, check if it is a variable name, and if it is add it to currentlyDeclaredVariableNames
 			// Otherwise if it is not a variable name, just add it with addToResponseMap(NameHere, dependenciesMap)
-			for typeName := range importedTypeNames {
-				ok := checkIfTypeNameIsInFunctionality(method.Functionality[i:], []byte(typeName), currentScope)
+			wordEndIndex := i
+			for wordEndIndex < len(method.Functionality) && (isIdentifierByte(method.Functionality[wordEndIndex]) || method.Functionality[wordEndIndex] == Period) {
+				wordEndIndex++
+			}
 
-				if !ok {
+			for j := wordEndIndex; j > i; j-- {
+				if j != wordEndIndex && method.Functionality[j] != Period {
 					continue
 				}
 
-				addToResponseMap([]byte(typeName), dependenciesMap)
+				typeName := method.Functionality[i:j]
+				if _, ok := importedTypeNames[string(typeName)]; !ok || !checkIfTypeNameIsInFunctionality(method.Functionality[i:], typeName, currentScope) {
+					continue
+				}
+
+				addToResponseMap(typeName, dependenciesMap)
 				break
 			}
 
@@ -409,25 +501,6 @@ func getClassRelationConnections(relations []types.Relation, importedTypeNames m
 	// Arrow types: Association, Dependency, Realization, Generalization, NestedOwnership
 	// Correlating: Associations, Dependencies, Implements, Extends, DefinedWithin
 
-	ensureClassIdBelongsToCurrentProject := func(classId []byte) []byte {
-		if bytes.Count(classId, []byte(".")) != 0 {
-			if _, ok := importedTypeNames[string(classId)]; ok {
-				return classId
-			}
-
-			return nil
-		}
-
-		classIdString := "." + string(classId)
-		for importedTypeName := range importedTypeNames {
-			if strings.HasSuffix(importedTypeName, classIdString) {
-				return []byte(importedTypeName)
-			}
-		}
-
-		return nil
-	}
-
 	getExistingRelationData := func(classId1, classId2 []byte) *types.Relation {
 		for i := 0; i < len(relations); i++ {
 			if (bytes.Equal(relations[i].FromClassId, classId1) && bytes.Equal(relations[i].ToClassId, classId2)) ||
@@ -440,9 +513,12 @@ func getClassRelationConnections(relations []types.Relation, importedTypeNames m
 	}
 
 	appendRelation := func(fromClassId, targetId []byte, relation types.RelationData) {
+		// Parameterized supertypes connect to their raw type: Base<User>
+		targetId, _, _ = bytes.Cut(targetId, []byte("<"))
+
 		var toClassId []byte
 		toClassId = append(toClassId, targetId...)
-		toClassId = ensureClassIdBelongsToCurrentProject(toClassId)
+		toClassId = ensureClassIdBelongsToCurrentProject(importedTypeNames, toClassId)
 
 		if toClassId == nil {
 			return
@@ -538,6 +614,46 @@ func getClassRelationConnections(relations []types.Relation, importedTypeNames m
 			appendRelation(FromClassId, append([]byte(""), dependency...), &types.Dependency{})
 		}
 
+	case types.JavaRecord:
+		var FromClassId []byte
+		FromClassId = append(FromClassId, c.Package...)
+		FromClassId = append(FromClassId, byte('.'))
+		FromClassId = append(FromClassId, c.Name...)
+
+		for _, implement := range c.Implements {
+			appendRelation(FromClassId, append([]byte(""), implement...), &types.Realization{})
+		}
+
+		if c.DefinedWithin != nil {
+			appendRelation(FromClassId, append([]byte(""), c.DefinedWithin...), &types.NestedOwnership{})
+		}
+
+		for _, association := range c.Associations {
+			appendRelation(FromClassId, append([]byte(""), association...), &types.Association{})
+		}
+
+		for _, dependency := range c.Dependencies {
+			appendRelation(FromClassId, append([]byte(""), dependency...), &types.Dependency{})
+		}
+
+	case types.JavaAnnotation:
+		var FromClassId []byte
+		FromClassId = append(FromClassId, c.Package...)
+		FromClassId = append(FromClassId, byte('.'))
+		FromClassId = append(FromClassId, c.Name...)
+
+		if c.DefinedWithin != nil {
+			appendRelation(FromClassId, append([]byte(""), c.DefinedWithin...), &types.NestedOwnership{})
+		}
+
+		for _, association := range c.Associations {
+			appendRelation(FromClassId, append([]byte(""), association...), &types.Association{})
+		}
+
+		for _, dependency := range c.Dependencies {
+			appendRelation(FromClassId, append([]byte(""), dependency...), &types.Dependency{})
+		}
+
 	case types.JavaEnum:
 		var FromClassId []byte
 		FromClassId = append(FromClassId, c.Package...)
@@ -555,3 +671,52 @@ func getClassRelationConnections(relations []types.Relation, importedTypeNames m
 
 	return response
 }
+
+// Returns the class id of a type name that belongs to the current project, or nil
+func ensureClassIdBelongsToCurrentProject(importedTypeNames map[string]struct{}, classId []byte) []byte {
+	if bytes.Count(classId, []byte(".")) != 0 {
+		if _, ok := importedTypeNames[string(classId)]; ok {
+			return classId
+		}
+
+		return nil
+	}
+
+	classIdString := "." + string(classId)
+	for importedTypeName := range importedTypeNames {
+		if strings.HasSuffix(importedTypeName, classIdString) {
+			return []byte(importedTypeName)
+		}
+	}
+
+	return nil
+}
+
+// Returns a generalization from every permitted subclass of a sealed class to the sealed class.
+// Subclasses that are nested in the sealed class may be permitted by their qualified name: Shape.Circle
+func getPermittedSubclassRelations(importedTypeNames map[string]struct{}, packageName, className []byte, permits []types.CustomByteSlice) []types.Relation {
+	var response []types.Relation
+
+	toClassId := []byte(string(packageName) + "." + string(className))
+
+	for _, permit := range permits {
+		fromClassId := ensureClassIdBelongsToCurrentProject(importedTypeNames, permit)
+		if fromClassId == nil && bytes.LastIndexByte(permit, Period) != -1 {
+			fromClassId = ensureClassIdBelongsToCurrentProject(importedTypeNames, permit[bytes.LastIndexByte(permit, Period)+1:])
+		}
+
+		if fromClassId == nil {
+			continue
+		}
+
+		relation := &types.Generalization{}
+		relation.SetToArrow(true)
+		response = append(response, types.Relation{
+			FromClassId: fromClassId,
+			ToClassId:   toClassId,
+			Type:        relation,
+		})
+	}
+
+	return response
+}
@@ -2,9 +2,12 @@ package java
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 	"testing"
 
 	types "github.com/junioryono/ProUML/backend/transpiler/types"
@@ -1669,7 +1672,7 @@ func TestParseProject(t *testing.T) {
 
 	for testIndex, tt := range tests {
 		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
-			response := ParseProject(tt.Input)
+			response := ParseProject(context.Background(), tt.Input)
 
 			if len(response.Nodes) != len(tt.Output.Nodes) {
 				subtest.Errorf("testIndex: %s. incorrect number of nodes.\nExpected %s. Got %s\n", strconv.Itoa(testIndex), strconv.Itoa(len(tt.Output.Nodes)), strconv.Itoa(len(response.Nodes)))
@@ -2031,3 +2034,378 @@ func TestParseProject(t *testing.T) {
 		})
 	}
 }
+
+func TestParseProjectRecords(t *testing.T) {
+	type ParseProjectRecordsTest struct {
+		Input []types.File
+		Nodes []string
+		Edges []string
+	}
+
+	var tests = []ParseProjectRecordsTest{
+		{
+			Input: []types.File{
+				{
+					Name:      "OrderDto",
+					Extension: "java",
+					Code: []byte(`
+					package com.shop.dto;
+					import com.shop.model.Identified;
+					public record OrderDto(String id, CustomerDto customer, List<LineDto> lines) implements Identified {
+						public OrderDto {
+							Validator.check(id);
+						}
+						public record LineDto(String sku, int quantity) {}
+					}
+					`),
+				},
+				{
+					Name:      "CustomerDto",
+					Extension: "java",
+					Code:      []byte("package com.shop.dto;public record CustomerDto(String name){}"),
+				},
+				{
+					Name:      "Validator",
+					Extension: "java",
+					Code:      []byte("package com.shop.dto;public class Validator{public static void check(String id){}}"),
+				},
+				{
+					Name:      "Identified",
+					Extension: "java",
+					Code:      []byte("package com.shop.model;public interface Identified{String id();}"),
+				},
+			},
+			Nodes: []string{
+				"com.shop.dto.OrderDto",
+				"com.shop.dto.LineDto",
+				"com.shop.dto.CustomerDto",
+				"com.shop.dto.Validator",
+				"com.shop.model.Identified",
+			},
+			Edges: []string{
+				"com.shop.dto.LineDto -> com.shop.dto.OrderDto *types.NestedOwnership",
+				"com.shop.dto.OrderDto -> com.shop.dto.CustomerDto *types.Association",
+				"com.shop.dto.OrderDto -> com.shop.dto.CustomerDto *types.Dependency",
+				"com.shop.dto.OrderDto -> com.shop.dto.LineDto *types.Association",
+				"com.shop.dto.OrderDto -> com.shop.dto.LineDto *types.Dependency",
+				"com.shop.dto.OrderDto -> com.shop.dto.Validator *types.Dependency",
+				"com.shop.dto.OrderDto -> com.shop.model.Identified *types.Realization",
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			checkProjectNodesAndEdges(subtest, ParseProject(context.Background(), tt.Input), tt.Nodes, tt.Edges)
+		})
+	}
+}
+
+func TestParseProjectSealed(t *testing.T) {
+	type ParseProjectSealedTest struct {
+		Input []types.File
+		Nodes []string
+		Edges []string
+	}
+
+	var tests = []ParseProjectSealedTest{
+		{
+			Input: []types.File{
+				{
+					Name:      "Shape",
+					Extension: "java",
+					Code: []byte(`
+					package geometry;
+					import geometry.flat.Square;
+					public abstract sealed class Shape permits Circle, Square, Shape.Dot, Shape.Polygon {
+						public abstract double area();
+						public static final class Dot extends Shape {
+							public double area() { return 0; }
+						}
+						static non-sealed class Polygon extends Shape {
+							public double area() { return 1; }
+						}
+					}
+					`),
+				},
+				{
+					Name:      "Circle",
+					Extension: "java",
+					Code:      []byte("package geometry;public final class Circle extends Shape{private double radius;public double area(){return radius;}}"),
+				},
+				{
+					// Square is permitted by Shape, but its supertype is only known from the permits clause
+					Name:      "Square",
+					Extension: "java",
+					Code:      []byte("package geometry.flat;public non-sealed class Square{private Shape inner;}"),
+				},
+			},
+			Nodes: []string{
+				"geometry.Shape",
+				"geometry.Dot",
+				"geometry.Polygon",
+				"geometry.Circle",
+				"geometry.flat.Square",
+			},
+			Edges: []string{
+				"geometry.Circle -> geometry.Shape *types.Generalization",
+				"geometry.Dot -> geometry.Shape *types.Generalization",
+				"geometry.Dot -> geometry.Shape *types.NestedOwnership",
+				"geometry.Polygon -> geometry.Shape *types.Generalization",
+				"geometry.Polygon -> geometry.Shape *types.NestedOwnership",
+				"geometry.flat.Square -> geometry.Shape *types.Generalization",
+			},
+		},
+		{
+			Input: []types.File{
+				{
+					Name:      "Result",
+					Extension: "java",
+					Code: []byte(`
+					package api;
+					public sealed interface Result {}
+					record Ok(String value) implements Result {}
+					final class Failure implements Result {}
+					`),
+				},
+			},
+			Nodes: []string{
+				"api.Result",
+				"api.Ok",
+				"api.Failure",
+			},
+			Edges: []string{
+				"api.Failure -> api.Result *types.Realization",
+				"api.Ok -> api.Result *types.Realization",
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			checkProjectNodesAndEdges(subtest, ParseProject(context.Background(), tt.Input), tt.Nodes, tt.Edges)
+		})
+	}
+}
+
+func TestParseProjectAnnotations(t *testing.T) {
+	type ParseProjectAnnotationsTest struct {
+		Input []types.File
+		Nodes []string
+		Edges []string
+	}
+
+	var tests = []ParseProjectAnnotationsTest{
+		{
+			Input: []types.File{
+				{
+					Name:      "Audit",
+					Extension: "java",
+					Code: []byte(`
+					package com.shop.audit;
+					public class Audit {
+						@Retention(RetentionPolicy.RUNTIME)
+						public @interface Audited {
+							Level level() default Level.LOW;
+							Class<? extends Formatter> formatter();
+						}
+					}
+					`),
+				},
+				{
+					Name:      "Level",
+					Extension: "java",
+					Code:      []byte("package com.shop.audit;public enum Level{LOW,HIGH}"),
+				},
+				{
+					Name:      "Formatter",
+					Extension: "java",
+					Code:      []byte("package com.shop.audit;public interface Formatter{String format(Object value);}"),
+				},
+			},
+			Nodes: []string{
+				"com.shop.audit.Audit",
+				"com.shop.audit.Audited",
+				"com.shop.audit.Level",
+				"com.shop.audit.Formatter",
+			},
+			Edges: []string{
+				"com.shop.audit.Audited -> com.shop.audit.Audit *types.NestedOwnership",
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			checkProjectNodesAndEdges(subtest, ParseProject(context.Background(), tt.Input), tt.Nodes, tt.Edges)
+		})
+	}
+}
+
+func TestParseProjectGenerics(t *testing.T) {
+	type ParseProjectGenericsTest struct {
+		Input []types.File
+		Nodes []string
+		Edges []string
+	}
+
+	var tests = []ParseProjectGenericsTest{
+		{
+			Input: []types.File{
+				{
+					Name:      "UserRepository",
+					Extension: "java",
+					Code: []byte(`
+					package com.shop.data;
+					import com.shop.model.User;
+					public class UserRepository extends Repository<User, Long> implements Store<User> {
+						private Map<String, ? extends Auditor> auditors;
+					}
+					`),
+				},
+				{
+					Name:      "Repository",
+					Extension: "java",
+					Code:      []byte("package com.shop.data;public abstract class Repository<T extends Entity & Comparable<T>, ID> {public abstract T find(ID id);}"),
+				},
+				{
+					Name:      "Store",
+					Extension: "java",
+					Code:      []byte("package com.shop.data;public interface Store<T extends Entity> {void save(T value);}"),
+				},
+				{
+					Name:      "Entity",
+					Extension: "java",
+					Code:      []byte("package com.shop.data;public class Entity {}"),
+				},
+				{
+					Name:      "Auditor",
+					Extension: "java",
+					Code:      []byte("package com.shop.data;public interface Auditor {}"),
+				},
+				{
+					Name:      "User",
+					Extension: "java",
+					Code:      []byte("package com.shop.model;import com.shop.data.Entity;public class User extends Entity {}"),
+				},
+			},
+			Nodes: []string{
+				"com.shop.data.UserRepository",
+				"com.shop.data.Repository",
+				"com.shop.data.Store",
+				"com.shop.data.Entity",
+				"com.shop.data.Auditor",
+				"com.shop.model.User",
+			},
+			Edges: []string{
+				"com.shop.data.Repository -> com.shop.data.Entity *types.Dependency",
+				"com.shop.data.Store -> com.shop.data.Entity *types.Dependency",
+				"com.shop.data.UserRepository -> com.shop.data.Auditor *types.Association",
+				"com.shop.data.UserRepository -> com.shop.data.Repository *types.Generalization",
+				"com.shop.data.UserRepository -> com.shop.data.Store *types.Realization",
+				"com.shop.data.UserRepository -> com.shop.model.User *types.Dependency",
+				"com.shop.model.User -> com.shop.data.Entity *types.Generalization",
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			checkProjectNodesAndEdges(subtest, ParseProject(context.Background(), tt.Input), tt.Nodes, tt.Edges)
+		})
+	}
+}
+
+func TestParseProjectThrows(t *testing.T) {
+	type ParseProjectThrowsTest struct {
+		Input []types.File
+		Nodes []string
+		Edges []string
+	}
+
+	var tests = []ParseProjectThrowsTest{
+		{
+			Input: []types.File{
+				{
+					Name:      "OrderService",
+					Extension: "java",
+					Code: []byte(`
+					package com.shop.service;
+					import java.io.IOException;
+					import com.shop.errors.*;
+					public interface OrderService {
+						String find(String id) throws OrderNotFoundException, IOException;
+						void cancel(String id) throws CancelException;
+					}
+					`),
+				},
+				{
+					Name:      "OrderNotFoundException",
+					Extension: "java",
+					Code:      []byte("package com.shop.errors;public class OrderNotFoundException extends Exception {public OrderNotFoundException(String id) {super(id);}}"),
+				},
+				{
+					Name:      "CancelException",
+					Extension: "java",
+					Code:      []byte("package com.shop.errors;public class CancelException extends Exception {}"),
+				},
+			},
+			Nodes: []string{
+				"com.shop.service.OrderService",
+				"com.shop.errors.OrderNotFoundException",
+				"com.shop.errors.CancelException",
+			},
+			Edges: []string{
+				"com.shop.service.OrderService -> com.shop.errors.CancelException *types.Dependency",
+				"com.shop.service.OrderService -> com.shop.errors.OrderNotFoundException *types.Dependency",
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			checkProjectNodesAndEdges(subtest, ParseProject(context.Background(), tt.Input), tt.Nodes, tt.Edges)
+		})
+	}
+}
+
+// Compares the class ids of the nodes in order and the edges in any order
+func checkProjectNodesAndEdges(subtest *testing.T, response *types.Project, nodes, edges []string) {
+	if len(response.Nodes) != len(nodes) {
+		subtest.Errorf("incorrect number of nodes.\nexpected: %s\ngot: %s\n", strconv.Itoa(len(nodes)), strconv.Itoa(len(response.Nodes)))
+		subtest.FailNow()
+	}
+
+	for i, node := range response.Nodes {
+		var classId string
+		switch class := node.(type) {
+		case types.JavaAbstract:
+			classId = string(class.Package) + "." + string(class.Name)
+		case types.JavaClass:
+			classId = string(class.Package) + "." + string(class.Name)
+		case types.JavaInterface:
+			classId = string(class.Package) + "." + string(class.Name)
+		case types.JavaEnum:
+			classId = string(class.Package) + "." + string(class.Name)
+		case types.JavaRecord:
+			classId = string(class.Package) + "." + string(class.Name)
+		case types.JavaAnnotation:
+			classId = string(class.Package) + "." + string(class.Name)
+		}
+
+		if classId != nodes[i] {
+			subtest.Errorf("incorrect node.\nexpected:\n%s\ngot:\n%s\n", nodes[i], classId)
+		}
+	}
+
+	var actualEdges []string
+	for _, edge := range response.Edges {
+		actualEdges = append(actualEdges, fmt.Sprintf("%s -> %s %T", edge.FromClassId, edge.ToClassId, edge.Type))
+	}
+
+	sort.Strings(actualEdges)
+
+	if strings.Join(actualEdges, "\n") != strings.Join(edges, "\n") {
+		subtest.Errorf("incorrect edges.\nexpected:\n%s\ngot:\n%s\n", strings.Join(edges, "\n"), strings.Join(actualEdges, "\n"))
+	}
+}
@@ -0,0 +1,520 @@
+package java
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+const (
+	sourceClass = iota
+	sourceVariable
+	sourceMethod
+)
+
+// A declaration found in the original code of a file, before it is flattened by the parser
+type sourceDeclaration struct {
+	Kind       int
+	Names      [][]byte // Variables can declare more than one name: int x,y;
+	Parameters int
+	Span       types.SourceSpan
+	Members    []sourceDeclaration
+	used       []bool
+	matched    bool
+}
+
+// Get the declarations of a file with the lines that they span and the syntax errors of the file.
+// Comments and the contents of quotations are blanked out first, so that the offsets of the code stay the same as in the file.
+func getSourceDeclarations(code []byte) ([]sourceDeclaration, []types.Diagnostic) {
+	var (
+		text, unclosedIndex = maskCommentsAndQuotes(code)
+		lineStarts          = []int{0}
+	)
+
+	for i, b := range text {
+		if b == NewLine {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+
+	getLine := func(offset int) int {
+		return sort.Search(len(lineStarts), func(i int) bool { return lineStarts[i] > offset })
+	}
+
+	return scanSourceBody(text, 0, len(text), nil, getLine), getSyntaxDiagnostics(text, unclosedIndex, getLine)
+}
+
+// Replace comments and the contents of quotations with spaces. New lines are kept.
+// The index of a comment or text block that is never closed is returned too, or -1.
+func maskCommentsAndQuotes(code []byte) ([]byte, int) {
+	var (
+		text          = append([]byte{}, code...)
+		unclosedIndex = -1
+	)
+
+	blank := func(start, end int) {
+		for i := start; i < end && i < len(text); i++ {
+			if text[i] != NewLine {
+				text[i] = Space
+			}
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		switch {
+		case bytes.HasPrefix(text[i:], []byte("//")):
+			endIndex := bytes.IndexByte(text[i:], NewLine)
+			if endIndex == -1 {
+				endIndex = len(text) - i
+			}
+
+			blank(i, i+endIndex)
+			i += endIndex
+		case bytes.HasPrefix(text[i:], []byte("/*")):
+			endIndex := bytes.Index(text[i+2:], []byte("*/"))
+			if endIndex == -1 {
+				endIndex = len(text) - i - 4
+				unclosedIndex = i
+			}
+
+			blank(i, i+2+endIndex+2)
+			i += 2 + endIndex + 1
+		case bytes.HasPrefix(text[i:], []byte(`"""`)):
+			// Text blocks end with the next unescaped triple quote
+			j := i + 3
+			for j < len(text) && !(bytes.HasPrefix(text[j:], []byte(`"""`)) && text[j-1] != Backslash) {
+				j++
+			}
+
+			if j >= len(text) {
+				unclosedIndex = i
+			}
+
+			blank(i+3, j)
+			i = j + 2
+		case text[i] == DoubleQuote || text[i] == SingleQuote:
+			j := i + 1
+			for j < len(text) && text[j] != text[i] && text[j] != NewLine {
+				if text[j] == Backslash {
+					j++
+				}
+
+				j++
+			}
+
+			blank(i+1, j)
+			i = j
+		}
+	}
+
+	return text, unclosedIndex
+}
+
+// Get the declarations between start and end, which are the braces of a class body or the whole file.
+// Method bodies and the values of variables are skipped.
+func scanSourceBody(text []byte, start, end int, record *sourceDeclaration, getLine func(int) int) []sourceDeclaration {
+	var (
+		response      []sourceDeclaration
+		segmentStart  int = -1
+		parenthesis   int = 0
+		hasAssignment bool
+	)
+
+	for i := start; i < end; i++ {
+		b := text[i]
+
+		if segmentStart == -1 {
+			if b == Space || b == Tab || b == NewLine || b == '\r' || b == SemiColon {
+				continue
+			}
+
+			segmentStart = i
+			hasAssignment = false
+		}
+
+		switch b {
+		case OpenParenthesis:
+			parenthesis++
+		case ClosedParenthesis:
+			parenthesis--
+		case EqualSign:
+			if parenthesis == 0 {
+				hasAssignment = true
+			}
+		case SemiColon:
+			if parenthesis != 0 {
+				continue
+			}
+
+			if declaration, ok := getSourceDeclaration(text[segmentStart:i], false, record); ok {
+				declaration.Span = types.SourceSpan{StartLine: getLine(segmentStart), EndLine: getLine(i)}
+				response = append(response, declaration)
+			}
+
+			segmentStart = -1
+		case OpenCurly:
+			if parenthesis != 0 {
+				continue
+			}
+
+			closedCurlyIndex := getClosedCurlyIndex(text, i, end)
+
+			// Values can hold bodies, such as anonymous classes and lambdas: Runnable r=()->{};
+			if hasAssignment {
+				i = closedCurlyIndex
+				continue
+			}
+
+			header := text[segmentStart:i]
+			if declaration, ok := getSourceDeclaration(header, true, record); ok {
+				declaration.Span = types.SourceSpan{StartLine: getLine(segmentStart), EndLine: getLine(closedCurlyIndex)}
+
+				if declaration.Kind == sourceClass {
+					if bytes.Contains(removeSourceAnnotations(header), []byte("record ")) {
+						declaration.Members = append(declaration.Members, getRecordComponentDeclarations(text, segmentStart, i, getLine)...)
+						declaration.Members = append(declaration.Members, scanSourceBody(text, i+1, closedCurlyIndex, &declaration, getLine)...)
+					} else {
+						declaration.Members = scanSourceBody(text, i+1, closedCurlyIndex, nil, getLine)
+					}
+				}
+
+				response = append(response, declaration)
+			}
+
+			segmentStart = -1
+			i = closedCurlyIndex
+		}
+	}
+
+	return response
+}
+
+// Get the index of the brace that closes the brace at openCurlyIndex, or the index before end
+func getClosedCurlyIndex(text []byte, openCurlyIndex, end int) int {
+	for i, scope := openCurlyIndex, 0; i < end; i++ {
+		if text[i] == OpenCurly {
+			scope++
+		} else if text[i] == ClosedCurly {
+			scope--
+
+			if scope == 0 {
+				return i
+			}
+		}
+	}
+
+	return end - 1
+}
+
+// Get the declaration of a header: the text of a statement up to its body or its semicolon
+func getSourceDeclaration(header []byte, hasBody bool, record *sourceDeclaration) (sourceDeclaration, bool) {
+	header = removeSourceAnnotations(header)
+	words := bytes.Fields(header)
+
+	for i, word := range words {
+		if i+1 < len(words) && (bytes.Equal(word, []byte("class")) || bytes.Equal(word, []byte("interface")) ||
+			bytes.Equal(word, []byte("enum")) || bytes.Equal(word, []byte("record")) || bytes.Equal(word, []byte("@interface"))) {
+			name := words[i+1]
+			if index := bytes.IndexAny(name, "<("); index != -1 {
+				name = name[:index]
+			}
+
+			return sourceDeclaration{Kind: sourceClass, Names: [][]byte{name}}, true
+		}
+	}
+
+	openParenthesisIndex := bytes.IndexByte(header, OpenParenthesis)
+	equalSignIndex := bytes.IndexByte(header, EqualSign)
+
+	if openParenthesisIndex != -1 && (equalSignIndex == -1 || openParenthesisIndex < equalSignIndex) {
+		name := getLastIdentifier(header[:openParenthesisIndex])
+		if len(name) == 0 {
+			return sourceDeclaration{}, false
+		}
+
+		parameters := bytes.TrimSpace(header[openParenthesisIndex+1 : getClosedParenthesisIndex(header, openParenthesisIndex)])
+
+		declaration := sourceDeclaration{Kind: sourceMethod, Names: [][]byte{name}}
+		if len(parameters) != 0 {
+			declaration.Parameters = len(splitSourceList(parameters))
+		}
+
+		return declaration, true
+	}
+
+	// Compact constructors of records have no parameters: public Point{...}
+	if record != nil && hasBody {
+		if name := getLastIdentifier(header); bytes.Equal(name, record.Names[0]) {
+			return sourceDeclaration{Kind: sourceMethod, Names: [][]byte{name}, Parameters: -1}, true
+		}
+	}
+
+	// Blocks without a declaration are initializers: static{...}
+	if hasBody {
+		return sourceDeclaration{}, false
+	}
+
+	declaration := sourceDeclaration{Kind: sourceVariable}
+	for _, variable := range splitSourceList(header) {
+		if equalSignIndex := bytes.IndexByte(variable, EqualSign); equalSignIndex != -1 {
+			variable = variable[:equalSignIndex]
+		}
+
+		variable = bytes.TrimRight(bytes.TrimSpace(variable), "[] ")
+		if name := getLastIdentifier(variable); len(name) != 0 {
+			declaration.Names = append(declaration.Names, name)
+		}
+	}
+
+	return declaration, len(declaration.Names) != 0
+}
+
+// Get the components of a record header as variables: record Point(int x,int y)
+func getRecordComponentDeclarations(text []byte, start, end int, getLine func(int) int) []sourceDeclaration {
+	var response []sourceDeclaration
+
+	openParenthesisIndex := bytes.IndexByte(text[start:end], OpenParenthesis)
+	if openParenthesisIndex == -1 {
+		return response
+	}
+
+	openParenthesisIndex += start
+	closedParenthesisIndex := openParenthesisIndex + getClosedParenthesisIndex(text[openParenthesisIndex:end], 0)
+
+	componentStart := openParenthesisIndex + 1
+	for _, component := range splitSourceList(text[componentStart:closedParenthesisIndex]) {
+		name := getLastIdentifier(bytes.TrimRight(removeSourceAnnotations(component), " \t\r\n"))
+		if len(name) != 0 {
+			line := getLine(componentStart + len(component) - len(bytes.TrimLeft(component, " \t\r\n")))
+			response = append(response, sourceDeclaration{
+				Kind:  sourceVariable,
+				Names: [][]byte{name},
+				Span:  types.SourceSpan{StartLine: line, EndLine: line},
+			})
+		}
+
+		componentStart += len(component) + 1
+	}
+
+	return response
+}
+
+// Get the index of the parenthesis that closes the parenthesis at openParenthesisIndex, or the length of the text
+func getClosedParenthesisIndex(text []byte, openParenthesisIndex int) int {
+	for i, scope := openParenthesisIndex, 0; i < len(text); i++ {
+		if text[i] == OpenParenthesis {
+			scope++
+		} else if text[i] == ClosedParenthesis {
+			scope--
+
+			if scope == 0 {
+				return i
+			}
+		}
+	}
+
+	return len(text)
+}
+
+// Split a list on the commas that are not inside of angle brackets, parentheses or braces: Map<K,V>a,int[]b={1,2}
+func splitSourceList(text []byte) [][]byte {
+	var (
+		response   [][]byte
+		scope      int = 0
+		startIndex int = 0
+	)
+
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case LeftArrow, OpenParenthesis, OpenCurly:
+			scope++
+		case RightArrow, ClosedParenthesis, ClosedCurly:
+			scope--
+		case Comma:
+			if scope == 0 {
+				response = append(response, text[startIndex:i])
+				startIndex = i + 1
+			}
+		}
+	}
+
+	return append(response, text[startIndex:])
+}
+
+// Remove annotations and their elements from a header, keeping its offsets: @Table(name="orders") public class Order
+func removeSourceAnnotations(header []byte) []byte {
+	response := append([]byte{}, header...)
+
+	for i := 0; i < len(response); i++ {
+		if response[i] != Asperand || bytes.HasPrefix(response[i:], []byte("@interface")) {
+			continue
+		}
+
+		endIndex := i + 1
+		for endIndex < len(response) && (isIdentifierByte(response[endIndex]) || response[endIndex] == Period) {
+			endIndex++
+		}
+
+		nextIndex := endIndex
+		for nextIndex < len(response) && (response[nextIndex] == Space || response[nextIndex] == Tab || response[nextIndex] == NewLine || response[nextIndex] == '\r') {
+			nextIndex++
+		}
+
+		if nextIndex < len(response) && response[nextIndex] == OpenParenthesis {
+			endIndex = getClosedParenthesisIndex(response, nextIndex) + 1
+		}
+
+		for j := i; j < endIndex && j < len(response); j++ {
+			if response[j] != NewLine {
+				response[j] = Space
+			}
+		}
+
+		i = endIndex - 1
+	}
+
+	return response
+}
+
+// Get the identifier at the end of text: public static List<String>names -> names
+func getLastIdentifier(text []byte) []byte {
+	text = bytes.TrimRight(text, " \t\r\n")
+
+	startIndex := len(text)
+	for startIndex > 0 && isIdentifierByte(text[startIndex-1]) {
+		startIndex--
+	}
+
+	return text[startIndex:]
+}
+
+func isIdentifierByte(b byte) bool {
+	return b == '_' || b == '$' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9') || b >= 0x80
+}
+
+// Set the path of the file and the spans of the declarations on the classes of the file and their members.
+// Classes are matched by their name and the class they are defined within, members by their name
+// and methods also by their number of parameters, so that overloads keep their own lines.
+// Declarations that were not matched could not be parsed and are returned as diagnostics.
+func attachSourceSpans(classes []any, file types.File) ([]any, []types.Diagnostic) {
+	var (
+		path                      = file.Path
+		declarations, diagnostics = getSourceDeclarations(file.Code)
+		classSpans                = make(map[string][]*sourceDeclaration)
+	)
+
+	var addClasses func(definedWithin []byte, declarations []sourceDeclaration)
+	addClasses = func(definedWithin []byte, declarations []sourceDeclaration) {
+		for i := range declarations {
+			if declarations[i].Kind != sourceClass {
+				continue
+			}
+
+			key := string(definedWithin) + "." + string(declarations[i].Names[0])
+			classSpans[key] = append(classSpans[key], &declarations[i])
+			addClasses(declarations[i].Names[0], declarations[i].Members)
+		}
+	}
+
+	addClasses(nil, declarations)
+
+	getClass := func(definedWithin, name []byte) *sourceDeclaration {
+		key := string(definedWithin) + "." + string(name)
+		if len(classSpans[key]) == 0 {
+			return nil
+		}
+
+		declaration := classSpans[key][0]
+		declaration.matched = true
+		classSpans[key] = classSpans[key][1:]
+		return declaration
+	}
+
+	for i, class := range classes {
+		switch c := class.(type) {
+		case types.JavaAbstract:
+			c.Path = path
+			if declaration := getClass(c.DefinedWithin, c.Name); declaration != nil {
+				c.Span = &declaration.Span
+				attachMemberSpans(declaration, c.Variables, c.Methods)
+			}
+			classes[i] = c
+		case types.JavaClass:
+			c.Path = path
+			if declaration := getClass(c.DefinedWithin, c.Name); declaration != nil {
+				c.Span = &declaration.Span
+				attachMemberSpans(declaration, c.Variables, c.Methods)
+			}
+			classes[i] = c
+		case types.JavaInterface:
+			c.Path = path
+			if declaration := getClass(c.DefinedWithin, c.Name); declaration != nil {
+				c.Span = &declaration.Span
+				attachMemberSpans(declaration, c.Variables, c.Methods)
+			}
+			classes[i] = c
+		case types.JavaEnum:
+			c.Path = path
+			if declaration := getClass(c.DefinedWithin, c.Name); declaration != nil {
+				c.Span = &declaration.Span
+			}
+			classes[i] = c
+		case types.JavaRecord:
+			c.Path = path
+			if declaration := getClass(c.DefinedWithin, c.Name); declaration != nil {
+				c.Span = &declaration.Span
+				attachMemberSpans(declaration, c.Variables, c.Methods)
+			}
+			classes[i] = c
+		case types.JavaAnnotation:
+			c.Path = path
+			if declaration := getClass(c.DefinedWithin, c.Name); declaration != nil {
+				c.Span = &declaration.Span
+				attachMemberSpans(declaration, c.Variables, c.Methods)
+			}
+			classes[i] = c
+		}
+	}
+
+	diagnostics = append(diagnostics, getMissingDeclarationDiagnostics(declarations, nil)...)
+	for i := range diagnostics {
+		diagnostics[i].Path = file.GetPath()
+	}
+
+	return classes, diagnostics
+}
+
+// Members that the parser generates, such as the accessors of records, have no span
+func attachMemberSpans(class *sourceDeclaration, variables []types.JavaVariable, methods []types.JavaMethod) {
+	for i := range class.Members {
+		class.Members[i].used = make([]bool, len(class.Members[i].Names))
+	}
+
+	find := func(kind int, name []byte, parameters int) *types.SourceSpan {
+		for i := range class.Members {
+			member := &class.Members[i]
+			if member.Kind != kind || kind == sourceMethod && member.Parameters != parameters && member.Parameters != -1 {
+				continue
+			}
+
+			for j, memberName := range member.Names {
+				if !member.used[j] && bytes.Equal(memberName, name) {
+					member.used[j] = true
+					span := member.Span
+					return &span
+				}
+			}
+		}
+
+		return nil
+	}
+
+	for i := range variables {
+		variables[i].Span = find(sourceVariable, variables[i].Name, 0)
+	}
+
+	for i := range methods {
+		methods[i].Span = find(sourceMethod, methods[i].Name, len(methods[i].Parameters))
+	}
+}
@@ -0,0 +1,108 @@
+package java
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestAttachSourceSpans(t *testing.T) {
+	input := []byte(`package com.shop;
+
+/** An order. { */
+@Entity
+public class Order {
+	private String note = "}", tag;
+	private int a,
+		b;
+
+	public Order() {
+		// }
+		this.note = "{";
+	}
+
+	public void add(String item) {}
+
+	public void add(String item,
+			int quantity) {
+		Runnable r = () -> {};
+	}
+
+	public static class Line {
+		private String sku;
+	}
+
+	public record Total(int amount,
+			String currency) {
+		public Total {
+		}
+	}
+}
+`)
+
+	response := ParseFile(types.File{Path: "src/main/java/com/shop/Order.java", Name: "Order", Extension: "java", Code: input})
+
+	expected := []string{
+		"Order 4-31",
+		"note 6-6",
+		"tag 6-6",
+		"a 7-8",
+		"b 7-8",
+		"Order() 10-13",
+		"add(1) 15-15",
+		"add(2) 17-20",
+		"Line 22-24",
+		"sku 23-23",
+		"Total 26-30",
+		"amount 26-26",
+		"currency 27-27",
+		"amount() -",
+		"currency() -",
+		"Total(2) 28-29",
+	}
+
+	formatSpan := func(span *types.SourceSpan) string {
+		if span == nil {
+			return "-"
+		}
+
+		return strconv.Itoa(span.StartLine) + "-" + strconv.Itoa(span.EndLine)
+	}
+
+	var actual []string
+	addMembers := func(variables []types.JavaVariable, methods []types.JavaMethod) {
+		for _, variable := range variables {
+			actual = append(actual, string(variable.Name)+" "+formatSpan(variable.Span))
+		}
+
+		for _, method := range methods {
+			parameters := ""
+			if len(method.Parameters) != 0 {
+				parameters = strconv.Itoa(len(method.Parameters))
+			}
+
+			actual = append(actual, fmt.Sprintf("%s(%s) %s", method.Name, parameters, formatSpan(method.Span)))
+		}
+	}
+
+	for _, class := range response.Data {
+		switch c := class.(type) {
+		case types.JavaClass:
+			if c.Path != "src/main/java/com/shop/Order.java" {
+				t.Errorf("incorrect path.\nexpected:\nsrc/main/java/com/shop/Order.java\ngot:\n%s\n", c.Path)
+			}
+
+			actual = append(actual, string(c.Name)+" "+formatSpan(c.Span))
+			addMembers(c.Variables, c.Methods)
+		case types.JavaRecord:
+			actual = append(actual, string(c.Name)+" "+formatSpan(c.Span))
+			addMembers(c.Variables, c.Methods)
+		}
+	}
+
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("incorrect spans.\nexpected:\n%v\ngot:\n%v\n", expected, actual)
+	}
+}
@@ -0,0 +1,967 @@
+package kotlin
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+// Package of the classes declared in files without a package header. Java uses the same name.
+const defaultPackage = "default"
+
+var modifierKeywords = map[string]struct{}{
+	"public": {}, "private": {}, "protected": {}, "internal": {},
+	"open": {}, "final": {}, "abstract": {}, "sealed": {}, "override": {},
+	"data": {}, "enum": {}, "annotation": {}, "inner": {}, "value": {}, "inline": {}, "companion": {},
+	"lateinit": {}, "const": {}, "external": {}, "suspend": {}, "tailrec": {}, "operator": {}, "infix": {},
+	"expect": {}, "actual": {}, "vararg": {}, "noinline": {}, "crossinline": {}, "reified": {},
+}
+
+// Modifiers that are shown as stereotypes, in the order they are shown
+var stereotypeModifiers = []string{"sealed", "data"}
+
+// Tokens that continue a statement on the next line when they end a line
+var continuingLineEnds = map[string]struct{}{
+	"=": {}, ".": {}, "?.": {}, ",": {}, "(": {}, "[": {}, ":": {}, "->": {}, "::": {}, "..": {},
+	"+": {}, "-": {}, "*": {}, "/": {}, "%": {}, "&&": {}, "||": {}, "?:": {}, "==": {}, "!=": {}, "!": {},
+	"by": {}, "as": {}, "is": {}, "in": {},
+}
+
+// Tokens that continue the statement of the previous line when they start a line
+var continuingLineStarts = map[string]struct{}{
+	".": {}, "?.": {}, "?:": {}, "&&": {}, "||": {}, ":": {}, "->": {}, "=": {}, "{": {}, ")": {}, "]": {},
+	"as": {}, "by": {}, "where": {},
+}
+
+type parser struct {
+	tokens  []token
+	code    string
+	pkg     []byte
+	imports [][]byte
+	data    []any
+}
+
+type parameter struct {
+	Name      string
+	Type      string
+	Value     string
+	Property  string // "val" or "var" when a primary constructor parameter is also a property
+	Modifiers map[string]bool
+}
+
+type supertype struct {
+	Name string
+	Call bool // True when the supertype is called with constructor arguments, which only classes are
+}
+
+// A class, interface or object before it becomes a Java class
+type declaration struct {
+	Keyword    string // "class", "interface" or "object"
+	Name       string
+	Modifiers  map[string]bool
+	Supertypes []supertype
+	Variables  []types.JavaVariable
+	Methods    []types.JavaMethod
+	Entries    []types.CustomByteSlice // Enum entries
+	Init       []byte                  // Bodies of the init blocks, which run with the primary constructor
+}
+
+func parseFile(file types.File) types.FileResponse {
+	p := parser{
+		tokens: tokenize(file.Code),
+		code:   string(file.Code),
+		pkg:    []byte(defaultPackage),
+	}
+
+	p.parseDeclarations(0, len(p.tokens))
+
+	return types.FileResponse{
+		Package: p.pkg,
+		Imports: p.imports,
+		Data:    p.data,
+	}
+}
+
+// Parses the top level of a file. Functions and properties outside of classes are not part of the diagram.
+func (p *parser) parseDeclarations(start, end int) {
+	for i := start; i < end; {
+		// File annotations come before the package header: @file:JvmName("Zoo")
+		modifiers, keyword := p.readModifiers(i)
+		next := keyword + 1
+
+		switch {
+		case p.is(keyword, "package"):
+			var name string
+			name, next = p.readQualifiedName(keyword + 1)
+			p.pkg = []byte(name)
+		case p.is(keyword, "import"):
+			// Aliases are not kept: import zoo.Dog as Pet
+			var name string
+			name, next = p.readQualifiedName(keyword + 1)
+			p.imports = append(p.imports, []byte(name))
+
+			if p.is(next, "as") {
+				next += 2
+			}
+		case p.is(keyword, "class") || p.is(keyword, "interface") || p.is(keyword, "object"):
+			next = p.parseClass(keyword, end, modifiers, nil)
+		case keyword < end:
+			next = p.memberEnd(keyword, end)
+		}
+
+		if next <= i {
+			next = i + 1
+		}
+
+		i = next
+	}
+}
+
+// Parses the class, interface or object whose keyword is at index i and returns the index after it.
+// Companion objects add their members to the owner as static members.
+func (p *parser) parseClass(i, end int, modifiers map[string]bool, owner *declaration) int {
+	d := declaration{Keyword: p.tokens[i].Text, Modifiers: modifiers}
+	i++
+
+	if i < end && p.tokens[i].Kind == identifierToken && !p.is(i, "constructor") && !p.is(i, "where") {
+		d.Name = p.tokens[i].Text
+		i++
+	} else if modifiers["companion"] {
+		d.Name = "Companion"
+	} else {
+		return i
+	}
+
+	if p.is(i, "<") {
+		i = p.skipAngles(i)
+	}
+
+	// Primary constructor: class Dog private constructor(val name: String)
+	constructorModifiers, next := p.readModifiers(i)
+	if p.is(next, "constructor") {
+		i = next + 1
+	} else {
+		constructorModifiers = nil
+	}
+
+	var (
+		primaryConstructor    []parameter
+		hasPrimaryConstructor bool
+	)
+
+	if p.is(i, "(") {
+		primaryConstructor, i = p.parseParameters(i)
+		hasPrimaryConstructor = true
+	}
+
+	if p.is(i, ":") {
+		d.Supertypes, i = p.parseSupertypes(i+1, end)
+	}
+
+	// Generic constraints: where T : Comparable<T>
+	if p.is(i, "where") {
+		for i++; i < end && !p.is(i, "{") && (!p.tokens[i].NewlineBefore || p.is(i-1, ",")); i++ {
+		}
+	}
+
+	// Nodes keep the order of their declarations, so nested classes come after the class they are declared in
+	index := -1
+	if !modifiers["companion"] || owner == nil {
+		index = len(p.data)
+		p.data = append(p.data, nil)
+	}
+
+	if p.is(i, "{") {
+		bodyEnd := p.skipBalanced(i)
+
+		nestedOwner := &d
+		if index == -1 {
+			nestedOwner = owner
+		}
+
+		p.parseBody(i+1, bodyEnd-1, &d, nestedOwner)
+		i = bodyEnd
+	}
+
+	var variables []types.JavaVariable
+	for _, parameter := range primaryConstructor {
+		if parameter.Property == "" {
+			continue
+		}
+
+		variables = append(variables, types.JavaVariable{
+			Name:           []byte(parameter.Name),
+			Type:           []byte(parameter.Type),
+			Value:          getValue(parameter.Value),
+			AccessModifier: getAccessModifier(parameter.Modifiers),
+			Final:          parameter.Property == "val",
+		})
+	}
+
+	d.Variables = append(variables, d.Variables...)
+
+	// Init blocks run with the primary constructor. Without one, they run with every secondary constructor.
+	if !hasPrimaryConstructor && len(d.Init) != 0 {
+		var hasSecondaryConstructor bool
+
+		for j := range d.Methods {
+			if string(d.Methods[j].Name) == d.Name {
+				d.Methods[j].Functionality = append(d.Methods[j].Functionality, d.Init...)
+				hasSecondaryConstructor = true
+			}
+		}
+
+		if hasSecondaryConstructor {
+			d.Init = nil
+		}
+	}
+
+	if hasPrimaryConstructor || len(d.Init) != 0 {
+		d.Methods = append([]types.JavaMethod{{
+			Name:           []byte(d.Name),
+			IsConstructor:  true,
+			AccessModifier: getAccessModifier(constructorModifiers),
+			Parameters:     getMethodParameters(primaryConstructor),
+			Functionality:  d.Init,
+		}}, d.Methods...)
+	}
+
+	// Members of a companion object are reached through the class that owns it, like static members
+	if index == -1 {
+		for j := range d.Variables {
+			d.Variables[j].Static = true
+		}
+
+		for j := range d.Methods {
+			d.Methods[j].Static = true
+		}
+
+		owner.Variables = append(owner.Variables, d.Variables...)
+		owner.Methods = append(owner.Methods, d.Methods...)
+		return i
+	}
+
+	p.data[index] = p.getNode(d, owner)
+
+	return i
+}
+
+// Returns the Java class of a declaration. Data classes and sealed classes keep their keyword as a stereotype, objects are singletons.
+func (p *parser) getNode(d declaration, owner *declaration) any {
+	var definedWithin []byte
+	if owner != nil {
+		definedWithin = []byte(owner.Name)
+	}
+
+	var stereotypes []types.CustomByteSlice
+	for _, modifier := range stereotypeModifiers {
+		if d.Modifiers[modifier] {
+			stereotypes = append(stereotypes, []byte(modifier))
+		}
+	}
+
+	if d.Keyword == "object" {
+		stereotypes = append(stereotypes, []byte("singleton"))
+	}
+
+	var extends, implements []types.CustomByteSlice
+	for _, s := range d.Supertypes {
+		if s.Call || d.Keyword == "interface" {
+			extends = append(extends, []byte(s.Name))
+		} else {
+			implements = append(implements, []byte(s.Name))
+		}
+	}
+
+	switch {
+	case d.Modifiers["annotation"]:
+		// The properties of the primary constructor are the elements of the annotation
+		var methods []types.JavaMethod
+		for _, variable := range d.Variables {
+			methods = append(methods, types.JavaMethod{
+				Type:           variable.Type,
+				Name:           variable.Name,
+				AccessModifier: []byte("public"),
+				Abstract:       true,
+			})
+		}
+
+		return types.JavaAnnotation{
+			DefinedWithin: definedWithin,
+			Package:       p.pkg,
+			Name:          []byte(d.Name),
+			Stereotypes:   stereotypes,
+			Methods:       methods,
+		}
+	case d.Keyword == "interface":
+		return types.JavaInterface{
+			DefinedWithin: definedWithin,
+			Package:       p.pkg,
+			Name:          []byte(d.Name),
+			Stereotypes:   stereotypes,
+			Extends:       extends,
+			Variables:     d.Variables,
+			Methods:       d.Methods,
+		}
+	case d.Modifiers["enum"]:
+		return types.JavaEnum{
+			DefinedWithin: definedWithin,
+			Package:       p.pkg,
+			Name:          []byte(d.Name),
+			Stereotypes:   stereotypes,
+			Declarations:  d.Entries,
+			Implements:    implements,
+		}
+	case d.Modifiers["abstract"] || d.Modifiers["sealed"]:
+		// Sealed classes are abstract, their subclasses extend them like any other class
+		return types.JavaAbstract{
+			DefinedWithin: definedWithin,
+			Package:       p.pkg,
+			Name:          []byte(d.Name),
+			Stereotypes:   stereotypes,
+			Implements:    implements,
+			Extends:       extends,
+			Variables:     d.Variables,
+			Methods:       d.Methods,
+		}
+	}
+
+	return types.JavaClass{
+		DefinedWithin: definedWithin,
+		Package:       p.pkg,
+		Name:          []byte(d.Name),
+		Stereotypes:   stereotypes,
+		Implements:    implements,
+		Extends:       extends,
+		Variables:     d.Variables,
+		Methods:       d.Methods,
+	}
+}
+
+// Parses the members between the braces of a class body. Nested classes are defined within owner.
+func (p *parser) parseBody(start, end int, d *declaration, owner *declaration) {
+	i := start
+	if d.Modifiers["enum"] {
+		i = p.parseEntries(start, end, d)
+	}
+
+	for i < end {
+		if p.is(i, ";") {
+			i++
+			continue
+		}
+
+		modifiers, keyword := p.readModifiers(i)
+		next := keyword + 1
+
+		switch {
+		case keyword >= end:
+			next = end
+		case p.is(keyword, "class") || p.is(keyword, "interface") || p.is(keyword, "object"):
+			next = p.parseClass(keyword, end, modifiers, owner)
+		case p.is(keyword, "fun"):
+			next = p.parseFunction(keyword, end, modifiers, d)
+		case p.is(keyword, "val") || p.is(keyword, "var"):
+			next = p.parseProperty(keyword, end, modifiers, d)
+		case p.is(keyword, "constructor"):
+			next = p.parseConstructor(keyword, end, modifiers, d)
+		case p.is(keyword, "init") && p.is(keyword+1, "{"):
+			next = p.skipBalanced(keyword + 1)
+			d.Init = append(d.Init, p.getFunctionality(keyword+2, next-1)...)
+		default:
+			next = p.memberEnd(keyword, end)
+		}
+
+		if next <= i {
+			next = i + 1
+		}
+
+		i = next
+	}
+}
+
+// Parses the entries at the start of an enum class body and returns the index of its first member
+func (p *parser) parseEntries(start, end int, d *declaration) int {
+	i := start
+
+	for i < end {
+		for p.is(i, "@") {
+			i = p.skipAnnotation(i)
+		}
+
+		if i >= end || p.tokens[i].Kind != identifierToken || p.isKeyword(i) {
+			break
+		}
+
+		d.Entries = append(d.Entries, []byte(p.tokens[i].Text))
+		i++
+
+		// Constructor arguments and class bodies of entries: RED("#f00") { override fun dark() = true }
+		if p.is(i, "(") {
+			i = p.skipBalanced(i)
+		}
+
+		if p.is(i, "{") {
+			i = p.skipBalanced(i)
+		}
+
+		if !p.is(i, ",") {
+			break
+		}
+
+		i++
+	}
+
+	if p.is(i, ";") {
+		i++
+	}
+
+	return i
+}
+
+// Parses a function whose fun keyword is at index i. Extension functions are not members of the class.
+func (p *parser) parseFunction(i, end int, modifiers map[string]bool, d *declaration) int {
+	i++
+	if p.is(i, "<") {
+		i = p.skipAngles(i)
+	}
+
+	name, next := p.readType(i)
+	if !p.is(next, "(") {
+		return p.memberEnd(i, end)
+	}
+
+	parameters, next := p.parseParameters(next)
+
+	var returnType string
+	if p.is(next, ":") {
+		returnType, next = p.readType(next + 1)
+	}
+
+	if p.is(next, "where") {
+		for next++; next < end && !p.is(next, "{") && !p.is(next, "=") && (!p.tokens[next].NewlineBefore || p.is(next-1, ",")); next++ {
+		}
+	}
+
+	// Functions return Unit when no type is written, unless the type of their expression body is inferred
+	if returnType == "" && !p.is(next, "=") {
+		returnType = "Unit"
+	}
+
+	var functionality []byte
+
+	switch {
+	case p.is(next, "{"):
+		bodyEnd := p.skipBalanced(next)
+		functionality = p.getFunctionality(next+1, bodyEnd-1)
+		next = bodyEnd
+	case p.is(next, "="):
+		bodyEnd := p.memberEnd(next, end)
+		functionality = p.getFunctionality(next+1, bodyEnd)
+		next = bodyEnd
+	}
+
+	if strings.ContainsAny(name, ".<") {
+		return next
+	}
+
+	d.Methods = append(d.Methods, types.JavaMethod{
+		Type:           []byte(returnType),
+		Name:           []byte(name),
+		AccessModifier: getAccessModifier(modifiers),
+		Parameters:     getMethodParameters(parameters),
+		Abstract:       modifiers["abstract"],
+		Final:          modifiers["final"],
+		Functionality:  functionality,
+	})
+
+	return next
+}
+
+// Parses a secondary constructor: constructor(name: String) : this(name, 0) { ... }
+func (p *parser) parseConstructor(i, end int, modifiers map[string]bool, d *declaration) int {
+	if !p.is(i+1, "(") {
+		return p.memberEnd(i, end)
+	}
+
+	parameters, next := p.parseParameters(i + 1)
+
+	functionStart := next
+	if p.is(next, ":") {
+		next += 2
+		if p.is(next, "(") {
+			next = p.skipBalanced(next)
+		}
+	}
+
+	if p.is(next, "{") {
+		next = p.skipBalanced(next)
+	}
+
+	d.Methods = append(d.Methods, types.JavaMethod{
+		Name:           []byte(d.Name),
+		IsConstructor:  true,
+		AccessModifier: getAccessModifier(modifiers),
+		Parameters:     getMethodParameters(parameters),
+		Functionality:  p.getFunctionality(functionStart, next),
+	})
+
+	return next
+}
+
+// Parses a property whose val or var keyword is at index i, including its getter and setter.
+// Extension properties are not members of the class.
+func (p *parser) parseProperty(i, end int, modifiers map[string]bool, d *declaration) int {
+	keyword := p.tokens[i].Text
+	propertyEnd := p.memberEnd(i, end)
+
+	// Getters and setters can follow on the next lines: private set
+	for {
+		_, accessor := p.readModifiers(propertyEnd)
+		if accessor >= end || !p.is(accessor, "get") && !p.is(accessor, "set") {
+			break
+		}
+
+		propertyEnd = p.memberEnd(accessor, end)
+	}
+
+	i++
+	if p.is(i, "<") {
+		i = p.skipAngles(i)
+	}
+
+	name, next := p.readType(i)
+	if strings.ContainsAny(name, ".<") || name == "" {
+		return propertyEnd
+	}
+
+	var variableType, value string
+	if p.is(next, ":") {
+		variableType, next = p.readType(next + 1)
+	}
+
+	valueEnd := p.memberEnd(next, end)
+	if valueEnd > propertyEnd {
+		valueEnd = propertyEnd
+	}
+
+	switch {
+	case p.is(next, "=") && next+1 < valueEnd:
+		value = p.getSource(next+1, valueEnd)
+
+		// The type of a property that is set to a new instance is the class of the instance: val dog = Dog()
+		if variableType == "" {
+			if valueType, valueTypeEnd := p.readType(next + 1); p.is(valueTypeEnd, "(") && isClassName(valueType) {
+				variableType = valueType
+			}
+		}
+	case p.is(next, "by") && next+1 < valueEnd:
+		value = "by " + p.getSource(next+1, valueEnd)
+	}
+
+	d.Variables = append(d.Variables, types.JavaVariable{
+		Type:           []byte(variableType),
+		Name:           []byte(name),
+		Value:          getValue(value),
+		AccessModifier: getAccessModifier(modifiers),
+		Static:         modifiers["const"],
+		Final:          keyword == "val",
+	})
+
+	return propertyEnd
+}
+
+// Parses the parameters between the parentheses at index i and returns the index after the closing parenthesis
+func (p *parser) parseParameters(i int) ([]parameter, int) {
+	var (
+		response []parameter
+		end      = p.skipBalanced(i) - 1
+	)
+
+	for i++; i < end; {
+		modifiers, next := p.readModifiers(i)
+
+		var property string
+		if p.is(next, "val") || p.is(next, "var") {
+			property = p.tokens[next].Text
+			next++
+		}
+
+		if next >= end {
+			break
+		}
+
+		current := parameter{Name: p.tokens[next].Text, Property: property, Modifiers: modifiers}
+		next++
+
+		if p.is(next, ":") {
+			current.Type, next = p.readType(next + 1)
+		}
+
+		if p.is(next, "=") {
+			valueStart := next + 1
+			for next = valueStart; next < end && !p.is(next, ","); next = p.skipToken(next) {
+			}
+
+			if valueStart < next {
+				current.Value = p.getSource(valueStart, next)
+			}
+		}
+
+		response = append(response, current)
+
+		for next < end && !p.is(next, ",") {
+			next = p.skipToken(next)
+		}
+
+		i = next + 1
+	}
+
+	return response, end + 1
+}
+
+// Parses the supertypes after the colon of a class header: Animal("dog"), Walker, Named by name
+func (p *parser) parseSupertypes(i, end int) ([]supertype, int) {
+	var response []supertype
+
+	for i < end {
+		for p.is(i, "@") {
+			i = p.skipAnnotation(i)
+		}
+
+		name, next := p.readType(i)
+		if name == "" {
+			break
+		}
+
+		current := supertype{Name: removeTypeArguments(name)}
+		if p.is(next, "(") && !p.tokens[next].NewlineBefore {
+			current.Call = true
+			next = p.skipBalanced(next)
+		}
+
+		// Delegation: Walker by walker
+		if p.is(next, "by") {
+			for next++; next < end && !p.is(next, ",") && !p.is(next, "{") && !p.is(next, "where") &&
+				(!p.tokens[next].NewlineBefore || p.continues(next)); next = p.skipToken(next) {
+			}
+		}
+
+		response = append(response, current)
+		i = next
+
+		if !p.is(i, ",") {
+			break
+		}
+
+		i++
+	}
+
+	return response, i
+}
+
+// Reads a type such as "Map<String,Dog>?", "(Int)->Unit" or "zoo.Dog" and returns it without spaces,
+// the way that Java types are written
+func (p *parser) readType(i int) (string, int) {
+	for p.is(i, "@") {
+		i = p.skipAnnotation(i)
+	}
+
+	start := i
+	if p.is(i, "suspend") && p.is(i+1, "(") {
+		i++
+	}
+
+	if p.is(i, "(") {
+		i = p.skipBalanced(i)
+	} else {
+		for i < len(p.tokens) && p.tokens[i].Kind == identifierToken {
+			i++
+
+			if p.is(i, "<") {
+				i = p.skipAngles(i)
+			}
+
+			if !p.is(i, ".") || i+1 >= len(p.tokens) || p.tokens[i+1].Kind != identifierToken {
+				break
+			}
+
+			i++
+		}
+
+		// Function types with a receiver: Dog.() -> Unit
+		if p.is(i, ".") && p.is(i+1, "(") {
+			i = p.skipBalanced(i + 1)
+		}
+	}
+
+	for p.is(i, "?") {
+		i++
+	}
+
+	if i > start && p.is(i, "->") {
+		_, i = p.readType(i + 1)
+	}
+
+	var builder strings.Builder
+	for j := start; j < i; j++ {
+		if j > start && p.tokens[j].Kind == identifierToken && p.tokens[j-1].Kind == identifierToken {
+			builder.WriteByte(' ')
+		}
+
+		builder.WriteString(p.tokens[j].Text)
+	}
+
+	return builder.String(), i
+}
+
+// Reads a dotted name such as a package or an import: zoo.animals.*
+func (p *parser) readQualifiedName(i int) (string, int) {
+	var builder strings.Builder
+
+	for i < len(p.tokens) && (p.tokens[i].Kind == identifierToken || p.is(i, "*")) {
+		builder.WriteString(p.tokens[i].Text)
+		i++
+
+		if !p.is(i, ".") || p.tokens[i].NewlineBefore {
+			break
+		}
+
+		builder.WriteByte('.')
+		i++
+	}
+
+	return builder.String(), i
+}
+
+// Reads annotations and modifiers and returns the modifiers and the index of the token after them
+func (p *parser) readModifiers(i int) (map[string]bool, int) {
+	response := make(map[string]bool)
+
+	for i < len(p.tokens) {
+		if p.is(i, "@") {
+			i = p.skipAnnotation(i)
+			continue
+		}
+
+		// fun interface Listener
+		if p.is(i, "fun") && p.is(i+1, "interface") {
+			i++
+			continue
+		}
+
+		if _, ok := modifierKeywords[p.tokens[i].Text]; !ok || p.tokens[i].Kind != identifierToken {
+			break
+		}
+
+		// Modifiers are followed by a declaration, not by punctuation: val data = 1
+		if i+1 < len(p.tokens) && p.tokens[i+1].Kind != identifierToken && !p.is(i+1, "@") {
+			break
+		}
+
+		response[p.tokens[i].Text] = true
+		i++
+	}
+
+	return response, i
+}
+
+// Skips an annotation such as @Inject, @field:JsonProperty("name") or @[Inject Named("a")]
+func (p *parser) skipAnnotation(i int) int {
+	i++
+
+	// Use-site targets: @get:JvmName("name")
+	if p.is(i+1, ":") && p.tokens[i].Kind == identifierToken {
+		i += 2
+	}
+
+	if p.is(i, "[") {
+		return p.skipBalanced(i)
+	}
+
+	_, i = p.readQualifiedName(i)
+
+	if p.is(i, "<") {
+		i = p.skipAngles(i)
+	}
+
+	if p.is(i, "(") && !p.tokens[i].NewlineBefore {
+		i = p.skipBalanced(i)
+	}
+
+	return i
+}
+
+// Returns the index where the member that starts at index i ends. Members end at a semicolon or at
+// a line break that does not continue the statement.
+func (p *parser) memberEnd(i, end int) int {
+	for j := i; j < end; {
+		if j > i && p.tokens[j].NewlineBefore && !p.continues(j) {
+			return j
+		}
+
+		if p.is(j, ";") {
+			return j
+		}
+
+		j = p.skipToken(j)
+	}
+
+	return end
+}
+
+// Returns true when the line that starts at index i continues the statement of the previous line
+func (p *parser) continues(i int) bool {
+	if _, ok := continuingLineStarts[p.tokens[i].Text]; ok && p.tokens[i].Kind != stringToken {
+		return true
+	}
+
+	_, ok := continuingLineEnds[p.tokens[i-1].Text]
+	return ok && p.tokens[i-1].Kind != stringToken
+}
+
+// Returns the index after the token at index i, or after its closing bracket if it opens one
+func (p *parser) skipToken(i int) int {
+	if p.is(i, "(") || p.is(i, "[") || p.is(i, "{") {
+		return p.skipBalanced(i)
+	}
+
+	return i + 1
+}
+
+// Returns the index after the bracket that closes the one at index i
+func (p *parser) skipBalanced(i int) int {
+	depth := 0
+
+	for ; i < len(p.tokens); i++ {
+		if p.tokens[i].Kind != punctuationToken {
+			continue
+		}
+
+		switch p.tokens[i].Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return len(p.tokens)
+}
+
+// Returns the index after the angle bracket that closes the one at index i
+func (p *parser) skipAngles(i int) int {
+	depth := 0
+
+	for ; i < len(p.tokens); i++ {
+		switch p.tokens[i].Text {
+		case "<":
+			depth++
+		case ">":
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case "{", "}", ";", "=":
+			return i
+		case "(":
+			i = p.skipBalanced(i) - 1
+		}
+	}
+
+	return len(p.tokens)
+}
+
+// Returns the code of a function body. Tokens are separated by spaces and strings are left empty,
+// so that the names in strings are not read as classes.
+func (p *parser) getFunctionality(start, end int) []byte {
+	var builder strings.Builder
+
+	for i := start; i < end; i++ {
+		if i > start {
+			builder.WriteByte(' ')
+		}
+
+		if p.tokens[i].Kind == stringToken {
+			builder.WriteString(`""`)
+			continue
+		}
+
+		builder.WriteString(p.tokens[i].Text)
+	}
+
+	return []byte(builder.String())
+}
+
+// Returns the code of the tokens from start to end as it is written, on a single line
+func (p *parser) getSource(start, end int) string {
+	return strings.Join(strings.Fields(p.code[p.tokens[start].Start:p.tokens[end-1].End]), " ")
+}
+
+func (p *parser) is(i int, text string) bool {
+	return i >= 0 && i < len(p.tokens) && p.tokens[i].Text == text && p.tokens[i].Kind != stringToken
+}
+
+// Returns true when the token at index i starts a member instead of naming an enum entry
+func (p *parser) isKeyword(i int) bool {
+	switch p.tokens[i].Text {
+	case "fun", "val", "var", "class", "interface", "object", "init", "constructor":
+		return true
+	}
+
+	_, ok := modifierKeywords[p.tokens[i].Text]
+	return ok && i+1 < len(p.tokens) && p.tokens[i+1].Kind == identifierToken
+}
+
+func getAccessModifier(modifiers map[string]bool) []byte {
+	switch {
+	case modifiers["private"]:
+		return []byte("private")
+	case modifiers["protected"]:
+		return []byte("protected")
+	}
+
+	// Kotlin members are public by default, internal members are public inside of their module
+	return []byte("public")
+}
+
+func getMethodParameters(parameters []parameter) []types.JavaMethodParameter {
+	var response []types.JavaMethodParameter
+
+	for _, parameter := range parameters {
+		response = append(response, types.JavaMethodParameter{
+			Type:    []byte(parameter.Type),
+			Name:    []byte(parameter.Name),
+			Varargs: parameter.Modifiers["vararg"],
+		})
+	}
+
+	return response
+}
+
+func getValue(value string) []byte {
+	if value == "" {
+		return nil
+	}
+
+	return []byte(value)
+}
+
+// Returns true when the last name of a type starts with an uppercase letter: zoo.Dog
+func isClassName(name string) bool {
+	name = name[strings.LastIndexByte(name, '.')+1:]
+	return name != "" && unicode.IsUpper(rune(name[0])) && !strings.ContainsAny(name, "()")
+}
+
+// List<Dog> -> List
+func removeTypeArguments(name string) string {
+	if index := strings.IndexByte(name, '<'); index != -1 {
+		return name[:index]
+	}
+
+	return name
+}
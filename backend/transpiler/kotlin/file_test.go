@@ -0,0 +1,192 @@
+package kotlin
+
+import (
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestParseFile(t *testing.T) {
+	file := types.File{
+		Name:      "Shapes",
+		Extension: "kt",
+		Code: []byte(`
+@file:JvmName("Shapes")
+package zoo.shapes
+
+import zoo.util.Logger
+import zoo.paint.*
+import kotlin.math.PI as Pi
+
+sealed class Shape(val name: String) : Drawable {
+	abstract fun area(): Double
+
+	data class Circle(val radius: Double, private var color: Color? = null) : Shape("circle") {
+		override fun area() = Pi * radius * radius
+	}
+
+	object Empty : Shape("empty") {
+		override fun area(): Double = 0.0
+	}
+}
+
+class Canvas private constructor(
+	internal val width: Int,
+	height: Int,
+) : Shape("canvas"), Drawable by Pencil() {
+	lateinit var logger: Logger
+	var shapes: MutableList<Shape> = mutableListOf()
+		private set
+	val brush = Brush(width)
+	val area: Double
+		get() = width.toDouble()
+
+	init {
+		logger = Logger("canvas")
+	}
+
+	constructor(size: Int) : this(size, size)
+
+	companion object Factory {
+		const val MAX = 10
+		fun create(vararg shapes: Shape): Canvas = Canvas(MAX)
+	}
+
+	fun String.shout() = uppercase()
+
+	fun interface Listener {
+		fun onDraw(canvas: Canvas, callback: (Shape) -> Unit)
+	}
+}
+
+enum class Color(val hex: String) : Drawable {
+	RED("#f00"),
+	GREEN("#0f0") {
+		override fun draw() {}
+	},
+	BLUE("#00f");
+
+	override fun draw() {}
+}
+
+annotation class Tag(val name: String, val priority: Int = 0)
+
+fun topLevel() = Canvas(1)
+`),
+	}
+
+	response := parseFile(file)
+
+	if string(response.Package) != "zoo.shapes" || len(response.Imports) != 3 || string(response.Imports[1]) != "zoo.paint.*" || string(response.Imports[2]) != "kotlin.math.PI" {
+		t.Errorf("incorrect package or imports: %s %s", response.Package, response.Imports)
+	}
+
+	if len(response.Data) != 7 {
+		t.Fatalf("incorrect number of classes.\nexpected: 7\ngot: %d\n", len(response.Data))
+	}
+
+	shape, ok := response.Data[0].(types.JavaAbstract)
+	if !ok {
+		t.Fatalf("sealed class Shape is not abstract")
+	}
+
+	if len(shape.Stereotypes) != 1 || string(shape.Stereotypes[0]) != "sealed" {
+		t.Errorf("incorrect stereotypes of Shape: %s", shape.Stereotypes)
+	}
+
+	if len(shape.Implements) != 1 || string(shape.Implements[0]) != "Drawable" || len(shape.Extends) != 0 {
+		t.Errorf("incorrect supertypes of Shape: %s %s", shape.Implements, shape.Extends)
+	}
+
+	if len(shape.Methods) != 2 || string(shape.Methods[0].Name) != "Shape" || !shape.Methods[1].Abstract || string(shape.Methods[1].Type) != "Double" {
+		t.Errorf("incorrect methods of Shape")
+	}
+
+	circle, ok := response.Data[1].(types.JavaClass)
+	if !ok || string(circle.DefinedWithin) != "Shape" || len(circle.Extends) != 1 || string(circle.Extends[0]) != "Shape" ||
+		len(circle.Stereotypes) != 1 || string(circle.Stereotypes[0]) != "data" {
+		t.Fatalf("incorrect data class")
+	}
+
+	if len(circle.Variables) != 2 || string(circle.Variables[1].Type) != "Color?" || string(circle.Variables[1].Value) != "null" ||
+		string(circle.Variables[1].AccessModifier) != "private" || circle.Variables[1].Final || !circle.Variables[0].Final {
+		t.Errorf("incorrect primary constructor properties of Circle")
+	}
+
+	empty, ok := response.Data[2].(types.JavaClass)
+	if !ok || string(empty.DefinedWithin) != "Shape" || len(empty.Stereotypes) != 1 || string(empty.Stereotypes[0]) != "singleton" ||
+		len(empty.Variables) != 0 || len(empty.Methods) != 1 || empty.Methods[0].Static {
+		t.Errorf("incorrect object")
+	}
+
+	canvas, ok := response.Data[3].(types.JavaClass)
+	if !ok {
+		t.Fatalf("Canvas is not a class")
+	}
+
+	if len(canvas.Extends) != 1 || string(canvas.Extends[0]) != "Shape" || len(canvas.Implements) != 1 || string(canvas.Implements[0]) != "Drawable" {
+		t.Errorf("incorrect supertypes of Canvas: %s %s", canvas.Extends, canvas.Implements)
+	}
+
+	expectedVariables := []types.JavaVariable{
+		{Name: []byte("width"), Type: []byte("Int"), AccessModifier: []byte("public"), Final: true},
+		{Name: []byte("logger"), Type: []byte("Logger"), AccessModifier: []byte("public")},
+		{Name: []byte("shapes"), Type: []byte("MutableList<Shape>"), Value: []byte("mutableListOf()"), AccessModifier: []byte("public")},
+		{Name: []byte("brush"), Type: []byte("Brush"), Value: []byte("Brush(width)"), AccessModifier: []byte("public"), Final: true},
+		{Name: []byte("area"), Type: []byte("Double"), AccessModifier: []byte("public"), Final: true},
+		{Name: []byte("MAX"), Value: []byte("10"), AccessModifier: []byte("public"), Static: true, Final: true},
+	}
+
+	if len(canvas.Variables) != len(expectedVariables) {
+		t.Fatalf("incorrect number of variables.\nexpected: %d\ngot: %d\n", len(expectedVariables), len(canvas.Variables))
+	}
+
+	for index, expected := range expectedVariables {
+		actual := canvas.Variables[index]
+		if string(actual.Name) != string(expected.Name) || string(actual.Type) != string(expected.Type) || string(actual.Value) != string(expected.Value) ||
+			string(actual.AccessModifier) != string(expected.AccessModifier) || actual.Static != expected.Static || actual.Final != expected.Final {
+			t.Errorf("incorrect variable.\nexpected:\n%s %s %s %s\ngot:\n%s %s %s %s\n",
+				expected.AccessModifier, expected.Name, expected.Type, expected.Value,
+				actual.AccessModifier, actual.Name, actual.Type, actual.Value)
+		}
+	}
+
+	expectedMethods := []types.JavaMethod{
+		{Name: []byte("Canvas"), AccessModifier: []byte("private")},
+		{Name: []byte("Canvas"), AccessModifier: []byte("public")},
+		{Name: []byte("create"), Type: []byte("Canvas"), AccessModifier: []byte("public"), Static: true},
+	}
+
+	if len(canvas.Methods) != len(expectedMethods) {
+		t.Fatalf("incorrect number of methods.\nexpected: %d\ngot: %d\n", len(expectedMethods), len(canvas.Methods))
+	}
+
+	for index, expected := range expectedMethods {
+		actual := canvas.Methods[index]
+		if string(actual.Name) != string(expected.Name) || string(actual.Type) != string(expected.Type) ||
+			string(actual.AccessModifier) != string(expected.AccessModifier) || actual.Static != expected.Static {
+			t.Errorf("incorrect method.\nexpected:\n%s %s %s\ngot:\n%s %s %s\n", expected.AccessModifier, expected.Name, expected.Type, actual.AccessModifier, actual.Name, actual.Type)
+		}
+	}
+
+	if constructor := canvas.Methods[0]; len(constructor.Parameters) != 2 || string(constructor.Parameters[1].Name) != "height" || string(constructor.Functionality) != `logger = Logger ( "" )` {
+		t.Errorf("incorrect primary constructor: %s", constructor.Functionality)
+	}
+
+	listener, ok := response.Data[4].(types.JavaInterface)
+	if !ok || string(listener.DefinedWithin) != "Canvas" || len(listener.Methods) != 1 || string(listener.Methods[0].Type) != "Unit" ||
+		string(listener.Methods[0].Parameters[1].Type) != "(Shape)->Unit" {
+		t.Errorf("incorrect fun interface")
+	}
+
+	color, ok := response.Data[5].(types.JavaEnum)
+	if !ok || len(color.Declarations) != 3 || string(color.Declarations[2]) != "BLUE" || len(color.Implements) != 1 {
+		t.Errorf("incorrect enum class")
+	}
+
+	tag, ok := response.Data[6].(types.JavaAnnotation)
+	if !ok || len(tag.Variables) != 0 || len(tag.Methods) != 2 || string(tag.Methods[1].Name) != "priority" || string(tag.Methods[1].Type) != "Int" ||
+		len(tag.Methods[1].Parameters) != 0 {
+		t.Errorf("incorrect annotation class")
+	}
+}
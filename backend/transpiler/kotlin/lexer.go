@@ -0,0 +1,194 @@
+package kotlin
+
+import "strings"
+
+type tokenKind int
+
+const (
+	identifierToken tokenKind = iota
+	punctuationToken
+	stringToken
+	numberToken
+)
+
+type token struct {
+	Kind          tokenKind
+	Text          string
+	NewlineBefore bool // True when a line break separates this token from the previous one
+	Start         int  // Offset of the token in the code
+	End           int
+}
+
+// Punctuation made of more than one character that matters to the parser.
+// Angle brackets are kept apart so that nested type arguments close one by one: List<List<Int>>
+var multiCharPunctuation = []string{"?.", "?:", "->", "::", "..", "==", "!=", "&&", "||", "!!"}
+
+// Splits code into tokens. Comments and whitespace are removed. Statements end at line breaks
+// in Kotlin, so every token records whether a line break comes before it.
+func tokenize(code []byte) []token {
+	var (
+		response      []token
+		text          = string(code)
+		newlineBefore = true
+	)
+
+	appendToken := func(kind tokenKind, start, end int) {
+		response = append(response, token{Kind: kind, Text: text[start:end], NewlineBefore: newlineBefore, Start: start, End: end})
+		newlineBefore = false
+	}
+
+	// Scripts may start with a shebang line
+	i := 0
+	if strings.HasPrefix(text, "#!") {
+		for i < len(text) && text[i] != '\n' {
+			i++
+		}
+	}
+
+	for i < len(text) {
+		c := text[i]
+
+		switch {
+		case c == '\n':
+			newlineBefore = true
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			i++
+		case strings.HasPrefix(text[i:], "//"):
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(text[i:], "/*"):
+			i = skipComment(text, i)
+		case c == '"' || c == '\'':
+			end := skipString(text, i)
+			appendToken(stringToken, i, end)
+			i = end
+		case c == '`':
+			// Names in backticks, such as `is` or `my test`, are written without the backticks
+			end := strings.IndexByte(text[i+1:], '`')
+			if end == -1 {
+				i = len(text)
+				break
+			}
+
+			appendToken(identifierToken, i+1, i+1+end)
+			i += end + 2
+		case isIdentifierStart(c):
+			end := i + 1
+			for end < len(text) && isIdentifierPart(text[end]) {
+				end++
+			}
+
+			appendToken(identifierToken, i, end)
+			i = end
+		case c >= '0' && c <= '9':
+			end := i + 1
+			for end < len(text) && (isIdentifierPart(text[end]) || text[end] == '.' && end+1 < len(text) && text[end+1] >= '0' && text[end+1] <= '9') {
+				end++
+			}
+
+			appendToken(numberToken, i, end)
+			i = end
+		default:
+			end := i + 1
+			for _, punctuation := range multiCharPunctuation {
+				if strings.HasPrefix(text[i:], punctuation) {
+					end = i + len(punctuation)
+					break
+				}
+			}
+
+			appendToken(punctuationToken, i, end)
+			i = end
+		}
+	}
+
+	return response
+}
+
+// Returns the index after the block comment that starts at start. Block comments nest in Kotlin.
+func skipComment(text string, start int) int {
+	depth := 0
+
+	for i := start; i < len(text); i++ {
+		if strings.HasPrefix(text[i:], "/*") {
+			depth++
+			i++
+		} else if strings.HasPrefix(text[i:], "*/") {
+			depth--
+			i++
+
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return len(text)
+}
+
+// Returns the index after the string or character literal that starts at start.
+// Handles templates ("${value}") and raw strings ("""...""").
+func skipString(text string, start int) int {
+	raw := strings.HasPrefix(text[start:], `"""`)
+	quote := text[start]
+
+	i := start + 1
+	if raw {
+		i = start + 3
+	}
+
+	for ; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case c == '\\' && !raw:
+			i++
+		case c == '$' && quote == '"' && i+1 < len(text) && text[i+1] == '{':
+			i = skipTemplate(text, i+1) - 1
+		case raw && strings.HasPrefix(text[i:], `"""`):
+			// Raw strings may end with more than three quotes, the extra quotes belong to the string
+			for i < len(text) && text[i] == '"' {
+				i++
+			}
+
+			return i
+		case !raw && c == quote:
+			return i + 1
+		case !raw && c == '\n':
+			return i
+		}
+	}
+
+	return len(text)
+}
+
+// Returns the index after the template expression "{...}" that starts at start
+func skipTemplate(text string, start int) int {
+	depth := 0
+
+	for i := start; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '"' || c == '\'':
+			i = skipString(text, i) - 1
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return len(text)
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,46 @@
+package kotlin
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	type TokenizeTest struct {
+		Input  string
+		Output []string
+	}
+
+	var tests = []TokenizeTest{
+		{
+			Input:  "#!/usr/bin/env kotlin\nval x = 1",
+			Output: []string{"val", "x", "=", "1"},
+		},
+		{
+			Input:  `val dogs: Map<String, List<Dog>>? = null // comment`,
+			Output: []string{"val", "dogs", ":", "Map", "<", "String", ",", "List", "<", "Dog", ">", ">", "?", "=", "null"},
+		},
+		{
+			Input:  "val s = \"a ${dog.name + \"}\"} b\" + \"\"\"raw \"quoted\" \"\"\"",
+			Output: []string{"val", "s", "=", "\"a ${dog.name + \"}\"} b\"", "+", "\"\"\"raw \"quoted\" \"\"\""},
+		},
+		{
+			Input:  "/* outer /* inner */ still outer */ fun `is valid`() = dog?.name ?: 1_000L",
+			Output: []string{"fun", "is valid", "(", ")", "=", "dog", "?.", "name", "?:", "1_000L"},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			var actualOutput []string
+			for _, token := range tokenize([]byte(tt.Input)) {
+				actualOutput = append(actualOutput, token.Text)
+			}
+
+			if strings.Join(actualOutput, " ") != strings.Join(tt.Output, " ") {
+				subtest.Errorf("incorrect tokens.\nexpected:\n%s\ngot:\n%s\n", tt.Output, actualOutput)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package kotlin
+
+import (
+	"context"
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler/java"
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+// Parses the Kotlin and Java files of a project together. Kotlin classes are parsed into Java classes,
+// so both languages share the packages, imports and relations of the Java front-end.
+func ParseProject(ctx context.Context, files []types.File) *types.Project {
+	var kotlinFiles []int
+
+	parsedFiles, diagnostics := types.ParseFiles(ctx, files, func(file types.File) types.FileResponse {
+		if file.Extension == "java" {
+			return java.ParseFile(file)
+		}
+
+		return parseFile(file)
+	})
+
+	// Classes are tagged with the language of their file, because the diagram shows both languages together
+	for i, file := range files {
+		language := "java"
+		if file.Extension != "java" {
+			kotlinFiles = append(kotlinFiles, i)
+			language = "kt"
+		}
+
+		for classIndex, parsedClass := range parsedFiles[i].Data {
+			if node, ok := parsedClass.(types.DiagramNode); ok {
+				parsedFiles[i].Data[classIndex] = types.UpdateDiagramNode(node, func(diagramNode *types.JavaDiagramNode) {
+					diagramNode.Language = language
+				})
+			}
+		}
+	}
+
+	// Kotlin calls the constructor of a superclass, but a class without a primary constructor
+	// names its superclass without the call. Supertypes that are classes of the project are superclasses.
+	classNames := make(map[string]struct{})
+	for _, parsedFile := range parsedFiles {
+		for _, parsedClass := range parsedFile.Data {
+			switch class := parsedClass.(type) {
+			case types.JavaAbstract:
+				classNames[string(class.Name)] = struct{}{}
+			case types.JavaClass:
+				classNames[string(class.Name)] = struct{}{}
+			}
+		}
+	}
+
+	for _, index := range kotlinFiles {
+		for classIndex, parsedClass := range parsedFiles[index].Data {
+			switch class := parsedClass.(type) {
+			case types.JavaAbstract:
+				class.Implements, class.Extends = resolveSupertypes(classNames, class.Implements, class.Extends)
+				parsedFiles[index].Data[classIndex] = class
+			case types.JavaClass:
+				class.Implements, class.Extends = resolveSupertypes(classNames, class.Implements, class.Extends)
+				parsedFiles[index].Data[classIndex] = class
+			}
+		}
+	}
+
+	response := java.ParseFileResponses(parsedFiles)
+	response.Diagnostics = append(diagnostics, response.Diagnostics...)
+
+	return response
+}
+
+// Moves the supertypes that name a class from implements to extends
+func resolveSupertypes(classNames map[string]struct{}, implements, extends []types.CustomByteSlice) ([]types.CustomByteSlice, []types.CustomByteSlice) {
+	var interfaces []types.CustomByteSlice
+
+	for _, implement := range implements {
+		name := string(implement)
+		if _, ok := classNames[name[strings.LastIndexByte(name, '.')+1:]]; ok {
+			extends = append(extends, implement)
+			continue
+		}
+
+		interfaces = append(interfaces, implement)
+	}
+
+	return interfaces, extends
+}
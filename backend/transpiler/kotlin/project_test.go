@@ -0,0 +1,154 @@
+package kotlin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func relationToString(relation types.Relation) string {
+	return fmt.Sprintf("%s -> %s %T from:%t to:%t", relation.FromClassId, relation.ToClassId, relation.Type, relation.Type.GetFromArrow(), relation.Type.GetToArrow())
+}
+
+func getClassId(class any) string {
+	switch c := class.(type) {
+	case types.JavaAbstract:
+		return string(c.Package) + "." + string(c.Name)
+	case types.JavaClass:
+		return string(c.Package) + "." + string(c.Name)
+	case types.JavaEnum:
+		return string(c.Package) + "." + string(c.Name)
+	case types.JavaInterface:
+		return string(c.Package) + "." + string(c.Name)
+	}
+
+	return ""
+}
+
+func TestParseProject(t *testing.T) {
+	type Output struct {
+		Nodes []string
+		Edges []types.Relation
+	}
+
+	type ParseProjectTest struct {
+		Input  []types.File
+		Output Output
+	}
+
+	var tests = []ParseProjectTest{
+		{
+			Input: []types.File{
+				{
+					Name:      "Animal",
+					Extension: "java",
+					Code: []byte(`
+package zoo;
+
+public abstract class Animal {
+	protected String name;
+}
+`),
+				},
+				{
+					Name:      "Keeper",
+					Extension: "java",
+					Code: []byte(`
+package zoo;
+
+import zoo.pets.Dog;
+
+public class Keeper {
+	private List<Dog> dogs;
+}
+`),
+				},
+				{
+					Name:      "Dog",
+					Extension: "kt",
+					Code: []byte(`
+package zoo.pets
+
+import zoo.Animal
+import zoo.Keeper
+
+class Dog(val keeper: Keeper?) : Animal(), Walker {
+	override fun walk() {
+		Leash.attach(this)
+	}
+}
+
+class Cat : Animal {
+	constructor(name: String) : super()
+}
+`),
+				},
+				{
+					Name:      "Walker",
+					Extension: "kt",
+					Code: []byte(`
+package zoo.pets
+
+import zoo.Animal
+
+interface Walker {
+	fun walk()
+}
+
+object Leash {
+	fun attach(animal: Animal) {}
+}
+`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{"zoo.Animal", "zoo.Keeper", "zoo.pets.Dog", "zoo.pets.Cat", "zoo.pets.Walker", "zoo.pets.Leash"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("zoo.Keeper"), ToClassId: []byte("zoo.pets.Dog"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("zoo.pets.Dog"), ToClassId: []byte("zoo.Keeper"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("zoo.pets.Dog"), ToClassId: []byte("zoo.Keeper"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("zoo.pets.Dog"), ToClassId: []byte("zoo.Animal"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("zoo.pets.Dog"), ToClassId: []byte("zoo.pets.Walker"), Type: &types.Realization{ToArrow: true}},
+					{FromClassId: []byte("zoo.pets.Dog"), ToClassId: []byte("zoo.pets.Leash"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("zoo.pets.Cat"), ToClassId: []byte("zoo.Animal"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("zoo.pets.Leash"), ToClassId: []byte("zoo.Animal"), Type: &types.Dependency{ToArrow: true}},
+				},
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := ParseProject(context.Background(), tt.Input)
+
+			var nodes []string
+			for _, node := range response.Nodes {
+				nodes = append(nodes, getClassId(node))
+			}
+
+			if fmt.Sprint(nodes) != fmt.Sprint(tt.Output.Nodes) {
+				subtest.Errorf("incorrect nodes.\nexpected:\n%s\ngot:\n%s\n", tt.Output.Nodes, nodes)
+			}
+
+			var expectedEdges, actualEdges []string
+			for _, edge := range tt.Output.Edges {
+				expectedEdges = append(expectedEdges, relationToString(edge))
+			}
+
+			for _, edge := range response.Edges {
+				actualEdges = append(actualEdges, relationToString(edge))
+			}
+
+			sort.Strings(expectedEdges)
+			sort.Strings(actualEdges)
+
+			if fmt.Sprint(expectedEdges) != fmt.Sprint(actualEdges) {
+				subtest.Errorf("incorrect edges.\nexpected:\n%s\ngot:\n%s\n", expectedEdges, actualEdges)
+			}
+		})
+	}
+}
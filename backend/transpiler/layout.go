@@ -0,0 +1,157 @@
+package transpiler
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-graphviz"
+	"github.com/goccy/go-graphviz/cgraph"
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+	httpTypes "github.com/junioryono/ProUML/backend/types"
+)
+
+const (
+	LayoutHierarchical = "hierarchical"
+	LayoutDot          = "dot"
+	LayoutNeato        = "neato"
+	LayoutFdp          = "fdp"
+
+	DefaultLayout = LayoutHierarchical
+
+	// Graphviz measures node sizes in inches and positions in points
+	pointsPerInch = 72
+)
+
+var SupportedLayouts = []string{LayoutHierarchical, LayoutDot, LayoutNeato, LayoutFdp}
+
+// Graphviz keeps global state in C and is not safe to run concurrently
+var graphvizMutex sync.Mutex
+
+// Assigns a position to every node using the given layout algorithm.
+// Nodes must already have their size set. Returns the group cells created by the layout, if any.
+func layoutNodes(nodes []types.DiagramNode, relations []types.Relation, layout string) ([]any, *httpTypes.WrappedError) {
+	if layout == "" {
+		layout = DefaultLayout
+	}
+
+	switch {
+	case layout == LayoutHierarchical:
+		return layoutNodesHierarchically(nodes, relations), nil
+	case contains(SupportedLayouts, layout) != "":
+		return nil, layoutNodesWithGraphviz(nodes, relations, layout)
+	default:
+		return nil, httpTypes.Wrap(errors.New("unsupported layout "+layout), httpTypes.ErrUnsupportedLayout)
+	}
+}
+
+// Assigns a position to every node using the given Graphviz layout algorithm
+func layoutNodesWithGraphviz(nodes []types.DiagramNode, relations []types.Relation, layout string) *httpTypes.WrappedError {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	graphvizMutex.Lock()
+	defer graphvizMutex.Unlock()
+
+	g := graphviz.New()
+	defer g.Close()
+
+	graph, err := g.Graph()
+	if err != nil {
+		return httpTypes.Wrap(err, httpTypes.ErrCouldNotGenerateLayout)
+	}
+	defer graph.Close()
+
+	graph.SetNodeSeparator(0.5)
+	graph.SetRankSeparator(0.75)
+
+	// The bundled Graphviz has no triangulation library, so overlaps are removed by scaling the layout
+	graph.SafeSet("overlap", "scale", "true")
+
+	// The key is the node's class id
+	graphNodes := make(map[string]*cgraph.Node, len(nodes))
+
+	for _, node := range nodes {
+		classId := string(getNodeClassId(node))
+		if _, ok := graphNodes[classId]; ok {
+			continue
+		}
+
+		graphNode, err := graph.CreateNode(classId)
+		if err != nil {
+			return httpTypes.Wrap(err, httpTypes.ErrCouldNotGenerateLayout)
+		}
+
+		graphNode.
+			SetShape(cgraph.BoxShape).
+			SetLabel("").
+			SetFixedSize(true).
+			SetWidth(node.GetDiagramNode().Width / pointsPerInch).
+			SetHeight(node.GetDiagramNode().Height / pointsPerInch)
+
+		graphNodes[classId] = graphNode
+	}
+
+	for i, relation := range relations {
+		if relation.Type == nil {
+			continue
+		}
+
+		from, fromOk := graphNodes[string(relation.FromClassId)]
+		to, toOk := graphNodes[string(relation.ToClassId)]
+		if !fromOk || !toOk {
+			continue
+		}
+
+		// Point inheritance edges from the parent to the child so that parents are ranked above their children
+		if relation.Type.GetType() == "generalization" || relation.Type.GetType() == "realization" {
+			from, to = to, from
+		}
+
+		if _, err := graph.CreateEdge("e"+strconv.Itoa(i), from, to); err != nil {
+			return httpTypes.Wrap(err, httpTypes.ErrCouldNotGenerateLayout)
+		}
+	}
+
+	g.SetLayout(graphviz.Layout(layout))
+
+	// Rendering runs the layout and stores the result in each node's "pos" attribute
+	if err := g.Render(graph, graphviz.XDOT, io.Discard); err != nil {
+		return httpTypes.Wrap(err, httpTypes.ErrCouldNotGenerateLayout)
+	}
+
+	// Graphviz places the origin in the bottom left corner, X6 places it in the top left corner
+	_, _, _, graphHeight := parseGraphvizPoints(graph.Get("bb"))
+
+	for i, node := range nodes {
+		graphNode, ok := graphNodes[string(getNodeClassId(node))]
+		if !ok {
+			continue
+		}
+
+		centerX, centerY, _, _ := parseGraphvizPoints(graphNode.Get("pos"))
+		nodes[i] = types.UpdateDiagramNode(node, func(diagramNode *types.JavaDiagramNode) {
+			diagramNode.Position = types.Position{X: centerX - diagramNode.Width/2, Y: graphHeight - centerY - diagramNode.Height/2}
+		})
+	}
+
+	return nil
+}
+
+// Parses a comma separated Graphviz point list such as "72,108" or "0,0,144,144"
+func parseGraphvizPoints(text string) (float64, float64, float64, float64) {
+	var points [4]float64
+
+	for i, point := range strings.Split(text, ",") {
+		if i >= len(points) {
+			break
+		}
+
+		points[i], _ = strconv.ParseFloat(strings.TrimSpace(point), 64)
+	}
+
+	return points[0], points[1], points[2], points[3]
+}
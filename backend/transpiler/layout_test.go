@@ -0,0 +1,71 @@
+package transpiler
+
+import (
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+	httpTypes "github.com/junioryono/ProUML/backend/types"
+)
+
+func getLayoutTestProject() ([]types.DiagramNode, []types.Relation) {
+	size := types.Size{Width: 150, Height: 80}
+
+	nodes := []types.DiagramNode{
+		types.JavaClass{Package: []byte("default"), Name: []byte("Dog"), JavaDiagramNode: types.JavaDiagramNode{Size: size}},
+		types.JavaClass{Package: []byte("default"), Name: []byte("Cat"), JavaDiagramNode: types.JavaDiagramNode{Size: size}},
+		types.JavaAbstract{Package: []byte("default"), Name: []byte("Animal"), JavaDiagramNode: types.JavaDiagramNode{Size: size}},
+		types.JavaInterface{Package: []byte("default"), Name: []byte("Pet"), JavaDiagramNode: types.JavaDiagramNode{Size: size}},
+	}
+
+	edges := []types.Relation{
+		{FromClassId: []byte("default.Dog"), ToClassId: []byte("default.Animal"), Type: &types.Generalization{ToArrow: true}},
+		{FromClassId: []byte("default.Cat"), ToClassId: []byte("default.Animal"), Type: &types.Generalization{ToArrow: true}},
+		{FromClassId: []byte("default.Animal"), ToClassId: []byte("default.Pet"), Type: &types.Realization{ToArrow: true}},
+	}
+
+	return nodes, edges
+}
+
+func TestLayoutNodes(t *testing.T) {
+	for _, layout := range SupportedLayouts {
+		t.Run(layout, func(subtest *testing.T) {
+			nodes, edges := getLayoutTestProject()
+
+			if _, err := layoutNodes(nodes, edges, layout); err != nil {
+				subtest.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			// No two nodes may overlap
+			for i := 0; i < len(nodes); i++ {
+				for j := i + 1; j < len(nodes); j++ {
+					overlapX := nodes[i].GetDiagramNode().Position.X < nodes[j].GetDiagramNode().Position.X+nodes[j].GetDiagramNode().Width &&
+						nodes[j].GetDiagramNode().Position.X < nodes[i].GetDiagramNode().Position.X+nodes[i].GetDiagramNode().Width
+					overlapY := nodes[i].GetDiagramNode().Position.Y < nodes[j].GetDiagramNode().Position.Y+nodes[j].GetDiagramNode().Height &&
+						nodes[j].GetDiagramNode().Position.Y < nodes[i].GetDiagramNode().Position.Y+nodes[i].GetDiagramNode().Height
+
+					if overlapX && overlapY {
+						subtest.Errorf("nodes %s and %s overlap", getNodeClassId(nodes[i]), getNodeClassId(nodes[j]))
+					}
+				}
+			}
+
+			if layout != LayoutDot && layout != LayoutHierarchical {
+				return
+			}
+
+			// Parents are ranked above their children
+			if nodes[2].GetDiagramNode().Position.Y >= nodes[0].GetDiagramNode().Position.Y || nodes[3].GetDiagramNode().Position.Y >= nodes[2].GetDiagramNode().Position.Y {
+				subtest.Errorf("parents are not placed above their children")
+			}
+		})
+	}
+}
+
+func TestLayoutNodesUnsupportedLayout(t *testing.T) {
+	nodes, edges := getLayoutTestProject()
+
+	_, err := layoutNodes(nodes, edges, "circo")
+	if err == nil || err.Str != httpTypes.ErrUnsupportedLayout {
+		t.Errorf("expected unsupported layout error, got %v", err)
+	}
+}
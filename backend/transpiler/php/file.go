@@ -0,0 +1,739 @@
+package php
+
+import (
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+// Package of the classes declared outside of a namespace. Java uses the same name.
+const globalNamespace = "default"
+
+var modifierKeywords = map[string]struct{}{
+	"public": {}, "protected": {}, "private": {}, "static": {}, "abstract": {}, "final": {}, "readonly": {}, "var": {},
+}
+
+// Names that do not refer to a class of the project
+var reservedNames = map[string]struct{}{
+	"self": {}, "static": {}, "parent": {}, "null": {}, "true": {}, "false": {},
+}
+
+// The names that are visible inside of a namespace
+type scope struct {
+	Namespace string
+	Uses      map[string]string // Lowercase alias to the fully qualified name
+}
+
+// A class, interface, trait or enum with the names it refers to before they are resolved
+type class struct {
+	Node  any
+	Scope *scope
+	Uses  []string // Traits that the class uses
+}
+
+type fileResponse struct {
+	Classes []class
+}
+
+type parser struct {
+	tokens  []token
+	classes []class
+}
+
+type parameter struct {
+	Name      string
+	Type      string
+	Value     string
+	Modifiers map[string]bool
+}
+
+func parseFile(file types.File) fileResponse {
+	p := parser{tokens: tokenize(file.Code)}
+	p.parseStatements(0, len(p.tokens), &scope{Uses: make(map[string]string)})
+
+	return fileResponse{Classes: p.classes}
+}
+
+// Parses the statements of a file or of a namespace block
+func (p *parser) parseStatements(start, end int, s *scope) {
+	for i := start; i < end; {
+		modifiers, keyword := p.readModifiers(i, end)
+		next := keyword + 1
+
+		switch {
+		case keyword >= end:
+			next = end
+		case p.is(keyword, "namespace") && !p.is(keyword+1, "\\"):
+			name := ""
+			if keyword+1 < end && p.tokens[keyword+1].Kind == identifierToken {
+				name = strings.TrimPrefix(p.tokens[keyword+1].Text, "\\")
+				next++
+			}
+
+			// namespace App { ... } contains its declarations, namespace App; applies to the rest of the file
+			if p.is(next, "{") {
+				blockEnd := p.skipBalanced(next)
+				p.parseStatements(next+1, blockEnd-1, &scope{Namespace: name, Uses: make(map[string]string)})
+				next = blockEnd
+			} else {
+				s = &scope{Namespace: name, Uses: make(map[string]string)}
+			}
+		case p.is(keyword, "use"):
+			next = p.parseUse(keyword+1, end, s)
+		case p.isClassKeyword(keyword):
+			next = p.parseClass(keyword, end, modifiers, s)
+		default:
+			next = p.skipStatement(keyword, end)
+		}
+
+		if next <= i {
+			next = i + 1
+		}
+
+		i = next
+	}
+}
+
+// Parses the names imported by a use statement: use App\Models\{User, Post as Article};
+func (p *parser) parseUse(i, end int, s *scope) int {
+	// Functions and constants are not classes: use function App\helper;
+	if p.is(i, "function") || p.is(i, "const") {
+		return p.skipStatement(i, end)
+	}
+
+	for i < end && !p.is(i, ";") {
+		if p.tokens[i].Kind != identifierToken {
+			i++
+			continue
+		}
+
+		name := strings.TrimPrefix(p.tokens[i].Text, "\\")
+		i++
+
+		if p.is(i, "\\") && p.is(i+1, "{") {
+			groupEnd := p.skipBalanced(i+1) - 1
+
+			for j := i + 2; j < groupEnd; j++ {
+				if p.tokens[j].Kind != identifierToken || p.is(j, "function") || p.is(j, "const") {
+					continue
+				}
+
+				j = p.addUse(s, name+"\\"+p.tokens[j].Text, j+1)
+			}
+
+			i = groupEnd + 1
+			continue
+		}
+
+		i = p.addUse(s, name, i)
+	}
+
+	return i + 1
+}
+
+// Adds a used name with its optional alias and returns the index after them
+func (p *parser) addUse(s *scope, name string, i int) int {
+	alias := name[strings.LastIndexByte(name, '\\')+1:]
+
+	if p.is(i, "as") && i+1 < len(p.tokens) {
+		alias = p.tokens[i+1].Text
+		i += 2
+	}
+
+	s.Uses[strings.ToLower(alias)] = name
+	return i
+}
+
+// Parses the class, interface, trait or enum whose keyword is at index i and returns the index after it
+func (p *parser) parseClass(i, end int, modifiers map[string]bool, s *scope) int {
+	keyword := strings.ToLower(p.tokens[i].Text)
+	name := p.tokens[i+1].Text
+	i += 2
+
+	// Backed enums: enum Suit: string
+	if keyword == "enum" && p.is(i, ":") {
+		i += 2
+	}
+
+	var extends, implements []types.CustomByteSlice
+	for i < end && !p.is(i, "{") {
+		switch {
+		case p.is(i, "extends"):
+			extends, i = p.readNameList(i+1, end)
+		case p.is(i, "implements"):
+			implements, i = p.readNameList(i+1, end)
+		default:
+			i++
+		}
+	}
+
+	if i >= end {
+		return end
+	}
+
+	var (
+		bodyEnd = p.skipBalanced(i)
+		c       = class{Scope: s}
+		members = p.parseMembers(i+1, bodyEnd-1, &c)
+		pkg     = []byte(globalNamespace)
+	)
+
+	if s.Namespace != "" {
+		pkg = []byte(s.Namespace)
+	}
+
+	switch {
+	case keyword == "interface":
+		c.Node = types.JavaInterface{
+			Package:      pkg,
+			Name:         []byte(name),
+			Extends:      extends,
+			Variables:    members.Variables,
+			Methods:      members.Methods,
+			Associations: members.Associations,
+			Dependencies: members.Dependencies,
+		}
+	case keyword == "trait":
+		c.Node = types.PhpTrait{
+			Package:      pkg,
+			Name:         []byte(name),
+			Variables:    members.Variables,
+			Methods:      members.Methods,
+			Associations: members.Associations,
+			Dependencies: members.Dependencies,
+		}
+	case keyword == "enum":
+		c.Node = types.JavaEnum{
+			Package:      pkg,
+			Name:         []byte(name),
+			Declarations: members.Cases,
+			Implements:   implements,
+		}
+	case modifiers["abstract"]:
+		c.Node = types.JavaAbstract{
+			Package:      pkg,
+			Name:         []byte(name),
+			Implements:   implements,
+			Extends:      extends,
+			Variables:    members.Variables,
+			Methods:      members.Methods,
+			Associations: members.Associations,
+			Dependencies: members.Dependencies,
+		}
+	default:
+		c.Node = types.JavaClass{
+			Package:      pkg,
+			Name:         []byte(name),
+			Implements:   implements,
+			Extends:      extends,
+			Variables:    members.Variables,
+			Methods:      members.Methods,
+			Associations: members.Associations,
+			Dependencies: members.Dependencies,
+		}
+	}
+
+	p.classes = append(p.classes, c)
+
+	return bodyEnd
+}
+
+// The members of a class body. Associations and dependencies hold the names as they are written.
+type members struct {
+	Variables    []types.JavaVariable
+	Methods      []types.JavaMethod
+	Cases        []types.CustomByteSlice
+	Associations []types.CustomByteSlice
+	Dependencies []types.CustomByteSlice
+}
+
+func (p *parser) parseMembers(start, end int, c *class) members {
+	var response members
+
+	for i := start; i < end; {
+		modifiers, keyword := p.readModifiers(i, end)
+		next := keyword + 1
+
+		switch {
+		case keyword >= end:
+			next = end
+		case p.is(keyword, "use"):
+			// Trait uses may resolve conflicts in a block: use A, B { A::hello insteadof B; }
+			var uses []types.CustomByteSlice
+			uses, next = p.readNameList(keyword+1, end)
+			for _, use := range uses {
+				c.Uses = append(c.Uses, string(use))
+			}
+
+			if p.is(next, "{") {
+				next = p.skipBalanced(next)
+			} else {
+				next++
+			}
+		case p.is(keyword, "case"):
+			if keyword+1 < end {
+				response.Cases = append(response.Cases, []byte(p.tokens[keyword+1].Text))
+			}
+
+			next = p.skipStatement(keyword, end)
+		case p.is(keyword, "const"):
+			next = p.parseConstants(keyword+1, end, modifiers, &response)
+		case p.is(keyword, "function"):
+			next = p.parseMethod(keyword+1, end, modifiers, &response)
+		default:
+			next = p.parseProperties(keyword, end, modifiers, &response)
+		}
+
+		if next <= i {
+			next = i + 1
+		}
+
+		i = next
+	}
+
+	return response
+}
+
+// Parses constants such as "const A = 1, B = 2;" and typed constants such as "const string NAME = 'a';"
+func (p *parser) parseConstants(i, end int, modifiers map[string]bool, m *members) int {
+	statementEnd := p.skipStatement(i, end)
+
+	var constantType string
+	if i+1 < end && p.tokens[i+1].Kind == identifierToken {
+		constantType = p.tokens[i].Text
+		i++
+	}
+
+	for _, declaration := range p.splitTopLevel(i, statementEnd-1) {
+		if declaration[1]-declaration[0] < 1 {
+			continue
+		}
+
+		m.Variables = append(m.Variables, types.JavaVariable{
+			Type:           []byte(constantType),
+			Name:           []byte(p.tokens[declaration[0]].Text),
+			Value:          p.getValue(declaration[0]+1, declaration[1]),
+			AccessModifier: getAccessModifier(modifiers),
+			Static:         true,
+			Final:          true,
+		})
+
+		m.Associations = append(m.Associations, p.getReferences(declaration[0]+1, declaration[1])...)
+	}
+
+	return statementEnd
+}
+
+// Parses typed properties: private ?Post $post = null, $other;
+func (p *parser) parseProperties(i, end int, modifiers map[string]bool, m *members) int {
+	statementEnd := p.skipStatement(i, end)
+
+	propertyType, next := p.readType(i, statementEnd)
+	if !p.isKind(next, variableToken) {
+		return statementEnd
+	}
+
+	m.Associations = append(m.Associations, getTypeNames(propertyType)...)
+
+	for _, declaration := range p.splitTopLevel(next, statementEnd-1) {
+		if !p.isKind(declaration[0], variableToken) {
+			continue
+		}
+
+		m.Variables = append(m.Variables, types.JavaVariable{
+			Type:           []byte(propertyType),
+			Name:           []byte(strings.TrimPrefix(p.tokens[declaration[0]].Text, "$")),
+			Value:          p.getValue(declaration[0]+1, declaration[1]),
+			AccessModifier: getAccessModifier(modifiers),
+			Static:         modifiers["static"],
+			Final:          modifiers["readonly"],
+		})
+
+		m.Associations = append(m.Associations, p.getReferences(declaration[0]+1, declaration[1])...)
+	}
+
+	return statementEnd
+}
+
+// Parses a method whose name is at index i. Constructor parameters with a visibility or readonly
+// are promoted to properties.
+func (p *parser) parseMethod(i, end int, modifiers map[string]bool, m *members) int {
+	if p.is(i, "&") {
+		i++
+	}
+
+	if i+1 >= end || !p.is(i+1, "(") {
+		return p.skipStatement(i, end)
+	}
+
+	name := p.tokens[i].Text
+	parameters, next := p.parseParameters(i+1, end)
+
+	var returnType string
+	if p.is(next, ":") {
+		returnType, next = p.readType(next+1, end)
+	}
+
+	method := types.JavaMethod{
+		Type:           []byte(returnType),
+		Name:           []byte(name),
+		AccessModifier: getAccessModifier(modifiers),
+		Abstract:       modifiers["abstract"],
+		Static:         modifiers["static"],
+		Final:          modifiers["final"],
+	}
+
+	for _, parameter := range parameters {
+		method.Parameters = append(method.Parameters, types.JavaMethodParameter{
+			Type: []byte(parameter.Type),
+			Name: []byte(parameter.Name),
+		})
+
+		if strings.EqualFold(name, "__construct") && (parameter.Modifiers["public"] || parameter.Modifiers["protected"] || parameter.Modifiers["private"] || parameter.Modifiers["readonly"]) {
+			m.Variables = append(m.Variables, types.JavaVariable{
+				Type:           []byte(parameter.Type),
+				Name:           []byte(parameter.Name),
+				Value:          getBytes(parameter.Value),
+				AccessModifier: getAccessModifier(parameter.Modifiers),
+				Final:          parameter.Modifiers["readonly"],
+			})
+
+			m.Associations = append(m.Associations, getTypeNames(parameter.Type)...)
+			continue
+		}
+
+		m.Dependencies = append(m.Dependencies, getTypeNames(parameter.Type)...)
+	}
+
+	if p.is(next, "{") {
+		bodyEnd := p.skipBalanced(next)
+		method.Functionality = []byte(p.joinTokens(next+1, bodyEnd-1))
+		m.Dependencies = append(m.Dependencies, p.getReferences(next+1, bodyEnd-1)...)
+		next = bodyEnd
+	} else {
+		next = p.skipStatement(next, end)
+	}
+
+	m.Methods = append(m.Methods, method)
+
+	return next
+}
+
+// Parses the parameters between the parentheses at index i and returns the index after the closing parenthesis
+func (p *parser) parseParameters(i, end int) ([]parameter, int) {
+	var (
+		response       []parameter
+		parametersEnd  = p.skipBalanced(i)
+		parametersList = p.splitTopLevel(i+1, parametersEnd-1)
+	)
+
+	for _, declaration := range parametersList {
+		modifiers, next := p.readModifiers(declaration[0], declaration[1])
+
+		current := parameter{Modifiers: modifiers}
+		current.Type, next = p.readType(next, declaration[1])
+
+		// References and variadic parameters: Foo &$foo, Foo ...$foos
+		for p.is(next, "&") || p.is(next, "...") {
+			next++
+		}
+
+		if !p.isKind(next, variableToken) {
+			continue
+		}
+
+		current.Name = strings.TrimPrefix(p.tokens[next].Text, "$")
+
+		if p.is(next+1, "=") && next+2 < declaration[1] {
+			current.Value = p.joinTokens(next+2, declaration[1])
+		}
+
+		response = append(response, current)
+	}
+
+	if parametersEnd > end {
+		parametersEnd = end
+	}
+
+	return response, parametersEnd
+}
+
+// Reads the class names after extends or implements, or the traits after use
+func (p *parser) readNameList(i, end int) ([]types.CustomByteSlice, int) {
+	var response []types.CustomByteSlice
+
+	for i < end && p.tokens[i].Kind == identifierToken {
+		response = append(response, []byte(p.tokens[i].Text))
+		i++
+
+		if !p.is(i, ",") {
+			break
+		}
+
+		i++
+	}
+
+	return response, i
+}
+
+// Reads a type such as "?Post", "int|string" or "(A&B)|null"
+func (p *parser) readType(i, end int) (string, int) {
+	var (
+		start = i
+		depth = 0
+	)
+
+	for ; i < end; i++ {
+		t := p.tokens[i]
+
+		// Parameters passed by reference: Foo &$foo
+		if t.Text == "&" && (p.isKind(i+1, variableToken) || p.is(i+1, "...")) {
+			break
+		}
+
+		if t.Text == "(" {
+			depth++
+		} else if t.Text == ")" {
+			if depth == 0 {
+				break
+			}
+
+			depth--
+		} else if t.Kind != identifierToken && t.Text != "?" && t.Text != "|" && t.Text != "&" {
+			break
+		}
+	}
+
+	return p.joinTokens(start, i), i
+}
+
+// Returns the names that a method body or a value refers to: new Post(), Post::find(), $x instanceof Post,
+// catch (NotFound $e) and fn (Post $post) => ...
+func (p *parser) getReferences(start, end int) []types.CustomByteSlice {
+	var response []types.CustomByteSlice
+
+	for i := start; i < end; i++ {
+		if p.tokens[i].Kind != identifierToken {
+			continue
+		}
+
+		if _, ok := reservedNames[strings.ToLower(p.tokens[i].Text)]; ok {
+			continue
+		}
+
+		if p.is(i-1, "->") || p.is(i-1, "?->") || p.is(i-1, "::") {
+			continue
+		}
+
+		if p.is(i-1, "new") || p.is(i-1, "instanceof") || p.is(i+1, "::") || p.isKind(i+1, variableToken) || p.is(i+1, "...") {
+			response = append(response, []byte(p.tokens[i].Text))
+		}
+	}
+
+	return response
+}
+
+// Reads the attributes and modifiers and returns the modifiers and the index of the token after them
+func (p *parser) readModifiers(i, end int) (map[string]bool, int) {
+	response := make(map[string]bool)
+
+	for i < end {
+		if p.is(i, "#[") {
+			i = p.skipBalanced(i)
+			continue
+		}
+
+		if _, ok := modifierKeywords[strings.ToLower(p.tokens[i].Text)]; !ok || p.tokens[i].Kind != identifierToken {
+			break
+		}
+
+		// Static calls and closures are statements, not modifiers: static::create(), static fn () => 1
+		if p.is(i+1, "::") || p.is(i+1, "(") {
+			break
+		}
+
+		response[strings.ToLower(p.tokens[i].Text)] = true
+		i++
+	}
+
+	return response, i
+}
+
+// Returns true when the token at index i declares a class: class, interface, trait or enum followed by a name
+func (p *parser) isClassKeyword(i int) bool {
+	switch strings.ToLower(p.tokens[i].Text) {
+	case "class", "interface", "trait", "enum":
+		return p.tokens[i].Kind == identifierToken && p.isKind(i+1, identifierToken) && !p.is(i-1, "new") && !p.is(i-1, "::")
+	}
+
+	return false
+}
+
+// Returns the index after the statement that starts at index i. Statements end with a semicolon
+// or with a block, such as the body of a function.
+func (p *parser) skipStatement(i, end int) int {
+	for i < end {
+		switch p.tokens[i].Text {
+		case ";":
+			if p.tokens[i].Kind == punctuationToken {
+				return i + 1
+			}
+		case "{":
+			if p.tokens[i].Kind == punctuationToken {
+				return p.skipBalanced(i)
+			}
+		case "(", "[", "#[":
+			if p.tokens[i].Kind == punctuationToken {
+				i = p.skipBalanced(i)
+				continue
+			}
+		}
+
+		i++
+	}
+
+	return end
+}
+
+// Returns the index after the bracket that closes the one at index i
+func (p *parser) skipBalanced(i int) int {
+	depth := 0
+
+	for ; i < len(p.tokens); i++ {
+		if p.tokens[i].Kind != punctuationToken {
+			continue
+		}
+
+		switch p.tokens[i].Text {
+		case "(", "[", "{", "#[":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return len(p.tokens)
+}
+
+// Splits the tokens from start to end at the commas outside of brackets. Every part is a start and an end index.
+func (p *parser) splitTopLevel(start, end int) [][2]int {
+	var response [][2]int
+
+	partStart := start
+	for i := start; i < end; {
+		if p.is(i, ",") {
+			response = append(response, [2]int{partStart, i})
+			partStart = i + 1
+			i++
+			continue
+		}
+
+		if p.is(i, "(") || p.is(i, "[") || p.is(i, "{") || p.is(i, "#[") {
+			i = p.skipBalanced(i)
+			continue
+		}
+
+		i++
+	}
+
+	if partStart < end {
+		response = append(response, [2]int{partStart, end})
+	}
+
+	return response
+}
+
+// Returns the value after the equal sign that starts at index i, or nil when there is none
+func (p *parser) getValue(i, end int) []byte {
+	if !p.is(i, "=") || i+1 >= end {
+		return nil
+	}
+
+	return []byte(p.joinTokens(i+1, end))
+}
+
+// Joins tokens into code. Spaces separate tokens, except around brackets, object operators and type operators.
+func (p *parser) joinTokens(start, end int) string {
+	var builder strings.Builder
+
+	for i := start; i < end; i++ {
+		if i > start && !p.joinsWithoutSpace(i, start) {
+			builder.WriteByte(' ')
+		}
+
+		builder.WriteString(p.tokens[i].Text)
+	}
+
+	return builder.String()
+}
+
+// Returns true when no space separates the token at index i from the previous token
+func (p *parser) joinsWithoutSpace(i, start int) bool {
+	current, previous := p.tokens[i], p.tokens[i-1]
+
+	if previous.Kind == punctuationToken {
+		switch previous.Text {
+		case "(", "[", "->", "?->", "::", "!", "|", "&", "\\", "...":
+			return true
+		case "?":
+			// Nullable types, but not the ternary operator
+			return i-1 == start || p.tokens[i-2].Kind == punctuationToken
+		}
+	}
+
+	if current.Kind == punctuationToken {
+		switch current.Text {
+		case ")", "]", ",", ";", "->", "?->", "::", "|", "&", "\\":
+			return true
+		case "(", "[":
+			// Calls and array access, but not "if (" or "= ["
+			return previous.Kind == identifierToken || previous.Kind == variableToken || previous.Text == ")" || previous.Text == "]"
+		}
+	}
+
+	return false
+}
+
+func (p *parser) is(i int, text string) bool {
+	return i >= 0 && i < len(p.tokens) && p.tokens[i].Text == text && p.tokens[i].Kind != stringToken
+}
+
+func (p *parser) isKind(i int, kind tokenKind) bool {
+	return i >= 0 && i < len(p.tokens) && p.tokens[i].Kind == kind
+}
+
+// Returns the class names of a type: ?Post -> Post, Post|Comment|null -> Post, Comment
+func getTypeNames(text string) []types.CustomByteSlice {
+	var response []types.CustomByteSlice
+
+	for _, name := range strings.FieldsFunc(text, func(r rune) bool { return strings.ContainsRune("?|&() ", r) }) {
+		if _, ok := reservedNames[strings.ToLower(name)]; !ok {
+			response = append(response, []byte(name))
+		}
+	}
+
+	return response
+}
+
+func getAccessModifier(modifiers map[string]bool) []byte {
+	switch {
+	case modifiers["private"]:
+		return []byte("private")
+	case modifiers["protected"]:
+		return []byte("protected")
+	}
+
+	// Members without a visibility are public
+	return []byte("public")
+}
+
+func getBytes(text string) []byte {
+	if text == "" {
+		return nil
+	}
+
+	return []byte(text)
+}
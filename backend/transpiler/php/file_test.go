@@ -0,0 +1,159 @@
+package php
+
+import (
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestParseFile(t *testing.T) {
+	file := types.File{
+		Name:      "Post",
+		Extension: "php",
+		Code: []byte(`<?php
+declare(strict_types=1);
+
+namespace App\Models;
+
+use App\Contracts\{Publishable, Searchable as Findable};
+use App\Support\Collection;
+use function App\helpers\slugify;
+
+#[Entity(table: "posts")]
+abstract class Post extends Model implements Publishable, Findable
+{
+	use HasComments, SoftDeletes {
+		HasComments::delete insteadof SoftDeletes;
+	}
+
+	public const STATUS = 'draft', LIMIT = 10;
+	final protected const string KIND = "post";
+
+	private ?Author $author = null;
+	protected static int $count = 0;
+	public readonly Collection|array $tags;
+	var $legacy;
+
+	public function __construct(
+		private readonly Slug $slug,
+		protected int $views = 0,
+		#[SensitiveParameter] string $secret = '',
+	) {
+		$this->tags = new Collection([]);
+	}
+
+	abstract public function render(Renderer $renderer): string;
+
+	public static function find(int ...$ids): ?static
+	{
+		try {
+			return Repository::load($ids);
+		} catch (NotFound $e) {
+			return null;
+		}
+	}
+}
+
+trait HasComments
+{
+	protected array $comments = [];
+
+	public function comment(Comment $comment): void {}
+}
+
+enum Status: string implements HasLabel
+{
+	case Draft = 'draft';
+	case Published = 'published';
+
+	public function label(): string { return ucfirst($this->value); }
+}
+`),
+	}
+
+	response := parseFile(file)
+
+	if len(response.Classes) != 3 {
+		t.Fatalf("incorrect number of classes.\nexpected: 3\ngot: %d\n", len(response.Classes))
+	}
+
+	post := response.Classes[0]
+	node, ok := post.Node.(types.JavaAbstract)
+	if !ok {
+		t.Fatalf("Post is not abstract")
+	}
+
+	if s := post.Scope; s.Namespace != `App\Models` || len(s.Uses) != 3 || s.Uses["findable"] != `App\Contracts\Searchable` || s.Uses["collection"] != `App\Support\Collection` {
+		t.Errorf("incorrect scope: %+v", *s)
+	}
+
+	if len(post.Uses) != 2 || post.Uses[0] != "HasComments" || post.Uses[1] != "SoftDeletes" {
+		t.Errorf("incorrect trait uses: %s", post.Uses)
+	}
+
+	if string(node.Package) != `App\Models` || len(node.Extends) != 1 || len(node.Implements) != 2 || string(node.Implements[1]) != "Findable" {
+		t.Errorf("incorrect class header")
+	}
+
+	expectedVariables := []types.JavaVariable{
+		{Name: []byte("STATUS"), Value: []byte("'draft'"), AccessModifier: []byte("public"), Static: true, Final: true},
+		{Name: []byte("LIMIT"), Value: []byte("10"), AccessModifier: []byte("public"), Static: true, Final: true},
+		{Name: []byte("KIND"), Type: []byte("string"), Value: []byte(`"post"`), AccessModifier: []byte("protected"), Static: true, Final: true},
+		{Name: []byte("author"), Type: []byte("?Author"), Value: []byte("null"), AccessModifier: []byte("private")},
+		{Name: []byte("count"), Type: []byte("int"), Value: []byte("0"), AccessModifier: []byte("protected"), Static: true},
+		{Name: []byte("tags"), Type: []byte("Collection|array"), AccessModifier: []byte("public"), Final: true},
+		{Name: []byte("legacy"), AccessModifier: []byte("public")},
+		{Name: []byte("slug"), Type: []byte("Slug"), AccessModifier: []byte("private"), Final: true},
+		{Name: []byte("views"), Type: []byte("int"), Value: []byte("0"), AccessModifier: []byte("protected")},
+	}
+
+	if len(node.Variables) != len(expectedVariables) {
+		t.Fatalf("incorrect number of variables.\nexpected: %d\ngot: %d\n", len(expectedVariables), len(node.Variables))
+	}
+
+	for index, expected := range expectedVariables {
+		actual := node.Variables[index]
+		if string(actual.Name) != string(expected.Name) || string(actual.Type) != string(expected.Type) || string(actual.Value) != string(expected.Value) ||
+			string(actual.AccessModifier) != string(expected.AccessModifier) || actual.Static != expected.Static || actual.Final != expected.Final {
+			t.Errorf("incorrect variable.\nexpected:\n%s %s %s %s\ngot:\n%s %s %s %s\n",
+				expected.AccessModifier, expected.Name, expected.Type, expected.Value,
+				actual.AccessModifier, actual.Name, actual.Type, actual.Value)
+		}
+	}
+
+	expectedMethods := []types.JavaMethod{
+		{Name: []byte("__construct"), AccessModifier: []byte("public")},
+		{Name: []byte("render"), Type: []byte("string"), AccessModifier: []byte("public"), Abstract: true},
+		{Name: []byte("find"), Type: []byte("?static"), AccessModifier: []byte("public"), Static: true},
+	}
+
+	if len(node.Methods) != len(expectedMethods) {
+		t.Fatalf("incorrect number of methods.\nexpected: %d\ngot: %d\n", len(expectedMethods), len(node.Methods))
+	}
+
+	for index, expected := range expectedMethods {
+		actual := node.Methods[index]
+		if string(actual.Name) != string(expected.Name) || string(actual.Type) != string(expected.Type) || string(actual.AccessModifier) != string(expected.AccessModifier) ||
+			actual.Abstract != expected.Abstract || actual.Static != expected.Static {
+			t.Errorf("incorrect method.\nexpected:\n%s %s %s\ngot:\n%s %s %s\n", expected.AccessModifier, expected.Name, expected.Type, actual.AccessModifier, actual.Name, actual.Type)
+		}
+	}
+
+	if constructor := node.Methods[0]; len(constructor.Parameters) != 3 || string(constructor.Parameters[2].Name) != "secret" || string(constructor.Functionality) != "$this->tags = new Collection([]);" {
+		t.Errorf("incorrect constructor: %s", constructor.Functionality)
+	}
+
+	if find := node.Methods[2]; len(find.Parameters) != 1 || string(find.Parameters[0].Name) != "ids" {
+		t.Errorf("incorrect variadic parameter")
+	}
+
+	trait, ok := response.Classes[1].Node.(types.PhpTrait)
+	if !ok || string(trait.Name) != "HasComments" || len(trait.Variables) != 1 || len(trait.Methods) != 1 || string(trait.Methods[0].Type) != "void" {
+		t.Errorf("incorrect trait")
+	}
+
+	status, ok := response.Classes[2].Node.(types.JavaEnum)
+	if !ok || len(status.Declarations) != 2 || string(status.Declarations[1]) != "Published" || len(status.Implements) != 1 {
+		t.Errorf("incorrect enum")
+	}
+}
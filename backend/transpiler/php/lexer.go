@@ -0,0 +1,181 @@
+package php
+
+import "strings"
+
+type tokenKind int
+
+const (
+	identifierToken tokenKind = iota
+	variableToken
+	punctuationToken
+	stringToken
+	numberToken
+)
+
+type token struct {
+	Kind tokenKind
+	Text string
+}
+
+// Punctuation made of more than one character that matters to the parser
+var multiCharPunctuation = []string{"?->", "::", "->", "=>", "...", "??", "==", "!=", "<=", ">=", "&&", "||"}
+
+// Splits code into tokens. Comments, whitespace and the HTML outside of <?php ?> tags are removed.
+// Names keep their namespace separators: \App\Models\User is one identifier.
+func tokenize(code []byte) []token {
+	var (
+		response []token
+		text     = string(code)
+		i        = skipInlineHTML(text, 0)
+	)
+
+	appendToken := func(kind tokenKind, start, end int) {
+		response = append(response, token{Kind: kind, Text: text[start:end]})
+	}
+
+	for i < len(text) {
+		c := text[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f':
+			i++
+		case strings.HasPrefix(text[i:], "?>"):
+			// The closing tag ends a statement like a semicolon
+			response = append(response, token{Kind: punctuationToken, Text: ";"})
+			i = skipInlineHTML(text, i+2)
+		case strings.HasPrefix(text[i:], "#["):
+			// Attributes are kept so that the parser can skip them: #[Route("/")]
+			appendToken(punctuationToken, i, i+2)
+			i += 2
+		case strings.HasPrefix(text[i:], "//") || c == '#':
+			for i < len(text) && text[i] != '\n' && !strings.HasPrefix(text[i:], "?>") {
+				i++
+			}
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end == -1 {
+				i = len(text)
+				break
+			}
+
+			i += end + 4
+		case strings.HasPrefix(text[i:], "<<<"):
+			end := skipHeredoc(text, i)
+			appendToken(stringToken, i, end)
+			i = end
+		case c == '"' || c == '\'' || c == '`':
+			end := skipString(text, i)
+			appendToken(stringToken, i, end)
+			i = end
+		case c == '$' && i+1 < len(text) && isIdentifierStart(text[i+1]):
+			end := i + 2
+			for end < len(text) && isIdentifierPart(text[end]) {
+				end++
+			}
+
+			appendToken(variableToken, i, end)
+			i = end
+		case isIdentifierStart(c) || (c == '\\' && i+1 < len(text) && isIdentifierStart(text[i+1])):
+			end := i + 1
+			for end < len(text) && (isIdentifierPart(text[end]) || text[end] == '\\' && end+1 < len(text) && isIdentifierStart(text[end+1])) {
+				end++
+			}
+
+			appendToken(identifierToken, i, end)
+			i = end
+		case c >= '0' && c <= '9':
+			end := i + 1
+			for end < len(text) && (isIdentifierPart(text[end]) || text[end] == '.' && end+1 < len(text) && text[end+1] >= '0' && text[end+1] <= '9') {
+				end++
+			}
+
+			appendToken(numberToken, i, end)
+			i = end
+		default:
+			end := i + 1
+			for _, punctuation := range multiCharPunctuation {
+				if strings.HasPrefix(text[i:], punctuation) {
+					end = i + len(punctuation)
+					break
+				}
+			}
+
+			appendToken(punctuationToken, i, end)
+			i = end
+		}
+	}
+
+	return response
+}
+
+// Returns the index after the next opening tag: <?php or <?=
+func skipInlineHTML(text string, start int) int {
+	index := strings.Index(text[start:], "<?")
+	if index == -1 {
+		return len(text)
+	}
+
+	i := start + index + 2
+	if strings.HasPrefix(text[i:], "php") {
+		i += 3
+	} else if strings.HasPrefix(text[i:], "=") {
+		i++
+	}
+
+	return i
+}
+
+// Returns the index after the string that starts at start
+func skipString(text string, start int) int {
+	quote := text[start]
+
+	for i := start + 1; i < len(text); i++ {
+		if text[i] == '\\' {
+			i++
+		} else if text[i] == quote {
+			return i + 1
+		}
+	}
+
+	return len(text)
+}
+
+// Returns the index after the heredoc or nowdoc that starts at start: <<<EOT ... EOT
+func skipHeredoc(text string, start int) int {
+	i := start + 3
+	for i < len(text) && (text[i] == ' ' || text[i] == '\t') {
+		i++
+	}
+
+	lineEnd := strings.IndexByte(text[i:], '\n')
+	if lineEnd == -1 {
+		return len(text)
+	}
+
+	label := strings.Trim(strings.TrimSpace(text[i:i+lineEnd]), `'"`)
+
+	// The closing label is the first word of a line, it may be indented since PHP 7.3
+	for i += lineEnd + 1; i < len(text); {
+		lineEnd := strings.IndexByte(text[i:], '\n')
+		if lineEnd == -1 {
+			lineEnd = len(text) - i
+		}
+
+		line := strings.TrimLeft(text[i:i+lineEnd], " \t")
+		if strings.HasPrefix(line, label) && (len(line) == len(label) || !isIdentifierPart(line[len(label)])) {
+			return i + lineEnd - len(line) + len(label)
+		}
+
+		i += lineEnd + 1
+	}
+
+	return len(text)
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,42 @@
+package php
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	type TokenizeTest struct {
+		Input  string
+		Output []string
+	}
+
+	var tests = []TokenizeTest{
+		{
+			Input:  "<h1><?= $title ?></h1>\n<?php\n$x = 1; # comment\n",
+			Output: []string{"$title", ";", "$x", "=", "1", ";"},
+		},
+		{
+			Input:  `<?php use \App\Models\{User, Post}; #[Route("/")] $user?->name;`,
+			Output: []string{"use", `\App\Models`, `\`, "{", "User", ",", "Post", "}", ";", "#[", "Route", "(", `"/"`, ")", "]", "$user", "?->", "name", ";"},
+		},
+		{
+			Input:  "<?php $s = <<<EOT\n  class Fake {}\n  EOT;\n$n = 1_000;",
+			Output: []string{"$s", "=", "<<<EOT\n  class Fake {}\n  EOT", ";", "$n", "=", "1_000", ";"},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			var actualOutput []string
+			for _, token := range tokenize([]byte(tt.Input)) {
+				actualOutput = append(actualOutput, token.Text)
+			}
+
+			if strings.Join(actualOutput, " ") != strings.Join(tt.Output, " ") {
+				subtest.Errorf("incorrect tokens.\nexpected:\n%s\ngot:\n%s\n", tt.Output, actualOutput)
+			}
+		})
+	}
+}
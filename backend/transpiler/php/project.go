@@ -0,0 +1,208 @@
+package php
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func ParseProject(ctx context.Context, files []types.File) *types.Project {
+	var (
+		response types.Project
+		classes  []class
+	)
+
+	parsedFiles, diagnostics := types.ParseFiles(ctx, files, parseFile)
+	response.Diagnostics = diagnostics
+
+	for _, parsedFile := range parsedFiles {
+		classes = append(classes, parsedFile.Classes...)
+	}
+
+	// The key is the lowercase fully qualified name of a class, because PHP class names are case insensitive.
+	// Classes declared by more than one file keep their first declaration.
+	classIds := make(map[string]string)
+	for _, c := range classes {
+		pkg, name := getClassPackageAndName(c.Node)
+		key := strings.ToLower(joinNamespace(getNamespace(pkg), name))
+
+		if _, ok := classIds[key]; !ok {
+			classIds[key] = string(pkg) + "." + name
+		}
+	}
+
+	for _, c := range classes {
+		var uses []types.CustomByteSlice
+		for _, use := range c.Uses {
+			uses = append(uses, []byte(use))
+		}
+
+		uses = resolveNames(classIds, c.Scope, uses)
+
+		switch node := c.Node.(type) {
+		case types.JavaAbstract:
+			node.Implements = resolveNames(classIds, c.Scope, node.Implements)
+			node.Extends = resolveNames(classIds, c.Scope, node.Extends)
+			node.Associations, node.Dependencies = getClassAssociationsAndDependencies(classIds, c.Scope, node.Associations, node.Dependencies)
+			response.Nodes = append(response.Nodes, node)
+			addClassRelations(&response, node.Package, node.Name, node.Extends, node.Implements, uses, node.Associations, node.Dependencies)
+		case types.JavaClass:
+			node.Implements = resolveNames(classIds, c.Scope, node.Implements)
+			node.Extends = resolveNames(classIds, c.Scope, node.Extends)
+			node.Associations, node.Dependencies = getClassAssociationsAndDependencies(classIds, c.Scope, node.Associations, node.Dependencies)
+			response.Nodes = append(response.Nodes, node)
+			addClassRelations(&response, node.Package, node.Name, node.Extends, node.Implements, uses, node.Associations, node.Dependencies)
+		case types.JavaInterface:
+			node.Extends = resolveNames(classIds, c.Scope, node.Extends)
+			node.Associations, node.Dependencies = getClassAssociationsAndDependencies(classIds, c.Scope, node.Associations, node.Dependencies)
+			response.Nodes = append(response.Nodes, node)
+			addClassRelations(&response, node.Package, node.Name, node.Extends, nil, nil, node.Associations, node.Dependencies)
+		case types.PhpTrait:
+			node.Uses = uses
+			node.Associations, node.Dependencies = getClassAssociationsAndDependencies(classIds, c.Scope, node.Associations, node.Dependencies)
+			response.Nodes = append(response.Nodes, node)
+			addClassRelations(&response, node.Package, node.Name, nil, nil, node.Uses, node.Associations, node.Dependencies)
+		case types.JavaEnum:
+			node.Implements = resolveNames(classIds, c.Scope, node.Implements)
+			response.Nodes = append(response.Nodes, node)
+			addClassRelations(&response, node.Package, node.Name, nil, node.Implements, uses, nil, nil)
+		}
+	}
+
+	return &response
+}
+
+// Resolves a name as it is written to a class id, or returns an empty string.
+// Fully qualified names start with a backslash, other names start with an alias or are in the current namespace.
+func resolveName(classIds map[string]string, s *scope, name string) string {
+	var qualifiedName string
+
+	switch {
+	case strings.HasPrefix(name, "\\"):
+		qualifiedName = name[1:]
+	case strings.HasPrefix(strings.ToLower(name), "namespace\\"):
+		qualifiedName = joinNamespace(s.Namespace, name[len("namespace\\"):])
+	default:
+		first, rest := name, ""
+		if index := strings.IndexByte(name, '\\'); index != -1 {
+			first, rest = name[:index], name[index:]
+		}
+
+		if use, ok := s.Uses[strings.ToLower(first)]; ok {
+			qualifiedName = use + rest
+		} else {
+			qualifiedName = joinNamespace(s.Namespace, name)
+		}
+	}
+
+	return classIds[strings.ToLower(qualifiedName)]
+}
+
+func resolveNames(classIds map[string]string, s *scope, names []types.CustomByteSlice) []types.CustomByteSlice {
+	var response []types.CustomByteSlice
+
+	for _, name := range names {
+		if classId := resolveName(classIds, s, string(name)); classId != "" {
+			response = append(response, []byte(classId))
+		}
+	}
+
+	return response
+}
+
+// Returns associations and dependencies as class ids. Property types are associations, parameter types
+// and the classes used by method bodies are dependencies.
+func getClassAssociationsAndDependencies(classIds map[string]string, s *scope, associations, dependencies []types.CustomByteSlice) ([]types.CustomByteSlice, []types.CustomByteSlice) {
+	var (
+		associationsMap = make(map[string]struct{})
+		dependenciesMap = make(map[string]struct{})
+	)
+
+	for _, classId := range resolveNames(classIds, s, associations) {
+		associationsMap[string(classId)] = struct{}{}
+	}
+
+	for _, classId := range resolveNames(classIds, s, dependencies) {
+		// Association is a stronger form of a dependency
+		if _, ok := associationsMap[string(classId)]; !ok {
+			dependenciesMap[string(classId)] = struct{}{}
+		}
+	}
+
+	return sortedKeys(associationsMap), sortedKeys(dependenciesMap)
+}
+
+func addClassRelations(project *types.Project, packageName, name []byte, extends, implements, uses, associations, dependencies []types.CustomByteSlice) {
+	fromClassId := []byte(string(packageName) + "." + string(name))
+
+	for _, extend := range extends {
+		project.AddRelation(fromClassId, extend, &types.Generalization{})
+	}
+
+	for _, implement := range implements {
+		project.AddRelation(fromClassId, implement, &types.Realization{})
+	}
+
+	for _, use := range uses {
+		project.AddRelation(fromClassId, use, &types.Uses{})
+	}
+
+	for _, association := range associations {
+		project.AddRelation(fromClassId, association, &types.Association{})
+	}
+
+	for _, dependency := range dependencies {
+		project.AddRelation(fromClassId, dependency, &types.Dependency{})
+	}
+}
+
+func getClassPackageAndName(class any) ([]byte, string) {
+	switch c := class.(type) {
+	case types.JavaAbstract:
+		return c.Package, string(c.Name)
+	case types.JavaClass:
+		return c.Package, string(c.Name)
+	case types.JavaInterface:
+		return c.Package, string(c.Name)
+	case types.JavaEnum:
+		return c.Package, string(c.Name)
+	case types.PhpTrait:
+		return c.Package, string(c.Name)
+	}
+
+	return nil, ""
+}
+
+func getNamespace(pkg []byte) string {
+	if string(pkg) == globalNamespace {
+		return ""
+	}
+
+	return string(pkg)
+}
+
+func joinNamespace(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+
+	return namespace + "\\" + name
+}
+
+func sortedKeys(m map[string]struct{}) []types.CustomByteSlice {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var response []types.CustomByteSlice
+	for _, key := range keys {
+		response = append(response, []byte(key))
+	}
+
+	return response
+}
@@ -0,0 +1,164 @@
+package php
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func relationToString(relation types.Relation) string {
+	return fmt.Sprintf("%s -> %s %T from:%t to:%t", relation.FromClassId, relation.ToClassId, relation.Type, relation.Type.GetFromArrow(), relation.Type.GetToArrow())
+}
+
+func TestParseProject(t *testing.T) {
+	type Output struct {
+		Nodes []string
+		Edges []types.Relation
+	}
+
+	type ParseProjectTest struct {
+		Input  []types.File
+		Output Output
+	}
+
+	var tests = []ParseProjectTest{
+		{
+			Input: []types.File{
+				{
+					Name:      "Contracts",
+					Extension: "php",
+					Code: []byte(`<?php
+namespace App\Contracts;
+
+interface Shape
+{
+	public function area(): float;
+}
+
+interface Drawable extends Shape
+{
+	public function draw(Canvas $canvas): void;
+}
+
+trait Loggable
+{
+	protected ?\App\Support\Logger $logger = null;
+}
+`),
+				},
+				{
+					Name:      "Circle",
+					Extension: "php",
+					Code: []byte(`<?php
+namespace App\Shapes;
+
+use App\Contracts\Drawable as CanDraw;
+use App\Contracts\{Loggable, Canvas};
+use App\Support;
+
+abstract class Figure implements CanDraw
+{
+	use Loggable;
+}
+
+final class Circle extends Figure
+{
+	public function __construct(private Point $center, private float $radius) {}
+
+	public function area(): float
+	{
+		return pi() * $this->radius ** 2;
+	}
+
+	public function draw(Canvas $canvas): void
+	{
+		$color = Support\Color::random();
+	}
+}
+
+class Point
+{
+	public function __construct(public float $x = 0, public float $y = 0) {}
+}
+`),
+				},
+				{
+					Name:      "Support",
+					Extension: "php",
+					Code: []byte(`<?php
+namespace App\Support {
+	class Logger {}
+
+	enum Color: string
+	{
+		case Red = 'red';
+
+		public static function random(): self { return self::Red; }
+	}
+}
+
+namespace {
+	class Kernel
+	{
+		private App\Shapes\Circle $shape;
+	}
+}
+`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{
+					`App\Contracts.Shape`, `App\Contracts.Drawable`, `App\Contracts.Loggable`,
+					`App\Shapes.Figure`, `App\Shapes.Circle`, `App\Shapes.Point`,
+					`App\Support.Logger`, `App\Support.Color`, `default.Kernel`,
+				},
+				Edges: []types.Relation{
+					{FromClassId: []byte(`App\Contracts.Drawable`), ToClassId: []byte(`App\Contracts.Shape`), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte(`App\Contracts.Loggable`), ToClassId: []byte(`App\Support.Logger`), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte(`App\Shapes.Figure`), ToClassId: []byte(`App\Contracts.Drawable`), Type: &types.Realization{ToArrow: true}},
+					{FromClassId: []byte(`App\Shapes.Figure`), ToClassId: []byte(`App\Contracts.Loggable`), Type: &types.Uses{ToArrow: true}},
+					{FromClassId: []byte(`App\Shapes.Circle`), ToClassId: []byte(`App\Shapes.Figure`), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte(`App\Shapes.Circle`), ToClassId: []byte(`App\Shapes.Point`), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte(`App\Shapes.Circle`), ToClassId: []byte(`App\Support.Color`), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte(`default.Kernel`), ToClassId: []byte(`App\Shapes.Circle`), Type: &types.Association{ToArrow: true}},
+				},
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := ParseProject(context.Background(), tt.Input)
+
+			var nodes []string
+			for _, node := range response.Nodes {
+				pkg, name := getClassPackageAndName(node)
+				nodes = append(nodes, string(pkg)+"."+name)
+			}
+
+			if fmt.Sprint(nodes) != fmt.Sprint(tt.Output.Nodes) {
+				subtest.Errorf("incorrect nodes.\nexpected:\n%s\ngot:\n%s\n", tt.Output.Nodes, nodes)
+			}
+
+			var expectedEdges, actualEdges []string
+			for _, edge := range tt.Output.Edges {
+				expectedEdges = append(expectedEdges, relationToString(edge))
+			}
+
+			for _, edge := range response.Edges {
+				actualEdges = append(actualEdges, relationToString(edge))
+			}
+
+			sort.Strings(expectedEdges)
+			sort.Strings(actualEdges)
+
+			if fmt.Sprint(expectedEdges) != fmt.Sprint(actualEdges) {
+				subtest.Errorf("incorrect edges.\nexpected:\n%s\ngot:\n%s\n", expectedEdges, actualEdges)
+			}
+		})
+	}
+}
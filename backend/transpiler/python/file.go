@@ -0,0 +1,936 @@
+package python
+
+import (
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+const tabSize = 8
+
+// A logical line of Python code, with comments removed and bracketed or escaped continuations joined
+type line struct {
+	Indent int
+	Text   string
+}
+
+// An import statement. Module is the dotted module path without leading periods, Level is the number of leading periods.
+// Name is the imported class or module name, or "*" for wildcard imports. Name is empty for "import module".
+type pythonImport struct {
+	Module string
+	Level  int
+	Name   string
+	Alias  string
+}
+
+type fileResponse struct {
+	Module  string
+	Package string // The package that relative imports are resolved against
+	Imports []pythonImport
+	Data    []any // Holds [](JavaAbstract | JavaClass | JavaInterface | JavaEnum)
+}
+
+var (
+	enumBases     = map[string]struct{}{"Enum": {}, "IntEnum": {}, "StrEnum": {}, "Flag": {}, "IntFlag": {}}
+	protocolBases = map[string]struct{}{"Protocol": {}}
+	abstractBases = map[string]struct{}{"ABC": {}}
+	ignoredBases  = map[string]struct{}{"object": {}, "Generic": {}, "NamedTuple": {}, "TypedDict": {}}
+)
+
+// Parses a file of the module, see getModuleNames
+func parseFile(file types.File, module string) fileResponse {
+	var (
+		response = fileResponse{Module: module, Package: module}
+		lines    = getLogicalLines(file.Code)
+	)
+
+	// The __init__ file of a package is the package itself
+	if file.Name != "__init__" {
+		response.Package = parentModule(module)
+	}
+
+	if len(lines) == 0 {
+		return response
+	}
+
+	response.Imports = getImports(lines)
+	response.Data = getFileClasses(lines, []byte(response.Module))
+
+	return response
+}
+
+// Split code into logical lines. Comments are removed, and lines inside of brackets,
+// triple quoted strings, or ending with a backslash are joined with the next line.
+func getLogicalLines(code []byte) []line {
+	var (
+		response     []line
+		current      strings.Builder
+		indent       int
+		atLineStart  = true
+		bracketDepth int
+		quote        string // The active string delimiter
+	)
+
+	text := strings.ReplaceAll(string(code), "\r", "")
+
+	flush := func() {
+		trimmed := strings.TrimSpace(current.String())
+		if trimmed != "" {
+			response = append(response, line{Indent: indent, Text: trimmed})
+		}
+
+		current.Reset()
+		atLineStart = true
+		indent = 0
+	}
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		if atLineStart {
+			if c == ' ' {
+				indent++
+				continue
+			} else if c == '\t' {
+				indent += tabSize - indent%tabSize
+				continue
+			} else if c == '\n' {
+				indent = 0
+				continue
+			}
+
+			atLineStart = false
+		}
+
+		if quote != "" {
+			if c == '\\' && i+1 < len(text) {
+				current.WriteByte(c)
+				current.WriteByte(text[i+1])
+				i++
+				continue
+			}
+
+			if strings.HasPrefix(text[i:], quote) {
+				current.WriteString(quote)
+				i += len(quote) - 1
+				quote = ""
+				continue
+			}
+
+			if c == '\n' {
+				// Only triple quoted strings can span multiple lines
+				if len(quote) == 1 {
+					quote = ""
+					flush()
+					continue
+				}
+
+				current.WriteByte(' ')
+				continue
+			}
+
+			current.WriteByte(c)
+			continue
+		}
+
+		switch c {
+		case '#':
+			for i+1 < len(text) && text[i+1] != '\n' {
+				i++
+			}
+		case '"', '\'':
+			if strings.HasPrefix(text[i:], strings.Repeat(string(c), 3)) {
+				quote = strings.Repeat(string(c), 3)
+			} else {
+				quote = string(c)
+			}
+
+			current.WriteString(quote)
+			i += len(quote) - 1
+		case '(', '[', '{':
+			bracketDepth++
+			current.WriteByte(c)
+		case ')', ']', '}':
+			if bracketDepth > 0 {
+				bracketDepth--
+			}
+
+			current.WriteByte(c)
+		case '\\':
+			if i+1 < len(text) && text[i+1] == '\n' {
+				current.WriteByte(' ')
+				i++
+				continue
+			}
+
+			current.WriteByte(c)
+		case '\n':
+			if bracketDepth > 0 {
+				current.WriteByte(' ')
+				continue
+			}
+
+			flush()
+		case '\t':
+			current.WriteByte(' ')
+		default:
+			current.WriteByte(c)
+		}
+	}
+
+	flush()
+
+	// Collapse repeated spaces that were added while joining lines
+	for i := range response {
+		response[i].Text = strings.Join(strings.Fields(response[i].Text), " ")
+	}
+
+	return response
+}
+
+// Get all imports declared at the top level of the file
+func getImports(lines []line) []pythonImport {
+	var response []pythonImport
+
+	for _, l := range lines {
+		if l.Indent != 0 {
+			continue
+		}
+
+		if strings.HasPrefix(l.Text, "import ") {
+			for _, part := range splitTopLevel(strings.TrimPrefix(l.Text, "import "), ',') {
+				module, alias := splitAlias(part)
+				response = append(response, pythonImport{
+					Module: module,
+					Alias:  alias,
+				})
+			}
+		} else if strings.HasPrefix(l.Text, "from ") {
+			module, names, found := strings.Cut(strings.TrimPrefix(l.Text, "from "), " import ")
+			if !found {
+				continue
+			}
+
+			module = strings.TrimSpace(module)
+			level := len(module) - len(strings.TrimLeft(module, "."))
+			module = module[level:]
+			names = strings.Trim(strings.TrimSpace(names), "()")
+
+			for _, part := range splitTopLevel(names, ',') {
+				name, alias := splitAlias(part)
+				if name == "" {
+					continue
+				}
+
+				response = append(response, pythonImport{
+					Module: module,
+					Level:  level,
+					Name:   name,
+					Alias:  alias,
+				})
+			}
+		}
+	}
+
+	return response
+}
+
+// Splits "name as alias" into its parts. The alias defaults to the last dotted component of the name.
+func splitAlias(text string) (string, string) {
+	name, alias, found := strings.Cut(strings.TrimSpace(text), " as ")
+	name = strings.TrimSpace(name)
+
+	if found {
+		return name, strings.TrimSpace(alias)
+	}
+
+	if index := strings.LastIndexByte(name, '.'); index != -1 {
+		return name, name[index+1:]
+	}
+
+	return name, name
+}
+
+// Get all classes declared in the file, including nested classes
+func getFileClasses(lines []line, module []byte) []any {
+	var response []any
+
+	for i := 0; i < len(lines); i++ {
+		if !isClassDeclaration(lines[i].Text) {
+			continue
+		}
+
+		classes, end := parseClass(lines, i, module, nil)
+		response = append(response, classes...)
+		i = end - 1
+	}
+
+	return response
+}
+
+func isClassDeclaration(text string) bool {
+	return strings.HasPrefix(text, "class ") && indexTopLevel(text, ':') != -1
+}
+
+// Get the decorators written directly above the line at index
+func getDecorators(lines []line, index int) []string {
+	var response []string
+
+	for i := index - 1; i >= 0 && lines[i].Indent == lines[index].Indent && strings.HasPrefix(lines[i].Text, "@"); i-- {
+		response = append([]string{strings.TrimPrefix(lines[i].Text, "@")}, response...)
+	}
+
+	return response
+}
+
+// Returns the index of the first line after the block that starts at index
+func getBlockEnd(lines []line, index int) int {
+	end := index + 1
+	for end < len(lines) && lines[end].Indent > lines[index].Indent {
+		end++
+	}
+
+	return end
+}
+
+// Parses the class declared at index and every class nested inside of it.
+// Returns the classes and the index of the first line after the class body.
+func parseClass(lines []line, index int, module []byte, definedWithin []byte) ([]any, int) {
+	var (
+		header     = strings.TrimSuffix(strings.TrimPrefix(lines[index].Text, "class "), ":")
+		decorators = getDecorators(lines, index)
+		end        = getBlockEnd(lines, index)
+		name       = header
+		arguments  string
+		response   []any
+	)
+
+	// The body of a one line class is written after the colon
+	if colonIndex := indexTopLevel(header, ':'); colonIndex != -1 {
+		header = strings.TrimSpace(header[:colonIndex])
+		name = header
+	}
+
+	if openIndex := strings.IndexByte(header, '('); openIndex != -1 {
+		name = strings.TrimSpace(header[:openIndex])
+		arguments = strings.TrimSuffix(header[openIndex+1:], ")")
+	}
+
+	// Remove type parameters: class Box[T]
+	if bracketIndex := strings.IndexByte(name, '['); bracketIndex != -1 {
+		name = name[:bracketIndex]
+	}
+
+	var (
+		extends    []types.CustomByteSlice
+		isEnum     bool
+		isProtocol bool
+		isAbstract bool
+	)
+
+	for _, argument := range splitTopLevel(arguments, ',') {
+		if key, value, found := strings.Cut(argument, "="); found {
+			if strings.TrimSpace(key) == "metaclass" && lastComponent(value) == "ABCMeta" {
+				isAbstract = true
+			}
+
+			continue
+		}
+
+		base := stripSubscript(argument)
+		baseName := lastComponent(base)
+
+		if _, ok := enumBases[baseName]; ok {
+			isEnum = true
+		} else if _, ok := protocolBases[baseName]; ok {
+			isProtocol = true
+		} else if _, ok := abstractBases[baseName]; ok {
+			isAbstract = true
+		} else if _, ok := ignoredBases[baseName]; !ok && base != "" {
+			extends = append(extends, types.CustomByteSlice(base))
+		}
+	}
+
+	var (
+		isDataclass bool
+		isFrozen    bool
+	)
+
+	for _, decorator := range decorators {
+		decoratorName := stripCall(decorator)
+		if lastComponent(decoratorName) == "dataclass" {
+			isDataclass = true
+			isFrozen = strings.Contains(decorator, "frozen=True")
+		}
+	}
+
+	var (
+		variables    []types.JavaVariable
+		methods      []types.JavaMethod
+		declarations []types.CustomByteSlice
+		nested       []any
+		bodyIndent   = -1
+	)
+
+	for i := index + 1; i < end; i++ {
+		if bodyIndent == -1 {
+			bodyIndent = lines[i].Indent
+		}
+
+		if lines[i].Indent != bodyIndent {
+			continue
+		}
+
+		text := lines[i].Text
+
+		switch {
+		case strings.HasPrefix(text, "@"):
+			continue
+		case isClassDeclaration(text):
+			classes, classEnd := parseClass(lines, i, module, []byte(name))
+			nested = append(nested, classes...)
+			i = classEnd - 1
+		case strings.HasPrefix(text, "def ") || strings.HasPrefix(text, "async def "):
+			methodEnd := getBlockEnd(lines, i)
+			method := parseMethod(text, getDecorators(lines, i), lines[i+1:methodEnd])
+			if method.Abstract {
+				isAbstract = true
+			}
+
+			if string(method.Name) == "__init__" {
+				variables = appendInstanceVariables(variables, method, lines[i+1:methodEnd], isFrozen)
+			}
+
+			methods = append(methods, method)
+			i = methodEnd - 1
+		default:
+			classVariables := parseClassVariables(text, isDataclass, isFrozen)
+			if isEnum {
+				for _, variable := range classVariables {
+					declarations = append(declarations, variable.Name)
+				}
+
+				continue
+			}
+
+			for _, variable := range classVariables {
+				variables = appendVariable(variables, variable)
+			}
+		}
+	}
+
+	switch {
+	case isEnum:
+		response = append(response, types.JavaEnum{
+			DefinedWithin: definedWithin,
+			Package:       module,
+			Name:          []byte(name),
+			Declarations:  declarations,
+		})
+	case isProtocol:
+		response = append(response, types.JavaInterface{
+			DefinedWithin: definedWithin,
+			Package:       module,
+			Name:          []byte(name),
+			Extends:       extends,
+			Variables:     variables,
+			Methods:       methods,
+		})
+	case isAbstract:
+		response = append(response, types.JavaAbstract{
+			DefinedWithin: definedWithin,
+			Package:       module,
+			Name:          []byte(name),
+			Extends:       extends,
+			Variables:     variables,
+			Methods:       methods,
+		})
+	default:
+		response = append(response, types.JavaClass{
+			DefinedWithin: definedWithin,
+			Package:       module,
+			Name:          []byte(name),
+			Extends:       extends,
+			Variables:     variables,
+			Methods:       methods,
+		})
+	}
+
+	return append(response, nested...), end
+}
+
+// Parses a method declaration line such as: async def name(self, a: int = 1) -> str:
+func parseMethod(text string, decorators []string, body []line) types.JavaMethod {
+	var method types.JavaMethod
+
+	text = strings.TrimPrefix(text, "async ")
+	text = strings.TrimPrefix(text, "def ")
+
+	openIndex := strings.IndexByte(text, '(')
+	closeIndex := indexClosingBracket(text, openIndex)
+	if openIndex == -1 || closeIndex == -1 {
+		return method
+	}
+
+	method.Name = []byte(strings.TrimSpace(text[:openIndex]))
+	method.AccessModifier = getAccessModifier(string(method.Name))
+
+	rest := strings.TrimSpace(text[closeIndex+1:])
+	if strings.HasPrefix(rest, "->") {
+		returnType := strings.TrimSpace(strings.TrimPrefix(rest, "->"))
+		if colonIndex := indexTopLevel(returnType, ':'); colonIndex != -1 {
+			returnType = returnType[:colonIndex]
+		}
+
+		method.Type = []byte(unquoteAnnotation(returnType))
+	}
+
+	isClassMethod := false
+	for _, decorator := range decorators {
+		switch lastComponent(stripCall(decorator)) {
+		case "abstractmethod":
+			method.Abstract = true
+		case "staticmethod":
+			method.Static = true
+		case "classmethod":
+			method.Static = true
+			isClassMethod = true
+		case "final":
+			method.Final = true
+		}
+	}
+
+	for index, parameter := range splitTopLevel(text[openIndex+1:closeIndex], ',') {
+		if parameter == "/" || parameter == "*" {
+			continue
+		}
+
+		// The first parameter of instance and class methods is the instance or the class
+		if index == 0 && (!method.Static || isClassMethod) && (parameter == "self" || parameter == "cls") {
+			continue
+		}
+
+		name, parameterType, _ := splitAnnotation(parameter)
+		method.Parameters = append(method.Parameters, types.JavaMethodParameter{
+			Type: []byte(parameterType),
+			Name: []byte(name),
+		})
+	}
+
+	var functionality []string
+	for _, l := range body {
+		functionality = append(functionality, l.Text)
+	}
+
+	method.Functionality = []byte(strings.Join(functionality, ";"))
+
+	return method
+}
+
+// Parses a class level statement into variables. Supports:
+// name: Type
+// name: Type = value
+// name = value
+// first = second = value
+func parseClassVariables(text string, isDataclass, isFrozen bool) []types.JavaVariable {
+	var response []types.JavaVariable
+
+	if text == "pass" || text == "..." || strings.HasPrefix(text, "\"") || strings.HasPrefix(text, "'") {
+		return nil
+	}
+
+	equalIndex := indexAssignment(text)
+	colonIndex := indexTopLevel(text, ':')
+
+	if colonIndex != -1 && (equalIndex == -1 || colonIndex < equalIndex) {
+		name, variableType, value := splitAnnotation(text)
+		if !isIdentifier(name) {
+			return nil
+		}
+
+		variable := types.JavaVariable{
+			Type:           []byte(variableType),
+			Name:           []byte(name),
+			AccessModifier: getAccessModifier(name),
+			Final:          isFrozen,
+		}
+
+		if value != "" {
+			variable.Value = []byte(value)
+		}
+
+		if wrapper := stripSubscript(variableType); lastComponent(wrapper) == "ClassVar" {
+			variable.Type = []byte(unwrapSubscript(variableType))
+			variable.Static = true
+		} else if lastComponent(wrapper) == "Final" {
+			variable.Type = []byte(unwrapSubscript(variableType))
+			variable.Final = true
+		}
+
+		return append(response, variable)
+	}
+
+	if equalIndex == -1 {
+		return nil
+	}
+
+	// Every target of a chained assignment receives the value after the last equal sign
+	var (
+		targets []string
+		value   = text
+	)
+
+	for index := indexAssignment(value); index != -1; index = indexAssignment(value) {
+		targets = append(targets, strings.TrimSpace(value[:index]))
+		value = strings.TrimSpace(value[index+1:])
+	}
+
+	for _, target := range targets {
+		if !isIdentifier(target) {
+			continue
+		}
+
+		response = append(response, types.JavaVariable{
+			Name:           []byte(target),
+			Value:          []byte(value),
+			AccessModifier: getAccessModifier(target),
+			Static:         !isDataclass,
+			Final:          isFrozen,
+		})
+	}
+
+	return response
+}
+
+// Adds the attributes assigned to self inside of __init__
+func appendInstanceVariables(variables []types.JavaVariable, init types.JavaMethod, body []line, isFrozen bool) []types.JavaVariable {
+	parameterTypes := make(map[string]string)
+	for _, parameter := range init.Parameters {
+		parameterTypes[string(parameter.Name)] = string(parameter.Type)
+	}
+
+	for _, l := range body {
+		if !strings.HasPrefix(l.Text, "self.") {
+			continue
+		}
+
+		equalIndex := indexAssignment(l.Text)
+		if equalIndex == -1 {
+			continue
+		}
+
+		target := strings.TrimPrefix(strings.TrimSpace(l.Text[:equalIndex]), "self.")
+		value := strings.TrimSpace(l.Text[equalIndex+1:])
+
+		name, variableType, _ := splitAnnotation(target)
+		if !isIdentifier(name) {
+			continue
+		}
+
+		// Infer the type from the parameter or the constructor being assigned
+		if variableType == "" {
+			if parameterType, ok := parameterTypes[value]; ok {
+				variableType = parameterType
+			} else if callee := stripCall(value); callee != value && isIdentifier(strings.ReplaceAll(callee, ".", "")) && isUpper(lastComponent(callee)) {
+				variableType = callee
+			}
+		}
+
+		variable := types.JavaVariable{
+			Type:           []byte(variableType),
+			Name:           []byte(name),
+			AccessModifier: getAccessModifier(name),
+			Final:          isFrozen,
+		}
+
+		if _, ok := parameterTypes[value]; !ok {
+			variable.Value = []byte(value)
+		}
+
+		variables = appendVariable(variables, variable)
+	}
+
+	return variables
+}
+
+// Appends a variable unless one with the same name was already declared
+func appendVariable(variables []types.JavaVariable, variable types.JavaVariable) []types.JavaVariable {
+	for i, existing := range variables {
+		if string(existing.Name) != string(variable.Name) {
+			continue
+		}
+
+		// Keep the declared type, but learn the type from the assignment if it was not declared
+		if len(existing.Type) == 0 {
+			variables[i].Type = variable.Type
+		}
+
+		return variables
+	}
+
+	return append(variables, variable)
+}
+
+// Names starting with two underscores are private and names starting with one underscore are protected.
+// Special methods such as __init__ are public.
+func getAccessModifier(name string) types.CustomByteSlice {
+	if strings.HasPrefix(name, "__") && !strings.HasSuffix(name, "__") {
+		return []byte("private")
+	}
+
+	if strings.HasPrefix(name, "_") && !strings.HasSuffix(name, "__") {
+		return []byte("protected")
+	}
+
+	return []byte("public")
+}
+
+// Splits "name: Type = value" into its parts
+func splitAnnotation(text string) (string, string, string) {
+	var name, annotation, value string
+
+	if equalIndex := indexAssignment(text); equalIndex != -1 {
+		value = strings.TrimSpace(text[equalIndex+1:])
+		text = text[:equalIndex]
+	}
+
+	name = strings.TrimSpace(text)
+	if colonIndex := indexTopLevel(text, ':'); colonIndex != -1 {
+		name = strings.TrimSpace(text[:colonIndex])
+		annotation = unquoteAnnotation(text[colonIndex+1:])
+	}
+
+	return name, annotation, value
+}
+
+// Removes the quotes of forward references, the strings that name a class before it is declared:
+// "Point" -> Point, Optional['Point'] -> Optional[Point]. The values of Literal types are strings and keep their quotes.
+func unquoteAnnotation(text string) string {
+	text = strings.TrimSpace(text)
+
+	// The whole annotation may be a string: "Optional[Point]"
+	if len(text) > 1 && (text[0] == '"' || text[0] == '\'') && strings.IndexByte(text[1:], text[0]) == len(text)-2 {
+		return unquoteAnnotation(text[1 : len(text)-1])
+	}
+
+	var (
+		response   []byte
+		subscripts []string
+		nameStart  int
+	)
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case c == '[':
+			subscripts = append(subscripts, lastComponent(text[nameStart:i]))
+		case c == ']' && len(subscripts) != 0:
+			subscripts = subscripts[:len(subscripts)-1]
+		case c == '"' || c == '\'':
+			endIndex := strings.IndexByte(text[i+1:], c)
+			if endIndex == -1 {
+				return string(append(response, text[i:]...))
+			}
+
+			reference := text[i+1 : i+1+endIndex]
+			if isDottedName(reference) && (len(subscripts) == 0 || subscripts[len(subscripts)-1] != "Literal") {
+				response = append(response, reference...)
+			} else {
+				response = append(response, text[i:i+endIndex+2]...)
+			}
+
+			i += endIndex + 1
+			continue
+		}
+
+		if !isIdentifier(string(c)) && c != '.' && !(c >= '0' && c <= '9') {
+			nameStart = i + 1
+		}
+
+		response = append(response, c)
+	}
+
+	return string(response)
+}
+
+// Returns the index of the first "=" that is an assignment, or -1
+func indexAssignment(text string) int {
+	for i := indexTopLevel(text, '='); i != -1; {
+		before, after := byte(0), byte(0)
+		if i > 0 {
+			before = text[i-1]
+		}
+
+		if i+1 < len(text) {
+			after = text[i+1]
+		}
+
+		if after != '=' && before != '=' && before != '!' && before != '<' && before != '>' && before != ':' {
+			return i
+		}
+
+		next := indexTopLevel(text[i+1:], '=')
+		if next == -1 {
+			return -1
+		}
+
+		i += next + 1
+	}
+
+	return -1
+}
+
+// Returns the index of the first b that is not inside of brackets or quotes, or -1
+func indexTopLevel(text string, b byte) int {
+	var (
+		depth int
+		quote byte
+	)
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+
+			continue
+		}
+
+		switch {
+		case c == b && depth == 0:
+			return i
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		}
+	}
+
+	return -1
+}
+
+// Returns the index of the bracket that closes the one at openIndex, or -1
+func indexClosingBracket(text string, openIndex int) int {
+	if openIndex == -1 {
+		return -1
+	}
+
+	closeIndex := indexTopLevel(text[openIndex+1:], ')')
+	if closeIndex == -1 {
+		return -1
+	}
+
+	return openIndex + 1 + closeIndex
+}
+
+// Splits text on every separator that is not inside of brackets or quotes
+func splitTopLevel(text string, separator byte) []string {
+	var response []string
+
+	for {
+		index := indexTopLevel(text, separator)
+		if index == -1 {
+			break
+		}
+
+		if part := strings.TrimSpace(text[:index]); part != "" {
+			response = append(response, part)
+		}
+
+		text = text[index+1:]
+	}
+
+	if part := strings.TrimSpace(text); part != "" {
+		response = append(response, part)
+	}
+
+	return response
+}
+
+// Removes a trailing subscript: List[int] -> List
+func stripSubscript(text string) string {
+	text = strings.TrimSpace(text)
+	if index := strings.IndexByte(text, '['); index != -1 {
+		return strings.TrimSpace(text[:index])
+	}
+
+	return text
+}
+
+// Returns the contents of a trailing subscript: ClassVar[int] -> int
+func unwrapSubscript(text string) string {
+	openIndex := strings.IndexByte(text, '[')
+	closeIndex := strings.LastIndexByte(text, ']')
+	if openIndex == -1 || closeIndex < openIndex {
+		return text
+	}
+
+	return strings.TrimSpace(text[openIndex+1 : closeIndex])
+}
+
+// Removes call arguments: dataclass(frozen=True) -> dataclass
+func stripCall(text string) string {
+	if index := strings.IndexByte(text, '('); index != -1 {
+		return strings.TrimSpace(text[:index])
+	}
+
+	return strings.TrimSpace(text)
+}
+
+// Returns the text before the last period: shop.models -> shop
+func parentModule(text string) string {
+	if index := strings.LastIndexByte(text, '.'); index != -1 {
+		return text[:index]
+	}
+
+	return ""
+}
+
+// Returns the text after the last period: abc.ABCMeta -> ABCMeta
+func lastComponent(text string) string {
+	text = strings.TrimSpace(text)
+	if index := strings.LastIndexByte(text, '.'); index != -1 {
+		return text[index+1:]
+	}
+
+	return text
+}
+
+// Reports whether the text is a name that may be qualified by its module: admin.Admin
+func isDottedName(text string) bool {
+	for _, component := range strings.Split(text, ".") {
+		if !isIdentifier(component) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isIdentifier(text string) bool {
+	if text == "" {
+		return false
+	}
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func isUpper(text string) bool {
+	return text != "" && text[0] >= 'A' && text[0] <= 'Z'
+}
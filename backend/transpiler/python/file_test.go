@@ -0,0 +1,271 @@
+package python
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestGetLogicalLines(t *testing.T) {
+	type LogicalLinesTest struct {
+		Input  []byte
+		Output []line
+	}
+
+	var tests = []LogicalLinesTest{
+		{
+			Input: []byte("class A:\n    x = 1  # comment\n\n    def f(self):\n\tpass\n"),
+			Output: []line{
+				{Indent: 0, Text: "class A:"},
+				{Indent: 4, Text: "x = 1"},
+				{Indent: 4, Text: "def f(self):"},
+				{Indent: 8, Text: "pass"},
+			},
+		},
+		{
+			Input: []byte("def f(a,\n      b):\n    return a + \\\n        b\n"),
+			Output: []line{
+				{Indent: 0, Text: "def f(a, b):"},
+				{Indent: 4, Text: "return a + b"},
+			},
+		},
+		{
+			Input: []byte("class A:\n    \"\"\"Docs # not a comment\n    more docs\"\"\"\n    y = '#'\n"),
+			Output: []line{
+				{Indent: 0, Text: "class A:"},
+				{Indent: 4, Text: "\"\"\"Docs # not a comment more docs\"\"\""},
+				{Indent: 4, Text: "y = '#'"},
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			actualOutput := getLogicalLines(tt.Input)
+
+			if len(tt.Output) != len(actualOutput) {
+				subtest.Errorf("incorrect length.\nexpected: %s\ngot: %s\n", strconv.Itoa(len(tt.Output)), strconv.Itoa(len(actualOutput)))
+				subtest.FailNow()
+			}
+
+			for index, expected := range tt.Output {
+				if expected != actualOutput[index] {
+					subtest.Errorf("incorrect line.\nexpected:\n%d %s\ngot:\n%d %s\n", expected.Indent, expected.Text, actualOutput[index].Indent, actualOutput[index].Text)
+				}
+			}
+		})
+	}
+}
+
+func TestGetImports(t *testing.T) {
+	type ImportsTest struct {
+		Input  []byte
+		Output []pythonImport
+	}
+
+	var tests = []ImportsTest{
+		{
+			Input: []byte("import os\nimport app.models as m, sys\n"),
+			Output: []pythonImport{
+				{Module: "os", Alias: "os"},
+				{Module: "app.models", Alias: "m"},
+				{Module: "sys", Alias: "sys"},
+			},
+		},
+		{
+			Input: []byte("from .models import (\n    Dog,\n    Cat as Kitten,\n)\nfrom ..shapes import *\n"),
+			Output: []pythonImport{
+				{Module: "models", Level: 1, Name: "Dog", Alias: "Dog"},
+				{Module: "models", Level: 1, Name: "Cat", Alias: "Kitten"},
+				{Module: "shapes", Level: 2, Name: "*", Alias: "*"},
+			},
+		},
+		{
+			Input:  []byte("def f():\n    import os\n"),
+			Output: nil,
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			actualOutput := getImports(getLogicalLines(tt.Input))
+
+			if len(tt.Output) != len(actualOutput) {
+				subtest.Errorf("incorrect length.\nexpected: %s\ngot: %s\n", strconv.Itoa(len(tt.Output)), strconv.Itoa(len(actualOutput)))
+				subtest.FailNow()
+			}
+
+			for index, expected := range tt.Output {
+				if expected != actualOutput[index] {
+					subtest.Errorf("incorrect import.\nexpected:\n%+v\ngot:\n%+v\n", expected, actualOutput[index])
+				}
+			}
+		})
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	file := types.File{
+		Name:      "animals",
+		Extension: "py",
+		Code: []byte(`
+from abc import ABC, abstractmethod
+from dataclasses import dataclass, field
+from enum import Enum
+from typing import ClassVar, Protocol
+
+
+class Color(Enum):
+    RED = 1
+    GREEN = 2
+
+
+class Named(Protocol):
+    def name(self) -> str: ...
+
+
+class Animal(ABC):
+    count: ClassVar[int] = 0
+
+    def __init__(self, owner: "Owner", age: int = 0):
+        self.owner = owner
+        self._age = age
+        self.__secret = Secret()
+
+    @abstractmethod
+    def speak(self) -> str:
+        pass
+
+    @staticmethod
+    def create(kind: str) -> "Animal":
+        return Dog()
+
+
+@dataclass(frozen=True)
+class Dog(Animal, Named):
+    color: Color = Color.RED
+    tags: list[str] = field(default_factory=list)
+
+    class Collar:
+        size = 3
+
+    def speak(self) -> str:
+        return "woof"
+`),
+	}
+
+	response := parseFile(file, "animals")
+
+	if len(response.Data) != 5 {
+		t.Fatalf("incorrect number of classes.\nexpected: 5\ngot: %d\n", len(response.Data))
+	}
+
+	if _, ok := response.Data[0].(types.JavaEnum); !ok {
+		t.Errorf("Color is not an enum")
+	} else if declarations := response.Data[0].(types.JavaEnum).Declarations; len(declarations) != 2 || string(declarations[0]) != "RED" {
+		t.Errorf("incorrect enum declarations: %s", declarations)
+	}
+
+	if _, ok := response.Data[1].(types.JavaInterface); !ok {
+		t.Errorf("Named is not an interface")
+	}
+
+	animal, ok := response.Data[2].(types.JavaAbstract)
+	if !ok {
+		t.Fatalf("Animal is not abstract")
+	}
+
+	expectedVariables := []types.JavaVariable{
+		{Name: []byte("count"), Type: []byte("int"), Value: []byte("0"), AccessModifier: []byte("public"), Static: true},
+		{Name: []byte("owner"), Type: []byte("Owner"), AccessModifier: []byte("public")},
+		{Name: []byte("_age"), Type: []byte("int"), AccessModifier: []byte("protected")},
+		{Name: []byte("__secret"), Type: []byte("Secret"), Value: []byte("Secret()"), AccessModifier: []byte("private")},
+	}
+
+	if len(animal.Variables) != len(expectedVariables) {
+		t.Fatalf("incorrect number of variables.\nexpected: %d\ngot: %d\n", len(expectedVariables), len(animal.Variables))
+	}
+
+	for index, expected := range expectedVariables {
+		actual := animal.Variables[index]
+		if string(actual.Name) != string(expected.Name) || string(actual.Type) != string(expected.Type) || string(actual.Value) != string(expected.Value) ||
+			string(actual.AccessModifier) != string(expected.AccessModifier) || actual.Static != expected.Static {
+			t.Errorf("incorrect variable.\nexpected:\n%s %s %s %s %t\ngot:\n%s %s %s %s %t\n",
+				expected.AccessModifier, expected.Name, expected.Type, expected.Value, expected.Static,
+				actual.AccessModifier, actual.Name, actual.Type, actual.Value, actual.Static)
+		}
+	}
+
+	if len(animal.Methods) != 3 {
+		t.Fatalf("incorrect number of methods.\nexpected: 3\ngot: %d\n", len(animal.Methods))
+	}
+
+	if init := animal.Methods[0]; len(init.Parameters) != 2 || string(init.Parameters[0].Type) != "Owner" {
+		t.Errorf("incorrect __init__ parameters")
+	}
+
+	if speak := animal.Methods[1]; !speak.Abstract || string(speak.Type) != "str" {
+		t.Errorf("speak is not an abstract method returning str")
+	}
+
+	if create := animal.Methods[2]; !create.Static || len(create.Parameters) != 1 {
+		t.Errorf("create is not a static method with one parameter")
+	}
+
+	dog, ok := response.Data[3].(types.JavaClass)
+	if !ok {
+		t.Fatalf("Dog is not a class")
+	}
+
+	if len(dog.Extends) != 2 || string(dog.Extends[0]) != "Animal" || string(dog.Extends[1]) != "Named" {
+		t.Errorf("incorrect base classes: %s", dog.Extends)
+	}
+
+	// Dataclass fields are instance variables, frozen dataclass fields are final
+	if len(dog.Variables) != 2 || dog.Variables[0].Static || !dog.Variables[0].Final || string(dog.Variables[1].Type) != "list[str]" {
+		t.Errorf("incorrect dataclass fields")
+	}
+
+	collar, ok := response.Data[4].(types.JavaClass)
+	if !ok || string(collar.DefinedWithin) != "Dog" || string(collar.Package) != "animals" {
+		t.Errorf("Collar is not nested inside of Dog")
+	}
+}
+
+func TestGetAccessModifier(t *testing.T) {
+	var tests = map[string]string{
+		"name":     "public",
+		"_name":    "protected",
+		"__name":   "private",
+		"__init__": "public",
+	}
+
+	for input, expected := range tests {
+		t.Run(input, func(subtest *testing.T) {
+			if actual := string(getAccessModifier(input)); actual != expected {
+				subtest.Errorf("incorrect access modifier.\nexpected: %s\ngot: %s\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestUnquoteAnnotation(t *testing.T) {
+	var tests = map[string]string{
+		`"Point"`:                   "Point",
+		`'admin.Admin'`:             "admin.Admin",
+		`"Optional['Point']"`:       "Optional[Point]",
+		`dict[str, "Point"]`:        "dict[str, Point]",
+		`Literal["a", 'b']`:         `Literal["a", 'b']`,
+		`typing.Literal['x'] | "P"`: "typing.Literal['x'] | P",
+		`list[int]`:                 "list[int]",
+	}
+
+	for input, expected := range tests {
+		t.Run(input, func(subtest *testing.T) {
+			if actual := unquoteAnnotation(input); actual != expected {
+				subtest.Errorf("incorrect annotation.\nexpected: %s\ngot: %s\n", expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package python
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+var identifierRegex = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*`)
+
+func ParseProject(ctx context.Context, files []types.File) *types.Project {
+	moduleNames, namespace := getModuleNames(files)
+	parsedFiles, diagnostics := types.ParseFiles(ctx, files, func(file types.File) fileResponse {
+		return parseFile(file, moduleNames[file.GetPath()])
+	})
+	response := types.Project{Diagnostics: diagnostics}
+
+	moduleClasses := getModuleClasses(parsedFiles)
+
+	for _, parsedFile := range parsedFiles {
+		scope := getFileScope(moduleClasses, namespace, parsedFile)
+
+		for _, parsedClass := range parsedFile.Data {
+			switch class := parsedClass.(type) {
+			case types.JavaAbstract:
+				class.Associations, class.Dependencies = getClassAssociationsAndDependencies(scope, class.Variables, class.Methods)
+				response.Nodes = append(response.Nodes, class)
+				addClassRelations(&response, moduleClasses, scope, class.Package, class.Name, class.Extends, class.DefinedWithin, class.Associations, class.Dependencies, false)
+			case types.JavaClass:
+				class.Associations, class.Dependencies = getClassAssociationsAndDependencies(scope, class.Variables, class.Methods)
+				response.Nodes = append(response.Nodes, class)
+				addClassRelations(&response, moduleClasses, scope, class.Package, class.Name, class.Extends, class.DefinedWithin, class.Associations, class.Dependencies, false)
+			case types.JavaInterface:
+				class.Associations, class.Dependencies = getClassAssociationsAndDependencies(scope, class.Variables, class.Methods)
+				response.Nodes = append(response.Nodes, class)
+				addClassRelations(&response, moduleClasses, scope, class.Package, class.Name, class.Extends, class.DefinedWithin, class.Associations, class.Dependencies, true)
+			case types.JavaEnum:
+				response.Nodes = append(response.Nodes, class)
+				addClassRelations(&response, moduleClasses, scope, class.Package, class.Name, nil, class.DefinedWithin, nil, nil, false)
+			}
+		}
+	}
+
+	return &response
+}
+
+// Returns the dotted module name of every file, keyed by the path of the file.
+// Paths are relative to the directory that holds every file, or to the parent of the top level package when that directory is a package.
+// The __init__ file of a package is named after the package: shop/models/__init__.py -> shop.models
+// Also returns the name of the directory that the paths are relative to, which imports start with when it is a namespace package.
+func getModuleNames(files []types.File) (map[string]string, string) {
+	var (
+		response = make(map[string]string)
+		packages = make(map[string]struct{})
+		root     string
+	)
+
+	for i, file := range files {
+		dir := path.Dir(strings.ReplaceAll(file.GetPath(), "\\", "/"))
+		if file.Name == "__init__" {
+			packages[dir] = struct{}{}
+		}
+
+		if i == 0 {
+			root = dir
+		}
+
+		for root != "." && dir != root && !strings.HasPrefix(dir, root+"/") {
+			root = path.Dir(root)
+		}
+	}
+
+	for root != "." {
+		if _, ok := packages[root]; !ok {
+			break
+		}
+
+		root = path.Dir(root)
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(strings.ReplaceAll(file.GetPath(), "\\", "/"), "."+file.Extension)
+		if root != "." {
+			name = strings.TrimPrefix(name, root+"/")
+		}
+
+		if file.Name == "__init__" {
+			name = path.Dir(name)
+		}
+
+		module := strings.ReplaceAll(strings.Trim(name, "./"), "/", ".")
+		if module == "" {
+			module = file.Name
+		}
+
+		response[file.GetPath()] = module
+	}
+
+	if root == "." {
+		return response, ""
+	}
+
+	return response, path.Base(root)
+}
+
+// Returns every class declared in the project.
+// The key is the module name, the inner key is the class name and the inner value is the class.
+func getModuleClasses(parsedFiles []fileResponse) map[string]map[string]any {
+	response := make(map[string]map[string]any)
+
+	for _, parsedFile := range parsedFiles {
+		if _, ok := response[parsedFile.Module]; !ok {
+			response[parsedFile.Module] = make(map[string]any)
+		}
+
+		for _, parsedClass := range parsedFile.Data {
+			response[parsedFile.Module][string(getClassName(parsedClass))] = parsedClass
+		}
+	}
+
+	return response
+}
+
+// Returns the names that are visible inside of a file.
+// The key is the name as written in the file and the value is the class id, or the module name followed by a period for imported modules.
+func getFileScope(moduleClasses map[string]map[string]any, namespace string, parsedFile fileResponse) map[string]string {
+	scope := make(map[string]string)
+
+	for _, impt := range parsedFile.Imports {
+		name := impt.Module
+		if impt.Level > 0 {
+			// Every period after the first one moves up one package
+			pkg := parsedFile.Package
+			for i := 1; i < impt.Level; i++ {
+				pkg = parentModule(pkg)
+			}
+
+			name = joinModule(pkg, impt.Module)
+		} else if _, ok := resolveModule(moduleClasses, name); !ok && namespace != "" && (name == namespace || strings.HasPrefix(name, namespace+".")) {
+			// from app import admin, where app is the uploaded directory
+			name = strings.TrimPrefix(strings.TrimPrefix(name, namespace), ".")
+		}
+
+		module, ok := resolveModule(moduleClasses, name)
+
+		switch {
+		case impt.Name == "":
+			if ok {
+				scope[impt.Alias] = module + "."
+				scope[impt.Module] = module + "."
+			}
+		case impt.Name == "*":
+			for className := range moduleClasses[module] {
+				scope[className] = module + "." + className
+			}
+		default:
+			if _, found := moduleClasses[module][impt.Name]; ok && found {
+				scope[impt.Alias] = module + "." + impt.Name
+			} else if submodule, ok := resolveModule(moduleClasses, joinModule(name, impt.Name)); ok {
+				// from package import module
+				scope[impt.Alias] = submodule + "."
+			}
+		}
+	}
+
+	// Classes declared in the file take precedence over imports
+	for className := range moduleClasses[parsedFile.Module] {
+		scope[className] = parsedFile.Module + "." + className
+	}
+
+	return scope
+}
+
+// Returns the module of the project with the dotted name. The project can be uploaded without the directories that
+// its imports start with, so a name that is not found matches the one module that ends with it, such as shop.models for models.
+func resolveModule(moduleClasses map[string]map[string]any, name string) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+
+	if _, ok := moduleClasses[name]; ok {
+		return name, true
+	}
+
+	var response string
+	for module := range moduleClasses {
+		if strings.HasSuffix(module, "."+name) {
+			if response != "" {
+				return "", false
+			}
+
+			response = module
+		}
+	}
+
+	return response, response != ""
+}
+
+func joinModule(pkg, module string) string {
+	if pkg == "" || module == "" {
+		return pkg + module
+	}
+
+	return pkg + "." + module
+}
+
+// Resolves a name such as "Dog" or "models.Dog" to a class id, or returns an empty string
+func resolveName(scope map[string]string, name string) string {
+	if classId, ok := scope[name]; ok && !strings.HasSuffix(classId, ".") {
+		return classId
+	}
+
+	index := strings.LastIndexByte(name, '.')
+	if index == -1 {
+		return ""
+	}
+
+	if module, ok := scope[name[:index]]; ok && strings.HasSuffix(module, ".") {
+		return module + name[index+1:]
+	}
+
+	return ""
+}
+
+// Returns the class ids of every project class referenced in the text
+func getReferencedClassIds(scope map[string]string, text string) []string {
+	var response []string
+
+	for _, name := range identifierRegex.FindAllString(text, -1) {
+		if classId := resolveName(scope, name); classId != "" {
+			response = append(response, classId)
+		}
+	}
+
+	return response
+}
+
+// Returns associations and dependencies
+func getClassAssociationsAndDependencies(scope map[string]string, variables []types.JavaVariable, methods []types.JavaMethod) ([]types.CustomByteSlice, []types.CustomByteSlice) {
+	var (
+		associationsMap = make(map[string]struct{})
+		dependenciesMap = make(map[string]struct{})
+	)
+
+	for _, variable := range variables {
+		for _, classId := range getReferencedClassIds(scope, string(variable.Type)) {
+			associationsMap[classId] = struct{}{}
+		}
+
+		for _, classId := range getReferencedClassIds(scope, string(variable.Value)) {
+			associationsMap[classId] = struct{}{}
+		}
+	}
+
+	for _, method := range methods {
+		for _, parameter := range method.Parameters {
+			for _, classId := range getReferencedClassIds(scope, string(parameter.Type)) {
+				dependenciesMap[classId] = struct{}{}
+			}
+		}
+
+		for _, classId := range getReferencedClassIds(scope, removeStrings(string(method.Functionality))) {
+			dependenciesMap[classId] = struct{}{}
+		}
+	}
+
+	// Association is a stronger form of a dependency
+	for classId := range associationsMap {
+		delete(dependenciesMap, classId)
+	}
+
+	return sortedKeys(associationsMap), sortedKeys(dependenciesMap)
+}
+
+func addClassRelations(project *types.Project, moduleClasses map[string]map[string]any, scope map[string]string, module, name []byte, extends []types.CustomByteSlice, definedWithin []byte, associations, dependencies []types.CustomByteSlice, isInterface bool) {
+	fromClassId := []byte(string(module) + "." + string(name))
+
+	for _, extend := range extends {
+		toClassId := resolveName(scope, string(extend))
+		if toClassId == "" {
+			continue
+		}
+
+		// Classes realize protocols, protocols extend protocols
+		if _, ok := getClass(moduleClasses, toClassId).(types.JavaInterface); ok && !isInterface {
+			project.AddRelation(fromClassId, []byte(toClassId), &types.Realization{})
+			continue
+		}
+
+		project.AddRelation(fromClassId, []byte(toClassId), &types.Generalization{})
+	}
+
+	if definedWithin != nil {
+		project.AddRelation(fromClassId, []byte(string(module)+"."+string(definedWithin)), &types.NestedOwnership{})
+	}
+
+	for _, association := range associations {
+		project.AddRelation(fromClassId, association, &types.Association{})
+	}
+
+	for _, dependency := range dependencies {
+		project.AddRelation(fromClassId, dependency, &types.Dependency{})
+	}
+}
+
+func getClass(moduleClasses map[string]map[string]any, classId string) any {
+	index := strings.LastIndexByte(classId, '.')
+	if index == -1 {
+		return nil
+	}
+
+	return moduleClasses[classId[:index]][classId[index+1:]]
+}
+
+func getClassName(class any) []byte {
+	switch c := class.(type) {
+	case types.JavaAbstract:
+		return c.Name
+	case types.JavaClass:
+		return c.Name
+	case types.JavaInterface:
+		return c.Name
+	case types.JavaEnum:
+		return c.Name
+	}
+
+	return nil
+}
+
+// Replaces the contents of string literals with spaces so that words inside of them are not mistaken for names
+func removeStrings(text string) string {
+	var (
+		response = []byte(text)
+		quote    byte
+	)
+
+	for i := 0; i < len(response); i++ {
+		c := response[i]
+
+		if quote == 0 {
+			if c == '"' || c == '\'' {
+				quote = c
+			}
+
+			continue
+		}
+
+		if c == '\\' && i+1 < len(response) {
+			response[i], response[i+1] = ' ', ' '
+			i++
+		} else if c == quote {
+			quote = 0
+		} else {
+			response[i] = ' '
+		}
+	}
+
+	return string(response)
+}
+
+func sortedKeys(m map[string]struct{}) []types.CustomByteSlice {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var response []types.CustomByteSlice
+	for _, key := range keys {
+		response = append(response, []byte(key))
+	}
+
+	return response
+}
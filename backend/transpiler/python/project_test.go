@@ -0,0 +1,249 @@
+package python
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func relationToString(relation types.Relation) string {
+	return fmt.Sprintf("%s -> %s %T from:%t to:%t", relation.FromClassId, relation.ToClassId, relation.Type, relation.Type.GetFromArrow(), relation.Type.GetToArrow())
+}
+
+func TestParseProject(t *testing.T) {
+	type Output struct {
+		Nodes []string
+		Edges []types.Relation
+	}
+
+	type ParseProjectTest struct {
+		Input  []types.File
+		Output Output
+	}
+
+	var tests = []ParseProjectTest{
+		{
+			Input: []types.File{
+				{
+					Name:      "base",
+					Extension: "py",
+					Code: []byte(`
+from abc import ABC, abstractmethod
+from typing import Protocol
+
+
+class Speaker(Protocol):
+    def speak(self) -> str: ...
+
+
+class Animal(ABC):
+    @abstractmethod
+    def speak(self) -> str: ...
+`),
+				},
+				{
+					Name:      "dog",
+					Extension: "py",
+					Code: []byte(`
+from .base import Animal, Speaker
+from . import owner as people
+
+
+class Dog(Animal, Speaker):
+    def __init__(self, owner: "people.Owner"):
+        self.owner = owner
+
+    def speak(self) -> str:
+        return "Dog says woof"
+`),
+				},
+				{
+					Name:      "owner",
+					Extension: "py",
+					Code: []byte(`
+import base
+
+
+class Owner:
+    class Address:
+        street: str
+
+    def adopt(self, pet: base.Animal) -> None:
+        pet.speak()
+`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{"base.Speaker", "base.Animal", "dog.Dog", "owner.Owner", "owner.Address"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("dog.Dog"), ToClassId: []byte("base.Animal"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("dog.Dog"), ToClassId: []byte("base.Speaker"), Type: &types.Realization{ToArrow: true}},
+					{FromClassId: []byte("dog.Dog"), ToClassId: []byte("owner.Owner"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("owner.Owner"), ToClassId: []byte("base.Animal"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("owner.Address"), ToClassId: []byte("owner.Owner"), Type: &types.NestedOwnership{ToArrow: true}},
+				},
+			},
+		},
+		{
+			Input: []types.File{
+				{
+					Name:      "shapes",
+					Extension: "py",
+					Code: []byte(`
+class Shape:
+    pass
+
+
+class Circle(Shape):
+    pass
+`),
+				},
+				{
+					Name:      "canvas",
+					Extension: "py",
+					Code: []byte(`
+from shapes import *
+
+
+class Canvas:
+    shapes: list[Shape] = []
+
+    def add(self) -> None:
+        self.shapes.append(Circle())
+
+
+class Layer(Canvas):
+    canvas: Canvas
+`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{"shapes.Shape", "shapes.Circle", "canvas.Canvas", "canvas.Layer"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("shapes.Circle"), ToClassId: []byte("shapes.Shape"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("canvas.Canvas"), ToClassId: []byte("shapes.Shape"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("canvas.Canvas"), ToClassId: []byte("shapes.Circle"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("canvas.Layer"), ToClassId: []byte("canvas.Canvas"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("canvas.Layer"), ToClassId: []byte("canvas.Canvas"), Type: &types.Association{ToArrow: true}},
+				},
+			},
+		},
+		{
+			// Modules with the same file name are told apart by their package
+			Input: []types.File{
+				{Path: "shop/__init__.py", Name: "__init__", Extension: "py", Code: []byte{}},
+				{Path: "shop/accounts/__init__.py", Name: "__init__", Extension: "py", Code: []byte(`
+from .models import User
+
+
+class Registry:
+    users: list[User]
+`)},
+				{Path: "shop/accounts/models.py", Name: "models", Extension: "py", Code: []byte(`
+class User:
+    name: str
+`)},
+				{Path: "shop/billing/models.py", Name: "models", Extension: "py", Code: []byte(`
+class User:
+    account_id: int
+`)},
+				{Path: "shop/billing/invoice.py", Name: "invoice", Extension: "py", Code: []byte(`
+from .models import User
+from ..accounts import models as accounts
+
+
+class Invoice:
+    customer: accounts.User
+    billed_to: User
+`)},
+			},
+			Output: Output{
+				Nodes: []string{"shop.accounts.Registry", "shop.accounts.models.User", "shop.billing.models.User", "shop.billing.invoice.Invoice"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("shop.accounts.Registry"), ToClassId: []byte("shop.accounts.models.User"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("shop.billing.invoice.Invoice"), ToClassId: []byte("shop.accounts.models.User"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("shop.billing.invoice.Invoice"), ToClassId: []byte("shop.billing.models.User"), Type: &types.Association{ToArrow: true}},
+				},
+			},
+		},
+		{
+			// Forward references lose their quotes, and the uploaded directory can be imported as a namespace package
+			Input: []types.File{
+				{Path: "app/admin.py", Name: "admin", Extension: "py", Code: []byte(`
+class Admin:
+    pass
+`)},
+				{Path: "app/users.py", Name: "users", Extension: "py", Code: []byte(`
+from app import admin
+
+
+class User:
+    def promote(self, by: admin.Admin) -> None:
+        pass
+
+    def invite(self, friend: "Guest") -> "Guest":
+        return friend
+
+
+class Guest:
+    pass
+`)},
+			},
+			Output: Output{
+				Nodes: []string{"admin.Admin", "users.User", "users.Guest"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("users.User"), ToClassId: []byte("admin.Admin"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("users.User"), ToClassId: []byte("users.Guest"), Type: &types.Dependency{ToArrow: true}},
+				},
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := ParseProject(context.Background(), tt.Input)
+
+			if len(response.Nodes) != len(tt.Output.Nodes) {
+				subtest.Fatalf("incorrect number of nodes.\nexpected: %d\ngot: %d\n", len(tt.Output.Nodes), len(response.Nodes))
+			}
+
+			for index, node := range response.Nodes {
+				var classId string
+				switch class := node.(type) {
+				case types.JavaAbstract:
+					classId = string(class.Package) + "." + string(class.Name)
+				case types.JavaClass:
+					classId = string(class.Package) + "." + string(class.Name)
+				case types.JavaInterface:
+					classId = string(class.Package) + "." + string(class.Name)
+				case types.JavaEnum:
+					classId = string(class.Package) + "." + string(class.Name)
+				}
+
+				if classId != tt.Output.Nodes[index] {
+					subtest.Errorf("incorrect node.\nexpected: %s\ngot: %s\n", tt.Output.Nodes[index], classId)
+				}
+			}
+
+			var expectedEdges, actualEdges []string
+			for _, edge := range tt.Output.Edges {
+				expectedEdges = append(expectedEdges, relationToString(edge))
+			}
+
+			for _, edge := range response.Edges {
+				actualEdges = append(actualEdges, relationToString(edge))
+			}
+
+			sort.Strings(expectedEdges)
+			sort.Strings(actualEdges)
+
+			if fmt.Sprint(expectedEdges) != fmt.Sprint(actualEdges) {
+				subtest.Errorf("incorrect edges.\nexpected:\n%s\ngot:\n%s\n", expectedEdges, actualEdges)
+			}
+		})
+	}
+}
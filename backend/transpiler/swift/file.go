@@ -0,0 +1,876 @@
+package swift
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+// Swift has no packages, the types of a module share the package that Java gives to files without one
+const defaultPackage = "default"
+
+var modifierKeywords = map[string]struct{}{
+	"public": {}, "private": {}, "fileprivate": {}, "internal": {}, "open": {}, "package": {},
+	"final": {}, "static": {}, "class": {}, "override": {}, "required": {}, "convenience": {}, "dynamic": {},
+	"mutating": {}, "nonmutating": {}, "lazy": {}, "weak": {}, "unowned": {}, "optional": {}, "indirect": {},
+	"prefix": {}, "postfix": {}, "infix": {}, "nonisolated": {}, "distributed": {},
+}
+
+// Keywords that declare a type
+var typeKeywords = map[string]struct{}{
+	"class": {}, "struct": {}, "enum": {}, "protocol": {}, "actor": {},
+}
+
+// Tokens that continue a statement on the next line when they end a line
+var continuingLineEnds = map[string]struct{}{
+	"=": {}, ".": {}, "?.": {}, ",": {}, "(": {}, "[": {}, ":": {}, "->": {}, "&": {}, "|": {},
+	"+": {}, "-": {}, "*": {}, "/": {}, "%": {}, "&&": {}, "||": {}, "??": {}, "==": {}, "!=": {},
+	"...": {}, "..<": {}, "as": {}, "is": {}, "in": {}, "where": {},
+}
+
+// Tokens that continue the statement of the previous line when they start a line
+var continuingLineStarts = map[string]struct{}{
+	".": {}, "?.": {}, "??": {}, "&&": {}, "||": {}, ":": {}, "->": {}, "=": {}, "{": {}, ")": {}, "]": {}, ",": {},
+	"as": {}, "is": {}, "where": {}, "async": {}, "throws": {}, "rethrows": {},
+}
+
+type fileResponse struct {
+	Classes    []class
+	Extensions []extension
+}
+
+// A node and the name that reaches it from the top level of the module: Dog.Collar
+type class struct {
+	Node          any // Holds JavaClass | JavaInterface | JavaEnum
+	QualifiedName string
+}
+
+// An extension adds its members and conformances to a type that is declared somewhere else
+type extension struct {
+	Name      string
+	Inherits  []string
+	Variables []types.JavaVariable
+	Methods   []types.JavaMethod
+}
+
+type parser struct {
+	tokens   []token
+	code     string
+	response fileResponse
+}
+
+type parameter struct {
+	Name  string
+	Type  string
+	Value string
+}
+
+// A class, struct, enum, protocol, actor or extension before it becomes a node
+type declaration struct {
+	Keyword       string
+	Name          string
+	QualifiedName string
+	Modifiers     map[string]bool
+	Inherits      []string
+	Variables     []types.JavaVariable
+	Methods       []types.JavaMethod
+	Cases         []types.CustomByteSlice
+}
+
+func parseFile(file types.File) fileResponse {
+	p := parser{
+		tokens: tokenize(file.Code),
+		code:   string(file.Code),
+	}
+
+	p.parseBody(0, len(p.tokens), nil)
+
+	return p.response
+}
+
+// Parses the declarations from start to end. Members are added to d, which is nil at the top level of a file,
+// where functions, variables and statements are not part of the diagram.
+func (p *parser) parseBody(start, end int, d *declaration) {
+	for i := start; i < end; {
+		if p.is(i, ";") {
+			i++
+			continue
+		}
+
+		modifiers, keyword := p.readModifiers(i, end)
+		next := keyword + 1
+
+		switch {
+		case keyword >= end:
+			next = end
+		case p.isTypeKeyword(keyword):
+			next = p.parseType(keyword, end, modifiers, d)
+		case p.is(keyword, "extension"):
+			next = p.parseExtension(keyword, end)
+		case d == nil:
+			next = p.memberEnd(keyword, end)
+		case p.is(keyword, "func"):
+			next = p.parseFunction(keyword, end, modifiers, d)
+		case p.is(keyword, "init"):
+			next = p.parseInitializer(keyword, end, modifiers, d)
+		case p.is(keyword, "subscript"):
+			next = p.parseSubscript(keyword, end, modifiers, d)
+		case p.is(keyword, "var") || p.is(keyword, "let"):
+			next = p.parseProperty(keyword, end, modifiers, d)
+		case p.is(keyword, "case") && d.Keyword == "enum":
+			next = p.parseCases(keyword, end, d)
+		default:
+			next = p.memberEnd(keyword, end)
+		}
+
+		if next <= i {
+			next = i + 1
+		}
+
+		i = next
+	}
+}
+
+// Parses the class, struct, enum, protocol or actor whose keyword is at index i and returns the index after it.
+// Nested types are defined within owner.
+func (p *parser) parseType(i, end int, modifiers map[string]bool, owner *declaration) int {
+	d := declaration{Keyword: p.tokens[i].Text, Modifiers: modifiers}
+	i++
+
+	if i >= end || p.tokens[i].Kind != identifierToken {
+		return p.memberEnd(i, end)
+	}
+
+	d.Name = p.tokens[i].Text
+	d.QualifiedName = d.Name
+	if owner != nil {
+		d.QualifiedName = owner.QualifiedName + "." + d.Name
+	}
+
+	i++
+	if p.is(i, "<") {
+		i = p.skipAngles(i)
+	}
+
+	if p.is(i, ":") {
+		d.Inherits, i = p.parseInherits(i+1, end)
+	}
+
+	i = p.skipWhere(i, end)
+
+	// Nodes keep the order of their declarations, so nested types come after the type they are declared in
+	index := len(p.response.Classes)
+	p.response.Classes = append(p.response.Classes, class{QualifiedName: d.QualifiedName})
+
+	if p.is(i, "{") {
+		bodyEnd := p.skipBalanced(i)
+		p.parseBody(i+1, bodyEnd-1, &d)
+		i = bodyEnd
+	}
+
+	p.response.Classes[index].Node = getNode(d, owner)
+
+	return i
+}
+
+// Parses an extension: extension Dog: Walker where Self: Animal { ... }
+func (p *parser) parseExtension(i, end int) int {
+	name, next := p.readType(i + 1)
+	if name == "" {
+		return p.memberEnd(i, end)
+	}
+
+	name = removeGenericArguments(name)
+	d := declaration{Keyword: "extension", Name: name[strings.LastIndexByte(name, '.')+1:], QualifiedName: name}
+
+	if p.is(next, ":") {
+		d.Inherits, next = p.parseInherits(next+1, end)
+	}
+
+	next = p.skipWhere(next, end)
+
+	if p.is(next, "{") {
+		bodyEnd := p.skipBalanced(next)
+		p.parseBody(next+1, bodyEnd-1, &d)
+		next = bodyEnd
+	}
+
+	p.response.Extensions = append(p.response.Extensions, extension{
+		Name:      d.QualifiedName,
+		Inherits:  d.Inherits,
+		Variables: d.Variables,
+		Methods:   d.Methods,
+	})
+
+	return next
+}
+
+// Returns the node of a declaration. Structs and actors are classes that cannot be inherited from.
+// The inherited types are sorted into superclasses and protocols once the whole project is parsed.
+func getNode(d declaration, owner *declaration) any {
+	var definedWithin []byte
+	if owner != nil {
+		definedWithin = []byte(owner.Name)
+	}
+
+	var inherits []types.CustomByteSlice
+	for _, inherit := range d.Inherits {
+		inherits = append(inherits, []byte(inherit))
+	}
+
+	switch d.Keyword {
+	case "protocol":
+		return types.JavaInterface{
+			DefinedWithin: definedWithin,
+			Package:       []byte(defaultPackage),
+			Name:          []byte(d.Name),
+			Extends:       inherits,
+			Variables:     d.Variables,
+			Methods:       d.Methods,
+		}
+	case "enum":
+		return types.JavaEnum{
+			DefinedWithin: definedWithin,
+			Package:       []byte(defaultPackage),
+			Name:          []byte(d.Name),
+			Declarations:  d.Cases,
+			Implements:    inherits,
+		}
+	}
+
+	return types.JavaClass{
+		DefinedWithin: definedWithin,
+		Package:       []byte(defaultPackage),
+		Name:          []byte(d.Name),
+		Implements:    inherits,
+		Variables:     d.Variables,
+		Methods:       d.Methods,
+	}
+}
+
+// Parses the cases after the case keyword at index i. Associated values are kept with the name of the case:
+// case success(Data), failure(code: Int)
+func (p *parser) parseCases(i, end int, d *declaration) int {
+	caseEnd := p.memberEnd(i, end)
+
+	for i++; i < caseEnd; {
+		if p.tokens[i].Kind != identifierToken {
+			break
+		}
+
+		name := p.tokens[i].Text
+		i++
+
+		if p.is(i, "(") {
+			valuesEnd := p.skipBalanced(i)
+			name += p.getSource(i, valuesEnd)
+			i = valuesEnd
+		}
+
+		d.Cases = append(d.Cases, []byte(name))
+
+		// Raw values: case red = "#f00"
+		for i < caseEnd && !p.is(i, ",") {
+			i = p.skipToken(i)
+		}
+
+		i++
+	}
+
+	return caseEnd
+}
+
+// Parses a function whose func keyword is at index i. Operators are named by their symbol: static func == (...)
+func (p *parser) parseFunction(i, end int, modifiers map[string]bool, d *declaration) int {
+	i++
+
+	var name string
+	if i < end && p.tokens[i].Kind == identifierToken {
+		name = p.tokens[i].Text
+		i++
+	} else {
+		for ; i < end && p.tokens[i].Kind == punctuationToken && !p.is(i, "("); i++ {
+			name += p.tokens[i].Text
+		}
+	}
+
+	if p.is(i, "<") {
+		i = p.skipAngles(i)
+	}
+
+	if name == "" || !p.is(i, "(") {
+		return p.memberEnd(i, end)
+	}
+
+	parameters, next := p.parseParameters(i)
+	next = p.skipEffects(next)
+
+	// Functions without a return type return Void
+	returnType := "Void"
+	if p.is(next, "->") {
+		returnType, next = p.readType(next + 1)
+	}
+
+	next = p.skipWhere(next, end)
+
+	var functionality []byte
+	if p.is(next, "{") {
+		bodyEnd := p.skipBalanced(next)
+		functionality = p.getFunctionality(next+1, bodyEnd-1)
+		next = bodyEnd
+	}
+
+	d.Methods = append(d.Methods, types.JavaMethod{
+		Type:           []byte(returnType),
+		Name:           []byte(name),
+		AccessModifier: getAccessModifier(modifiers),
+		Parameters:     getMethodParameters(parameters),
+		Static:         modifiers["static"] || modifiers["class"],
+		Final:          modifiers["final"],
+		Functionality:  functionality,
+	})
+
+	return next
+}
+
+// Parses an initializer: init(name: String), init?(rawValue: Int) or convenience init()
+func (p *parser) parseInitializer(i, end int, modifiers map[string]bool, d *declaration) int {
+	i++
+	if p.is(i, "?") || p.is(i, "!") {
+		i++
+	}
+
+	if p.is(i, "<") {
+		i = p.skipAngles(i)
+	}
+
+	if !p.is(i, "(") {
+		return p.memberEnd(i, end)
+	}
+
+	parameters, next := p.parseParameters(i)
+	next = p.skipWhere(p.skipEffects(next), end)
+
+	var functionality []byte
+	if p.is(next, "{") {
+		bodyEnd := p.skipBalanced(next)
+		functionality = p.getFunctionality(next+1, bodyEnd-1)
+		next = bodyEnd
+	}
+
+	d.Methods = append(d.Methods, types.JavaMethod{
+		Name:           []byte("init"),
+		AccessModifier: getAccessModifier(modifiers),
+		Parameters:     getMethodParameters(parameters),
+		Functionality:  functionality,
+	})
+
+	return next
+}
+
+// Parses a subscript, which is a method named subscript: subscript(index: Int) -> Dog { get set }
+func (p *parser) parseSubscript(i, end int, modifiers map[string]bool, d *declaration) int {
+	i++
+	if p.is(i, "<") {
+		i = p.skipAngles(i)
+	}
+
+	if !p.is(i, "(") {
+		return p.memberEnd(i, end)
+	}
+
+	parameters, next := p.parseParameters(i)
+
+	var returnType string
+	if p.is(next, "->") {
+		returnType, next = p.readType(next + 1)
+	}
+
+	next = p.skipWhere(next, end)
+
+	var functionality []byte
+	if p.is(next, "{") {
+		bodyEnd := p.skipBalanced(next)
+		functionality = p.getFunctionality(next+1, bodyEnd-1)
+		next = bodyEnd
+	}
+
+	d.Methods = append(d.Methods, types.JavaMethod{
+		Type:           []byte(returnType),
+		Name:           []byte("subscript"),
+		AccessModifier: getAccessModifier(modifiers),
+		Parameters:     getMethodParameters(parameters),
+		Static:         modifiers["static"] || modifiers["class"],
+		Functionality:  functionality,
+	})
+
+	return next
+}
+
+// Parses the properties declared by the var or let keyword at index i: var x = 0, y = 0.
+// Computed properties and property observers are skipped with their braces.
+func (p *parser) parseProperty(i, end int, modifiers map[string]bool, d *declaration) int {
+	keyword := p.tokens[i].Text
+	propertyEnd := p.memberEnd(i, end)
+
+	for i++; i < propertyEnd; {
+		// Tuple patterns do not declare named members: let (x, y) = point
+		if p.tokens[i].Kind != identifierToken {
+			break
+		}
+
+		name := p.tokens[i].Text
+		i++
+
+		var variableType, value string
+		if p.is(i, ":") {
+			variableType, i = p.readType(i + 1)
+		}
+
+		if p.is(i, "=") {
+			valueStart := i + 1
+			for i = valueStart; i < propertyEnd && !p.is(i, ",") && !p.isObserverBlock(i); i = p.skipToken(i) {
+			}
+
+			if valueStart < i {
+				value = p.getSource(valueStart, i)
+
+				// The type of a property that is set to a new instance is the type of the instance: let dog = Dog()
+				if variableType == "" {
+					if valueType, valueTypeEnd := p.readType(valueStart); p.is(valueTypeEnd, "(") && isTypeName(valueType) {
+						variableType = valueType
+					}
+				}
+			}
+		}
+
+		if p.is(i, "{") {
+			i = p.skipBalanced(i)
+		}
+
+		d.Variables = append(d.Variables, types.JavaVariable{
+			Type:           []byte(variableType),
+			Name:           []byte(name),
+			Value:          getValue(value),
+			AccessModifier: getAccessModifier(modifiers),
+			Static:         modifiers["static"] || modifiers["class"],
+			Final:          keyword == "let",
+		})
+
+		if !p.is(i, ",") {
+			break
+		}
+
+		i++
+	}
+
+	return propertyEnd
+}
+
+// Returns true when the brace at index i opens the willSet and didSet observers of a property
+func (p *parser) isObserverBlock(i int) bool {
+	if !p.is(i, "{") {
+		return false
+	}
+
+	_, next := p.readModifiers(i+1, len(p.tokens))
+	return p.is(next, "willSet") || p.is(next, "didSet")
+}
+
+// Parses the parameters between the parentheses at index i and returns the index after the closing parenthesis.
+// Parameters are named by their second name when they have an argument label: func move(to point: Point)
+func (p *parser) parseParameters(i int) ([]parameter, int) {
+	var (
+		response []parameter
+		end      = p.skipBalanced(i) - 1
+	)
+
+	for i++; i < end; {
+		_, next := p.readModifiers(i, end)
+
+		var names []string
+		for ; next < end && p.tokens[next].Kind == identifierToken; next++ {
+			names = append(names, p.tokens[next].Text)
+		}
+
+		if len(names) == 0 {
+			break
+		}
+
+		current := parameter{Name: names[len(names)-1]}
+
+		if p.is(next, ":") {
+			current.Type, next = p.readType(next + 1)
+		}
+
+		if p.is(next, "=") {
+			valueStart := next + 1
+			for next = valueStart; next < end && !p.is(next, ","); next = p.skipToken(next) {
+			}
+
+			if valueStart < next {
+				current.Value = p.getSource(valueStart, next)
+			}
+		}
+
+		response = append(response, current)
+
+		for next < end && !p.is(next, ",") {
+			next = p.skipToken(next)
+		}
+
+		i = next + 1
+	}
+
+	return response, end + 1
+}
+
+// Parses the inherited types after the colon of a declaration: Animal, Codable, @unchecked Sendable
+func (p *parser) parseInherits(i, end int) ([]string, int) {
+	var response []string
+
+	for i < end {
+		// Suppressed conformances: ~Copyable
+		if p.is(i, "~") {
+			i++
+		}
+
+		name, next := p.readType(i)
+		if name == "" {
+			break
+		}
+
+		response = append(response, removeGenericArguments(name))
+		i = next
+
+		if !p.is(i, ",") {
+			break
+		}
+
+		i++
+	}
+
+	return response, i
+}
+
+// Reads a type such as "[String:Dog]", "Dog?", "(Int)->Void" or "some View" and returns it without spaces,
+// the way that the other front-ends write their types
+func (p *parser) readType(i int) (string, int) {
+	for p.is(i, "@") {
+		i = p.skipAttribute(i)
+	}
+
+	start := i
+	for p.is(i, "inout") || p.is(i, "some") || p.is(i, "any") || p.is(i, "borrowing") || p.is(i, "consuming") {
+		i++
+	}
+
+	if p.is(i, "(") || p.is(i, "[") {
+		i = p.skipBalanced(i)
+	} else {
+		for i < len(p.tokens) && p.tokens[i].Kind == identifierToken {
+			i++
+
+			if p.is(i, "<") && !p.tokens[i].NewlineBefore {
+				i = p.skipAngles(i)
+			}
+
+			if !p.is(i, ".") || i+1 >= len(p.tokens) || p.tokens[i+1].Kind != identifierToken {
+				break
+			}
+
+			i++
+		}
+	}
+
+	if i == start {
+		return "", i
+	}
+
+	for (p.is(i, "?") || p.is(i, "!") || p.is(i, "...")) && !p.tokens[i].NewlineBefore {
+		i++
+	}
+
+	// Function types: (Int) async throws -> Void
+	if effectsEnd := p.skipEffects(i); p.is(effectsEnd, "->") {
+		_, i = p.readType(effectsEnd + 1)
+	}
+
+	// Protocol compositions: Named & Walker
+	if p.is(i, "&") {
+		_, i = p.readType(i + 1)
+	}
+
+	var builder strings.Builder
+	for j := start; j < i; j++ {
+		if j > start && p.tokens[j].Kind == identifierToken && p.tokens[j-1].Kind == identifierToken {
+			builder.WriteByte(' ')
+		}
+
+		builder.WriteString(p.tokens[j].Text)
+	}
+
+	return builder.String(), i
+}
+
+// Reads attributes and modifiers and returns the modifiers and the index of the token after them.
+// Setter access such as private(set) does not change the access of the getter.
+func (p *parser) readModifiers(i, end int) (map[string]bool, int) {
+	response := make(map[string]bool)
+
+	for i < end {
+		if p.is(i, "@") {
+			i = p.skipAttribute(i)
+			continue
+		}
+
+		if _, ok := modifierKeywords[p.tokens[i].Text]; !ok || p.tokens[i].Kind != identifierToken || i+1 >= end {
+			break
+		}
+
+		if p.is(i+1, "(") {
+			i = p.skipBalanced(i + 1)
+			continue
+		}
+
+		// Modifiers are followed by a declaration, not by punctuation: open = true
+		if p.tokens[i+1].Kind != identifierToken && !p.is(i+1, "@") {
+			break
+		}
+
+		// class is a modifier of members and a keyword of types: class func make(), final class Dog
+		if p.is(i, "class") && p.isTypeKeyword(i) {
+			break
+		}
+
+		response[p.tokens[i].Text] = true
+		i++
+	}
+
+	return response, i
+}
+
+// Returns true when the token at index i declares a type. A class followed by a member keyword is a modifier.
+func (p *parser) isTypeKeyword(i int) bool {
+	if i >= len(p.tokens) || p.tokens[i].Kind != identifierToken {
+		return false
+	}
+
+	if _, ok := typeKeywords[p.tokens[i].Text]; !ok {
+		return false
+	}
+
+	if i+1 >= len(p.tokens) || p.tokens[i+1].Kind != identifierToken {
+		return false
+	}
+
+	switch next := p.tokens[i+1].Text; next {
+	case "func", "var", "let", "subscript", "init":
+		return false
+	default:
+		_, isModifier := modifierKeywords[next]
+		return !isModifier
+	}
+}
+
+// Skips an attribute such as @MainActor, @objc(name) or @available(iOS 15, *)
+func (p *parser) skipAttribute(i int) int {
+	i++
+	if i < len(p.tokens) && p.tokens[i].Kind == identifierToken {
+		i++
+	}
+
+	for p.is(i, ".") && i+1 < len(p.tokens) && p.tokens[i+1].Kind == identifierToken {
+		i += 2
+	}
+
+	if p.is(i, "(") && !p.tokens[i].NewlineBefore {
+		i = p.skipBalanced(i)
+	}
+
+	return i
+}
+
+// Skips the effects of a function: async throws, rethrows or throws(ParseError)
+func (p *parser) skipEffects(i int) int {
+	for p.is(i, "async") || p.is(i, "throws") || p.is(i, "rethrows") || p.is(i, "reasync") {
+		i++
+
+		if p.is(i-1, "throws") && p.is(i, "(") {
+			i = p.skipBalanced(i)
+		}
+	}
+
+	return i
+}
+
+// Skips a generic where clause that comes before the body of a declaration: where T: Equatable
+func (p *parser) skipWhere(i, end int) int {
+	if !p.is(i, "where") {
+		return i
+	}
+
+	for i++; i < end && !p.is(i, "{") && (!p.tokens[i].NewlineBefore || p.continues(i)); i = p.skipToken(i) {
+	}
+
+	return i
+}
+
+// Returns the index where the member that starts at index i ends. Members end at a semicolon or at
+// a line break that does not continue the statement.
+func (p *parser) memberEnd(i, end int) int {
+	for j := i; j < end; {
+		if j > i && p.tokens[j].NewlineBefore && !p.continues(j) {
+			return j
+		}
+
+		if p.is(j, ";") {
+			return j
+		}
+
+		j = p.skipToken(j)
+	}
+
+	return end
+}
+
+// Returns true when the line that starts at index i continues the statement of the previous line
+func (p *parser) continues(i int) bool {
+	if _, ok := continuingLineStarts[p.tokens[i].Text]; ok && p.tokens[i].Kind != stringToken {
+		return true
+	}
+
+	_, ok := continuingLineEnds[p.tokens[i-1].Text]
+	return ok && p.tokens[i-1].Kind != stringToken
+}
+
+// Returns the index after the token at index i, or after its closing bracket if it opens one
+func (p *parser) skipToken(i int) int {
+	if p.is(i, "(") || p.is(i, "[") || p.is(i, "{") {
+		return p.skipBalanced(i)
+	}
+
+	return i + 1
+}
+
+// Returns the index after the bracket that closes the one at index i
+func (p *parser) skipBalanced(i int) int {
+	depth := 0
+
+	for ; i < len(p.tokens); i++ {
+		if p.tokens[i].Kind != punctuationToken {
+			continue
+		}
+
+		switch p.tokens[i].Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return len(p.tokens)
+}
+
+// Returns the index after the angle bracket that closes the one at index i
+func (p *parser) skipAngles(i int) int {
+	depth := 0
+
+	for ; i < len(p.tokens); i++ {
+		switch p.tokens[i].Text {
+		case "<":
+			depth++
+		case ">":
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case "{", "}", ";", "=":
+			return i
+		case "(", "[":
+			i = p.skipBalanced(i) - 1
+		}
+	}
+
+	return len(p.tokens)
+}
+
+// Returns the code of a function body. Tokens are separated by spaces and strings are left empty,
+// so that the names in strings are not read as types.
+func (p *parser) getFunctionality(start, end int) []byte {
+	var builder strings.Builder
+
+	for i := start; i < end; i++ {
+		if i > start {
+			builder.WriteByte(' ')
+		}
+
+		if p.tokens[i].Kind == stringToken {
+			builder.WriteString(`""`)
+			continue
+		}
+
+		builder.WriteString(p.tokens[i].Text)
+	}
+
+	return []byte(builder.String())
+}
+
+// Returns the code of the tokens from start to end as it is written, on a single line
+func (p *parser) getSource(start, end int) string {
+	return strings.Join(strings.Fields(p.code[p.tokens[start].Start:p.tokens[end-1].End]), " ")
+}
+
+func (p *parser) is(i int, text string) bool {
+	return i >= 0 && i < len(p.tokens) && p.tokens[i].Text == text && p.tokens[i].Kind != stringToken
+}
+
+func getAccessModifier(modifiers map[string]bool) []byte {
+	if modifiers["private"] || modifiers["fileprivate"] {
+		return []byte("private")
+	}
+
+	// Internal members, the default, are public inside of their module
+	return []byte("public")
+}
+
+func getMethodParameters(parameters []parameter) []types.JavaMethodParameter {
+	var response []types.JavaMethodParameter
+
+	for _, parameter := range parameters {
+		response = append(response, types.JavaMethodParameter{
+			Type: []byte(parameter.Type),
+			Name: []byte(parameter.Name),
+		})
+	}
+
+	return response
+}
+
+func getValue(value string) []byte {
+	if value == "" {
+		return nil
+	}
+
+	return []byte(value)
+}
+
+// Returns true when the last name of a type starts with an uppercase letter: Zoo.Dog
+func isTypeName(name string) bool {
+	name = name[strings.LastIndexByte(name, '.')+1:]
+	return name != "" && unicode.IsUpper(rune(name[0])) && !strings.ContainsAny(name, "()[]")
+}
+
+// Array<Dog> -> Array
+func removeGenericArguments(name string) string {
+	if index := strings.IndexByte(name, '<'); index != -1 {
+		return name[:index]
+	}
+
+	return name
+}
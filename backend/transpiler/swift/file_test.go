@@ -0,0 +1,152 @@
+package swift
+
+import (
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestParseFile(t *testing.T) {
+	file := types.File{
+		Name:      "Zoo",
+		Extension: "swift",
+		Code: []byte(`
+import Foundation
+
+protocol Feedable: AnyObject {
+	var diet: Diet { get }
+	func feed(_ food: Food) throws -> Bool
+}
+
+@MainActor
+open class Animal: NSObject, Feedable, @unchecked Sendable {
+	public private(set) var name: String = "animal"
+	weak var keeper: Keeper?
+	lazy var friends: [Animal] = []
+	static let shared = Animal(name: "shared")
+	private var x = 0, y: Int = 0
+	var diet: Diet { .meat }
+	var age = 0 {
+		didSet { print(age) }
+	}
+
+	required init?(name: String, _ age: Int = 1, tags: String...) {
+		self.name = name
+	}
+
+	deinit {}
+
+	class func make<T: Feedable>(with other: T) async throws -> Animal where T: Equatable {
+		return Animal(name: "")
+	}
+
+	static func == (lhs: Animal, rhs: Animal) -> Bool { lhs === rhs }
+
+	subscript(index: Int) -> Animal? { friends[index] }
+
+	struct Tag {}
+}
+
+enum Diet: String, CaseIterable {
+	case meat, plants = "plants"
+	case custom(name: String, calories: Int)
+	indirect case mixed(Diet)
+
+	func label() -> String { rawValue }
+}
+
+extension Animal: Hashable {
+	func hash(into hasher: inout Hasher) {}
+}
+
+#if os(iOS)
+struct Dog {}
+#endif
+`),
+	}
+
+	response := parseFile(file)
+
+	if len(response.Classes) != 5 {
+		t.Fatalf("incorrect number of classes.\nexpected: 5\ngot: %d\n", len(response.Classes))
+	}
+
+	feedable, ok := response.Classes[0].Node.(types.JavaInterface)
+	if !ok || len(feedable.Extends) != 1 || len(feedable.Variables) != 1 || len(feedable.Methods) != 1 ||
+		string(feedable.Methods[0].Type) != "Bool" || string(feedable.Methods[0].Parameters[0].Name) != "food" {
+		t.Errorf("incorrect protocol")
+	}
+
+	animal, ok := response.Classes[1].Node.(types.JavaClass)
+	if !ok {
+		t.Fatalf("Animal is not a class")
+	}
+
+	if len(animal.Implements) != 3 || string(animal.Implements[0]) != "NSObject" || string(animal.Implements[2]) != "Sendable" {
+		t.Errorf("incorrect inherited types of Animal: %s", animal.Implements)
+	}
+
+	expectedVariables := []types.JavaVariable{
+		{Name: []byte("name"), Type: []byte("String"), Value: []byte(`"animal"`), AccessModifier: []byte("public")},
+		{Name: []byte("keeper"), Type: []byte("Keeper?"), AccessModifier: []byte("public")},
+		{Name: []byte("friends"), Type: []byte("[Animal]"), Value: []byte("[]"), AccessModifier: []byte("public")},
+		{Name: []byte("shared"), Type: []byte("Animal"), Value: []byte(`Animal(name: "shared")`), AccessModifier: []byte("public"), Static: true, Final: true},
+		{Name: []byte("x"), Value: []byte("0"), AccessModifier: []byte("private")},
+		{Name: []byte("y"), Type: []byte("Int"), Value: []byte("0"), AccessModifier: []byte("private")},
+		{Name: []byte("diet"), Type: []byte("Diet"), AccessModifier: []byte("public")},
+		{Name: []byte("age"), Value: []byte("0"), AccessModifier: []byte("public")},
+	}
+
+	if len(animal.Variables) != len(expectedVariables) {
+		t.Fatalf("incorrect number of variables.\nexpected: %d\ngot: %d\n", len(expectedVariables), len(animal.Variables))
+	}
+
+	for index, expected := range expectedVariables {
+		actual := animal.Variables[index]
+		if string(actual.Name) != string(expected.Name) || string(actual.Type) != string(expected.Type) || string(actual.Value) != string(expected.Value) ||
+			string(actual.AccessModifier) != string(expected.AccessModifier) || actual.Static != expected.Static || actual.Final != expected.Final {
+			t.Errorf("incorrect variable.\nexpected:\n%s %s %s %s\ngot:\n%s %s %s %s\n",
+				expected.AccessModifier, expected.Name, expected.Type, expected.Value,
+				actual.AccessModifier, actual.Name, actual.Type, actual.Value)
+		}
+	}
+
+	expectedMethods := []types.JavaMethod{
+		{Name: []byte("init"), AccessModifier: []byte("public")},
+		{Name: []byte("make"), Type: []byte("Animal"), AccessModifier: []byte("public"), Static: true},
+		{Name: []byte("=="), Type: []byte("Bool"), AccessModifier: []byte("public"), Static: true},
+		{Name: []byte("subscript"), Type: []byte("Animal?"), AccessModifier: []byte("public")},
+	}
+
+	if len(animal.Methods) != len(expectedMethods) {
+		t.Fatalf("incorrect number of methods.\nexpected: %d\ngot: %d\n", len(expectedMethods), len(animal.Methods))
+	}
+
+	for index, expected := range expectedMethods {
+		actual := animal.Methods[index]
+		if string(actual.Name) != string(expected.Name) || string(actual.Type) != string(expected.Type) ||
+			string(actual.AccessModifier) != string(expected.AccessModifier) || actual.Static != expected.Static {
+			t.Errorf("incorrect method.\nexpected:\n%s %s %s\ngot:\n%s %s %s\n", expected.AccessModifier, expected.Name, expected.Type, actual.AccessModifier, actual.Name, actual.Type)
+		}
+	}
+
+	if initializer := animal.Methods[0]; len(initializer.Parameters) != 3 || string(initializer.Parameters[1].Name) != "age" ||
+		string(initializer.Parameters[2].Type) != "String..." || string(initializer.Functionality) != "self . name = name" {
+		t.Errorf("incorrect initializer: %s", initializer.Functionality)
+	}
+
+	if tag := response.Classes[2]; tag.QualifiedName != "Animal.Tag" || string(tag.Node.(types.JavaClass).DefinedWithin) != "Animal" {
+		t.Errorf("incorrect nested struct")
+	}
+
+	diet, ok := response.Classes[3].Node.(types.JavaEnum)
+	if !ok || len(diet.Declarations) != 4 || string(diet.Declarations[1]) != "plants" ||
+		string(diet.Declarations[2]) != "custom(name: String, calories: Int)" || string(diet.Declarations[3]) != "mixed(Diet)" {
+		t.Errorf("incorrect enum: %s", diet.Declarations)
+	}
+
+	if len(response.Extensions) != 1 || response.Extensions[0].Name != "Animal" || len(response.Extensions[0].Methods) != 1 ||
+		string(response.Extensions[0].Methods[0].Parameters[0].Type) != "inout Hasher" {
+		t.Errorf("incorrect extension")
+	}
+}
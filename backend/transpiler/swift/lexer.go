@@ -0,0 +1,221 @@
+package swift
+
+import "strings"
+
+type tokenKind int
+
+const (
+	identifierToken tokenKind = iota
+	punctuationToken
+	stringToken
+	numberToken
+)
+
+type token struct {
+	Kind          tokenKind
+	Text          string
+	NewlineBefore bool // True when a line break separates this token from the previous one
+	Start         int  // Offset of the token in the code
+	End           int
+}
+
+// Punctuation made of more than one character that matters to the parser.
+// Angle brackets are kept apart so that nested generic arguments close one by one: Array<Set<Int>>
+var multiCharPunctuation = []string{"...", "..<", "===", "!==", "->", "?.", "??", "==", "!=", "&&", "||", "<=", ">="}
+
+// Compiler directives whose lines are removed. The code of every branch is kept.
+var conditionalDirectives = map[string]struct{}{"#if": {}, "#elseif": {}, "#else": {}, "#endif": {}}
+
+// Splits code into tokens. Comments, whitespace and conditional compilation lines are removed.
+// Statements end at line breaks in Swift, so every token records whether a line break comes before it.
+func tokenize(code []byte) []token {
+	var (
+		response      []token
+		text          = string(code)
+		newlineBefore = true
+	)
+
+	appendToken := func(kind tokenKind, start, end int) {
+		response = append(response, token{Kind: kind, Text: text[start:end], NewlineBefore: newlineBefore, Start: start, End: end})
+		newlineBefore = false
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+
+		switch {
+		case c == '\n':
+			newlineBefore = true
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			i++
+		case strings.HasPrefix(text[i:], "//"):
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(text[i:], "/*"):
+			i = skipComment(text, i)
+		case c == '"' || c == '#' && isRawStringStart(text, i):
+			end := skipString(text, i)
+			appendToken(stringToken, i, end)
+			i = end
+		case c == '`':
+			// Names in backticks, such as `default`, are written without the backticks
+			end := strings.IndexByte(text[i+1:], '`')
+			if end == -1 {
+				i = len(text)
+				break
+			}
+
+			appendToken(identifierToken, i+1, i+1+end)
+			i += end + 2
+		case c == '#' && i+1 < len(text) && isIdentifierStart(text[i+1]):
+			end := i + 2
+			for end < len(text) && isIdentifierPart(text[end]) {
+				end++
+			}
+
+			if _, ok := conditionalDirectives[text[i:end]]; ok {
+				for end < len(text) && text[end] != '\n' {
+					end++
+				}
+
+				i = end
+				break
+			}
+
+			// Expressions such as #selector(tap) and #available(iOS 15, *)
+			appendToken(identifierToken, i, end)
+			i = end
+		case isIdentifierStart(c):
+			end := i + 1
+			for end < len(text) && isIdentifierPart(text[end]) {
+				end++
+			}
+
+			appendToken(identifierToken, i, end)
+			i = end
+		case c >= '0' && c <= '9':
+			end := i + 1
+			for end < len(text) && (isIdentifierPart(text[end]) || text[end] == '.' && end+1 < len(text) && text[end+1] >= '0' && text[end+1] <= '9') {
+				end++
+			}
+
+			appendToken(numberToken, i, end)
+			i = end
+		default:
+			end := i + 1
+			for _, punctuation := range multiCharPunctuation {
+				if strings.HasPrefix(text[i:], punctuation) {
+					end = i + len(punctuation)
+					break
+				}
+			}
+
+			appendToken(punctuationToken, i, end)
+			i = end
+		}
+	}
+
+	return response
+}
+
+// Returns the index after the block comment that starts at start. Block comments nest in Swift.
+func skipComment(text string, start int) int {
+	depth := 0
+
+	for i := start; i < len(text); i++ {
+		if strings.HasPrefix(text[i:], "/*") {
+			depth++
+			i++
+		} else if strings.HasPrefix(text[i:], "*/") {
+			depth--
+			i++
+
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return len(text)
+}
+
+// Returns true when the number signs at start open a raw string: #"C:\path"#
+func isRawStringStart(text string, start int) bool {
+	i := start
+	for i < len(text) && text[i] == '#' {
+		i++
+	}
+
+	return i < len(text) && text[i] == '"'
+}
+
+// Returns the index after the string that starts at start. Handles interpolation ("\(value)"),
+// multiline strings ("""...""") and raw strings (#"..."#).
+func skipString(text string, start int) int {
+	i := start
+	for i < len(text) && text[i] == '#' {
+		i++
+	}
+
+	var (
+		delimiter = text[start:i]
+		multiline = strings.HasPrefix(text[i:], `"""`)
+		escape    = `\` + delimiter
+		closing   = `"` + delimiter
+	)
+
+	if multiline {
+		i += 3
+		closing = `"""` + delimiter
+	} else {
+		i++
+	}
+
+	for i < len(text) {
+		switch {
+		case strings.HasPrefix(text[i:], escape+"("):
+			i = skipInterpolation(text, i+len(escape))
+		case strings.HasPrefix(text[i:], escape):
+			i += len(escape) + 1
+		case strings.HasPrefix(text[i:], closing):
+			return i + len(closing)
+		case !multiline && text[i] == '\n':
+			return i
+		default:
+			i++
+		}
+	}
+
+	return len(text)
+}
+
+// Returns the index after the interpolated expression "(...)" that starts at start
+func skipInterpolation(text string, start int) int {
+	depth := 0
+
+	for i := start; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '"' || c == '#' && isRawStringStart(text, i):
+			i = skipString(text, i) - 1
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return len(text)
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,46 @@
+package swift
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	type TokenizeTest struct {
+		Input  string
+		Output []string
+	}
+
+	var tests = []TokenizeTest{
+		{
+			Input:  `var dogs: [String: Array<Dog>]? = nil // comment`,
+			Output: []string{"var", "dogs", ":", "[", "String", ":", "Array", "<", "Dog", ">", "]", "?", "=", "nil"},
+		},
+		{
+			Input:  `let s = "a \(dog.name + ")") b" + #"raw "quoted" \(x)"#`,
+			Output: []string{"let", "s", "=", `"a \(dog.name + ")") b"`, "+", `#"raw "quoted" \(x)"#`},
+		},
+		{
+			Input:  "let text = \"\"\"\n  multi \"line\"\n  \"\"\"\n/* outer /* inner */ still outer */ func `default`() -> Int { $0 ?? 1_000 }",
+			Output: []string{"let", "text", "=", "\"\"\"\n  multi \"line\"\n  \"\"\"", "func", "default", "(", ")", "->", "Int", "{", "$0", "??", "1_000", "}"},
+		},
+		{
+			Input:  "#if os(iOS)\nimport UIKit\n#else\nimport AppKit\n#endif\nlet s = #selector(tap)",
+			Output: []string{"import", "UIKit", "import", "AppKit", "let", "s", "=", "#selector", "(", "tap", ")"},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			var actualOutput []string
+			for _, token := range tokenize([]byte(tt.Input)) {
+				actualOutput = append(actualOutput, token.Text)
+			}
+
+			if strings.Join(actualOutput, " ") != strings.Join(tt.Output, " ") {
+				subtest.Errorf("incorrect tokens.\nexpected:\n%s\ngot:\n%s\n", tt.Output, actualOutput)
+			}
+		})
+	}
+}
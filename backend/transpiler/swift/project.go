@@ -0,0 +1,272 @@
+package swift
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+var identifierRegex = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*`)
+
+func ParseProject(ctx context.Context, files []types.File) *types.Project {
+	var (
+		response   types.Project
+		classes    []class
+		extensions []extension
+	)
+
+	parsedFiles, diagnostics := types.ParseFiles(ctx, files, parseFile)
+	response.Diagnostics = diagnostics
+
+	for _, parsedFile := range parsedFiles {
+		classes = append(classes, parsedFile.Classes...)
+		extensions = append(extensions, parsedFile.Extensions...)
+	}
+
+	// The key is the qualified name of a type, such as Dog.Collar, and the value is the index of its class.
+	// Every type in a module is visible to the others, so nested types can also be found by their own name.
+	// Types declared more than once keep their first declaration.
+	classIds := make(map[string]int)
+	for index, c := range classes {
+		if _, ok := classIds[c.QualifiedName]; !ok {
+			classIds[c.QualifiedName] = index
+		}
+	}
+
+	for index, c := range classes {
+		name := c.QualifiedName[strings.LastIndexByte(c.QualifiedName, '.')+1:]
+		if _, ok := classIds[name]; !ok {
+			classIds[name] = index
+		}
+	}
+
+	// Extensions add their members and conformances to their type. Extensions of types that are not
+	// declared by the project, such as String, are not part of the diagram.
+	for _, e := range extensions {
+		index, ok := resolveClass(classIds, "", e.Name)
+		if !ok {
+			continue
+		}
+
+		var inherits []types.CustomByteSlice
+		for _, inherit := range e.Inherits {
+			inherits = append(inherits, []byte(inherit))
+		}
+
+		switch node := classes[index].Node.(type) {
+		case types.JavaClass:
+			node.Implements = append(node.Implements, inherits...)
+			node.Variables = append(node.Variables, e.Variables...)
+			node.Methods = append(node.Methods, e.Methods...)
+			classes[index].Node = node
+		case types.JavaInterface:
+			// Protocol extensions give default implementations to the requirements of a protocol
+			node.Variables = append(node.Variables, e.Variables...)
+			node.Methods = append(node.Methods, e.Methods...)
+			classes[index].Node = node
+		case types.JavaEnum:
+			node.Implements = append(node.Implements, inherits...)
+			classes[index].Node = node
+		}
+	}
+
+	for index, c := range classes {
+		if classIds[c.QualifiedName] != index {
+			continue
+		}
+
+		scope := c.QualifiedName
+
+		switch node := c.Node.(type) {
+		case types.JavaClass:
+			// A class lists its superclass and its protocols together, the kind of each type tells them apart
+			var protocols []types.CustomByteSlice
+			for _, inherit := range node.Implements {
+				index, ok := resolveClass(classIds, scope, string(inherit))
+				if !ok {
+					continue
+				}
+
+				switch classes[index].Node.(type) {
+				case types.JavaInterface:
+					protocols = append(protocols, []byte(getClassId(classes[index].Node)))
+				case types.JavaClass:
+					node.Extends = append(node.Extends, []byte(getClassId(classes[index].Node)))
+				}
+			}
+
+			node.Implements = protocols
+			node.Associations, node.Dependencies = getClassAssociationsAndDependencies(classIds, classes, scope, node.Variables, node.Methods)
+			response.Nodes = append(response.Nodes, node)
+			addClassRelations(&response, node.Package, node.Name, node.DefinedWithin, node.Extends, node.Implements, node.Associations, node.Dependencies)
+		case types.JavaInterface:
+			node.Extends = resolveProtocols(classIds, classes, scope, node.Extends)
+			node.Associations, node.Dependencies = getClassAssociationsAndDependencies(classIds, classes, scope, node.Variables, node.Methods)
+			response.Nodes = append(response.Nodes, node)
+			addClassRelations(&response, node.Package, node.Name, node.DefinedWithin, node.Extends, nil, node.Associations, node.Dependencies)
+		case types.JavaEnum:
+			node.Implements = resolveProtocols(classIds, classes, scope, node.Implements)
+			response.Nodes = append(response.Nodes, node)
+			addClassRelations(&response, node.Package, node.Name, node.DefinedWithin, nil, node.Implements, nil, nil)
+		}
+	}
+
+	return &response
+}
+
+// Resolves a name such as "Collar" or "Dog.Collar" to the index of its class. Names are looked up
+// in the types that enclose the scope first, from the innermost to the top level of the module.
+func resolveClass(classIds map[string]int, scope, name string) (int, bool) {
+	for {
+		qualifiedName := name
+		if scope != "" {
+			qualifiedName = scope + "." + name
+		}
+
+		if index, ok := classIds[qualifiedName]; ok {
+			return index, true
+		}
+
+		if scope == "" {
+			return 0, false
+		}
+
+		if index := strings.LastIndexByte(scope, '.'); index != -1 {
+			scope = scope[:index]
+		} else {
+			scope = ""
+		}
+	}
+}
+
+// Resolves a name such as "Dog.Collar.init" to a class id, or returns an empty string.
+// The longest prefix that names a type wins.
+func resolveName(classIds map[string]int, classes []class, scope, name string) string {
+	components := strings.Split(name, ".")
+
+	for end := len(components); end > 0; end-- {
+		if index, ok := resolveClass(classIds, scope, strings.Join(components[:end], ".")); ok {
+			return getClassId(classes[index].Node)
+		}
+	}
+
+	return ""
+}
+
+// Returns the class ids of the protocols in names. Enums and structs only conform to protocols,
+// their raw value types, such as String, are not part of the diagram.
+func resolveProtocols(classIds map[string]int, classes []class, scope string, names []types.CustomByteSlice) []types.CustomByteSlice {
+	var response []types.CustomByteSlice
+
+	for _, name := range names {
+		index, ok := resolveClass(classIds, scope, string(name))
+		if !ok {
+			continue
+		}
+
+		if _, ok := classes[index].Node.(types.JavaInterface); ok {
+			response = append(response, []byte(getClassId(classes[index].Node)))
+		}
+	}
+
+	return response
+}
+
+// Returns associations and dependencies. Property types are associations, parameter types and the
+// types used by method bodies are dependencies.
+func getClassAssociationsAndDependencies(classIds map[string]int, classes []class, scope string, variables []types.JavaVariable, methods []types.JavaMethod) ([]types.CustomByteSlice, []types.CustomByteSlice) {
+	var (
+		associationsMap = make(map[string]struct{})
+		dependenciesMap = make(map[string]struct{})
+	)
+
+	for _, variable := range variables {
+		addReferences(associationsMap, classIds, classes, scope, variable.Type)
+		addReferences(associationsMap, classIds, classes, scope, variable.Value)
+	}
+
+	for _, method := range methods {
+		for _, parameter := range method.Parameters {
+			addReferences(dependenciesMap, classIds, classes, scope, parameter.Type)
+		}
+
+		addReferences(dependenciesMap, classIds, classes, scope, method.Functionality)
+	}
+
+	// Association is a stronger form of a dependency
+	for classId := range associationsMap {
+		delete(dependenciesMap, classId)
+	}
+
+	return sortedKeys(associationsMap), sortedKeys(dependenciesMap)
+}
+
+// Adds the class id of every name in the text that resolves to a type
+func addReferences(m map[string]struct{}, classIds map[string]int, classes []class, scope string, text []byte) {
+	for _, name := range identifierRegex.FindAllString(string(text), -1) {
+		if classId := resolveName(classIds, classes, scope, name); classId != "" {
+			m[classId] = struct{}{}
+		}
+	}
+}
+
+func addClassRelations(project *types.Project, packageName, name, definedWithin []byte, extends, implements, associations, dependencies []types.CustomByteSlice) {
+	fromClassId := []byte(string(packageName) + "." + string(name))
+
+	for _, extend := range extends {
+		project.AddRelation(fromClassId, extend, &types.Generalization{})
+	}
+
+	for _, implement := range implements {
+		project.AddRelation(fromClassId, implement, &types.Realization{})
+	}
+
+	if len(definedWithin) != 0 {
+		project.AddRelation(fromClassId, []byte(string(packageName)+"."+string(definedWithin)), &types.NestedOwnership{})
+	}
+
+	for _, association := range associations {
+		project.AddRelation(fromClassId, association, &types.Association{})
+	}
+
+	for _, dependency := range dependencies {
+		project.AddRelation(fromClassId, dependency, &types.Dependency{})
+	}
+}
+
+func getClassPackageAndName(class any) ([]byte, string) {
+	switch c := class.(type) {
+	case types.JavaClass:
+		return c.Package, string(c.Name)
+	case types.JavaInterface:
+		return c.Package, string(c.Name)
+	case types.JavaEnum:
+		return c.Package, string(c.Name)
+	}
+
+	return nil, ""
+}
+
+func getClassId(class any) string {
+	pkg, name := getClassPackageAndName(class)
+	return string(pkg) + "." + name
+}
+
+func sortedKeys(m map[string]struct{}) []types.CustomByteSlice {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var response []types.CustomByteSlice
+	for _, key := range keys {
+		response = append(response, []byte(key))
+	}
+
+	return response
+}
@@ -0,0 +1,153 @@
+package swift
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func relationToString(relation types.Relation) string {
+	return fmt.Sprintf("%s -> %s %T from:%t to:%t", relation.FromClassId, relation.ToClassId, relation.Type, relation.Type.GetFromArrow(), relation.Type.GetToArrow())
+}
+
+func TestParseProject(t *testing.T) {
+	type Output struct {
+		Nodes []string
+		Edges []types.Relation
+	}
+
+	type ParseProjectTest struct {
+		Input  []types.File
+		Output Output
+	}
+
+	var tests = []ParseProjectTest{
+		{
+			Input: []types.File{
+				{
+					Name:      "Animal",
+					Extension: "swift",
+					Code: []byte(`
+protocol Named {
+	var name: String { get }
+}
+
+protocol Walker: Named {
+	func walk(steps: Int)
+}
+
+class Animal: Named {
+	let name: String
+	var keeper: Keeper?
+
+	init(name: String) {
+		self.name = name
+	}
+}
+`),
+				},
+				{
+					Name:      "Dog",
+					Extension: "swift",
+					Code: []byte(`
+final class Dog: Animal, Walker {
+	var collars: [Collar] = []
+
+	func walk(steps: Int) {
+		let route = Route.shortest(steps)
+	}
+
+	struct Collar {
+		var tag: String
+	}
+}
+
+enum Mood: String, Named {
+	case happy, sleepy
+}
+
+extension Dog {
+	func play(with toy: Toy) {}
+}
+
+extension Keeper: Named {}
+
+extension String: Named {}
+`),
+				},
+				{
+					Name:      "Keeper",
+					Extension: "swift",
+					Code: []byte(`
+struct Keeper {
+	var name: String
+}
+
+struct Route {
+	static func shortest(_ steps: Int) -> Route { Route() }
+}
+
+struct Toy {}
+`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{"default.Named", "default.Walker", "default.Animal", "default.Dog", "default.Collar", "default.Mood", "default.Keeper", "default.Route", "default.Toy"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("default.Walker"), ToClassId: []byte("default.Named"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("default.Animal"), ToClassId: []byte("default.Named"), Type: &types.Realization{ToArrow: true}},
+					{FromClassId: []byte("default.Animal"), ToClassId: []byte("default.Keeper"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("default.Dog"), ToClassId: []byte("default.Animal"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("default.Dog"), ToClassId: []byte("default.Walker"), Type: &types.Realization{ToArrow: true}},
+					{FromClassId: []byte("default.Dog"), ToClassId: []byte("default.Collar"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("default.Dog"), ToClassId: []byte("default.Route"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("default.Dog"), ToClassId: []byte("default.Toy"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("default.Collar"), ToClassId: []byte("default.Dog"), Type: &types.NestedOwnership{ToArrow: true}},
+					{FromClassId: []byte("default.Mood"), ToClassId: []byte("default.Named"), Type: &types.Realization{ToArrow: true}},
+					{FromClassId: []byte("default.Keeper"), ToClassId: []byte("default.Named"), Type: &types.Realization{ToArrow: true}},
+				},
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := ParseProject(context.Background(), tt.Input)
+
+			var nodes []string
+			for _, node := range response.Nodes {
+				nodes = append(nodes, getClassId(node))
+			}
+
+			if fmt.Sprint(nodes) != fmt.Sprint(tt.Output.Nodes) {
+				subtest.Errorf("incorrect nodes.\nexpected:\n%s\ngot:\n%s\n", tt.Output.Nodes, nodes)
+			}
+
+			var expectedEdges, actualEdges []string
+			for _, edge := range tt.Output.Edges {
+				expectedEdges = append(expectedEdges, relationToString(edge))
+			}
+
+			for _, edge := range response.Edges {
+				actualEdges = append(actualEdges, relationToString(edge))
+			}
+
+			sort.Strings(expectedEdges)
+			sort.Strings(actualEdges)
+
+			if fmt.Sprint(expectedEdges) != fmt.Sprint(actualEdges) {
+				subtest.Errorf("incorrect edges.\nexpected:\n%s\ngot:\n%s\n", expectedEdges, actualEdges)
+			}
+
+			for _, node := range response.Nodes {
+				if dog, ok := node.(types.JavaClass); ok && string(dog.Name) == "Dog" && len(dog.Methods) != 2 {
+					subtest.Errorf("the extension of Dog was not merged")
+				}
+			}
+		})
+	}
+}
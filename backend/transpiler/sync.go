@@ -0,0 +1,524 @@
+package transpiler
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+const (
+	// Space that is left between a new node and the nodes around it
+	syncNodeGap = 60
+
+	// Number of places along every side of a related node that are tried before a new node is placed below the diagram
+	syncPlacementAttempts = 20
+
+	// Nodes of the diagram that were not found in the source are flagged with this key
+	missingFromSourceKey = "missingFromSource"
+)
+
+// Keys of a node cell that are generated from the source code. The other keys of a cell, such as its position,
+// its colors and the group that it is in, belong to the diagram and are kept when the diagram is synced.
+var nodeSourceKeys = []string{"type", "packageName", "name", "typeParameters", "stereotypes", "javadoc", "variables", "methods", "declarations", "language", "path", "span"}
+
+type syncRect struct {
+	x, y, width, height float64
+}
+
+func (r syncRect) overlaps(other syncRect) bool {
+	return r.x < other.x+other.width+syncNodeGap/2 && other.x < r.x+r.width+syncNodeGap/2 &&
+		r.y < other.y+other.height+syncNodeGap/2 && other.y < r.y+r.height+syncNodeGap/2
+}
+
+// Merges the cells of a newly transpiled project into the cells of an existing diagram.
+// Nodes are matched by their fully qualified name. A matched node gets the members of the new node and keeps its id,
+// position, group and styling, so that the issues that are connected to it stay connected.
+// New nodes are placed next to a node that they are related to, or below the diagram when they are not related to any.
+// Nodes that are not in the source anymore are removed when removeMissing is set, and flagged otherwise.
+// Edges that were generated from the source are reconciled with the relations of the project, edges that the user drew are kept.
+func SyncDiagram(content []map[string]any, transpiled []any, removeMissing bool) ([]any, types.SyncSummary, error) {
+	var (
+		summary  types.SyncSummary
+		response []map[string]any
+		fresh    []map[string]any
+	)
+
+	// The new cells are handled as JSON too, like the cells that the frontend saved
+	b, err := json.Marshal(transpiled)
+	if err != nil {
+		return nil, summary, err
+	}
+
+	if err := json.Unmarshal(b, &fresh); err != nil {
+		return nil, summary, err
+	}
+
+	var (
+		oldNodes   = make(map[string]map[string]any)
+		groups     = make(map[string]map[string]any)
+		idMap      = make(map[string]string)
+		sourceIds  = make(map[string]bool)
+		removedIds = make(map[string]bool)
+		newNodes   []map[string]any
+		freshEdges []map[string]any
+	)
+
+	for _, cell := range content {
+		if isSyncNode(cell) {
+			if classId := getCellClassId(cell); oldNodes[classId] == nil {
+				oldNodes[classId] = cell
+			}
+		} else if packageName, _ := cell["packageName"].(string); packageName != "" && groups[packageName] == nil {
+			groups[packageName] = cell
+		}
+	}
+
+	// Update the nodes that are still in the source
+	for _, cell := range fresh {
+		switch {
+		case isSyncNode(cell):
+			old := oldNodes[getCellClassId(cell)]
+			if old == nil {
+				// Cells that were saved before nodes were tagged with their language are matched by their name
+				old = oldNodes[getCellName(cell)]
+			}
+
+			if old == nil || sourceIds[getCellId(old)] {
+				newNodes = append(newNodes, cell)
+				sourceIds[getCellId(cell)] = true
+				continue
+			}
+
+			updateSyncNode(old, cell)
+			idMap[getCellId(cell)] = getCellId(old)
+			sourceIds[getCellId(old)] = true
+			summary.Updated++
+		case cell["shape"] == "edge":
+			freshEdges = append(freshEdges, cell)
+		}
+	}
+
+	// Remove or flag the nodes that are not in the source anymore
+	for _, cell := range content {
+		if !isSyncNode(cell) || sourceIds[getCellId(cell)] {
+			continue
+		}
+
+		if removeMissing {
+			removedIds[getCellId(cell)] = true
+			summary.Removed++
+		} else {
+			cell[missingFromSourceKey] = true
+			summary.Flagged++
+		}
+	}
+
+	freshEdgeKeys := make(map[string]bool)
+	for _, edge := range freshEdges {
+		freshEdgeKeys[getSyncEdgeKey(edge, idMap)] = true
+	}
+
+	// Keep the edges that are still related in the source and the edges that the user drew
+	existingEdgeKeys := make(map[string]bool)
+	for _, cell := range content {
+		if removedIds[getCellId(cell)] {
+			continue
+		}
+
+		if cell["shape"] == "edge" {
+			source, target := getEdgeCellIds(cell, nil)
+			key := getSyncEdgeKey(cell, nil)
+
+			if removedIds[source] || removedIds[target] || (isSourceEdge(cell) && sourceIds[source] && sourceIds[target] && !freshEdgeKeys[key]) {
+				summary.EdgesRemoved++
+				continue
+			}
+
+			existingEdgeKeys[key] = true
+		}
+
+		if children, ok := cell["children"].([]any); ok && len(removedIds) != 0 {
+			var kept []any
+			for _, child := range children {
+				if id, _ := child.(string); !removedIds[id] {
+					kept = append(kept, child)
+				}
+			}
+
+			cell["children"] = kept
+		}
+
+		response = append(response, cell)
+	}
+
+	response = append(response, placeSyncNodes(response, newNodes, freshEdges, idMap, groups)...)
+	response = append(response, newNodes...)
+	summary.Added = len(newNodes)
+
+	// Add the edges of the relations that are new in the source
+	rects := make(map[string]syncRect)
+	for _, cell := range response {
+		if isSyncNode(cell) {
+			rects[getCellId(cell)] = getCellRect(cell)
+		}
+	}
+
+	for _, edge := range freshEdges {
+		key := getSyncEdgeKey(edge, idMap)
+		if existingEdgeKeys[key] {
+			continue
+		}
+
+		existingEdgeKeys[key] = true
+		source, target := getEdgeCellIds(edge, idMap)
+
+		sourceRect, targetRect := rects[source], rects[target]
+		sourcePort, targetPort := selectPort(
+			sourceRect.x+sourceRect.width/2,
+			sourceRect.y+sourceRect.height/2,
+			targetRect.x+targetRect.width/2,
+			targetRect.y+targetRect.height/2,
+		)
+
+		setEdgeConnection(edge, "source", source, sourcePort)
+		setEdgeConnection(edge, "target", target, targetPort)
+		response = append(response, edge)
+		summary.EdgesAdded++
+	}
+
+	cells := make([]any, len(response))
+	for i, cell := range response {
+		cells[i] = cell
+	}
+
+	return cells, summary, nil
+}
+
+// Copies the source keys of the new node to the node of the diagram
+func updateSyncNode(old, cell map[string]any) {
+	for _, key := range nodeSourceKeys {
+		if value, ok := cell[key]; ok {
+			old[key] = value
+		} else {
+			delete(old, key)
+		}
+	}
+
+	delete(old, missingFromSourceKey)
+
+	// The height follows the members of the node, but a node that was made wider keeps its width
+	oldRect, rect := getCellRect(old), getCellRect(cell)
+	old["size"] = map[string]any{
+		"width":  math.Max(oldRect.width, rect.width),
+		"height": rect.height,
+	}
+}
+
+// Positions the new nodes next to a node that they are related to. Nodes in the same package are preferred,
+// and a new node joins the group of its package. When the diagram groups its packages but has no group for the package
+// of a new node, a group is created for it. Returns the new group cells.
+func placeSyncNodes(cells, newNodes, edges []map[string]any, idMap map[string]string, groups map[string]map[string]any) []map[string]any {
+	var (
+		rects     = make(map[string]syncRect)
+		placed    []syncRect
+		neighbors = make(map[string][]string)
+		packages  = make(map[string]string)
+		bottom    = math.Inf(-1)
+		left      = math.Inf(1)
+		useGroups = len(groups) != 0
+		newGroups []map[string]any
+	)
+
+	addPlaced := func(cell map[string]any) {
+		rect := getCellRect(cell)
+		rects[getCellId(cell)] = rect
+		placed = append(placed, rect)
+		packages[getCellId(cell)], _ = cell["packageName"].(string)
+	}
+
+	for _, cell := range cells {
+		if isSyncNode(cell) {
+			addPlaced(cell)
+		}
+	}
+
+	for _, rect := range placed {
+		bottom = math.Max(bottom, rect.y+rect.height)
+		left = math.Min(left, rect.x)
+	}
+
+	if len(placed) == 0 {
+		bottom, left = -syncNodeGap*2, 0
+	}
+
+	// New nodes that are not related to a placed node are put in a row below the diagram
+	rowX, rowY := left, bottom+syncNodeGap*2
+
+	// Edges of the new project point to new node ids, which are replaced by the ids of the nodes they were matched to
+	for _, edge := range edges {
+		source, target := getEdgeCellIds(edge, idMap)
+
+		neighbors[source] = append(neighbors[source], target)
+		neighbors[target] = append(neighbors[target], source)
+	}
+
+	isFree := func(rect syncRect) bool {
+		for _, other := range placed {
+			if rect.overlaps(other) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// Returns a free place for a node next to the anchor, trying the right, bottom, left and top of it
+	placeNear := func(anchor syncRect, width, height float64) (syncRect, bool) {
+		for attempt := 0; attempt < syncPlacementAttempts; attempt++ {
+			offset := float64(attempt) * (height + syncNodeGap)
+
+			for _, rect := range []syncRect{
+				{anchor.x + anchor.width + syncNodeGap, anchor.y + offset, width, height},
+				{anchor.x + offset, anchor.y + anchor.height + syncNodeGap, width, height},
+				{anchor.x - syncNodeGap - width, anchor.y + offset, width, height},
+				{anchor.x + offset, anchor.y - syncNodeGap - height, width, height},
+			} {
+				if isFree(rect) {
+					return rect, true
+				}
+			}
+		}
+
+		return syncRect{}, false
+	}
+
+	// Returns the placed node that a new node is placed next to
+	getAnchor := func(cell map[string]any) (syncRect, bool) {
+		packageName, _ := cell["packageName"].(string)
+
+		var related []string
+		for _, id := range neighbors[getCellId(cell)] {
+			if _, ok := rects[id]; ok && packages[id] == packageName {
+				related = append(related, id)
+			}
+		}
+
+		for _, id := range neighbors[getCellId(cell)] {
+			if _, ok := rects[id]; ok && packages[id] != packageName {
+				related = append(related, id)
+			}
+		}
+
+		if len(related) != 0 {
+			return rects[related[0]], true
+		}
+
+		// Nodes of the same package are related too, once a new node of a package with no group was placed
+		if group := groups[packageName]; group != nil {
+			for _, child := range getCellChildren(group) {
+				if rect, ok := rects[child]; ok {
+					return rect, true
+				}
+			}
+		}
+
+		return syncRect{}, false
+	}
+
+	place := func(cell map[string]any, rect syncRect) {
+		cell["position"] = map[string]any{"x": rect.x, "y": rect.y}
+		delete(cell, "parent")
+
+		packageName, _ := cell["packageName"].(string)
+		if groups[packageName] == nil && useGroups {
+			groups[packageName] = newSyncGroup(packageName, rect)
+			newGroups = append(newGroups, groups[packageName])
+		}
+
+		if group := groups[packageName]; group != nil {
+			cell["parent"] = getCellId(group)
+			group["children"] = append(getCellChildrenAny(group), getCellId(cell))
+			growSyncGroup(group, rect)
+		}
+
+		addPlaced(cell)
+	}
+
+	pending := newNodes
+	for len(pending) != 0 {
+		var remaining []map[string]any
+
+		for _, cell := range pending {
+			rect := getCellRect(cell)
+
+			anchor, ok := getAnchor(cell)
+			if !ok {
+				remaining = append(remaining, cell)
+				continue
+			}
+
+			if rect, ok = placeNear(anchor, rect.width, rect.height); !ok {
+				remaining = append(remaining, cell)
+				continue
+			}
+
+			place(cell, rect)
+		}
+
+		// When no node could be placed next to a related node, the first one starts the row below the diagram
+		if len(remaining) == len(pending) {
+			rect := getCellRect(remaining[0])
+			place(remaining[0], syncRect{rowX, rowY, rect.width, rect.height})
+			rowX += rect.width + syncNodeGap
+			remaining = remaining[1:]
+		}
+
+		pending = remaining
+	}
+
+	return newGroups
+}
+
+// Returns a group cell for a package, large enough to hold the rect
+func newSyncGroup(packageName string, rect syncRect) map[string]any {
+	group := newPackageGroup(&layeredItem{
+		id:     packageName,
+		x:      rect.x - hierarchicalGroupPadding,
+		y:      rect.y - hierarchicalGroupPadding - hierarchicalGroupHeader,
+		width:  rect.width + 2*hierarchicalGroupPadding,
+		height: rect.height + 2*hierarchicalGroupPadding + hierarchicalGroupHeader,
+	})
+
+	// The group is handled as JSON like the other cells, it only holds strings and numbers
+	var response map[string]any
+	b, _ := json.Marshal(group)
+	json.Unmarshal(b, &response)
+
+	return response
+}
+
+// Makes a group large enough to hold the rect
+func growSyncGroup(group map[string]any, rect syncRect) {
+	groupRect := getCellRect(group)
+	padding := float64(hierarchicalGroupPadding)
+
+	x := math.Min(groupRect.x, rect.x-padding)
+	y := math.Min(groupRect.y, rect.y-padding)
+	width := math.Max(groupRect.x+groupRect.width, rect.x+rect.width+padding) - x
+	height := math.Max(groupRect.y+groupRect.height, rect.y+rect.height+padding) - y
+
+	group["position"] = map[string]any{"x": x, "y": y}
+	group["size"] = map[string]any{"width": width, "height": height}
+}
+
+func isSyncNode(cell map[string]any) bool {
+	return cell["shape"] == "custom-class"
+}
+
+func getCellId(cell map[string]any) string {
+	id, _ := cell["id"].(string)
+	return id
+}
+
+// Returns the class id of a node cell, like getNodeClassId does for parsed nodes
+func getCellClassId(cell map[string]any) string {
+	if language, _ := cell["language"].(string); language != "" {
+		return getLanguage(language) + ":" + getCellName(cell)
+	}
+
+	return getCellName(cell)
+}
+
+// Returns the fully qualified name of a node cell: com.shop.Order
+func getCellName(cell map[string]any) string {
+	packageName, _ := cell["packageName"].(string)
+	name, _ := cell["name"].(string)
+	return packageName + "." + name
+}
+
+func getCellRect(cell map[string]any) syncRect {
+	var rect syncRect
+
+	if position, ok := cell["position"].(map[string]any); ok {
+		rect.x, _ = position["x"].(float64)
+		rect.y, _ = position["y"].(float64)
+	}
+
+	if size, ok := cell["size"].(map[string]any); ok {
+		rect.width, _ = size["width"].(float64)
+		rect.height, _ = size["height"].(float64)
+	}
+
+	return rect
+}
+
+func getCellChildrenAny(cell map[string]any) []any {
+	children, _ := cell["children"].([]any)
+	return children
+}
+
+func getCellChildren(cell map[string]any) []string {
+	var response []string
+	for _, child := range getCellChildrenAny(cell) {
+		if id, ok := child.(string); ok {
+			response = append(response, id)
+		}
+	}
+
+	return response
+}
+
+// Returns the ids of the nodes that an edge connects.
+// Edges of a new project point to the ids of new nodes, which idMap replaces with the ids of the nodes they were matched to.
+func getEdgeCellIds(edge map[string]any, idMap map[string]string) (string, string) {
+	var source, target string
+
+	if connection, ok := edge["source"].(map[string]any); ok {
+		source, _ = connection["cell"].(string)
+	}
+
+	if connection, ok := edge["target"].(map[string]any); ok {
+		target, _ = connection["cell"].(string)
+	}
+
+	if mapped, ok := idMap[source]; ok {
+		source = mapped
+	}
+
+	if mapped, ok := idMap[target]; ok {
+		target = mapped
+	}
+
+	return source, target
+}
+
+// Returns the key that identifies an edge by the nodes and the relation that it connects.
+// Different relations can share an edge type, so the relation of an edge that was generated from the source is used.
+func getSyncEdgeKey(edge map[string]any, idMap map[string]string) string {
+	source, target := getEdgeCellIds(edge, idMap)
+
+	relation, _ := edge["edgeType"].(string)
+	if data, ok := edge["data"].(map[string]any); ok && data["relation"] != nil {
+		relation, _ = data["relation"].(string)
+	}
+
+	return source + " " + relation + " " + target
+}
+
+// Reports whether the edge was generated from a relation of the source, see types.EdgeData
+func isSourceEdge(edge map[string]any) bool {
+	data, ok := edge["data"].(map[string]any)
+	return ok && data["relation"] != nil
+}
+
+func setEdgeConnection(edge map[string]any, end, cellId, port string) {
+	connection, ok := edge[end].(map[string]any)
+	if !ok {
+		connection = make(map[string]any)
+		edge[end] = connection
+	}
+
+	connection["cell"] = cellId
+	connection["port"] = port
+}
@@ -0,0 +1,321 @@
+package transpiler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func getSyncTestDiagram(t *testing.T, files []types.File) []map[string]any {
+	cells, _, _, err := Transpile(context.Background(), nil, files, LayoutHierarchical)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	b, _ := json.Marshal(cells)
+
+	var content []map[string]any
+	if err := json.Unmarshal(b, &content); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	return content
+}
+
+func TestSyncDiagram(t *testing.T) {
+	content := getSyncTestDiagram(t, []types.File{
+		{Path: "shop/Order.java", Name: "Order", Extension: "java", Code: []byte("package shop;\n\npublic class Order {\n\tprivate Customer customer;\n}\n")},
+		{Path: "shop/Customer.java", Name: "Customer", Extension: "java", Code: []byte("package shop;\n\npublic class Customer {\n\tprivate String name;\n}\n")},
+	})
+
+	// The user moves and colors the order
+	var orderId string
+	for _, cell := range content {
+		if cell["name"] == "Order" {
+			orderId = getCellId(cell)
+			cell["position"] = map[string]any{"x": 1000.0, "y": 1000.0}
+			cell["backgroundColor"] = "#ff0000"
+		}
+	}
+
+	transpiled, _, _, err := Transpile(context.Background(), nil, []types.File{
+		{Path: "shop/Order.java", Name: "Order", Extension: "java", Code: []byte("package shop;\n\npublic class Order {\n\tprivate Invoice invoice;\n\n\tpublic int total() {\n\t\treturn 0;\n\t}\n}\n")},
+		{Path: "shop/Invoice.java", Name: "Invoice", Extension: "java", Code: []byte("package shop;\n\npublic class Invoice {\n}\n")},
+	}, LayoutHierarchical)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	for _, removeMissing := range []bool{false, true} {
+		t.Run(fmt.Sprintf("removeMissing=%t", removeMissing), func(subtest *testing.T) {
+			cells, summary, err := SyncDiagram(getSyncTestDiagramCopy(content), transpiled, removeMissing)
+			if err != nil {
+				subtest.Fatalf("unexpected error: %s", err.Error())
+			}
+
+			var (
+				nodes   = make(map[string]map[string]any)
+				ids     = make(map[string]string)
+				actual  []string
+				invoice syncRect
+				order   syncRect
+			)
+
+			for _, cell := range cells {
+				if node := cell.(map[string]any); isSyncNode(node) {
+					nodes[getCellId(node)] = node
+					ids[getCellId(node)], _ = node["name"].(string)
+				}
+			}
+
+			for _, cell := range cells {
+				node := cell.(map[string]any)
+				switch {
+				case isSyncNode(node):
+					description := node["name"].(string)
+					if node[missingFromSourceKey] == true {
+						description += " missing"
+					}
+
+					actual = append(actual, description)
+				case node["shape"] == "edge":
+					source, target := getEdgeCellIds(node, nil)
+					actual = append(actual, ids[source]+" -> "+ids[target])
+				}
+			}
+
+			order = getCellRect(nodes[orderId])
+			for _, node := range nodes {
+				if node["name"] == "Invoice" {
+					invoice = getCellRect(node)
+				}
+			}
+
+			expected := []string{"Customer missing", "Invoice", "Order", "Order -> Customer", "Order -> Invoice"}
+			if removeMissing {
+				expected = []string{"Invoice", "Order", "Order -> Invoice"}
+			}
+
+			sort.Strings(actual)
+			if strings.Join(actual, ", ") != strings.Join(expected, ", ") {
+				subtest.Errorf("incorrect cells.\nexpected:\n%v\ngot:\n%v\n", expected, actual)
+			}
+
+			if nodes[orderId] == nil {
+				subtest.Fatalf("order lost its id %s", orderId)
+			}
+
+			if order.x != 1000 || order.y != 1000 || nodes[orderId]["backgroundColor"] != "#ff0000" {
+				subtest.Errorf("order lost its layout.\nexpected:\n1000 1000 #ff0000\ngot:\n%v %v %v\n", order.x, order.y, nodes[orderId]["backgroundColor"])
+			}
+
+			if methods, _ := nodes[orderId]["methods"].([]any); len(methods) != 1 {
+				subtest.Errorf("incorrect number of order methods.\nexpected: 1\ngot: %d\n", len(methods))
+			}
+
+			// The new invoice is placed right next to the order, which it is related to
+			if invoice.overlaps(order) || invoice.x != order.x+order.width+syncNodeGap || invoice.y != order.y {
+				subtest.Errorf("incorrect invoice position.\nexpected:\n%v %v\ngot:\n%v %v\n", order.x+order.width+syncNodeGap, order.y, invoice.x, invoice.y)
+			}
+
+			expectedSummary := types.SyncSummary{Updated: 1, Added: 1, Flagged: 1, EdgesAdded: 1}
+			if removeMissing {
+				expectedSummary = types.SyncSummary{Updated: 1, Added: 1, Removed: 1, EdgesAdded: 1, EdgesRemoved: 1}
+			}
+
+			if summary != expectedSummary {
+				subtest.Errorf("incorrect summary.\nexpected:\n%+v\ngot:\n%+v\n", expectedSummary, summary)
+			}
+		})
+	}
+}
+
+func TestSyncDiagramEdges(t *testing.T) {
+	content := getSyncTestDiagram(t, []types.File{
+		{Path: "shop/Order.java", Name: "Order", Extension: "java", Code: []byte("package shop;\n\npublic class Order {\n\tprivate Customer customer;\n}\n")},
+		{Path: "shop/Customer.java", Name: "Customer", Extension: "java", Code: []byte("package shop;\n\npublic class Customer {\n}\n")},
+	})
+
+	// The user draws an edge from the customer to the order
+	var orderId, customerId string
+	for _, cell := range content {
+		switch cell["name"] {
+		case "Order":
+			orderId = getCellId(cell)
+		case "Customer":
+			customerId = getCellId(cell)
+		}
+	}
+
+	content = append(content, map[string]any{
+		"id":       "drawn",
+		"shape":    "edge",
+		"edgeType": "association",
+		"source":   map[string]any{"cell": customerId},
+		"target":   map[string]any{"cell": orderId},
+	})
+
+	transpiled, _, _, err := Transpile(context.Background(), nil, []types.File{
+		{Path: "shop/Order.java", Name: "Order", Extension: "java", Code: []byte("package shop;\n\npublic class Order {\n}\n")},
+		{Path: "shop/Customer.java", Name: "Customer", Extension: "java", Code: []byte("package shop;\n\npublic class Customer {\n}\n")},
+	}, LayoutHierarchical)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cells, summary, syncErr := SyncDiagram(content, transpiled, true)
+	if syncErr != nil {
+		t.Fatalf("unexpected error: %s", syncErr.Error())
+	}
+
+	var edges []string
+	for _, cell := range cells {
+		if edge := cell.(map[string]any); edge["shape"] == "edge" {
+			edges = append(edges, getCellId(edge))
+		}
+	}
+
+	if strings.Join(edges, ", ") != "drawn" || summary.EdgesRemoved != 1 {
+		t.Errorf("incorrect edges.\nexpected:\ndrawn 1\ngot:\n%v %d\n", edges, summary.EdgesRemoved)
+	}
+}
+
+func TestSyncDiagramGroups(t *testing.T) {
+	content := getSyncTestDiagram(t, []types.File{
+		{Path: "shop/Order.java", Name: "Order", Extension: "java", Code: []byte("package shop;\n\nimport billing.Invoice;\n\npublic class Order {\n\tprivate Invoice invoice;\n}\n")},
+		{Path: "billing/Invoice.java", Name: "Invoice", Extension: "java", Code: []byte("package billing;\n\npublic class Invoice {\n}\n")},
+	})
+
+	transpiled, _, _, err := Transpile(context.Background(), nil, []types.File{
+		{Path: "shop/Order.java", Name: "Order", Extension: "java", Code: []byte("package shop;\n\nimport billing.Invoice;\nimport crm.Customer;\n\npublic class Order {\n\tprivate Invoice invoice;\n\tprivate Customer customer;\n}\n")},
+		{Path: "billing/Invoice.java", Name: "Invoice", Extension: "java", Code: []byte("package billing;\n\npublic class Invoice {\n}\n")},
+		{Path: "crm/Customer.java", Name: "Customer", Extension: "java", Code: []byte("package crm;\n\npublic class Customer {\n}\n")},
+	}, LayoutHierarchical)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	cells, _, syncErr := SyncDiagram(content, transpiled, false)
+	if syncErr != nil {
+		t.Fatalf("unexpected error: %s", syncErr.Error())
+	}
+
+	var (
+		customer map[string]any
+		groups   = make(map[string]map[string]any)
+	)
+
+	for _, cell := range cells {
+		node := cell.(map[string]any)
+		if node["name"] == "Customer" {
+			customer = node
+		} else if packageName, _ := node["packageName"].(string); !isSyncNode(node) && packageName != "" {
+			groups[packageName] = node
+		}
+	}
+
+	group := groups["crm"]
+	if len(groups) != 3 || group == nil || customer == nil {
+		t.Fatalf("incorrect groups.\nexpected:\n3 crm\ngot:\n%d %v\n", len(groups), group)
+	}
+
+	groupRect, customerRect := getCellRect(group), getCellRect(customer)
+	if customer["parent"] != getCellId(group) || strings.Join(getCellChildren(group), ", ") != getCellId(customer) ||
+		customerRect.x < groupRect.x || customerRect.y < groupRect.y ||
+		customerRect.x+customerRect.width > groupRect.x+groupRect.width || customerRect.y+customerRect.height > groupRect.y+groupRect.height {
+		t.Errorf("customer is not inside of the crm group")
+	}
+}
+
+func TestGetSyncEdgeKey(t *testing.T) {
+	type GetSyncEdgeKeyTest struct {
+		Input  map[string]any
+		Output string
+	}
+
+	var tests = []GetSyncEdgeKeyTest{
+		{
+			Input: map[string]any{
+				"edgeType": "classic",
+				"source":   map[string]any{"cell": "a"},
+				"target":   map[string]any{"cell": "b"},
+				"data":     map[string]any{"relation": "dependency"},
+			},
+			Output: "a dependency b",
+		},
+		{
+			// Dependencies and nested classes are both drawn as classic edges
+			Input: map[string]any{
+				"edgeType": "classic",
+				"source":   map[string]any{"cell": "a"},
+				"target":   map[string]any{"cell": "b"},
+				"data":     map[string]any{"relation": "nestedOwnership"},
+			},
+			Output: "a nestedOwnership b",
+		},
+		{
+			// Edges that the user drew have no relation
+			Input: map[string]any{
+				"edgeType": "association",
+				"source":   map[string]any{"cell": "a"},
+				"target":   map[string]any{"cell": "b"},
+			},
+			Output: "a association b",
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			if key := getSyncEdgeKey(tt.Input, nil); key != tt.Output {
+				subtest.Errorf("incorrect key.\nexpected:\n%s\ngot:\n%s\n", tt.Output, key)
+			}
+		})
+	}
+}
+
+func TestGetCellClassId(t *testing.T) {
+	type GetCellClassIdTest struct {
+		Input  map[string]any
+		Output string
+	}
+
+	var tests = []GetCellClassIdTest{
+		{
+			Input:  map[string]any{"packageName": "shop", "name": "Order", "language": "kt"},
+			Output: "jvm:shop.Order",
+		},
+		{
+			Input:  map[string]any{"packageName": "shop", "name": "Order", "language": "swift"},
+			Output: "swift:shop.Order",
+		},
+		{
+			// Cells that were saved before nodes were tagged with their language
+			Input:  map[string]any{"packageName": "shop", "name": "Order"},
+			Output: "shop.Order",
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			if classId := getCellClassId(tt.Input); classId != tt.Output {
+				subtest.Errorf("incorrect class id.\nexpected:\n%s\ngot:\n%s\n", tt.Output, classId)
+			}
+		})
+	}
+}
+
+// SyncDiagram changes the cells that it is given, so every subtest syncs its own copy
+func getSyncTestDiagramCopy(content []map[string]any) []map[string]any {
+	b, _ := json.Marshal(content)
+
+	var response []map[string]any
+	json.Unmarshal(b, &response)
+
+	return response
+}
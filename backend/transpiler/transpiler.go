@@ -2,100 +2,238 @@ package transpiler
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"math"
+	"path"
 	"path/filepath"
-	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/fogleman/gg"
 	"github.com/google/uuid"
 	"github.com/junioryono/ProUML/backend/sdk"
-	"github.com/junioryono/ProUML/backend/transpiler/java"
+	"github.com/junioryono/ProUML/backend/transpiler/cpp"
+	"github.com/junioryono/ProUML/backend/transpiler/csharp"
+	"github.com/junioryono/ProUML/backend/transpiler/golang"
+	"github.com/junioryono/ProUML/backend/transpiler/kotlin"
+	"github.com/junioryono/ProUML/backend/transpiler/php"
+	"github.com/junioryono/ProUML/backend/transpiler/python"
+	"github.com/junioryono/ProUML/backend/transpiler/swift"
 	"github.com/junioryono/ProUML/backend/transpiler/types"
+	"github.com/junioryono/ProUML/backend/transpiler/typescript"
 	httpTypes "github.com/junioryono/ProUML/backend/types"
 )
 
 var (
-	SupportedLanguages   = []string{"java"}
-	UnsupportedLanguages = []string{"cpp", "go", "js", "ts", "html", "css", "py", "cs", "php", "swift", "vb"}
+	SupportedLanguages   = []string{"java", "kt", "kts", "py", "ts", "tsx", "js", "jsx", "go", "cs", "cpp", "cc", "cxx", "h", "hpp", "hh", "hxx", "php", "swift"}
+	UnsupportedLanguages = []string{"html", "css", "vb"}
+
+	// Extensions that are parsed together with another extension.
+	// Java and Kotlin compile to the same JVM classes, so a project can mix them.
+	languageAliases = map[string]string{
+		"java": "jvm", "kt": "jvm", "kts": "jvm",
+		"tsx": "ts", "js": "ts", "jsx": "ts",
+		"cc": "cpp", "cxx": "cpp", "h": "cpp", "hpp": "cpp", "hh": "cpp", "hxx": "cpp",
+	}
+
+	// Files that are parsed together with the source files of a language, keyed by their name.
+	// The go.mod file holds the module path that the import paths of Go packages start with.
+	projectFiles = map[string]string{"go.mod": "go"}
 )
 
-func Transpile(sdkP *sdk.SDK, files []types.File) ([]any, *httpTypes.WrappedError) {
-	language, err := getProjectLanguage(files)
+// Parses the files into a diagram. The diagnostics hold the problems of the files that were parsed, ordered by path and line.
+// Files are parsed in parallel, and transpiling stops early when the context is done.
+// The progress of the transpile is reported to the progress function of the context, see types.WithProgress.
+func Transpile(ctx context.Context, sdkP *sdk.SDK, files []types.File, layout string) ([]any, []types.LanguageSummary, []types.Diagnostic, *httpTypes.WrappedError) {
+	parsedProject, summaries, err := parseProject(ctx, files)
 	if err != nil {
-		return nil, err
+		return nil, summaries, nil, err
 	}
 
-	// Remove files that are not supported
-	for i := 0; i < len(files); i++ {
-		if files[i].Extension != language {
-			files = append(files[:i], files[i+1:]...)
-			i--
+	types.ReportProgress(ctx, types.ProgressParsed)
+
+	diagnostics := parsedProject.Diagnostics
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Path != diagnostics[j].Path {
+			return diagnostics[i].Path < diagnostics[j].Path
 		}
-	}
 
-	parsedProject, err := parseProjectByLanguage(language, files)
+		return diagnostics[i].Line < diagnostics[j].Line
+	})
+
+	diagramLayout, err := generateDiagramLayout(parsedProject, layout)
 	if err != nil {
-		return nil, err
+		return nil, summaries, diagnostics, err
 	}
 
-	diagramLayout := generateDiagramLayout(parsedProject)
+	types.ReportProgress(ctx, types.ProgressLayout)
 
-	return diagramLayout, nil
+	return diagramLayout, summaries, diagnostics, nil
 }
 
-func getProjectLanguage(files []types.File) (string, *httpTypes.WrappedError) {
-	var language string
-
-	// Iterate through files to find the language
-	// Use a map to store the languages found and how many times they were found
-	languagesMap := make(map[string]int)
+// Sends every file to the front-end of its language and merges the results into one project.
+// Files of languages that cannot be parsed are skipped and reported in the summaries, other files such as README.md are ignored.
+func parseProject(ctx context.Context, files []types.File) (*types.Project, []types.LanguageSummary, *httpTypes.WrappedError) {
+	var (
+		response      types.Project
+		summaries     []types.LanguageSummary
+		languages     []string
+		languageFiles = make(map[string][]types.File)
+		parsed        bool
+		unsupported   bool
+	)
 
 	for _, file := range files {
 		if file.Name == "" || file.Extension == "" {
 			continue
 		}
 
-		// Increment language count
-		languagesMap[file.Extension]++
+		language := getFileLanguage(file)
+		if language == "" {
+			continue
+		}
+
+		if _, ok := languageFiles[language]; !ok {
+			languages = append(languages, language)
+		}
+
+		languageFiles[language] = append(languageFiles[language], file)
 	}
 
-	// Iterate through languagesMap and find the language that is used the most
-	var mostUsedLanguage string
-	var mostUsedLanguageCount int
+	sort.Strings(languages)
 
-	for key, value := range languagesMap {
-		if value > mostUsedLanguageCount {
-			mostUsedLanguage = key
-			mostUsedLanguageCount = value
+	for _, language := range languages {
+		summary := types.LanguageSummary{
+			Language: language,
+			Files:    len(languageFiles[language]),
 		}
+
+		parsedProject, err := parseProjectByLanguage(ctx, language, languageFiles[language])
+
+		// Files that were not parsed before the context was done are missing from the project
+		if ctx.Err() != nil {
+			return nil, summaries, httpTypes.Wrap(ctx.Err(), httpTypes.ErrTranspileCanceled)
+		}
+
+		if err != nil {
+			summary.Skipped = true
+			summary.Reason = err.Error()
+			summaries = append(summaries, summary)
+
+			if err.Str == httpTypes.ErrUnsupportedLang {
+				unsupported = true
+			}
+
+			continue
+		}
+
+		parsed = true
+		summary.Nodes = len(parsedProject.Nodes)
+		summary.Edges = len(parsedProject.Edges)
+		summaries = append(summaries, summary)
+
+		mergeProject(&response, parsedProject, language)
 	}
 
-	// If the language that is used the most is used more than 50% of the time, return that language
-	if mostUsedLanguageCount > (len(languagesMap) / 2) {
-		language = mostUsedLanguage
-		return language, nil
+	if !parsed {
+		if unsupported {
+			return nil, summaries, httpTypes.Wrap(errors.New("this is an unsupported language"), httpTypes.ErrUnsupportedLang)
+		}
+
+		return nil, summaries, httpTypes.Wrap(errors.New("could not figure out which language was used"), httpTypes.ErrCouldNotFigureOutLang)
 	}
 
-	// If the language that is used the most is not used more than 50% of the time, return an error
-	return "", httpTypes.Wrap(errors.New("could not figure out which language was used"), httpTypes.ErrCouldNotFigureOutLang)
+	return &response, summaries, nil
+}
 
+// Adds the nodes, edges and diagnostics of one language to the project.
+// Nodes that the front-end did not tag with the language of their file are tagged with the language,
+// and the class ids of the edges are prefixed with it like getNodeClassId does, so languages never share a class id.
+func mergeProject(project *types.Project, parsedProject *types.Project, language string) {
+	for _, node := range parsedProject.Nodes {
+		if node.GetDiagramNode().Language == "" {
+			node = types.UpdateDiagramNode(node, func(diagramNode *types.JavaDiagramNode) {
+				diagramNode.Language = language
+			})
+		}
+
+		project.Nodes = append(project.Nodes, node)
+	}
+
+	for _, edge := range parsedProject.Edges {
+		edge.FromClassId = append([]byte(language+":"), edge.FromClassId...)
+		edge.ToClassId = append([]byte(language+":"), edge.ToClassId...)
+		project.Edges = append(project.Edges, edge)
+	}
+
+	project.Diagnostics = append(project.Diagnostics, parsedProject.Diagnostics...)
 }
 
-func parseProjectByLanguage(language string, files []types.File) (*types.Project, *httpTypes.WrappedError) {
+func parseProjectByLanguage(ctx context.Context, language string, files []types.File) (*types.Project, *httpTypes.WrappedError) {
 	// Call transpilation of specified language
 	switch language {
-	case "java":
-		return java.ParseProject(files), nil
+	case "jvm":
+		return kotlin.ParseProject(ctx, files), nil
+	case "py":
+		return python.ParseProject(ctx, files), nil
+	case "ts":
+		return typescript.ParseProject(ctx, files), nil
+	case "go":
+		return golang.ParseProject(ctx, files), nil
+	case "cs":
+		return csharp.ParseProject(ctx, files), nil
+	case "cpp":
+		return cpp.ParseProject(ctx, files), nil
+	case "php":
+		return php.ParseProject(ctx, files), nil
+	case "swift":
+		return swift.ParseProject(ctx, files), nil
 	case contains(UnsupportedLanguages, language):
-		// Covers C++, Go, JavaScript, TypeScript, HTML, CSS, Python , C#, PHP, Swift, Visual Basic
+		// Covers HTML, CSS, Visual Basic
 		return nil, httpTypes.Wrap(errors.New("this is an unsupported language"), httpTypes.ErrUnsupportedLang)
 	default:
 		return nil, httpTypes.Wrap(errors.New("could not figure out which language was used"), httpTypes.ErrCouldNotFigureOutLang)
 	}
 }
 
+// Source files larger than this are usually generated and are not parsed
+const MaxSourceFileSize = 4 * 1024 * 1024
+
+// Files of other extensions are never parsed, so their code does not need to be read
+func IsSupportedExtension(extension string) bool {
+	return contains(SupportedLanguages, extension) != ""
+}
+
+// Reports whether the code of the file is parsed, see IsSupportedExtension and projectFiles
+func IsSupportedFile(file types.File) bool {
+	_, ok := projectFiles[path.Base(file.GetPath())]
+	return ok || IsSupportedExtension(file.Extension)
+}
+
+// Returns the language that the file is parsed with, see projectFiles.
+// Returns an empty string for files that are not source files, such as README.md.
+func getFileLanguage(file types.File) string {
+	if language, ok := projectFiles[path.Base(file.GetPath())]; ok {
+		return language
+	}
+
+	if contains(SupportedLanguages, file.Extension) == "" && contains(UnsupportedLanguages, file.Extension) == "" {
+		return ""
+	}
+
+	return getLanguage(file.Extension)
+}
+
+func getLanguage(extension string) string {
+	if language, ok := languageAliases[extension]; ok {
+		return language
+	}
+
+	return extension
+}
+
 func contains(s []string, e string) string {
 	for _, a := range s {
 		if a == e {
@@ -106,7 +244,7 @@ func contains(s []string, e string) string {
 	return ""
 }
 
-func generateDiagramLayout(project *types.Project) []any {
+func generateDiagramLayout(project *types.Project, layout string) ([]any, *httpTypes.WrappedError) {
 	var diagramContent []any
 
 	for i := 0; i < len(project.Nodes); i++ {
@@ -115,13 +253,13 @@ func generateDiagramLayout(project *types.Project) []any {
 			node.ID = uuid.New().String()
 			node.Type = "abstract"
 			node.Shape = "custom-class"
-			node.Width, node.Height = getNodeSize(node.Name, node.Variables, node.Methods, nil, false)
+			node.Width, node.Height = getNodeSize(getNodeTitle(node.Name, node.TypeParameters), node.Variables, node.Methods, nil, len(node.Stereotypes) != 0)
 			project.Nodes[i] = node
 		case types.JavaClass:
 			node.ID = uuid.New().String()
 			node.Type = "class"
 			node.Shape = "custom-class"
-			node.Width, node.Height = getNodeSize(node.Name, node.Variables, node.Methods, nil, false)
+			node.Width, node.Height = getNodeSize(getNodeTitle(node.Name, node.TypeParameters), node.Variables, node.Methods, nil, len(node.Stereotypes) != 0)
 			project.Nodes[i] = node
 		case types.JavaEnum:
 			node.ID = uuid.New().String()
@@ -133,26 +271,55 @@ func generateDiagramLayout(project *types.Project) []any {
 			node.ID = uuid.New().String()
 			node.Type = "interface"
 			node.Shape = "custom-class"
+			node.Width, node.Height = getNodeSize(getNodeTitle(node.Name, node.TypeParameters), node.Variables, node.Methods, nil, true)
+			project.Nodes[i] = node
+		case types.PhpTrait:
+			node.ID = uuid.New().String()
+			node.Type = "trait"
+			node.Shape = "custom-class"
+			node.Width, node.Height = getNodeSize(node.Name, node.Variables, node.Methods, nil, true)
+			project.Nodes[i] = node
+		case types.JavaRecord:
+			node.ID = uuid.New().String()
+			node.Type = "record"
+			node.Shape = "custom-class"
+			node.Width, node.Height = getNodeSize(getNodeTitle(node.Name, node.TypeParameters), node.Variables, node.Methods, nil, true)
+			project.Nodes[i] = node
+		case types.JavaAnnotation:
+			node.ID = uuid.New().String()
+			node.Type = "annotation"
+			node.Shape = "custom-class"
 			node.Width, node.Height = getNodeSize(node.Name, node.Variables, node.Methods, nil, true)
 			project.Nodes[i] = node
 		}
 	}
 
+	// Position nodes and add the package groups to diagramContent
+	groups, err := layoutNodes(project.Nodes, project.Edges, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	diagramContent = append(diagramContent, groups...)
+
 	// Add nodes to diagramContent
-	diagramContent = append(diagramContent, project.Nodes...)
+	for _, node := range project.Nodes {
+		diagramContent = append(diagramContent, node)
+	}
 
 	// Add edges to diagramContent
+	diagramContent = append(diagramContent, generateDiagramEdges(project.Nodes, project.Edges)...)
 
-	return diagramContent
+	return diagramContent, nil
 }
 
-func generateChatGPTPrompt(nodes []any, edges []types.Relation) string {
+func generateChatGPTPrompt(nodes []types.DiagramNode, edges []types.Relation) string {
 	var prompt string
 
 	prompt += "\n\n The nodes are: \n\n"
 
 	for i := 0; i < len(nodes); i++ {
-		prompt += "Name: " + string(getNodeClassId(nodes[i])) + "\n"
+		prompt += "Name: " + string(nodes[i].GetClassId()) + "\n"
 
 		switch node := nodes[i].(type) {
 		case types.JavaAbstract:
@@ -201,6 +368,48 @@ func generateChatGPTPrompt(nodes []any, edges []types.Relation) string {
 				prompt += "Type: " + string(node.Variables[j].Type) + "\n"
 			}
 
+			for j := 0; j < len(node.Methods); j++ {
+				prompt += "Method: " + string(node.Methods[j].Name) + "\n"
+				prompt += "Type: " + string(node.Methods[j].Type) + "\n"
+			}
+		case types.PhpTrait:
+			prompt += "Type: Trait\n"
+			prompt += "Width: " + strconv.Itoa(int(node.Width)) + "\n"
+			prompt += "Height: " + strconv.Itoa(int(node.Height)) + "\n"
+
+			for j := 0; j < len(node.Variables); j++ {
+				prompt += "Variable: " + string(node.Variables[j].Name) + "\n"
+				prompt += "Type: " + string(node.Variables[j].Type) + "\n"
+			}
+
+			for j := 0; j < len(node.Methods); j++ {
+				prompt += "Method: " + string(node.Methods[j].Name) + "\n"
+				prompt += "Type: " + string(node.Methods[j].Type) + "\n"
+			}
+		case types.JavaRecord:
+			prompt += "Type: Record\n"
+			prompt += "Width: " + strconv.Itoa(int(node.Width)) + "\n"
+			prompt += "Height: " + strconv.Itoa(int(node.Height)) + "\n"
+
+			for j := 0; j < len(node.Variables); j++ {
+				prompt += "Variable: " + string(node.Variables[j].Name) + "\n"
+				prompt += "Type: " + string(node.Variables[j].Type) + "\n"
+			}
+
+			for j := 0; j < len(node.Methods); j++ {
+				prompt += "Method: " + string(node.Methods[j].Name) + "\n"
+				prompt += "Type: " + string(node.Methods[j].Type) + "\n"
+			}
+		case types.JavaAnnotation:
+			prompt += "Type: Annotation\n"
+			prompt += "Width: " + strconv.Itoa(int(node.Width)) + "\n"
+			prompt += "Height: " + strconv.Itoa(int(node.Height)) + "\n"
+
+			for j := 0; j < len(node.Variables); j++ {
+				prompt += "Variable: " + string(node.Variables[j].Name) + "\n"
+				prompt += "Type: " + string(node.Variables[j].Type) + "\n"
+			}
+
 			for j := 0; j < len(node.Methods); j++ {
 				prompt += "Method: " + string(node.Methods[j].Name) + "\n"
 				prompt += "Type: " + string(node.Methods[j].Type) + "\n"
@@ -267,7 +476,7 @@ func getNodeSize(name []byte, variables []types.JavaVariable, methods []types.Ja
 
 	// Variables
 	for _, variable := range variables {
-		variableString := string(variable.AccessModifier) + string(variable.Name) + ": " + string(variable.Type)
+		variableString := getStereotypesString(variable.Annotations) + string(variable.AccessModifier) + string(variable.Name) + ": " + string(variable.Type)
 		if variable.Value != nil {
 			variableString += " = " + string(variable.Value)
 		}
@@ -284,14 +493,37 @@ func getNodeSize(name []byte, variables []types.JavaVariable, methods []types.Ja
 
 	// Methods
 	for _, method := range methods {
-		methodString := string(method.AccessModifier) + string(method.Name) + "("
+		stereotypes := method.Annotations
+		if method.IsConstructor {
+			stereotypes = append([]types.CustomByteSlice{[]byte("create")}, stereotypes...)
+		}
+
+		methodString := getStereotypesString(stereotypes) + string(method.AccessModifier) + string(method.Name) + "("
 		for i, parameter := range method.Parameters {
-			methodString += string(parameter.Type) + " " + string(parameter.Name)
+			methodString += string(parameter.Type)
+			if parameter.Varargs {
+				methodString += "..."
+			}
+
+			methodString += " " + string(parameter.Name)
 			if i != len(method.Parameters)-1 {
 				methodString += ", "
 			}
 		}
-		methodString += "): " + string(method.Type)
+		methodString += ")"
+
+		if len(method.Type) != 0 {
+			methodString += ": " + string(method.Type)
+		}
+
+		if len(method.Throws) != 0 {
+			var exceptions []string
+			for _, exception := range method.Throws {
+				exceptions = append(exceptions, string(exception))
+			}
+
+			methodString += " throws " + strings.Join(exceptions, ", ")
+		}
 
 		// Measure the width of the method
 		w, _ := ggContext.MeasureString(methodString)
@@ -306,187 +538,53 @@ func getNodeSize(name []byte, variables []types.JavaVariable, methods []types.Ja
 	return width, height
 }
 
-func getNodeClassId(node any) []byte {
-	var nodeClassId []byte
-	switch class := node.(type) {
-	case types.JavaAbstract:
-		nodeClassId = append(nodeClassId, class.Package...)
-		nodeClassId = append(nodeClassId, byte('.'))
-		nodeClassId = append(nodeClassId, class.Name...)
-	case types.JavaClass:
-		nodeClassId = append(nodeClassId, class.Package...)
-		nodeClassId = append(nodeClassId, byte('.'))
-		nodeClassId = append(nodeClassId, class.Name...)
-	case types.JavaEnum:
-		nodeClassId = append(nodeClassId, class.Package...)
-		nodeClassId = append(nodeClassId, byte('.'))
-		nodeClassId = append(nodeClassId, class.Name...)
-	case types.JavaInterface:
-		nodeClassId = append(nodeClassId, class.Package...)
-		nodeClassId = append(nodeClassId, byte('.'))
-		nodeClassId = append(nodeClassId, class.Name...)
-	}
-
-	return nodeClassId
-}
-
-func getNodeId(node any) string {
-	switch class := node.(type) {
-	case types.JavaAbstract:
-		return string(class.ID)
-	case types.JavaClass:
-		return string(class.ID)
-	case types.JavaEnum:
-		return string(class.ID)
-	case types.JavaInterface:
-		return string(class.ID)
+// Returns the name of a node with its type parameters, such as "Repository<T extends Entity, ID>"
+func getNodeTitle(name []byte, typeParameters []types.JavaTypeParameter) []byte {
+	if len(typeParameters) == 0 {
+		return name
 	}
 
-	return ""
-}
-
-func getNodeIndex(nodes []any, nodeClassId []byte) int {
-	for i, node := range nodes {
-		if bytes.Equal(getNodeClassId(node), nodeClassId) {
-			return i
-		}
-	}
-
-	return -1
-}
-
-type Connections struct {
-	FromClassIds [][]byte
-	ToClassIds   [][]byte
-}
-
-// Returns a map nodeIds to their group number
-func groupNodes(nodes []any, edges []types.Relation) map[string]int {
-	connections := getNodeConnections(nodes, edges)
-
-	// Create a map of nodes to groups
-	// The key is the node's class id and the value is the group number
-	var (
-		nodeGroups   = make(map[string]int) // The key is the node's class id and the value is the group number
-		currentGroup = 0
-	)
-
-	// Organizes the nodes into groups. Grouping nodes can help you to determine the overall layout of the diagram.
-	// Use ToClassIds to group nodes that are connected to each other.
-	for i := 0; i < len(nodes); i++ {
-		// If the node is already in a group, skip
-		if _, ok := nodeGroups[string(getNodeClassId(nodes[i]))]; ok {
-			continue
-		}
-
-		var (
-			// Get the connections of the outer node
-			outerNodeConnections = connections[string(getNodeClassId(nodes[i]))]
-			foundGroup           = false
-		)
-
-		// Iterate through all nodes again
-		for j := i + 1; j < len(nodes); j++ {
-			if _, ok := nodeGroups[string(getNodeClassId(nodes[j]))]; ok {
-				continue
+	var parameters []string
+	for _, typeParameter := range typeParameters {
+		parameter := string(typeParameter.Name)
+		if len(typeParameter.Bounds) != 0 {
+			var bounds []string
+			for _, bound := range typeParameter.Bounds {
+				bounds = append(bounds, string(bound))
 			}
 
-			// Get the connections of the inner node
-			var innerNodeConnections = connections[string(getNodeClassId(nodes[j]))]
-
-			// If the connections of the outer node and the inner node are the same, create a group
-			if reflect.DeepEqual(outerNodeConnections.ToClassIds, innerNodeConnections.ToClassIds) {
-				// Create a new group
-				nodeGroups[string(getNodeClassId(nodes[i]))] = currentGroup
-				nodeGroups[string(getNodeClassId(nodes[j]))] = currentGroup
-				foundGroup = true
-			}
+			parameter += " extends " + strings.Join(bounds, " & ")
 		}
 
-		if foundGroup {
-			currentGroup++
-		}
+		parameters = append(parameters, parameter)
 	}
 
-	// Organizes the nodes into groups. Grouping nodes can help you to determine the overall layout of the diagram.
-	// Use FromClassIds to group nodes that are connected to each other. Maybe remove this for loop?
-	for i := 0; i < len(nodes); i++ {
-		// If the node is already in a group, skip
-		if _, ok := nodeGroups[string(getNodeClassId(nodes[i]))]; ok {
-			continue
-		}
-
-		var (
-			// Get the connections of the outer node
-			outerNodeConnections = connections[string(getNodeClassId(nodes[i]))]
-			foundGroup           = false
-		)
-
-		// Iterate through all nodes again
-		for j := i + 1; j < len(nodes); j++ {
-			if _, ok := nodeGroups[string(getNodeClassId(nodes[j]))]; ok {
-				continue
-			}
-
-			// Get the connections of the inner node
-			var innerNodeConnections = connections[string(getNodeClassId(nodes[j]))]
-
-			// If the connections of the outer node and the inner node are the same, create a group
-			if reflect.DeepEqual(outerNodeConnections.FromClassIds, innerNodeConnections.FromClassIds) {
-				// Create a new group
-				nodeGroups[string(getNodeClassId(nodes[i]))] = currentGroup
-				nodeGroups[string(getNodeClassId(nodes[j]))] = currentGroup
-				foundGroup = true
-			}
-		}
-
-		if foundGroup {
-			currentGroup++
-		}
-	}
+	return []byte(string(name) + "<" + strings.Join(parameters, ", ") + ">")
+}
 
-	var groups = make(map[int][]any)
-	for nodeClassId, groupNumber := range nodeGroups {
-		groups[groupNumber] = append(groups[groupNumber], nodes[getNodeIndex(nodes, []byte(nodeClassId))])
+// Returns the stereotypes that are shown before a member, such as "<<Id, Column>> "
+func getStereotypesString(stereotypes []types.CustomByteSlice) string {
+	if len(stereotypes) == 0 {
+		return ""
 	}
 
-	// Add the nodes that are not in a group to their own group
-	for i := 0; i < len(nodes); i++ {
-		if _, ok := nodeGroups[string(getNodeClassId(nodes[i]))]; !ok {
-			groups[currentGroup] = append(groups[currentGroup], nodes[i])
-			currentGroup++
-		}
+	var names []string
+	for _, stereotype := range stereotypes {
+		names = append(names, string(stereotype))
 	}
 
-	return nodeGroups
+	return "<<" + strings.Join(names, ", ") + ">> "
 }
 
-func getNodeConnections(nodes []any, edges []types.Relation) map[string]Connections {
-	var connections = make(map[string]Connections)
-	for i := 0; i < len(nodes); i++ {
-		nodeClassId := getNodeClassId(nodes[i])
-		connections[string(nodeClassId)] = Connections{
-			FromClassIds: [][]byte{},
-			ToClassIds:   [][]byte{},
-		}
-
-		for j := 0; j < len(edges); j++ {
-			edge := edges[j]
-			if bytes.Equal(edge.FromClassId, nodeClassId) {
-				connections[string(nodeClassId)] = Connections{
-					FromClassIds: connections[string(nodeClassId)].FromClassIds,
-					ToClassIds:   append(connections[string(nodeClassId)].ToClassIds, edge.ToClassId),
-				}
-			} else if bytes.Equal(edge.ToClassId, nodeClassId) {
-				connections[string(nodeClassId)] = Connections{
-					FromClassIds: append(connections[string(nodeClassId)].FromClassIds, edge.FromClassId),
-					ToClassIds:   connections[string(nodeClassId)].ToClassIds,
-				}
-			}
-		}
+// Returns the id of a node in the relations of the project. Every language names its classes on its own,
+// so the class ids of a node that is tagged with a language are prefixed with its language group: jvm:com.shop.Order
+func getNodeClassId(node types.DiagramNode) []byte {
+	classId := node.GetClassId()
+	if language := node.GetDiagramNode().Language; language != "" {
+		return append([]byte(getLanguage(language)+":"), classId...)
 	}
 
-	return connections
+	return classId
 }
 
 func selectPort(sourceX, sourceY, targetX, targetY float64) (sourcePort, targetPort string) {
@@ -503,18 +601,18 @@ func selectPort(sourceX, sourceY, targetX, targetY float64) (sourcePort, targetP
 		}
 	} else {
 		if yDiff > 0 {
-			sourcePort = "top-middle"
-			targetPort = "bottom-middle"
-		} else {
 			sourcePort = "bottom-middle"
 			targetPort = "top-middle"
+		} else {
+			sourcePort = "top-middle"
+			targetPort = "bottom-middle"
 		}
 	}
 
 	return sourcePort, targetPort
 }
 
-func getNodeIndexById(nodes []any, id []byte) int {
+func getNodeIndexById(nodes []types.DiagramNode, id []byte) int {
 	for i, node := range nodes {
 		if bytes.Equal(getNodeClassId(node), id) {
 			return i
@@ -522,31 +620,3 @@ func getNodeIndexById(nodes []any, id []byte) int {
 	}
 	return -1
 }
-
-func getNodePositionX(node any) float64 {
-	switch n := node.(type) {
-	case types.JavaAbstract:
-		return n.Position.X
-	case types.JavaClass:
-		return n.Position.X
-	case types.JavaEnum:
-		return n.Position.X
-	case types.JavaInterface:
-		return n.Position.X
-	}
-	return 0
-}
-
-func getNodePositionY(node any) float64 {
-	switch n := node.(type) {
-	case types.JavaAbstract:
-		return n.Position.Y
-	case types.JavaClass:
-		return n.Position.Y
-	case types.JavaEnum:
-		return n.Position.Y
-	case types.JavaInterface:
-		return n.Position.Y
-	}
-	return 0
-}
@@ -1,5 +1,268 @@
 package transpiler
 
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+	httpTypes "github.com/junioryono/ProUML/backend/types"
+)
+
+func TestParseProject(t *testing.T) {
+	type Output struct {
+		Nodes     []string
+		Languages []string
+		Summaries []types.LanguageSummary
+		Err       string
+	}
+
+	type ParseProjectTest struct {
+		Input  []types.File
+		Output Output
+	}
+
+	var tests = []ParseProjectTest{
+		{
+			// A Java backend and a TypeScript frontend are parsed together, the HTML page is skipped and the README is ignored
+			Input: []types.File{
+				{Name: "User", Extension: "java", Code: []byte("package api; public class User { private Account account; }")},
+				{Name: "Account", Extension: "kt", Code: []byte("package api\nclass Account")},
+				{Name: "user", Extension: "ts", Code: []byte("export class User { name: string; }")},
+				{Name: "README", Extension: "md", Code: []byte("# Project")},
+				{Name: "index", Extension: "html", Code: []byte("<html></html>")},
+			},
+			Output: Output{
+				Nodes:     []string{"jvm:api.User", "jvm:api.Account", "ts:user.User"},
+				Languages: []string{"java", "kt", "ts"},
+				Summaries: []types.LanguageSummary{
+					{Language: "html", Files: 1, Skipped: true, Reason: httpTypes.ErrUnsupportedLang},
+					{Language: "jvm", Files: 2, Nodes: 2, Edges: 1},
+					{Language: "ts", Files: 1, Nodes: 1},
+				},
+			},
+		},
+		{
+			// Both languages declare default.Dog, the class ids of every language are prefixed with the language
+			Input: []types.File{
+				{Name: "Dog", Extension: "java", Code: []byte("public class Dog { }")},
+				{Name: "Dog", Extension: "swift", Code: []byte("class Dog { let owner: Owner }\nstruct Owner {}")},
+			},
+			Output: Output{
+				Nodes:     []string{"jvm:default.Dog", "swift:default.Dog", "swift:default.Owner"},
+				Languages: []string{"java", "swift", "swift"},
+				Summaries: []types.LanguageSummary{
+					{Language: "jvm", Files: 1, Nodes: 1},
+					{Language: "swift", Files: 1, Nodes: 2, Edges: 1},
+				},
+			},
+		},
+		{
+			// The go.mod file is parsed with the Go files
+			Input: []types.File{
+				{Path: "go.mod", Name: "go", Extension: "mod", Code: []byte("module example.com/shop\n")},
+				{Path: "orders/order.go", Name: "order", Extension: "go", Code: []byte("package orders\n\ntype Order struct{}\n")},
+			},
+			Output: Output{
+				Nodes:     []string{"go:example.com/shop/orders.Order"},
+				Languages: []string{"go"},
+				Summaries: []types.LanguageSummary{
+					{Language: "go", Files: 2, Nodes: 1},
+				},
+			},
+		},
+		{
+			// Files that are not source files are not reported as skipped languages
+			Input: []types.File{
+				{Name: "README", Extension: "md", Code: []byte("# Project")},
+			},
+			Output: Output{
+				Err: httpTypes.ErrCouldNotFigureOutLang,
+			},
+		},
+		{
+			Input: []types.File{
+				{Name: "style", Extension: "css", Code: []byte("body {}")},
+			},
+			Output: Output{
+				Summaries: []types.LanguageSummary{
+					{Language: "css", Files: 1, Skipped: true, Reason: httpTypes.ErrUnsupportedLang},
+				},
+				Err: httpTypes.ErrUnsupportedLang,
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			project, summaries, err := parseProject(context.Background(), tt.Input)
+
+			if fmt.Sprint(summaries) != fmt.Sprint(tt.Output.Summaries) {
+				subtest.Errorf("incorrect summaries.\nexpected:\n%v\ngot:\n%v\n", tt.Output.Summaries, summaries)
+			}
+
+			if err != nil || tt.Output.Err != "" {
+				if err == nil || err.Str != tt.Output.Err {
+					subtest.Errorf("incorrect error.\nexpected:\n%s\ngot:\n%v\n", tt.Output.Err, err)
+				}
+
+				return
+			}
+
+			var nodes, languages []string
+			for _, node := range project.Nodes {
+				nodes = append(nodes, string(getNodeClassId(node)))
+				languages = append(languages, node.GetDiagramNode().Language)
+			}
+
+			if fmt.Sprint(nodes) != fmt.Sprint(tt.Output.Nodes) || fmt.Sprint(languages) != fmt.Sprint(tt.Output.Languages) {
+				subtest.Errorf("incorrect nodes.\nexpected:\n%s %s\ngot:\n%s %s\n", tt.Output.Nodes, tt.Output.Languages, nodes, languages)
+			}
+
+			for _, edge := range project.Edges {
+				if getNodeIndexById(project.Nodes, edge.FromClassId) == -1 || getNodeIndexById(project.Nodes, edge.ToClassId) == -1 {
+					subtest.Errorf("edge %s -> %s does not connect two nodes", edge.FromClassId, edge.ToClassId)
+				}
+			}
+		})
+	}
+}
+
+func TestTranspileDiagnostics(t *testing.T) {
+	files := []types.File{
+		{Path: "src/shop/Order.java", Name: "Order", Extension: "java", Code: []byte("package shop;\n\npublic class Order {\n\tprivate int[] lines, totals[];\n}\n")},
+		{Path: "cmd/main.go", Name: "main", Extension: "go", Code: []byte("package main\n\ntype Server struct {\n\tPort int\n")},
+		{Path: "src/shop/Cart.java", Name: "Cart", Extension: "java", Code: []byte("package shop;\n\npublic class Cart {\n\t/* Never closed\n}\n")},
+	}
+
+	expected := []string{
+		"error cmd/main.go:4",
+		"error src/shop/Cart.java:3",
+		"error src/shop/Cart.java:4",
+		"warning src/shop/Order.java:4",
+	}
+
+	_, _, diagnostics, err := Transpile(context.Background(), nil, files, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	var actual []string
+	for _, diagnostic := range diagnostics {
+		actual = append(actual, fmt.Sprintf("%s %s:%d", diagnostic.Severity, diagnostic.Path, diagnostic.Line))
+	}
+
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("incorrect diagnostics.\nexpected:\n%v\ngot:\n%v\n", expected, actual)
+	}
+}
+
+func TestTranspileProgress(t *testing.T) {
+	var (
+		stages []string
+		parsed int
+		mutex  sync.Mutex
+	)
+
+	// Files are parsed in parallel, so every stage after them records how many files were parsed before it
+	ctx := types.WithProgress(context.Background(), func(stage string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if stage == types.ProgressFileParsed {
+			parsed++
+			return
+		}
+
+		stages = append(stages, stage+" "+strconv.Itoa(parsed))
+	})
+
+	if _, _, _, err := Transpile(ctx, nil, getSyntheticProject(3), ""); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := []string{types.ProgressParsed + " 3", types.ProgressLayout + " 3"}
+	if fmt.Sprint(stages) != fmt.Sprint(expected) {
+		t.Errorf("incorrect progress.\nexpected:\n%v\ngot:\n%v\n", expected, stages)
+	}
+}
+
+func TestParseProjectCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := parseProject(ctx, getSyntheticProject(100))
+	if err == nil || err.Str != httpTypes.ErrTranspileCanceled {
+		t.Errorf("incorrect error.\nexpected:\n%s\ngot:\n%v\n", httpTypes.ErrTranspileCanceled, err)
+	}
+}
+
+// Compares parsing a large project with one worker to parsing it with a worker for every processor: go test -bench ParseProject
+func BenchmarkParseProject(b *testing.B) {
+	files := getSyntheticProject(2000)
+
+	workers := []int{1}
+	if runtime.NumCPU() > 1 {
+		workers = append(workers, runtime.NumCPU())
+	}
+
+	for _, worker := range workers {
+		b.Run("workers="+strconv.Itoa(worker), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(worker))
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if _, _, err := parseProject(context.Background(), files); err != nil {
+					b.Fatal(err.Error())
+				}
+			}
+		})
+	}
+}
+
+// Get a Java project of classes that extend and use each other, with comments, Javadoc and method bodies like real code
+func getSyntheticProject(classes int) []types.File {
+	var files []types.File
+
+	for i := 0; i < classes; i++ {
+		var (
+			name     = "Class" + strconv.Itoa(i)
+			pkg      = "com.synthetic.p" + strconv.Itoa(i%20)
+			previous = "Class" + strconv.Itoa((i+classes-1)%classes)
+			code     strings.Builder
+		)
+
+		fmt.Fprintf(&code, "/*\n * Copyright (c) Synthetic\n */\npackage %s;\n\nimport java.util.List;\nimport java.util.ArrayList;\nimport com.synthetic.p%d.%s;\n\n", pkg, (i+classes-1)%classes%20, previous)
+		fmt.Fprintf(&code, "/**\n * The class %d of the project. It keeps a reference to the class before it.\n */\n@Deprecated\npublic class %s", i, name)
+		if i%10 != 0 {
+			fmt.Fprintf(&code, " extends %s", previous)
+		}
+
+		fmt.Fprintf(&code, " implements Comparable<%s> {\n\tprivate static final String NAME = \"%s // not a comment\";\n\tprivate %s previous;\n\tprivate List<String> names = new ArrayList<>();\n\n", name, name, previous)
+
+		for j := 0; j < 10; j++ {
+			fmt.Fprintf(&code, "\t/**\n\t * Counts the names that start with a prefix.\n\t * @param prefix the prefix\n\t * @return the count\n\t */\n")
+			fmt.Fprintf(&code, "\tpublic int count%d(String prefix, int limit) throws IllegalStateException {\n\t\tint count = 0; // The running count\n", j)
+			fmt.Fprintf(&code, "\t\tfor (String name : names) {\n\t\t\tif (name.startsWith(prefix) && count < limit) {\n\t\t\t\tcount++;\n\t\t\t}\n\t\t}\n\n\t\treturn count;\n\t}\n\n")
+		}
+
+		fmt.Fprintf(&code, "\t@Override\n\tpublic int compareTo(%s other) {\n\t\treturn NAME.compareTo(other.NAME);\n\t}\n}\n", name)
+
+		files = append(files, types.File{
+			Path:      "src/main/java/" + strings.ReplaceAll(pkg, ".", "/") + "/" + name + ".java",
+			Name:      name,
+			Extension: "java",
+			Code:      []byte(code.String()),
+		})
+	}
+
+	return files
+}
+
 // import (
 // 	"strconv"
 // 	"testing"
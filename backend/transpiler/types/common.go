@@ -1,10 +1,79 @@
 package types
 
+import "strings"
+
 type CustomByteSlice []byte
 
 type Project struct {
-	Nodes []any      `json:"nodes,omitempty"`
-	Edges []Relation `json:"edges,omitempty"`
+	Nodes       []DiagramNode `json:"nodes,omitempty"`
+	Edges       []Relation    `json:"edges,omitempty"`
+	Diagnostics []Diagnostic  `json:"diagnostics,omitempty"`
+}
+
+// Adds a relation from one class id to another.
+// If the same kind of relation already points the other way, that relation becomes bidirectional instead.
+func (p *Project) AddRelation(fromClassId, toClassId []byte, relation RelationData) {
+	if len(fromClassId) == 0 || len(toClassId) == 0 || string(fromClassId) == string(toClassId) {
+		return
+	}
+
+	for i := 0; i < len(p.Edges); i++ {
+		existing := p.Edges[i]
+		if existing.Type.GetType() != relation.GetType() {
+			continue
+		}
+
+		if string(existing.FromClassId) == string(fromClassId) && string(existing.ToClassId) == string(toClassId) {
+			return
+		}
+
+		if string(existing.FromClassId) == string(toClassId) && string(existing.ToClassId) == string(fromClassId) {
+			existing.Type.SetFromArrow(true)
+			return
+		}
+	}
+
+	relation.SetToArrow(true)
+	p.Edges = append(p.Edges, Relation{
+		FromClassId: fromClassId,
+		ToClassId:   toClassId,
+		Type:        relation,
+	})
+}
+
+// What the transpiler did with the files of one language.
+// Skipped languages were not parsed and their reason says why.
+type LanguageSummary struct {
+	Language string `json:"language"`
+	Files    int    `json:"files"`
+	Nodes    int    `json:"nodes"`
+	Edges    int    `json:"edges"`
+	Skipped  bool   `json:"skipped,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+const (
+	DiagnosticWarning = "warning" // Part of a file could not be parsed and is missing from the diagram
+	DiagnosticError   = "error"   // A file could not be read or is not valid code
+)
+
+// Number of changes that syncing a diagram with its source made
+type SyncSummary struct {
+	Updated      int `json:"updated"`
+	Added        int `json:"added"`
+	Removed      int `json:"removed"`
+	Flagged      int `json:"flagged"`
+	EdgesAdded   int `json:"edgesAdded"`
+	EdgesRemoved int `json:"edgesRemoved"`
+}
+
+// A problem found while reading or parsing a file of an upload.
+// Lines start at 1, diagnostics without a line are about the whole file.
+type Diagnostic struct {
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message"`
 }
 
 type Package struct {
@@ -13,28 +82,104 @@ type Package struct {
 }
 
 type File struct {
+	Path      string // The path of the file inside of the upload, such as src/main/java/com/shop/Order.java
 	Name      string
 	Extension string
 	Code      []byte
 }
 
+// Parts of a file name that come before the extension but are not part of the name: index.d.ts -> index, ts
+var multiPartExtensions = []string{".d.ts"}
+
+// Returns the file at the path of an upload, without its code. The extension is the part after the last period: user.service.ts -> user.service, ts
+// Returns false for files without an extension, which are not source files.
+func NewFile(path string) (File, bool) {
+	fileNameWithExtension := path[strings.LastIndexByte(path, '/')+1:]
+
+	periodIndex := strings.LastIndexByte(fileNameWithExtension, '.')
+	if periodIndex <= 0 {
+		return File{}, false
+	}
+
+	name := fileNameWithExtension[:periodIndex]
+	for _, extension := range multiPartExtensions {
+		if strings.HasSuffix(fileNameWithExtension, extension) && len(fileNameWithExtension) > len(extension) {
+			name = strings.TrimSuffix(fileNameWithExtension, extension)
+			break
+		}
+	}
+
+	return File{
+		Path:      path,
+		Name:      name,
+		Extension: fileNameWithExtension[periodIndex+1:],
+	}, true
+}
+
+// Get the path of the file, or its name when the upload did not keep the paths of its files
+func (file File) GetPath() string {
+	if file.Path != "" {
+		return file.Path
+	}
+
+	return file.Name + "." + file.Extension
+}
+
+// The lines of a file that declare a node or a member. Lines start at 1.
+type SourceSpan struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+}
+
 type FileResponse struct {
-	Package []byte
-	Imports [][]byte
-	Data    []any // Holds [](JavaAbstract | JavaClass | JavaInterface | JavaEnum)
+	Package     []byte
+	Imports     [][]byte
+	Data        []any // Holds [](JavaAbstract | JavaClass | JavaInterface | JavaEnum | JavaRecord | JavaAnnotation | PhpTrait)
+	Diagnostics []Diagnostic
 }
 
 type Node struct {
 	Type []byte
-	Data any // Holds JavaAbstract | JavaClass | JavaInterface | JavaEnum
+	Data any // Holds JavaAbstract | JavaClass | JavaInterface | JavaEnum | JavaRecord | JavaAnnotation | PhpTrait
 }
 
 type Edge struct {
 	EdgeType string             `json:"edgeType"`
 	ID       string             `json:"id"`
 	Shape    string             `json:"shape"`
+	ZIndex   int                `json:"zIndex"`
+	Attrs    EdgeAttrs          `json:"attrs"`
 	Source   EdgeNodeConnection `json:"source"`
 	Target   EdgeNodeConnection `json:"target"`
+	Data     *EdgeData          `json:"data,omitempty"`
+}
+
+// The data of an edge that was generated from a relation of the source code.
+// Edges that the user drew have no data, so syncing a diagram never removes them.
+type EdgeData struct {
+	Relation string `json:"relation"` // The kind of relation, see RelationData.GetType
+}
+
+type EdgeAttrs struct {
+	Line EdgeLine `json:"line"`
+}
+
+type EdgeLine struct {
+	Stroke          string      `json:"stroke"`
+	StrokeWidth     float64     `json:"strokeWidth"`
+	StrokeDasharray string      `json:"strokeDasharray,omitempty"`
+	SourceMarker    *EdgeMarker `json:"sourceMarker,omitempty"`
+	TargetMarker    *EdgeMarker `json:"targetMarker,omitempty"`
+}
+
+type EdgeMarker struct {
+	Type        string   `json:"type"`
+	Size        *float64 `json:"size,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	D           string   `json:"d,omitempty"`
+	Fill        string   `json:"fill,omitempty"`
+	StrokeWidth float64  `json:"strokeWidth,omitempty"`
+	OffsetX     float64  `json:"offsetX,omitempty"`
 }
 
 type EdgeNodeConnection struct {
@@ -52,6 +197,42 @@ type EdgeConnectionPointArgs struct {
 	Offset float64 `json:"offset"`
 }
 
+// Group is a cell that visually bounds the nodes of one package
+type Group struct {
+	ID       string          `json:"id"`
+	Shape    string          `json:"shape"`
+	ZIndex   int             `json:"zIndex"`
+	Package  CustomByteSlice `json:"packageName"`
+	Children []string        `json:"children,omitempty"`
+	Attrs    GroupAttrs      `json:"attrs"`
+	Position `json:"position"`
+	Size     `json:"size"`
+}
+
+type GroupAttrs struct {
+	Body  GroupBody  `json:"body"`
+	Label GroupLabel `json:"label"`
+}
+
+type GroupBody struct {
+	Fill            string  `json:"fill"`
+	Stroke          string  `json:"stroke"`
+	StrokeWidth     float64 `json:"strokeWidth"`
+	StrokeDasharray string  `json:"strokeDasharray,omitempty"`
+	Rx              float64 `json:"rx"`
+	Ry              float64 `json:"ry"`
+}
+
+type GroupLabel struct {
+	Text               string  `json:"text"`
+	FontSize           float64 `json:"fontSize"`
+	Fill               string  `json:"fill"`
+	RefX               float64 `json:"refX"`
+	RefY               float64 `json:"refY"`
+	TextAnchor         string  `json:"textAnchor"`
+	TextVerticalAnchor string  `json:"textVerticalAnchor"`
+}
+
 type Relation struct {
 	FromClassId CustomByteSlice `json:"fromClassId"`
 	ToClassId   CustomByteSlice `json:"toClassId"`
@@ -216,3 +397,33 @@ func (t *NestedOwnership) SetToArrow(value bool) {
 func (t NestedOwnership) GetType() string {
 	return "nestedOwnership"
 }
+
+// A class or trait uses the members of a trait
+type Uses struct {
+	FromArrow bool `json:"fromArrow"`
+	ToArrow   bool `json:"toArrow"`
+}
+
+func (t Uses) GetFromArrow() bool {
+	return t.FromArrow
+}
+
+func (t Uses) GetToArrow() bool {
+	if !t.FromArrow {
+		return true
+	}
+
+	return t.ToArrow
+}
+
+func (t *Uses) SetFromArrow(value bool) {
+	t.FromArrow = value
+}
+
+func (t *Uses) SetToArrow(value bool) {
+	t.ToArrow = value
+}
+
+func (t Uses) GetType() string {
+	return "uses"
+}
@@ -1,31 +1,24 @@
 package types
 
 import (
+	"encoding/json"
 	"errors"
 )
 
+// Marshals the text as a JSON string. Backslashes are escaped too, they separate PHP namespaces: App\Models
 func (t CustomByteSlice) MarshalJSON() ([]byte, error) {
-	// Make a copy of the slice
-	t = append([]byte(nil), t...)
-
-	for i := 0; i < len(t); i++ {
-		if t[i] == '"' && (i == 0 || t[i-1] != '\\') {
-			// Add an index to the slice and copy the rest of the slice
-			t = append(t, 0)
-			copy(t[i+1:], t[i:])
-			// Add a backslash before the double quote
-			t[i] = '\\'
-
-			// Increment i to skip the added backslash
-			i++
-		}
-	}
-
-	return []byte(`"` + string(t) + `"`), nil
+	return json.Marshal(string(t))
 }
 
 func (t *CustomByteSlice) UnmarshalJSON(data []byte) error {
-	*t = CustomByteSlice(data[1 : len(data)-1])
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		// Keep text that was stored before it was escaped
+		*t = CustomByteSlice(data[1 : len(data)-1])
+		return nil
+	}
+
+	*t = CustomByteSlice(text)
 	return nil
 }
 
@@ -59,12 +52,16 @@ func (t *Relation) MarshalJSON() ([]byte, error) {
 		relationTypeName = "realization"
 	case *Generalization:
 		relationTypeName = "generalization"
+	case *Uses:
+		relationTypeName = "uses"
 	default:
 		return nil, errors.New("invalid relation type")
 	}
 
 	// Create the relation JSON
-	relationJSON := []byte(`{"fromClassId":"` + string(t.FromClassId) + `","toClassId":"` + string(t.ToClassId) + `","` + relationTypeName + `":{`)
+	fromClassId, _ := t.FromClassId.MarshalJSON()
+	toClassId, _ := t.ToClassId.MarshalJSON()
+	relationJSON := []byte(`{"fromClassId":` + string(fromClassId) + `,"toClassId":` + string(toClassId) + `,"` + relationTypeName + `":{`)
 
 	// Add the from arrow JSON
 	relationJSON = append(relationJSON, []byte(`"fromArrow":`)...)
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestNewFile(t *testing.T) {
+	type NewFileTest struct {
+		Input  string
+		Output File
+		Ok     bool
+	}
+
+	var tests = []NewFileTest{
+		{Input: "src/Order.java", Output: File{Path: "src/Order.java", Name: "Order", Extension: "java"}, Ok: true},
+		{Input: "src/app/user.service.ts", Output: File{Path: "src/app/user.service.ts", Name: "user.service", Extension: "ts"}, Ok: true},
+		{Input: "types/index.d.ts", Output: File{Path: "types/index.d.ts", Name: "index", Extension: "ts"}, Ok: true},
+		{Input: "Forms/Foo.Designer.cs", Output: File{Path: "Forms/Foo.Designer.cs", Name: "Foo.Designer", Extension: "cs"}, Ok: true},
+		{Input: "go.mod", Output: File{Path: "go.mod", Name: "go", Extension: "mod"}, Ok: true},
+		{Input: "Makefile"},
+		{Input: "config/.gitignore"},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			file, ok := NewFile(tt.Input)
+			if ok != tt.Ok || file.Path != tt.Output.Path || file.Name != tt.Output.Name || file.Extension != tt.Output.Extension {
+				subtest.Errorf("incorrect file.\nexpected:\n%v %+v\ngot:\n%v %+v\n", tt.Ok, tt.Output, ok, file)
+			}
+		})
+	}
+}
@@ -13,65 +13,107 @@ type JavaClassExports struct {
 }
 
 type JavaVariable struct {
-	Type           CustomByteSlice `json:"type"`
-	Name           CustomByteSlice `json:"name"`
-	Value          CustomByteSlice `json:"value"`
-	AccessModifier CustomByteSlice `json:"accessModifier"` // "public" | "protected" | "private"
-	Static         bool            `json:"static"`
-	Final          bool            `json:"final"`
+	Annotations    []CustomByteSlice `json:"stereotypes,omitempty"` // Simple names of annotations, such as "Id"
+	Type           CustomByteSlice   `json:"type"`
+	Name           CustomByteSlice   `json:"name"`
+	Value          CustomByteSlice   `json:"value"`
+	AccessModifier CustomByteSlice   `json:"accessModifier"` // "public" | "protected" | "private"
+	Static         bool              `json:"static"`
+	Final          bool              `json:"final"`
+	Javadoc        *JavaDoc          `json:"javadoc,omitempty"`
+	Span           *SourceSpan       `json:"span,omitempty"`
 }
 
 type JavaMethodParameter struct {
-	Type CustomByteSlice `json:"type"`
-	Name CustomByteSlice `json:"name"`
+	Annotations []CustomByteSlice `json:"stereotypes,omitempty"`
+	Type        CustomByteSlice   `json:"type"`
+	Name        CustomByteSlice   `json:"name"`
+	Varargs     bool              `json:"varargs,omitempty"` // True for the last parameter of String...names, whose Type is String
+}
+
+// Documentation of a class or a member from its Javadoc comment
+type JavaDoc struct {
+	Summary          CustomByteSlice `json:"summary,omitempty"` // The first sentence of the description
+	Params           []JavaDocParam  `json:"params,omitempty"`
+	Return           CustomByteSlice `json:"return,omitempty"`
+	Deprecated       bool            `json:"deprecated,omitempty"`
+	DeprecatedReason CustomByteSlice `json:"deprecatedReason,omitempty"`
+}
+
+type JavaDocParam struct {
+	Name        CustomByteSlice `json:"name"`
+	Description CustomByteSlice `json:"description,omitempty"`
+}
+
+// A type parameter of a class, such as T in Repository<T extends Entity & Serializable>
+type JavaTypeParameter struct {
+	Name   CustomByteSlice   `json:"name"`
+	Bounds []CustomByteSlice `json:"bounds,omitempty"`
 }
 
 type JavaMethod struct {
+	Annotations    []CustomByteSlice     `json:"stereotypes,omitempty"`
 	Type           CustomByteSlice       `json:"type"`
 	Name           CustomByteSlice       `json:"name"`
 	AccessModifier CustomByteSlice       `json:"accessModifier,omitempty"` // "public" | "protected" | "private"
 	Parameters     []JavaMethodParameter `json:"parameters,omitempty"`
-	Abstract       bool                  `json:"abstract"` // If abstract is true, Static and Final must be false
+	Throws         []CustomByteSlice     `json:"throws,omitempty"`
+	IsConstructor  bool                  `json:"isConstructor,omitempty"` // Constructors have no Type
+	Abstract       bool                  `json:"abstract"`                // If abstract is true, Static and Final must be false
 	Static         bool                  `json:"static"`
 	Final          bool                  `json:"final"`
+	Javadoc        *JavaDoc              `json:"javadoc,omitempty"`
+	Span           *SourceSpan           `json:"span,omitempty"`
 	Functionality  []byte                `json:"-"`
 }
 
 type JavaAbstract struct {
-	DefinedWithin CustomByteSlice   `json:"-"`
-	Package       CustomByteSlice   `json:"packageName"`
-	Name          CustomByteSlice   `json:"name"`
-	Implements    []CustomByteSlice `json:"-"`
-	Extends       []CustomByteSlice `json:"-"`
-	Variables     []JavaVariable    `json:"variables,omitempty"`
-	Methods       []JavaMethod      `json:"methods,omitempty"`
-	Associations  []CustomByteSlice `json:"-"`
-	Dependencies  []CustomByteSlice `json:"-"`
+	DefinedWithin  CustomByteSlice     `json:"-"`
+	Package        CustomByteSlice     `json:"packageName"`
+	Name           CustomByteSlice     `json:"name"`
+	TypeParameters []JavaTypeParameter `json:"typeParameters,omitempty"`
+	Stereotypes    []CustomByteSlice   `json:"stereotypes,omitempty"` // Annotations, such as "Entity", followed by modifiers, such as "sealed"
+	Javadoc        *JavaDoc            `json:"javadoc,omitempty"`
+	Implements     []CustomByteSlice   `json:"-"`
+	Extends        []CustomByteSlice   `json:"-"`
+	Permits        []CustomByteSlice   `json:"-"`
+	Variables      []JavaVariable      `json:"variables,omitempty"`
+	Methods        []JavaMethod        `json:"methods,omitempty"`
+	Associations   []CustomByteSlice   `json:"-"`
+	Dependencies   []CustomByteSlice   `json:"-"`
 	JavaDiagramNode
 }
 
 type JavaClass struct {
-	DefinedWithin CustomByteSlice   `json:"-"`
-	Package       CustomByteSlice   `json:"packageName"`
-	Name          CustomByteSlice   `json:"name"`
-	Implements    []CustomByteSlice `json:"-"`
-	Extends       []CustomByteSlice `json:"-"`
-	Variables     []JavaVariable    `json:"variables,omitempty"`
-	Methods       []JavaMethod      `json:"methods,omitempty"`
-	Associations  []CustomByteSlice `json:"-"`
-	Dependencies  []CustomByteSlice `json:"-"`
+	DefinedWithin  CustomByteSlice     `json:"-"`
+	Package        CustomByteSlice     `json:"packageName"`
+	Name           CustomByteSlice     `json:"name"`
+	TypeParameters []JavaTypeParameter `json:"typeParameters,omitempty"`
+	Stereotypes    []CustomByteSlice   `json:"stereotypes,omitempty"` // Annotations, such as "Entity", followed by modifiers, such as "sealed"
+	Javadoc        *JavaDoc            `json:"javadoc,omitempty"`
+	Implements     []CustomByteSlice   `json:"-"`
+	Extends        []CustomByteSlice   `json:"-"`
+	Permits        []CustomByteSlice   `json:"-"`
+	Variables      []JavaVariable      `json:"variables,omitempty"`
+	Methods        []JavaMethod        `json:"methods,omitempty"`
+	Associations   []CustomByteSlice   `json:"-"`
+	Dependencies   []CustomByteSlice   `json:"-"`
 	JavaDiagramNode
 }
 
 type JavaInterface struct {
-	DefinedWithin CustomByteSlice   `json:"-"`
-	Package       CustomByteSlice   `json:"packageName"`
-	Name          CustomByteSlice   `json:"name"`
-	Extends       []CustomByteSlice `json:"-"`
-	Variables     []JavaVariable    `json:"variables,omitempty"`
-	Methods       []JavaMethod      `json:"methods,omitempty"`
-	Associations  []CustomByteSlice `json:"-"`
-	Dependencies  []CustomByteSlice `json:"-"`
+	DefinedWithin  CustomByteSlice     `json:"-"`
+	Package        CustomByteSlice     `json:"packageName"`
+	Name           CustomByteSlice     `json:"name"`
+	TypeParameters []JavaTypeParameter `json:"typeParameters,omitempty"`
+	Stereotypes    []CustomByteSlice   `json:"stereotypes,omitempty"` // Annotations, such as "Entity", followed by modifiers, such as "sealed"
+	Javadoc        *JavaDoc            `json:"javadoc,omitempty"`
+	Extends        []CustomByteSlice   `json:"-"`
+	Permits        []CustomByteSlice   `json:"-"`
+	Variables      []JavaVariable      `json:"variables,omitempty"`
+	Methods        []JavaMethod        `json:"methods,omitempty"`
+	Associations   []CustomByteSlice   `json:"-"`
+	Dependencies   []CustomByteSlice   `json:"-"`
 	JavaDiagramNode
 }
 
@@ -79,15 +121,52 @@ type JavaEnum struct {
 	DefinedWithin CustomByteSlice   `json:"-"`
 	Package       CustomByteSlice   `json:"packageName"`
 	Name          CustomByteSlice   `json:"name"`
+	Stereotypes   []CustomByteSlice `json:"stereotypes,omitempty"`
+	Javadoc       *JavaDoc          `json:"javadoc,omitempty"`
 	Declarations  []CustomByteSlice `json:"declarations,omitempty"`
 	Implements    []CustomByteSlice `json:"-,omitempty"`
 	JavaDiagramNode
 }
 
+// Records are classes whose state is a fixed list of components. Every component is
+// a final field with an accessor method of the same name.
+type JavaRecord struct {
+	DefinedWithin  CustomByteSlice     `json:"-"`
+	Package        CustomByteSlice     `json:"packageName"`
+	Name           CustomByteSlice     `json:"name"`
+	TypeParameters []JavaTypeParameter `json:"typeParameters,omitempty"`
+	Stereotypes    []CustomByteSlice   `json:"stereotypes,omitempty"`
+	Javadoc        *JavaDoc            `json:"javadoc,omitempty"`
+	Implements     []CustomByteSlice   `json:"-"`
+	Variables      []JavaVariable      `json:"variables,omitempty"`
+	Methods        []JavaMethod        `json:"methods,omitempty"`
+	Associations   []CustomByteSlice   `json:"-"`
+	Dependencies   []CustomByteSlice   `json:"-"`
+	JavaDiagramNode
+}
+
+// Annotation types are declared with @interface. Their elements are methods without parameters.
+type JavaAnnotation struct {
+	DefinedWithin CustomByteSlice   `json:"-"`
+	Package       CustomByteSlice   `json:"packageName"`
+	Name          CustomByteSlice   `json:"name"`
+	Stereotypes   []CustomByteSlice `json:"stereotypes,omitempty"` // Meta-annotations, such as "Retention"
+	Javadoc       *JavaDoc          `json:"javadoc,omitempty"`
+	Variables     []JavaVariable    `json:"variables,omitempty"`
+	Methods       []JavaMethod      `json:"methods,omitempty"`
+	Associations  []CustomByteSlice `json:"-"`
+	Dependencies  []CustomByteSlice `json:"-"`
+	JavaDiagramNode
+}
+
 type JavaDiagramNode struct {
-	ID       string `json:"id"`
-	Shape    string `json:"shape"`
-	Type     string `json:"type"`
+	ID       string      `json:"id"`
+	Shape    string      `json:"shape"`
+	Type     string      `json:"type"`
+	Parent   string      `json:"parent,omitempty"`
+	Language string      `json:"language,omitempty"` // The language of the file that declares the node
+	Path     string      `json:"path,omitempty"`     // The path of the file that declares the node
+	Span     *SourceSpan `json:"span,omitempty"`
 	Position `json:"position"`
 	Size     `json:"size"`
 }
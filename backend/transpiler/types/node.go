@@ -0,0 +1,178 @@
+package types
+
+// A node of the diagram. Every kind of class embeds JavaDiagramNode and belongs to a package.
+// The With methods return a copy of the node, because nodes are stored by value.
+type DiagramNode interface {
+	GetClassId() []byte // The package and the name of the node: com.shop.Order
+	GetPackage() []byte
+	GetDiagramNode() JavaDiagramNode
+	WithPackage(packageName []byte) DiagramNode
+	WithDiagramNode(diagramNode JavaDiagramNode) DiagramNode
+}
+
+// Returns a copy of the node with the changes of update applied to its diagram node
+func UpdateDiagramNode(node DiagramNode, update func(diagramNode *JavaDiagramNode)) DiagramNode {
+	diagramNode := node.GetDiagramNode()
+	update(&diagramNode)
+	return node.WithDiagramNode(diagramNode)
+}
+
+func getClassId(packageName, name []byte) []byte {
+	classId := append([]byte{}, packageName...)
+	classId = append(classId, '.')
+	return append(classId, name...)
+}
+
+func (n JavaAbstract) GetClassId() []byte {
+	return getClassId(n.Package, n.Name)
+}
+
+func (n JavaAbstract) GetPackage() []byte {
+	return n.Package
+}
+
+func (n JavaAbstract) GetDiagramNode() JavaDiagramNode {
+	return n.JavaDiagramNode
+}
+
+func (n JavaAbstract) WithPackage(packageName []byte) DiagramNode {
+	n.Package = packageName
+	return n
+}
+
+func (n JavaAbstract) WithDiagramNode(diagramNode JavaDiagramNode) DiagramNode {
+	n.JavaDiagramNode = diagramNode
+	return n
+}
+
+func (n JavaClass) GetClassId() []byte {
+	return getClassId(n.Package, n.Name)
+}
+
+func (n JavaClass) GetPackage() []byte {
+	return n.Package
+}
+
+func (n JavaClass) GetDiagramNode() JavaDiagramNode {
+	return n.JavaDiagramNode
+}
+
+func (n JavaClass) WithPackage(packageName []byte) DiagramNode {
+	n.Package = packageName
+	return n
+}
+
+func (n JavaClass) WithDiagramNode(diagramNode JavaDiagramNode) DiagramNode {
+	n.JavaDiagramNode = diagramNode
+	return n
+}
+
+func (n JavaEnum) GetClassId() []byte {
+	return getClassId(n.Package, n.Name)
+}
+
+func (n JavaEnum) GetPackage() []byte {
+	return n.Package
+}
+
+func (n JavaEnum) GetDiagramNode() JavaDiagramNode {
+	return n.JavaDiagramNode
+}
+
+func (n JavaEnum) WithPackage(packageName []byte) DiagramNode {
+	n.Package = packageName
+	return n
+}
+
+func (n JavaEnum) WithDiagramNode(diagramNode JavaDiagramNode) DiagramNode {
+	n.JavaDiagramNode = diagramNode
+	return n
+}
+
+func (n JavaInterface) GetClassId() []byte {
+	return getClassId(n.Package, n.Name)
+}
+
+func (n JavaInterface) GetPackage() []byte {
+	return n.Package
+}
+
+func (n JavaInterface) GetDiagramNode() JavaDiagramNode {
+	return n.JavaDiagramNode
+}
+
+func (n JavaInterface) WithPackage(packageName []byte) DiagramNode {
+	n.Package = packageName
+	return n
+}
+
+func (n JavaInterface) WithDiagramNode(diagramNode JavaDiagramNode) DiagramNode {
+	n.JavaDiagramNode = diagramNode
+	return n
+}
+
+func (n PhpTrait) GetClassId() []byte {
+	return getClassId(n.Package, n.Name)
+}
+
+func (n PhpTrait) GetPackage() []byte {
+	return n.Package
+}
+
+func (n PhpTrait) GetDiagramNode() JavaDiagramNode {
+	return n.JavaDiagramNode
+}
+
+func (n PhpTrait) WithPackage(packageName []byte) DiagramNode {
+	n.Package = packageName
+	return n
+}
+
+func (n PhpTrait) WithDiagramNode(diagramNode JavaDiagramNode) DiagramNode {
+	n.JavaDiagramNode = diagramNode
+	return n
+}
+
+func (n JavaRecord) GetClassId() []byte {
+	return getClassId(n.Package, n.Name)
+}
+
+func (n JavaRecord) GetPackage() []byte {
+	return n.Package
+}
+
+func (n JavaRecord) GetDiagramNode() JavaDiagramNode {
+	return n.JavaDiagramNode
+}
+
+func (n JavaRecord) WithPackage(packageName []byte) DiagramNode {
+	n.Package = packageName
+	return n
+}
+
+func (n JavaRecord) WithDiagramNode(diagramNode JavaDiagramNode) DiagramNode {
+	n.JavaDiagramNode = diagramNode
+	return n
+}
+
+func (n JavaAnnotation) GetClassId() []byte {
+	return getClassId(n.Package, n.Name)
+}
+
+func (n JavaAnnotation) GetPackage() []byte {
+	return n.Package
+}
+
+func (n JavaAnnotation) GetDiagramNode() JavaDiagramNode {
+	return n.JavaDiagramNode
+}
+
+func (n JavaAnnotation) WithPackage(packageName []byte) DiagramNode {
+	n.Package = packageName
+	return n
+}
+
+func (n JavaAnnotation) WithDiagramNode(diagramNode JavaDiagramNode) DiagramNode {
+	n.JavaDiagramNode = diagramNode
+	return n
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Parses the files with one worker for every processor that Go may use and returns the results in the order of the files.
+// When the context is done, the files that were not parsed yet keep the zero value of their result.
+// A file that makes its parser panic also keeps the zero value and is returned as a diagnostic.
+// Every file that was parsed is reported to the progress function of the context.
+func ParseFiles[T any](ctx context.Context, files []File, parse func(File) T) ([]T, []Diagnostic) {
+	var (
+		response    = make([]T, len(files))
+		diagnostics []Diagnostic
+		indexes     = make(chan int)
+		mutex       sync.Mutex
+		wg          sync.WaitGroup
+		workers     = runtime.GOMAXPROCS(0)
+	)
+
+	if workers > len(files) {
+		workers = len(files)
+	}
+
+	parseFile := func(i int) {
+		defer func() {
+			if r := recover(); r != nil {
+				mutex.Lock()
+				diagnostics = append(diagnostics, Diagnostic{
+					Severity: DiagnosticError,
+					Path:     files[i].GetPath(),
+					Message:  fmt.Sprintf("could not parse file: %v", r),
+				})
+				mutex.Unlock()
+			}
+		}()
+
+		defer ReportProgress(ctx, ProgressFileParsed)
+
+		response[i] = parse(files[i])
+	}
+
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				parseFile(i)
+			}
+		}()
+	}
+
+sendFiles:
+	for i := range files {
+		// A worker can be ready when the context is done too, and select would pick either of them
+		if ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break sendFiles
+		}
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	return response, diagnostics
+}
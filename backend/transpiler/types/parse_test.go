@@ -0,0 +1,66 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParseFiles(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	var files []File
+	for i := 0; i < 100; i++ {
+		files = append(files, File{Path: "src/" + strconv.Itoa(i) + ".java", Name: strconv.Itoa(i), Extension: "java"})
+	}
+
+	var parsed int32
+	progress := WithProgress(context.Background(), func(stage string) {
+		if stage == ProgressFileParsed {
+			atomic.AddInt32(&parsed, 1)
+		}
+	})
+
+	response, diagnostics := ParseFiles(progress, files, func(file File) string {
+		if file.Name == "42" {
+			panic("unexpected end of file")
+		}
+
+		return file.Name
+	})
+
+	for i, name := range response {
+		expected := strconv.Itoa(i)
+		if i == 42 {
+			expected = ""
+		}
+
+		if name != expected {
+			t.Errorf("incorrect result on index %d.\nexpected: %s\ngot: %s\n", i, expected, name)
+		}
+	}
+
+	if fmt.Sprint(diagnostics) != "[{error src/42.java 0 could not parse file: unexpected end of file}]" {
+		t.Errorf("incorrect diagnostics.\nexpected:\n[{error src/42.java 0 could not parse file: unexpected end of file}]\ngot:\n%v\n", diagnostics)
+	}
+
+	if parsed != int32(len(files)) {
+		t.Errorf("incorrect number of parsed files reported.\nexpected: %d\ngot: %d\n", len(files), parsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	response, _ = ParseFiles(ctx, files, func(file File) string {
+		return file.Name
+	})
+
+	for i, name := range response {
+		if name != "" {
+			t.Errorf("file on index %d was parsed after the context was done", i)
+		}
+	}
+}
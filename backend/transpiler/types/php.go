@@ -0,0 +1,14 @@
+package types
+
+// Traits are groups of members that PHP classes copy in with a use statement
+type PhpTrait struct {
+	DefinedWithin CustomByteSlice   `json:"-"`
+	Package       CustomByteSlice   `json:"packageName"`
+	Name          CustomByteSlice   `json:"name"`
+	Uses          []CustomByteSlice `json:"-"`
+	Variables     []JavaVariable    `json:"variables,omitempty"`
+	Methods       []JavaMethod      `json:"methods,omitempty"`
+	Associations  []CustomByteSlice `json:"-"`
+	Dependencies  []CustomByteSlice `json:"-"`
+	JavaDiagramNode
+}
@@ -0,0 +1,25 @@
+package types
+
+import "context"
+
+// Stages of a transpile that are reported to the progress function of its context
+const (
+	ProgressFileParsed = "file_parsed"
+	ProgressParsed     = "parsed"
+	ProgressLayout     = "layout"
+)
+
+type progressKey struct{}
+
+// Returns a context that reports the progress of the transpile it is passed to.
+// The function is called from the workers that parse the files, so it must be safe for concurrent use.
+func WithProgress(ctx context.Context, progress func(stage string)) context.Context {
+	return context.WithValue(ctx, progressKey{}, progress)
+}
+
+// Calls the progress function of the context, if it has one
+func ReportProgress(ctx context.Context, stage string) {
+	if progress, ok := ctx.Value(progressKey{}).(func(stage string)); ok && progress != nil {
+		progress(stage)
+	}
+}
@@ -0,0 +1,59 @@
+package typescript
+
+import (
+	"bytes"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+var closingBrackets = map[string]string{")": "(", "]": "[", "}": "{"}
+
+// Get the errors that make the parser lose declarations: brackets that are never closed or do not match
+func getSyntaxDiagnostics(code []byte, tokens []token) []types.Diagnostic {
+	var (
+		response       []types.Diagnostic
+		openingIndexes []int
+	)
+
+	getLine := func(t token) int {
+		return bytes.Count(code[:t.Start], []byte("\n")) + 1
+	}
+
+	for i, t := range tokens {
+		if t.Kind != punctuationToken {
+			continue
+		}
+
+		if isOpeningBracket(t.Text) {
+			openingIndexes = append(openingIndexes, i)
+			continue
+		}
+
+		opening, ok := closingBrackets[t.Text]
+		if !ok {
+			continue
+		}
+
+		if len(openingIndexes) == 0 || tokens[openingIndexes[len(openingIndexes)-1]].Text != opening {
+			return append(response, types.Diagnostic{
+				Severity: types.DiagnosticError,
+				Line:     getLine(t),
+				Message:  "closing bracket " + t.Text + " does not match an opening bracket",
+			})
+		}
+
+		openingIndexes = openingIndexes[:len(openingIndexes)-1]
+	}
+
+	// Only the innermost bracket that is still open is reported
+	if len(openingIndexes) != 0 {
+		t := tokens[openingIndexes[len(openingIndexes)-1]]
+		response = append(response, types.Diagnostic{
+			Severity: types.DiagnosticError,
+			Line:     getLine(t),
+			Message:  "opening bracket " + t.Text + " is never closed",
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,72 @@
+package typescript
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestGetDiagnostics(t *testing.T) {
+	type GetDiagnosticsTest struct {
+		Input  []byte
+		Output []string
+	}
+
+	var tests = []GetDiagnosticsTest{
+		{
+			Input: []byte(`export class Order {
+	note = "{ (";
+	add(): void {}
+}
+`),
+			Output: nil,
+		},
+		{
+			Input:  []byte(`import {`),
+			Output: []string{"error src/order.ts:1 opening bracket { is never closed"},
+		},
+		{
+			Input:  []byte(`import * as models from "./models"; namespace shop {`),
+			Output: []string{"error src/order.ts:1 opening bracket { is never closed"},
+		},
+		{
+			Input: []byte(`export class Order {
+	add(line: Line {
+	}
+}
+`),
+			Output: []string{"error src/order.ts:4 closing bracket } does not match an opening bracket"},
+		},
+		{
+			Input: []byte(`enum Status {
+	Open,
+`),
+			Output: []string{"error src/order.ts:1 opening bracket { is never closed"},
+		},
+		{
+			Input:  []byte(`const { Order = require("./order"`),
+			Output: []string{"error src/order.ts:1 opening bracket ( is never closed"},
+		},
+		{
+			Input:  []byte(`type Order = {`),
+			Output: []string{"error src/order.ts:1 opening bracket { is never closed"},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := parseFile(types.File{Path: "src/order.ts", Name: "order", Extension: "ts", Code: tt.Input}, "order")
+
+			var got []string
+			for _, diagnostic := range response.Diagnostics {
+				got = append(got, fmt.Sprintf("%s %s:%d %s", diagnostic.Severity, diagnostic.Path, diagnostic.Line, diagnostic.Message))
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.Output) {
+				subtest.Errorf("incorrect diagnostics.\nexpected:\n%v\ngot:\n%v\n", tt.Output, got)
+			}
+		})
+	}
+}
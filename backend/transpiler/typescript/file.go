@@ -0,0 +1,1101 @@
+package typescript
+
+import (
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+// An import binding. Name is the exported name, "default" for default imports and CommonJS requires,
+// or "*" for namespace imports. Alias is the local name.
+type tsImport struct {
+	Path  string
+	Name  string
+	Alias string
+}
+
+type fileResponse struct {
+	Module        string
+	Imports       []tsImport
+	DefaultExport string
+	Data          []any // Holds [](JavaAbstract | JavaClass | JavaInterface | JavaEnum)
+	Diagnostics   []types.Diagnostic
+}
+
+var memberModifiers = map[string]struct{}{
+	"public": {}, "private": {}, "protected": {}, "static": {}, "readonly": {}, "abstract": {}, "async": {},
+	"declare": {}, "override": {}, "accessor": {}, "get": {}, "set": {},
+}
+
+var parameterModifiers = map[string]struct{}{
+	"public": {}, "private": {}, "protected": {}, "readonly": {}, "override": {},
+}
+
+// Tokens after which an expression or type continues on the next line
+var continuationTokens = map[string]struct{}{
+	"=": {}, "=>": {}, ":": {}, "|": {}, "&": {}, ",": {}, ".": {}, "?.": {}, "?": {}, "??": {}, "+": {}, "-": {}, "*": {}, "/": {}, "%": {},
+	"!": {}, "(": {}, "[": {}, "{": {}, "<": {}, "new": {}, "typeof": {}, "keyof": {}, "extends": {}, "instanceof": {}, "in": {}, "await": {},
+}
+
+// Tokens that continue the previous line when they start a new line
+var continuationStartTokens = map[string]struct{}{
+	".": {}, "?.": {}, "|": {}, "&": {}, "=>": {}, "+": {}, "*": {}, "/": {}, "%": {}, "?": {}, "??": {}, "=": {}, ">": {},
+}
+
+// Tokens after which a curly bracket opens an object type instead of a body
+var objectTypePrecedingTokens = map[string]struct{}{
+	"|": {}, "&": {}, "=>": {}, ":": {}, "?": {},
+}
+
+// Parses a file of the module, see getModuleNames
+func parseFile(file types.File, module string) fileResponse {
+	var (
+		response = fileResponse{Module: module}
+		tokens   = tokenize(file.Code)
+	)
+
+	if len(tokens) == 0 {
+		return response
+	}
+
+	parseStatements(tokens, []byte(response.Module), &response)
+	response.Diagnostics = getSyntaxDiagnostics(file.Code, tokens)
+	for i := range response.Diagnostics {
+		response.Diagnostics[i].Path = file.GetPath()
+	}
+
+	return response
+}
+
+// Parses the declarations in a list of statements. Function bodies and other blocks are skipped.
+func parseStatements(tokens []token, module []byte, response *fileResponse) {
+	for i := 0; i < len(tokens); {
+		if tokens[i].Kind != identifierToken || isText(tokens, i-1, ".") {
+			if isOpeningBracket(tokens[i].Text) {
+				i = skipBalanced(tokens, i)
+				continue
+			}
+
+			i++
+			continue
+		}
+
+		switch tokens[i].Text {
+		case "import":
+			if isText(tokens, i+1, "(") || isText(tokens, i+1, ".") {
+				i++
+				continue
+			}
+
+			imports, end := parseImport(tokens, i)
+			response.Imports = append(response.Imports, imports...)
+			i = end
+		case "const", "let", "var":
+			if imports, end, ok := parseRequire(tokens, i); ok {
+				response.Imports = append(response.Imports, imports...)
+				i = end
+				continue
+			}
+
+			i++
+		case "export":
+			// export default Name;
+			if isText(tokens, i+1, "default") && isKind(tokens, i+2, identifierToken) && isStatementEnd(tokens, i+3) {
+				response.DefaultExport = tokens[i+2].Text
+			}
+
+			i++
+		case "class":
+			if !isKind(tokens, i+1, identifierToken) || isText(tokens, i+1, "extends") || isText(tokens, i+1, "implements") {
+				i++
+				continue
+			}
+
+			class, end := parseClass(tokens, i, module, isText(tokens, i-1, "abstract"))
+			response.Data = append(response.Data, class)
+
+			if isDefaultExport(tokens, i) {
+				response.DefaultExport = tokens[i+1].Text
+			}
+
+			i = end
+		case "interface":
+			if !isKind(tokens, i+1, identifierToken) {
+				i++
+				continue
+			}
+
+			class, end := parseInterface(tokens, i, module)
+			response.Data = append(response.Data, class)
+
+			if isDefaultExport(tokens, i) {
+				response.DefaultExport = tokens[i+1].Text
+			}
+
+			i = end
+		case "type":
+			if !isKind(tokens, i+1, identifierToken) || !(isText(tokens, i+2, "=") || isText(tokens, i+2, "<")) {
+				i++
+				continue
+			}
+
+			class, end := parseTypeAlias(tokens, i, module)
+			if class != nil {
+				response.Data = append(response.Data, class)
+			}
+
+			i = end
+		case "enum":
+			if !isKind(tokens, i+1, identifierToken) || !isText(tokens, i+2, "{") {
+				i++
+				continue
+			}
+
+			body, end := getBalanced(tokens, i+2)
+			response.Data = append(response.Data, types.JavaEnum{
+				Package:      module,
+				Name:         []byte(tokens[i+1].Text),
+				Declarations: getEnumDeclarations(body),
+			})
+
+			i = end
+		case "namespace", "module":
+			// Declarations inside of namespaces belong to the module
+			j := i + 1
+			for isKind(tokens, j, identifierToken) || isKind(tokens, j, stringToken) || isText(tokens, j, ".") {
+				j++
+			}
+
+			if j == i+1 || !isText(tokens, j, "{") {
+				i++
+				continue
+			}
+
+			body, end := getBalanced(tokens, j)
+			parseStatements(body, module, response)
+			i = end
+		default:
+			i++
+		}
+	}
+}
+
+// Parses an import declaration such as:
+// import Default, { A, B as C } from "./module";
+// import * as namespace from "./module";
+// import Name = require("./module");
+func parseImport(tokens []token, index int) ([]tsImport, int) {
+	var (
+		response []tsImport
+		i        = index + 1
+	)
+
+	// import type { A } from "./module"
+	if isText(tokens, i, "type") && !isText(tokens, i+1, "from") && !isText(tokens, i+1, ",") && !isText(tokens, i+1, "=") {
+		i++
+	}
+
+	for i < len(tokens) && tokens[i].Kind != stringToken && !isText(tokens, i, ";") {
+		switch {
+		case isText(tokens, i, "{"):
+			body, end := getBalanced(tokens, i)
+			for _, part := range splitTopLevel(body, ",") {
+				// Remove inline type modifiers: import { type A } from "./module"
+				if len(part) > 1 && part[0].Text == "type" {
+					part = part[1:]
+				}
+
+				if len(part) == 0 {
+					continue
+				}
+
+				name, alias := part[0].Text, part[len(part)-1].Text
+				response = append(response, tsImport{Name: name, Alias: alias})
+			}
+
+			i = end
+		case isText(tokens, i, "*") && isText(tokens, i+1, "as") && isKind(tokens, i+2, identifierToken):
+			response = append(response, tsImport{Name: "*", Alias: tokens[i+2].Text})
+			i += 3
+		case isKind(tokens, i, identifierToken) && tokens[i].Text != "from" && tokens[i].Text != "require":
+			response = append(response, tsImport{Name: "default", Alias: tokens[i].Text})
+			i++
+		default:
+			i++
+		}
+	}
+
+	if !isKind(tokens, i, stringToken) {
+		return nil, i
+	}
+
+	path := unquote(tokens[i].Text)
+	for index := range response {
+		response[index].Path = path
+	}
+
+	return response, i + 1
+}
+
+// Parses a CommonJS require such as:
+// const Name = require("./module");
+// const { A, B: C } = require("./module");
+func parseRequire(tokens []token, index int) ([]tsImport, int, bool) {
+	var (
+		response []tsImport
+		i        = index + 1
+	)
+
+	switch {
+	case isKind(tokens, i, identifierToken):
+		response = append(response, tsImport{Name: "default", Alias: tokens[i].Text})
+		i++
+	case isText(tokens, i, "{"):
+		body, end := getBalanced(tokens, i)
+		for _, part := range splitTopLevel(body, ",") {
+			if len(part) == 0 || part[0].Kind != identifierToken {
+				continue
+			}
+
+			response = append(response, tsImport{Name: part[0].Text, Alias: part[len(part)-1].Text})
+		}
+
+		i = end
+	default:
+		return nil, index, false
+	}
+
+	if !isText(tokens, i, "=") || !isText(tokens, i+1, "require") || !isText(tokens, i+2, "(") || !isKind(tokens, i+3, stringToken) || !isText(tokens, i+4, ")") {
+		return nil, index, false
+	}
+
+	path := unquote(tokens[i+3].Text)
+	for index := range response {
+		response[index].Path = path
+	}
+
+	return response, i + 5, true
+}
+
+// Parses the class declared at index. Returns the class and the index after its body.
+func parseClass(tokens []token, index int, module []byte, isAbstract bool) (any, int) {
+	var (
+		name       = tokens[index+1].Text
+		i          = index + 2
+		extends    []types.CustomByteSlice
+		implements []types.CustomByteSlice
+	)
+
+	if isText(tokens, i, "<") {
+		i = skipAngles(tokens, i)
+	}
+
+	for i < len(tokens) && !isText(tokens, i, "{") {
+		switch tokens[i].Text {
+		case "extends":
+			end := readHeritage(tokens, i+1)
+			if typeName := getTypeName(tokens[i+1 : end]); typeName != "" {
+				extends = append(extends, types.CustomByteSlice(typeName))
+			}
+
+			i = end
+		case "implements":
+			end := readHeritage(tokens, i+1)
+			for _, part := range splitTopLevel(tokens[i+1:end], ",") {
+				if typeName := getTypeName(part); typeName != "" {
+					implements = append(implements, types.CustomByteSlice(typeName))
+				}
+			}
+
+			i = end
+		default:
+			i++
+		}
+	}
+
+	if i >= len(tokens) {
+		return types.JavaClass{Package: module, Name: []byte(name)}, i
+	}
+
+	body, end := getBalanced(tokens, i)
+	variables, methods := parseMembers(body)
+
+	if isAbstract {
+		return types.JavaAbstract{
+			Package:    module,
+			Name:       []byte(name),
+			Implements: implements,
+			Extends:    extends,
+			Variables:  variables,
+			Methods:    methods,
+		}, end
+	}
+
+	return types.JavaClass{
+		Package:    module,
+		Name:       []byte(name),
+		Implements: implements,
+		Extends:    extends,
+		Variables:  variables,
+		Methods:    methods,
+	}, end
+}
+
+// Parses the interface declared at index. Returns the interface and the index after its body.
+func parseInterface(tokens []token, index int, module []byte) (any, int) {
+	var (
+		name    = tokens[index+1].Text
+		i       = index + 2
+		extends []types.CustomByteSlice
+	)
+
+	if isText(tokens, i, "<") {
+		i = skipAngles(tokens, i)
+	}
+
+	if isText(tokens, i, "extends") {
+		end := readHeritage(tokens, i+1)
+		for _, part := range splitTopLevel(tokens[i+1:end], ",") {
+			if typeName := getTypeName(part); typeName != "" {
+				extends = append(extends, types.CustomByteSlice(typeName))
+			}
+		}
+
+		i = end
+	}
+
+	if !isText(tokens, i, "{") {
+		return types.JavaInterface{Package: module, Name: []byte(name), Extends: extends}, i
+	}
+
+	body, end := getBalanced(tokens, i)
+	variables, methods := parseMembers(body)
+
+	return types.JavaInterface{
+		Package:   module,
+		Name:      []byte(name),
+		Extends:   extends,
+		Variables: variables,
+		Methods:   methods,
+	}, end
+}
+
+// Parses a type alias of an object shape, such as: type Name<T> = Base & { a: T }
+// Returns nil for aliases of other types.
+func parseTypeAlias(tokens []token, index int, module []byte) (any, int) {
+	var (
+		name = tokens[index+1].Text
+		i    = index + 2
+	)
+
+	if isText(tokens, i, "<") {
+		i = skipAngles(tokens, i)
+	}
+
+	if !isText(tokens, i, "=") {
+		return nil, i
+	}
+
+	end := readType(tokens, i+1)
+	value := tokens[i+1 : end]
+
+	// A leading operator is allowed: type A = | B | C
+	if len(value) > 0 && (value[0].Text == "|" || value[0].Text == "&") {
+		value = value[1:]
+	}
+
+	if len(splitTopLevel(value, "|")) != 1 {
+		return nil, end
+	}
+
+	var (
+		extends     []types.CustomByteSlice
+		variables   []types.JavaVariable
+		methods     []types.JavaMethod
+		isObject    bool
+		objectShape = true
+	)
+
+	for _, part := range splitTopLevel(value, "&") {
+		if body, end := getBalanced(part, 0); len(part) > 0 && part[0].Text == "{" && end == len(part) {
+			partVariables, partMethods := parseMembers(body)
+			variables = append(variables, partVariables...)
+			methods = append(methods, partMethods...)
+			isObject = true
+		} else if typeName := getTypeName(part); typeName != "" && isTypeReference(part) {
+			extends = append(extends, types.CustomByteSlice(typeName))
+		} else {
+			objectShape = false
+		}
+	}
+
+	if !isObject || !objectShape {
+		return nil, end
+	}
+
+	return types.JavaInterface{
+		Package:   module,
+		Name:      []byte(name),
+		Extends:   extends,
+		Variables: variables,
+		Methods:   methods,
+	}, end
+}
+
+// Parses the members of a class, interface or object type body
+func parseMembers(tokens []token) ([]types.JavaVariable, []types.JavaMethod) {
+	var (
+		variables []types.JavaVariable
+		methods   []types.JavaMethod
+	)
+
+	for i := 0; i < len(tokens); {
+		if tokens[i].Text == ";" || tokens[i].Text == "," {
+			i++
+			continue
+		}
+
+		// Skip decorators
+		for isText(tokens, i, "@") {
+			i++
+			for isKind(tokens, i, identifierToken) || isText(tokens, i, ".") {
+				i++
+			}
+
+			if isText(tokens, i, "(") {
+				i = skipBalanced(tokens, i)
+			}
+		}
+
+		modifiers := make(map[string]struct{})
+		for i+1 < len(tokens) && tokens[i].Kind == identifierToken && !isMemberNameEnd(tokens[i+1]) {
+			if _, ok := memberModifiers[tokens[i].Text]; !ok {
+				break
+			}
+
+			modifiers[tokens[i].Text] = struct{}{}
+			i++
+		}
+
+		// Generator methods
+		if isText(tokens, i, "*") {
+			i++
+		}
+
+		if i >= len(tokens) {
+			break
+		}
+
+		var name string
+		switch tokens[i].Kind {
+		case identifierToken, numberToken:
+			name = tokens[i].Text
+		case stringToken:
+			name = unquote(tokens[i].Text)
+		default:
+			// Index signatures, computed names and call signatures are not shown
+			if isOpeningBracket(tokens[i].Text) {
+				i = skipBalanced(tokens, i)
+			} else {
+				i++
+			}
+
+			i = getMemberEnd(tokens, i)
+			continue
+		}
+
+		// Construct signatures: new (): T
+		if name == "new" && (isText(tokens, i+1, "(") || isText(tokens, i+1, "<")) {
+			i = getMemberEnd(tokens, i+1)
+			continue
+		}
+
+		i++
+
+		accessModifier := getAccessModifier(modifiers)
+		if strings.HasPrefix(name, "#") {
+			name = name[1:]
+			accessModifier = []byte("private")
+		}
+
+		_, isStatic := modifiers["static"]
+		_, isReadonly := modifiers["readonly"]
+		_, isAbstract := modifiers["abstract"]
+
+		// Optional and definitely assigned members
+		if isText(tokens, i, "?") || isText(tokens, i, "!") {
+			i++
+		}
+
+		if isText(tokens, i, "(") || isText(tokens, i, "<") {
+			method := types.JavaMethod{
+				Name:           []byte(name),
+				AccessModifier: accessModifier,
+				Abstract:       isAbstract,
+				Static:         isStatic,
+			}
+
+			if isText(tokens, i, "<") {
+				i = skipAngles(tokens, i)
+			}
+
+			if !isText(tokens, i, "(") {
+				i = getMemberEnd(tokens, i)
+				continue
+			}
+
+			parameterTokens, parametersEnd := getBalanced(tokens, i)
+			parameters, parameterProperties := parseParameters(parameterTokens)
+			method.Parameters = parameters
+			i = parametersEnd
+
+			if isText(tokens, i, ":") {
+				end := readType(tokens, i+1)
+				method.Type = []byte(joinTokens(tokens[i+1 : end]))
+				i = end
+			}
+
+			if isText(tokens, i, "{") {
+				body, end := getBalanced(tokens, i)
+				method.Functionality = []byte(joinTokens(removeLiterals(body)))
+
+				if name == "constructor" {
+					variables = appendConstructorVariables(variables, parameters, body)
+				}
+
+				i = end
+			} else {
+				i = getMemberEnd(tokens, i)
+			}
+
+			for _, property := range parameterProperties {
+				variables = appendVariable(variables, property)
+			}
+
+			methods = append(methods, method)
+			continue
+		}
+
+		variable := types.JavaVariable{
+			Name:           []byte(name),
+			AccessModifier: accessModifier,
+			Static:         isStatic,
+			Final:          isReadonly,
+		}
+
+		if isText(tokens, i, ":") {
+			end := readType(tokens, i+1)
+			variable.Type = []byte(joinTokens(tokens[i+1 : end]))
+			i = end
+		}
+
+		if isText(tokens, i, "=") {
+			end := getMemberEnd(tokens, i+1)
+			variable.Value = []byte(joinTokens(tokens[i+1 : end]))
+			i = end
+		} else {
+			i = getMemberEnd(tokens, i)
+		}
+
+		variables = appendVariable(variables, variable)
+	}
+
+	return variables, methods
+}
+
+// Parses a parameter list. Returns the parameters and the properties declared with parameter modifiers.
+func parseParameters(tokens []token) ([]types.JavaMethodParameter, []types.JavaVariable) {
+	var (
+		parameters []types.JavaMethodParameter
+		properties []types.JavaVariable
+	)
+
+	for _, part := range splitTopLevel(tokens, ",") {
+		i := 0
+
+		// Skip decorators
+		for isText(part, i, "@") {
+			i++
+			for isKind(part, i, identifierToken) || isText(part, i, ".") {
+				i++
+			}
+
+			if isText(part, i, "(") {
+				i = skipBalanced(part, i)
+			}
+		}
+
+		modifiers := make(map[string]struct{})
+		for i+1 < len(part) && part[i].Kind == identifierToken && !isMemberNameEnd(part[i+1]) {
+			if _, ok := parameterModifiers[part[i].Text]; !ok {
+				break
+			}
+
+			modifiers[part[i].Text] = struct{}{}
+			i++
+		}
+
+		if isText(part, i, "...") {
+			i++
+		}
+
+		if i >= len(part) {
+			continue
+		}
+
+		var name string
+		if isOpeningBracket(part[i].Text) {
+			// Destructured parameters
+			end := skipBalanced(part, i)
+			name = joinTokens(part[i:end])
+			i = end
+		} else {
+			name = part[i].Text
+			i++
+		}
+
+		// The this parameter only declares the type of this
+		if name == "this" {
+			continue
+		}
+
+		if isText(part, i, "?") {
+			i++
+		}
+
+		var parameterType string
+		if isText(part, i, ":") {
+			end := readType(part, i+1)
+			parameterType = joinTokens(part[i+1 : end])
+		}
+
+		parameters = append(parameters, types.JavaMethodParameter{
+			Type: []byte(parameterType),
+			Name: []byte(name),
+		})
+
+		if len(modifiers) == 0 {
+			continue
+		}
+
+		_, isReadonly := modifiers["readonly"]
+		properties = append(properties, types.JavaVariable{
+			Type:           []byte(parameterType),
+			Name:           []byte(name),
+			AccessModifier: getAccessModifier(modifiers),
+			Final:          isReadonly,
+		})
+	}
+
+	return parameters, properties
+}
+
+// Adds the properties assigned to this inside of a constructor, which is how JavaScript classes declare fields
+func appendConstructorVariables(variables []types.JavaVariable, parameters []types.JavaMethodParameter, body []token) []types.JavaVariable {
+	parameterTypes := make(map[string]string)
+	for _, parameter := range parameters {
+		parameterTypes[string(parameter.Name)] = string(parameter.Type)
+	}
+
+	for i := 0; i+3 < len(body); i++ {
+		if body[i].Text != "this" || body[i+1].Text != "." || body[i+2].Kind != identifierToken || body[i+3].Text != "=" || isText(body, i+4, "=") || isText(body, i-1, ".") {
+			continue
+		}
+
+		var (
+			name  = body[i+2].Text
+			end   = getMemberEnd(body, i+4)
+			value = body[i+4 : end]
+		)
+
+		variable := types.JavaVariable{
+			Name:           []byte(strings.TrimPrefix(name, "#")),
+			AccessModifier: []byte("public"),
+		}
+
+		if strings.HasPrefix(name, "#") {
+			variable.AccessModifier = []byte("private")
+		}
+
+		// Infer the type from the parameter or the constructor being called
+		if len(value) == 1 && value[0].Kind == identifierToken {
+			if parameterType, ok := parameterTypes[value[0].Text]; ok {
+				variable.Type = []byte(parameterType)
+			}
+		} else if len(value) > 1 && value[0].Text == "new" {
+			variable.Type = []byte(getTypeName(value[1:]))
+		}
+
+		if _, ok := parameterTypes[joinTokens(value)]; !ok {
+			variable.Value = []byte(joinTokens(value))
+		}
+
+		variables = appendVariable(variables, variable)
+		i = end - 1
+	}
+
+	return variables
+}
+
+// Appends a variable unless one with the same name was already declared
+func appendVariable(variables []types.JavaVariable, variable types.JavaVariable) []types.JavaVariable {
+	for i, existing := range variables {
+		if string(existing.Name) != string(variable.Name) {
+			continue
+		}
+
+		// Keep the declared type, but learn the type from the assignment if it was not declared
+		if len(existing.Type) == 0 {
+			variables[i].Type = variable.Type
+		}
+
+		return variables
+	}
+
+	return append(variables, variable)
+}
+
+func getEnumDeclarations(tokens []token) []types.CustomByteSlice {
+	var response []types.CustomByteSlice
+
+	for _, part := range splitTopLevel(tokens, ",") {
+		switch part[0].Kind {
+		case identifierToken:
+			response = append(response, types.CustomByteSlice(part[0].Text))
+		case stringToken:
+			response = append(response, types.CustomByteSlice(unquote(part[0].Text)))
+		}
+	}
+
+	return response
+}
+
+func getAccessModifier(modifiers map[string]struct{}) types.CustomByteSlice {
+	if _, ok := modifiers["private"]; ok {
+		return []byte("private")
+	}
+
+	if _, ok := modifiers["protected"]; ok {
+		return []byte("protected")
+	}
+
+	return []byte("public")
+}
+
+// Returns the name of a type reference without type arguments: ns.Base<T> -> ns.Base
+func getTypeName(tokens []token) string {
+	var builder strings.Builder
+
+	for _, t := range tokens {
+		if t.Kind != identifierToken && t.Text != "." {
+			break
+		}
+
+		builder.WriteString(t.Text)
+	}
+
+	return builder.String()
+}
+
+// Reports whether the tokens are a single type reference such as ns.Base<T>
+func isTypeReference(tokens []token) bool {
+	i := 0
+	for i < len(tokens) && (tokens[i].Kind == identifierToken || tokens[i].Text == ".") {
+		i++
+	}
+
+	if isText(tokens, i, "<") {
+		i = skipAngles(tokens, i)
+	}
+
+	return i == len(tokens)
+}
+
+// Returns the index after the type that starts at start
+func readType(tokens []token, start int) int {
+	depth := 0
+
+	for i := start; i < len(tokens); i++ {
+		text := tokens[i].Text
+
+		if depth == 0 && i > start && tokens[i].NewlineBefore && !isContinuation(tokens, i) {
+			return i
+		}
+
+		switch {
+		case tokens[i].Kind != punctuationToken:
+			continue
+		case text == "(" || text == "[" || text == "<":
+			depth++
+		case text == "{":
+			// An opening curly bracket after a complete type starts a body
+			if depth == 0 && i > start {
+				if _, ok := objectTypePrecedingTokens[tokens[i-1].Text]; !ok {
+					return i
+				}
+			}
+
+			depth++
+		case text == ")" || text == "]" || text == "}" || text == ">":
+			if depth == 0 {
+				return i
+			}
+
+			depth--
+		case depth == 0 && (text == ";" || text == "," || text == "="):
+			return i
+		}
+	}
+
+	return len(tokens)
+}
+
+// Returns the index after the heritage clause that starts at start
+func readHeritage(tokens []token, start int) int {
+	for i := start; i < len(tokens); i++ {
+		switch {
+		case isOpeningBracket(tokens[i].Text) && tokens[i].Text != "{":
+			i = skipBalanced(tokens, i) - 1
+		case tokens[i].Text == "<":
+			i = skipAngles(tokens, i) - 1
+		case tokens[i].Text == "{" || tokens[i].Text == "implements" || tokens[i].Text == "extends":
+			return i
+		}
+	}
+
+	return len(tokens)
+}
+
+// Returns the index of the token that ends the member or statement that starts at start
+func getMemberEnd(tokens []token, start int) int {
+	depth := 0
+
+	for i := start; i < len(tokens); i++ {
+		if depth == 0 && i > 0 && tokens[i].NewlineBefore && !isContinuation(tokens, i) {
+			return i
+		}
+
+		switch tokens[i].Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			// Skip an unmatched closing bracket so that callers always make progress
+			if depth == 0 && i == start {
+				return i + 1
+			} else if depth == 0 {
+				return i
+			}
+
+			depth--
+		case ";", ",":
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return len(tokens)
+}
+
+// Reports whether the line break before the token at index continues the previous line
+func isContinuation(tokens []token, index int) bool {
+	if _, ok := continuationStartTokens[tokens[index].Text]; ok && tokens[index].Kind == punctuationToken {
+		return true
+	}
+
+	_, ok := continuationTokens[tokens[index-1].Text]
+	return ok && tokens[index-1].Kind != stringToken
+}
+
+// Reports whether the token follows a member name, meaning the previous identifier was not a modifier
+func isMemberNameEnd(t token) bool {
+	if t.Kind != punctuationToken {
+		return false
+	}
+
+	switch t.Text {
+	case "(", ":", "=", ";", "?", "!", "<", ",", "}":
+		return true
+	}
+
+	return false
+}
+
+func isStatementEnd(tokens []token, index int) bool {
+	return index >= len(tokens) || tokens[index].Text == ";" || tokens[index].NewlineBefore
+}
+
+// Reports whether the declaration at index is preceded by "export default"
+func isDefaultExport(tokens []token, index int) bool {
+	i := index - 1
+	if isText(tokens, i, "abstract") {
+		i--
+	}
+
+	return isText(tokens, i, "default") && isText(tokens, i-1, "export")
+}
+
+// Returns the index after the bracket that closes the one at index
+func skipBalanced(tokens []token, index int) int {
+	_, end := getBalanced(tokens, index)
+	return end
+}
+
+// Returns the tokens between the bracket at index and the one that closes it, and the index after the closing bracket.
+// A bracket that is never closed holds every token until the end.
+func getBalanced(tokens []token, index int) ([]token, int) {
+	depth := 0
+
+	for i := index; i < len(tokens); i++ {
+		if tokens[i].Kind != punctuationToken {
+			continue
+		}
+
+		switch tokens[i].Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return tokens[index+1 : i], i + 1
+			}
+		}
+	}
+
+	if index+1 >= len(tokens) {
+		return nil, len(tokens)
+	}
+
+	return tokens[index+1:], len(tokens)
+}
+
+// Returns the index after the angle bracket that closes the one at index
+func skipAngles(tokens []token, index int) int {
+	depth := 0
+
+	for i := index; i < len(tokens); i++ {
+		switch tokens[i].Text {
+		case "(", "[", "{":
+			i = skipBalanced(tokens, i) - 1
+		case "<":
+			depth++
+		case ">":
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case ";":
+			return i
+		}
+	}
+
+	return len(tokens)
+}
+
+// Splits tokens on every separator that is not inside of brackets
+func splitTopLevel(tokens []token, separator string) [][]token {
+	var (
+		response [][]token
+		start    int
+		depth    int
+	)
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].Kind != punctuationToken {
+			continue
+		}
+
+		switch tokens[i].Text {
+		case "(", "[", "{", "<":
+			depth++
+		case ")", "]", "}", ">":
+			depth--
+		case separator:
+			if depth != 0 {
+				continue
+			}
+
+			if i > start {
+				response = append(response, tokens[start:i])
+			}
+
+			start = i + 1
+		}
+	}
+
+	if start < len(tokens) {
+		response = append(response, tokens[start:])
+	}
+
+	return response
+}
+
+// Removes string, template and regular expression literals so that words inside of them are not mistaken for names
+func removeLiterals(tokens []token) []token {
+	var response []token
+
+	for _, t := range tokens {
+		if t.Kind == stringToken || t.Kind == templateToken || t.Kind == regexToken {
+			continue
+		}
+
+		response = append(response, t)
+	}
+
+	return response
+}
+
+// Joins tokens into readable code: Map<string, Dog[]>, (a: A) => B
+func joinTokens(tokens []token) string {
+	var builder strings.Builder
+
+	for i, t := range tokens {
+		if i > 0 && needsSpace(tokens[i-1], t) {
+			builder.WriteByte(' ')
+		}
+
+		builder.WriteString(t.Text)
+	}
+
+	return builder.String()
+}
+
+func needsSpace(previous, current token) bool {
+	if previous.Kind != punctuationToken && current.Kind != punctuationToken {
+		return true
+	}
+
+	switch previous.Text {
+	case ",", ":", "=", "=>", "|", "&", "?", "??", "+", "-", "*", "/", "%":
+		return true
+	}
+
+	switch current.Text {
+	case "=", "=>", "|", "&", "?", "??", "+", "-", "*", "/", "%":
+		return true
+	case "{":
+		return previous.Text != "<" && previous.Text != "(" && previous.Text != "["
+	}
+
+	return false
+}
+
+func unquote(text string) string {
+	if len(text) >= 2 {
+		return text[1 : len(text)-1]
+	}
+
+	return text
+}
+
+func isText(tokens []token, index int, text string) bool {
+	return index >= 0 && index < len(tokens) && tokens[index].Text == text
+}
+
+func isKind(tokens []token, index int, kind tokenKind) bool {
+	return index >= 0 && index < len(tokens) && tokens[index].Kind == kind
+}
+
+func isOpeningBracket(text string) bool {
+	return text == "(" || text == "[" || text == "{"
+}
@@ -0,0 +1,277 @@
+package typescript
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func TestGetImports(t *testing.T) {
+	type ImportsTest struct {
+		Input  []byte
+		Output []tsImport
+	}
+
+	var tests = []ImportsTest{
+		{
+			Input: []byte(`
+				import Dog, { Cat as Kitten, type Bird } from "./animals";
+				import * as models from '../models/index.js'
+				import type { Owner } from "./owner"
+				import "./styles.css";
+				import Legacy = require("./legacy");`),
+			Output: []tsImport{
+				{Path: "./animals", Name: "default", Alias: "Dog"},
+				{Path: "./animals", Name: "Cat", Alias: "Kitten"},
+				{Path: "./animals", Name: "Bird", Alias: "Bird"},
+				{Path: "../models/index.js", Name: "*", Alias: "models"},
+				{Path: "./owner", Name: "Owner", Alias: "Owner"},
+				{Path: "./legacy", Name: "default", Alias: "Legacy"},
+			},
+		},
+		{
+			Input: []byte(`
+				const Shape = require("./shape");
+				const { Circle, Square: Box } = require("./shapes");
+				const lazy = () => import("./lazy");`),
+			Output: []tsImport{
+				{Path: "./shape", Name: "default", Alias: "Shape"},
+				{Path: "./shapes", Name: "Circle", Alias: "Circle"},
+				{Path: "./shapes", Name: "Square", Alias: "Box"},
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			actualOutput := parseFile(types.File{Name: "test", Extension: "ts", Code: tt.Input}, "test").Imports
+
+			if len(tt.Output) != len(actualOutput) {
+				subtest.Errorf("incorrect length.\nexpected: %s\ngot: %s\n", strconv.Itoa(len(tt.Output)), strconv.Itoa(len(actualOutput)))
+				subtest.FailNow()
+			}
+
+			for index, expected := range tt.Output {
+				if expected != actualOutput[index] {
+					subtest.Errorf("incorrect import.\nexpected:\n%+v\ngot:\n%+v\n", expected, actualOutput[index])
+				}
+			}
+		})
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	file := types.File{
+		Name:      "zoo",
+		Extension: "ts",
+		Code: []byte(`
+import { Keeper } from "./keeper";
+
+export enum Diet {
+	Herbivore = "herbivore",
+	Carnivore,
+}
+
+export interface Named<T = string> extends Base, Other<T> {
+	readonly name: T
+	rename?(name: T): void;
+	[key: string]: unknown;
+}
+
+export type Point = {
+	x: number;
+	y: number;
+};
+
+type Located = Named & { position: Point };
+type Id = string | number;
+
+export default abstract class Animal<T> extends Base<T> implements Named, Comparable {
+	static count = 0;
+	#secret: string;
+	protected abstract diet: Diet;
+	private readonly keeper: Keeper = new Keeper();
+
+	constructor(public name: string, private age?: number) {
+		super();
+	}
+
+	abstract speak(): string;
+
+	@log()
+	public async feed(food: Food, amount = 1): Promise<{ eaten: boolean }> {
+		return { eaten: food.amount > amount };
+	}
+
+	get label(): string {
+		return this.name;
+	}
+}
+
+namespace Shapes {
+	export class Circle {
+		radius = 1
+		area = () => {
+			return Math.PI * this.radius ** 2
+		}
+	}
+}
+`),
+	}
+
+	response := parseFile(file, file.Name)
+
+	if response.DefaultExport != "Animal" {
+		t.Errorf("incorrect default export.\nexpected: Animal\ngot: %s\n", response.DefaultExport)
+	}
+
+	if len(response.Data) != 6 {
+		t.Fatalf("incorrect number of classes.\nexpected: 6\ngot: %d\n", len(response.Data))
+	}
+
+	diet, ok := response.Data[0].(types.JavaEnum)
+	if !ok || len(diet.Declarations) != 2 || string(diet.Declarations[0]) != "Herbivore" || string(diet.Declarations[1]) != "Carnivore" {
+		t.Errorf("incorrect enum")
+	}
+
+	named, ok := response.Data[1].(types.JavaInterface)
+	if !ok {
+		t.Fatalf("Named is not an interface")
+	}
+
+	if len(named.Extends) != 2 || string(named.Extends[1]) != "Other" {
+		t.Errorf("incorrect interface extends: %s", named.Extends)
+	}
+
+	if len(named.Variables) != 1 || !named.Variables[0].Final || string(named.Variables[0].Type) != "T" {
+		t.Errorf("incorrect interface variables")
+	}
+
+	if len(named.Methods) != 1 || string(named.Methods[0].Name) != "rename" || string(named.Methods[0].Parameters[0].Type) != "T" {
+		t.Errorf("incorrect interface methods")
+	}
+
+	if point, ok := response.Data[2].(types.JavaInterface); !ok || string(point.Name) != "Point" || len(point.Variables) != 2 {
+		t.Errorf("Point is not an object type")
+	}
+
+	if located, ok := response.Data[3].(types.JavaInterface); !ok || len(located.Extends) != 1 || len(located.Variables) != 1 {
+		t.Errorf("Located is not an object type extending Named")
+	}
+
+	animal, ok := response.Data[4].(types.JavaAbstract)
+	if !ok {
+		t.Fatalf("Animal is not abstract")
+	}
+
+	if len(animal.Extends) != 1 || string(animal.Extends[0]) != "Base" || len(animal.Implements) != 2 {
+		t.Errorf("incorrect heritage.\nextends: %s\nimplements: %s\n", animal.Extends, animal.Implements)
+	}
+
+	expectedVariables := []types.JavaVariable{
+		{Name: []byte("count"), Value: []byte("0"), AccessModifier: []byte("public"), Static: true},
+		{Name: []byte("secret"), Type: []byte("string"), AccessModifier: []byte("private")},
+		{Name: []byte("diet"), Type: []byte("Diet"), AccessModifier: []byte("protected")},
+		{Name: []byte("keeper"), Type: []byte("Keeper"), Value: []byte("new Keeper()"), AccessModifier: []byte("private"), Final: true},
+		{Name: []byte("name"), Type: []byte("string"), AccessModifier: []byte("public")},
+		{Name: []byte("age"), Type: []byte("number"), AccessModifier: []byte("private")},
+	}
+
+	if len(animal.Variables) != len(expectedVariables) {
+		t.Fatalf("incorrect number of variables.\nexpected: %d\ngot: %d\n", len(expectedVariables), len(animal.Variables))
+	}
+
+	for index, expected := range expectedVariables {
+		actual := animal.Variables[index]
+		if string(actual.Name) != string(expected.Name) || string(actual.Type) != string(expected.Type) || string(actual.Value) != string(expected.Value) ||
+			string(actual.AccessModifier) != string(expected.AccessModifier) || actual.Static != expected.Static || actual.Final != expected.Final {
+			t.Errorf("incorrect variable.\nexpected:\n%s %s %s %s\ngot:\n%s %s %s %s\n",
+				expected.AccessModifier, expected.Name, expected.Type, expected.Value,
+				actual.AccessModifier, actual.Name, actual.Type, actual.Value)
+		}
+	}
+
+	expectedMethods := []types.JavaMethod{
+		{Name: []byte("constructor"), AccessModifier: []byte("public")},
+		{Name: []byte("speak"), Type: []byte("string"), AccessModifier: []byte("public"), Abstract: true},
+		{Name: []byte("feed"), Type: []byte("Promise<{eaten: boolean}>"), AccessModifier: []byte("public")},
+		{Name: []byte("label"), Type: []byte("string"), AccessModifier: []byte("public")},
+	}
+
+	if len(animal.Methods) != len(expectedMethods) {
+		t.Fatalf("incorrect number of methods.\nexpected: %d\ngot: %d\n", len(expectedMethods), len(animal.Methods))
+	}
+
+	for index, expected := range expectedMethods {
+		actual := animal.Methods[index]
+		if string(actual.Name) != string(expected.Name) || string(actual.Type) != string(expected.Type) || actual.Abstract != expected.Abstract {
+			t.Errorf("incorrect method.\nexpected:\n%s %s\ngot:\n%s %s\n", expected.Name, expected.Type, actual.Name, actual.Type)
+		}
+	}
+
+	if feed := animal.Methods[2]; len(feed.Parameters) != 2 || string(feed.Parameters[0].Type) != "Food" || string(feed.Parameters[1].Name) != "amount" {
+		t.Errorf("incorrect feed parameters")
+	}
+
+	circle, ok := response.Data[5].(types.JavaClass)
+	if !ok || string(circle.Name) != "Circle" || string(circle.Package) != "zoo" || len(circle.Variables) != 2 {
+		t.Errorf("incorrect namespace class")
+	}
+}
+
+func TestParseFileJavaScript(t *testing.T) {
+	file := types.File{
+		Name:      "counter",
+		Extension: "js",
+		Code: []byte(`
+const EventEmitter = require("events");
+const Store = require("./store");
+
+class Counter extends EventEmitter {
+	#count = 0
+
+	constructor(store, step) {
+		super()
+		this.store = store
+		this.step = step || 1
+		this.history = new History()
+	}
+
+	increment() {
+		this.#count += this.step
+		this.emit("change", this.#count)
+	}
+}
+
+module.exports = Counter
+`),
+	}
+
+	response := parseFile(file, file.Name)
+
+	if len(response.Data) != 1 {
+		t.Fatalf("incorrect number of classes.\nexpected: 1\ngot: %d\n", len(response.Data))
+	}
+
+	counter := response.Data[0].(types.JavaClass)
+
+	expectedVariables := []string{"count", "store", "step", "history"}
+	if len(counter.Variables) != len(expectedVariables) {
+		t.Fatalf("incorrect number of variables.\nexpected: %d\ngot: %d\n", len(expectedVariables), len(counter.Variables))
+	}
+
+	for index, expected := range expectedVariables {
+		if string(counter.Variables[index].Name) != expected {
+			t.Errorf("incorrect variable.\nexpected: %s\ngot: %s\n", expected, counter.Variables[index].Name)
+		}
+	}
+
+	if string(counter.Variables[3].Type) != "History" {
+		t.Errorf("incorrect inferred type.\nexpected: History\ngot: %s\n", counter.Variables[3].Type)
+	}
+
+	if len(counter.Methods) != 2 || len(counter.Methods[0].Parameters) != 2 {
+		t.Errorf("incorrect methods")
+	}
+}
@@ -0,0 +1,227 @@
+package typescript
+
+import "strings"
+
+type tokenKind int
+
+const (
+	identifierToken tokenKind = iota
+	punctuationToken
+	stringToken
+	templateToken
+	numberToken
+	regexToken
+)
+
+type token struct {
+	Kind          tokenKind
+	Text          string
+	Start         int
+	End           int
+	NewlineBefore bool // True when a line break separates this token from the previous one
+}
+
+// Punctuation made of more than one character that matters to the parser
+var multiCharPunctuation = []string{"...", "=>", "?.", "??"}
+
+// Keywords after which a slash starts a regular expression instead of a division
+var regexPrecedingKeywords = map[string]struct{}{
+	"return": {}, "typeof": {}, "case": {}, "do": {}, "else": {}, "in": {}, "of": {}, "new": {}, "delete": {}, "void": {}, "throw": {}, "yield": {}, "await": {},
+}
+
+// Splits code into tokens. Comments and whitespace are removed.
+func tokenize(code []byte) []token {
+	var (
+		response      []token
+		text          = string(code)
+		newlineBefore bool
+	)
+
+	appendToken := func(kind tokenKind, start, end int) {
+		response = append(response, token{
+			Kind:          kind,
+			Text:          text[start:end],
+			Start:         start,
+			End:           end,
+			NewlineBefore: newlineBefore,
+		})
+
+		newlineBefore = false
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+
+		switch {
+		case c == '\n':
+			newlineBefore = true
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(text[i:], "//"):
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end == -1 {
+				i = len(text)
+				break
+			}
+
+			if strings.Contains(text[i:i+2+end], "\n") {
+				newlineBefore = true
+			}
+
+			i += end + 4
+		case c == '"' || c == '\'':
+			end := skipString(text, i)
+			appendToken(stringToken, i, end)
+			i = end
+		case c == '`':
+			end := skipTemplate(text, i)
+			appendToken(templateToken, i, end)
+			i = end
+		case c == '/' && isRegexStart(response):
+			end := skipRegex(text, i)
+			appendToken(regexToken, i, end)
+			i = end
+		case isIdentifierStart(c) || c == '#' && i+1 < len(text) && isIdentifierStart(text[i+1]):
+			end := i + 1
+			for end < len(text) && isIdentifierPart(text[end]) {
+				end++
+			}
+
+			appendToken(identifierToken, i, end)
+			i = end
+		case c >= '0' && c <= '9':
+			end := i + 1
+			for end < len(text) && (isIdentifierPart(text[end]) || text[end] == '.') {
+				end++
+			}
+
+			appendToken(numberToken, i, end)
+			i = end
+		default:
+			end := i + 1
+			for _, punctuation := range multiCharPunctuation {
+				if strings.HasPrefix(text[i:], punctuation) {
+					end = i + len(punctuation)
+					break
+				}
+			}
+
+			appendToken(punctuationToken, i, end)
+			i = end
+		}
+	}
+
+	return response
+}
+
+// Returns the index after the string that starts at start. Unterminated strings end at the line break.
+func skipString(text string, start int) int {
+	quote := text[start]
+
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		case '\n':
+			return i
+		}
+	}
+
+	return len(text)
+}
+
+// Returns the index after the template literal that starts at start, including nested substitutions
+func skipTemplate(text string, start int) int {
+	for i := start + 1; i < len(text); i++ {
+		switch {
+		case text[i] == '\\':
+			i++
+		case text[i] == '`':
+			return i + 1
+		case strings.HasPrefix(text[i:], "${"):
+			depth := 0
+			for i += 2; i < len(text); i++ {
+				c := text[i]
+
+				if c == '"' || c == '\'' {
+					i = skipString(text, i) - 1
+				} else if c == '`' {
+					i = skipTemplate(text, i) - 1
+				} else if c == '{' {
+					depth++
+				} else if c == '}' {
+					if depth == 0 {
+						break
+					}
+
+					depth--
+				}
+			}
+		}
+	}
+
+	return len(text)
+}
+
+// Returns the index after the regular expression that starts at start
+func skipRegex(text string, start int) int {
+	inClass := false
+
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '\n':
+			return i
+		case '/':
+			if inClass {
+				continue
+			}
+
+			for i++; i < len(text) && isIdentifierPart(text[i]); i++ {
+			}
+
+			return i
+		}
+	}
+
+	return len(text)
+}
+
+// A slash starts a regular expression unless it follows a value
+func isRegexStart(previous []token) bool {
+	if len(previous) == 0 {
+		return true
+	}
+
+	last := previous[len(previous)-1]
+
+	switch last.Kind {
+	case identifierToken:
+		_, ok := regexPrecedingKeywords[last.Text]
+		return ok
+	case punctuationToken:
+		return last.Text != ")" && last.Text != "]" && last.Text != "}"
+	}
+
+	return false
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || (c >= '0' && c <= '9')
+}
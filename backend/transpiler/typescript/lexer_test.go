@@ -0,0 +1,50 @@
+package typescript
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	type TokenizeTest struct {
+		Input  []byte
+		Output []string
+	}
+
+	var tests = []TokenizeTest{
+		{
+			Input:  []byte("class A { // comment\n  private x?: Map<string, B[]> = new Map(); /* block */ }"),
+			Output: []string{"class", "A", "{", "private", "x", "?", ":", "Map", "<", "string", ",", "B", "[", "]", ">", "=", "new", "Map", "(", ")", ";", "}"},
+		},
+		{
+			Input:  []byte("const s = `a ${b + `c ${d}`} }`; const r = /[/}]+/g; x = a / b"),
+			Output: []string{"const", "s", "=", "`a ${b + `c ${d}`} }`", ";", "const", "r", "=", "/[/}]+/g", ";", "x", "=", "a", "/", "b"},
+		},
+		{
+			Input:  []byte(`f = (...args) => args?.length ?? 0; this.#secret = "it's"`),
+			Output: []string{"f", "=", "(", "...", "args", ")", "=>", "args", "?.", "length", "??", "0", ";", "this", ".", "#secret", "=", `"it's"`},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			var actualOutput []string
+			for _, t := range tokenize(tt.Input) {
+				actualOutput = append(actualOutput, t.Text)
+			}
+
+			if strings.Join(actualOutput, " ") != strings.Join(tt.Output, " ") {
+				subtest.Errorf("incorrect tokens.\nexpected:\n%s\ngot:\n%s\n", strings.Join(tt.Output, " "), strings.Join(actualOutput, " "))
+			}
+		})
+	}
+}
+
+func TestTokenizeNewlines(t *testing.T) {
+	tokens := tokenize([]byte("a\n/* multi\nline */ b c"))
+
+	if len(tokens) != 3 || tokens[0].NewlineBefore || !tokens[1].NewlineBefore || tokens[2].NewlineBefore {
+		t.Errorf("incorrect newline flags")
+	}
+}
@@ -0,0 +1,389 @@
+package typescript
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+var identifierRegex = regexp.MustCompile(`[A-Za-z_$][A-Za-z0-9_$]*(\.[A-Za-z_$][A-Za-z0-9_$]*)*`)
+
+func ParseProject(ctx context.Context, files []types.File) *types.Project {
+	moduleNames := getModuleNames(files)
+	parsedFiles, diagnostics := types.ParseFiles(ctx, files, func(file types.File) fileResponse {
+		return parseFile(file, moduleNames[file.GetPath()])
+	})
+	response := types.Project{Diagnostics: diagnostics}
+
+	modulePaths := getModulePaths(files, moduleNames)
+	moduleClasses := getModuleClasses(parsedFiles)
+	defaultExports := getDefaultExports(parsedFiles)
+
+	for i, parsedFile := range parsedFiles {
+		response.Diagnostics = append(response.Diagnostics, parsedFile.Diagnostics...)
+		scope := getFileScope(moduleClasses, defaultExports, modulePaths, files[i].GetPath(), parsedFile)
+		language := getFileLanguage(files[i])
+
+		for _, parsedClass := range parsedFile.Data {
+			if node, ok := parsedClass.(types.DiagramNode); ok {
+				parsedClass = types.UpdateDiagramNode(node, func(diagramNode *types.JavaDiagramNode) {
+					diagramNode.Language = language
+				})
+			}
+
+			switch class := parsedClass.(type) {
+			case types.JavaAbstract:
+				class.Associations, class.Dependencies = getClassAssociationsAndDependencies(moduleClasses, scope, class.Variables, class.Methods)
+				response.Nodes = append(response.Nodes, class)
+				addClassRelations(&response, moduleClasses, scope, class.Package, class.Name, class.Implements, class.Extends, class.Associations, class.Dependencies)
+			case types.JavaClass:
+				class.Associations, class.Dependencies = getClassAssociationsAndDependencies(moduleClasses, scope, class.Variables, class.Methods)
+				response.Nodes = append(response.Nodes, class)
+				addClassRelations(&response, moduleClasses, scope, class.Package, class.Name, class.Implements, class.Extends, class.Associations, class.Dependencies)
+			case types.JavaInterface:
+				class.Associations, class.Dependencies = getClassAssociationsAndDependencies(moduleClasses, scope, class.Variables, class.Methods)
+				response.Nodes = append(response.Nodes, class)
+				addClassRelations(&response, moduleClasses, scope, class.Package, class.Name, nil, class.Extends, class.Associations, class.Dependencies)
+			case types.JavaEnum:
+				response.Nodes = append(response.Nodes, class)
+			}
+		}
+	}
+
+	return &response
+}
+
+// Returns the language that the file is written in, the classes of JavaScript files are parsed like TypeScript classes
+func getFileLanguage(file types.File) string {
+	if file.Extension == "js" || file.Extension == "jsx" {
+		return "js"
+	}
+
+	return "ts"
+}
+
+// Returns the module name of every file, keyed by the path of the file.
+// Paths are relative to the directory that holds every file, and their folders are joined with periods: src/models/dog.ts -> models.dog
+func getModuleNames(files []types.File) map[string]string {
+	var (
+		response = make(map[string]string)
+		root     string
+	)
+
+	for i, file := range files {
+		dir := path.Dir(strings.ReplaceAll(file.GetPath(), "\\", "/"))
+		if i == 0 {
+			root = dir
+		}
+
+		for root != "." && dir != root && !strings.HasPrefix(dir, root+"/") {
+			root = path.Dir(root)
+		}
+	}
+
+	for _, file := range files {
+		name := trimModuleExtension(strings.ReplaceAll(file.GetPath(), "\\", "/"))
+		if root != "." {
+			name = strings.TrimPrefix(name, root+"/")
+		}
+
+		module := strings.ReplaceAll(strings.Trim(name, "./"), "/", ".")
+		if module == "" {
+			module = file.Name
+		}
+
+		response[file.GetPath()] = module
+	}
+
+	return response
+}
+
+// Returns the module name of every file, keyed by the path of the file without its extension
+func getModulePaths(files []types.File, moduleNames map[string]string) map[string]string {
+	response := make(map[string]string)
+
+	for _, file := range files {
+		response[trimModuleExtension(path.Clean(strings.ReplaceAll(file.GetPath(), "\\", "/")))] = moduleNames[file.GetPath()]
+	}
+
+	return response
+}
+
+// Returns every class declared in the project.
+// The key is the module name, the inner key is the class name and the inner value is the class.
+func getModuleClasses(parsedFiles []fileResponse) map[string]map[string]any {
+	response := make(map[string]map[string]any)
+
+	for _, parsedFile := range parsedFiles {
+		if _, ok := response[parsedFile.Module]; !ok {
+			response[parsedFile.Module] = make(map[string]any)
+		}
+
+		for _, parsedClass := range parsedFile.Data {
+			response[parsedFile.Module][string(getClassName(parsedClass))] = parsedClass
+		}
+	}
+
+	return response
+}
+
+// Returns the name of the class exported by default from each module
+func getDefaultExports(parsedFiles []fileResponse) map[string]string {
+	response := make(map[string]string)
+
+	for _, parsedFile := range parsedFiles {
+		if parsedFile.DefaultExport != "" {
+			response[parsedFile.Module] = parsedFile.DefaultExport
+		}
+	}
+
+	return response
+}
+
+// Returns the names that are visible inside of a file.
+// The key is the name as written in the file and the value is the class id, or the module name followed by a period for namespace imports.
+func getFileScope(moduleClasses map[string]map[string]any, defaultExports, modulePaths map[string]string, filePath string, parsedFile fileResponse) map[string]string {
+	scope := make(map[string]string)
+
+	for _, impt := range parsedFile.Imports {
+		// Packages such as "react" are not part of the project
+		if !isProjectPath(impt.Path) {
+			continue
+		}
+
+		module := resolveModule(modulePaths, filePath, impt.Path)
+		classes, moduleExists := moduleClasses[module]
+
+		switch impt.Name {
+		case "*":
+			if moduleExists {
+				scope[impt.Alias] = module + "."
+			}
+		case "default":
+			if className, ok := defaultExports[module]; ok && classes[className] != nil {
+				scope[impt.Alias] = module + "." + className
+			} else if classes[impt.Alias] != nil {
+				scope[impt.Alias] = module + "." + impt.Alias
+			} else if moduleExists {
+				// CommonJS modules export an object holding the classes
+				scope[impt.Alias] = module + "."
+			}
+		default:
+			if classes[impt.Name] != nil {
+				scope[impt.Alias] = module + "." + impt.Name
+			} else if classId := findUniqueClass(moduleClasses, impt.Name); classId != "" {
+				// The class is re-exported, for example by an index file
+				scope[impt.Alias] = classId
+			}
+		}
+	}
+
+	// Classes declared in the file take precedence over imports
+	for className := range moduleClasses[parsedFile.Module] {
+		scope[className] = parsedFile.Module + "." + className
+	}
+
+	return scope
+}
+
+// Relative imports and common path aliases point inside of the project
+func isProjectPath(path string) bool {
+	return strings.HasPrefix(path, ".") || strings.HasPrefix(path, "@/") || strings.HasPrefix(path, "~/")
+}
+
+// Extensions that import paths may end with, longest first
+var moduleExtensions = []string{".d.ts", ".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs"}
+
+// Returns the path without the extension of a module: models/dog.js -> models/dog, types/index.d.ts -> types/index
+func trimModuleExtension(path string) string {
+	for _, extension := range moduleExtensions {
+		if strings.HasSuffix(path, extension) {
+			return strings.TrimSuffix(path, extension)
+		}
+	}
+
+	return path
+}
+
+// Returns the module name of an import path, or an empty string when the import does not point to a file of the project.
+// Relative paths are resolved from the directory of the importing file, and folders resolve to their index file: ../models -> models/index.ts
+// Path aliases such as @/models/dog are resolved to the only file whose path ends with the aliased path.
+func resolveModule(modulePaths map[string]string, filePath, importPath string) string {
+	importPath = trimModuleExtension(importPath)
+
+	var candidates []string
+	if strings.HasPrefix(importPath, ".") {
+		candidate := path.Join(path.Dir(strings.ReplaceAll(filePath, "\\", "/")), importPath)
+		candidates = []string{candidate, candidate + "/index"}
+	} else {
+		aliasedPath := importPath[strings.IndexByte(importPath, '/')+1:]
+		candidates = []string{aliasedPath, aliasedPath + "/index"}
+	}
+
+	for _, candidate := range candidates {
+		if module, ok := modulePaths[candidate]; ok {
+			return module
+		}
+	}
+
+	if strings.HasPrefix(importPath, ".") {
+		return ""
+	}
+
+	for _, candidate := range candidates {
+		var response string
+
+		for modulePath, module := range modulePaths {
+			if !strings.HasSuffix(modulePath, "/"+candidate) {
+				continue
+			}
+
+			if response != "" {
+				return ""
+			}
+
+			response = module
+		}
+
+		if response != "" {
+			return response
+		}
+	}
+
+	return ""
+}
+
+// Returns the id of the only class in the project with the name, or an empty string
+func findUniqueClass(moduleClasses map[string]map[string]any, name string) string {
+	var response string
+
+	for module, classes := range moduleClasses {
+		if classes[name] == nil {
+			continue
+		}
+
+		if response != "" {
+			return ""
+		}
+
+		response = module + "." + name
+	}
+
+	return response
+}
+
+// Resolves a name such as "Dog", "Dog.create" or "models.Dog" to a class id, or returns an empty string
+func resolveName(moduleClasses map[string]map[string]any, scope map[string]string, name string) string {
+	components := strings.Split(name, ".")
+
+	value, ok := scope[components[0]]
+	if !ok {
+		return ""
+	}
+
+	if !strings.HasSuffix(value, ".") {
+		return value
+	}
+
+	module := strings.TrimSuffix(value, ".")
+	if len(components) > 1 && moduleClasses[module][components[1]] != nil {
+		return module + "." + components[1]
+	}
+
+	return ""
+}
+
+// Returns associations and dependencies
+func getClassAssociationsAndDependencies(moduleClasses map[string]map[string]any, scope map[string]string, variables []types.JavaVariable, methods []types.JavaMethod) ([]types.CustomByteSlice, []types.CustomByteSlice) {
+	var (
+		associationsMap = make(map[string]struct{})
+		dependenciesMap = make(map[string]struct{})
+	)
+
+	addReferences := func(m map[string]struct{}, text []byte) {
+		for _, name := range identifierRegex.FindAllString(string(text), -1) {
+			if classId := resolveName(moduleClasses, scope, name); classId != "" {
+				m[classId] = struct{}{}
+			}
+		}
+	}
+
+	for _, variable := range variables {
+		addReferences(associationsMap, variable.Type)
+		addReferences(associationsMap, variable.Value)
+	}
+
+	for _, method := range methods {
+		for _, parameter := range method.Parameters {
+			addReferences(dependenciesMap, parameter.Type)
+		}
+
+		addReferences(dependenciesMap, method.Functionality)
+	}
+
+	// Association is a stronger form of a dependency
+	for classId := range associationsMap {
+		delete(dependenciesMap, classId)
+	}
+
+	return sortedKeys(associationsMap), sortedKeys(dependenciesMap)
+}
+
+func addClassRelations(project *types.Project, moduleClasses map[string]map[string]any, scope map[string]string, module, name []byte, implements, extends, associations, dependencies []types.CustomByteSlice) {
+	fromClassId := []byte(string(module) + "." + string(name))
+
+	for _, implement := range implements {
+		if toClassId := resolveName(moduleClasses, scope, string(implement)); toClassId != "" {
+			project.AddRelation(fromClassId, []byte(toClassId), &types.Realization{})
+		}
+	}
+
+	for _, extend := range extends {
+		if toClassId := resolveName(moduleClasses, scope, string(extend)); toClassId != "" {
+			project.AddRelation(fromClassId, []byte(toClassId), &types.Generalization{})
+		}
+	}
+
+	for _, association := range associations {
+		project.AddRelation(fromClassId, association, &types.Association{})
+	}
+
+	for _, dependency := range dependencies {
+		project.AddRelation(fromClassId, dependency, &types.Dependency{})
+	}
+}
+
+func getClassName(class any) []byte {
+	switch c := class.(type) {
+	case types.JavaAbstract:
+		return c.Name
+	case types.JavaClass:
+		return c.Name
+	case types.JavaInterface:
+		return c.Name
+	case types.JavaEnum:
+		return c.Name
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]struct{}) []types.CustomByteSlice {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var response []types.CustomByteSlice
+	for _, key := range keys {
+		response = append(response, []byte(key))
+	}
+
+	return response
+}
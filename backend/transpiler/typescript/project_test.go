@@ -0,0 +1,306 @@
+package typescript
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/junioryono/ProUML/backend/transpiler/types"
+)
+
+func relationToString(relation types.Relation) string {
+	return fmt.Sprintf("%s -> %s %T from:%t to:%t", relation.FromClassId, relation.ToClassId, relation.Type, relation.Type.GetFromArrow(), relation.Type.GetToArrow())
+}
+
+func TestParseProject(t *testing.T) {
+	type Output struct {
+		Nodes []string
+		Edges []types.Relation
+	}
+
+	type ParseProjectTest struct {
+		Input  []types.File
+		Output Output
+	}
+
+	var tests = []ParseProjectTest{
+		{
+			Input: []types.File{
+				{
+					Path:      "src/animals/animal.ts",
+					Name:      "animal",
+					Extension: "ts",
+					Code: []byte(`
+export interface Speaker {
+	speak(): string;
+}
+
+export default abstract class Animal implements Speaker {
+	abstract speak(): string;
+}`),
+				},
+				{
+					Path:      "src/animals/dog.ts",
+					Name:      "dog",
+					Extension: "ts",
+					Code: []byte(`
+import Animal from "./animal";
+import * as people from "../people/owner";
+import { Collar } from "./accessories";
+import { Component } from "react";
+
+export class Dog extends Animal {
+	collar: Collar;
+
+	constructor(private owner: people.Owner) {
+		super();
+	}
+
+	speak(): string {
+		return "Woof";
+	}
+}`),
+				},
+				{
+					Path:      "src/people/owner.ts",
+					Name:      "owner",
+					Extension: "ts",
+					Code: []byte(`
+import type { Dog } from "../animals/dog";
+import { Kennel } from "./kennel";
+
+export class Owner {
+	adopt(dog: Dog): void {
+		Kennel.release(dog);
+	}
+}`),
+				},
+				{
+					Path:      "src/people/kennel.ts",
+					Name:      "kennel",
+					Extension: "ts",
+					Code: []byte(`
+export class Kennel {
+	static release(dog: unknown) {}
+}`),
+				},
+				{
+					// Collar is re-exported by an index file that is not part of the upload
+					Path:      "src/animals/accessories/collar.ts",
+					Name:      "collar",
+					Extension: "ts",
+					Code: []byte(`
+export enum Size { Small, Large }
+
+export class Collar {
+	size: Size = Size.Small;
+}`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{"animals.animal.Speaker", "animals.animal.Animal", "animals.dog.Dog", "people.owner.Owner", "people.kennel.Kennel", "animals.accessories.collar.Size", "animals.accessories.collar.Collar"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("animals.animal.Animal"), ToClassId: []byte("animals.animal.Speaker"), Type: &types.Realization{ToArrow: true}},
+					{FromClassId: []byte("animals.dog.Dog"), ToClassId: []byte("animals.animal.Animal"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("animals.dog.Dog"), ToClassId: []byte("animals.accessories.collar.Collar"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("animals.dog.Dog"), ToClassId: []byte("people.owner.Owner"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("people.owner.Owner"), ToClassId: []byte("animals.dog.Dog"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("people.owner.Owner"), ToClassId: []byte("people.kennel.Kennel"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("animals.accessories.collar.Collar"), ToClassId: []byte("animals.accessories.collar.Size"), Type: &types.Association{ToArrow: true}},
+				},
+			},
+		},
+		{
+			Input: []types.File{
+				{
+					Name:      "shape",
+					Extension: "js",
+					Code: []byte(`
+class Shape {}
+
+module.exports = Shape;`),
+				},
+				{
+					Name:      "shapes",
+					Extension: "js",
+					Code: []byte(`
+const Shape = require("./shape");
+const { Canvas } = require("./canvas");
+
+class Circle extends Shape {}
+
+class Square extends Shape {
+	draw(canvas) {
+		return new Canvas().add(this);
+	}
+}
+
+module.exports = { Circle, Square };`),
+				},
+				{
+					Name:      "canvas",
+					Extension: "js",
+					Code: []byte(`
+const shapes = require("./shapes");
+
+class Canvas {
+	constructor() {
+		this.background = new shapes.Square();
+	}
+}
+
+module.exports = { Canvas };`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{"shape.Shape", "shapes.Circle", "shapes.Square", "canvas.Canvas"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("shapes.Circle"), ToClassId: []byte("shape.Shape"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("shapes.Square"), ToClassId: []byte("shape.Shape"), Type: &types.Generalization{ToArrow: true}},
+					{FromClassId: []byte("shapes.Square"), ToClassId: []byte("canvas.Canvas"), Type: &types.Dependency{ToArrow: true}},
+					{FromClassId: []byte("canvas.Canvas"), ToClassId: []byte("shapes.Square"), Type: &types.Association{ToArrow: true}},
+				},
+			},
+		},
+		{
+			// Module names keep every period of the file name but the extension
+			Input: []types.File{
+				{
+					Name:      "user.service",
+					Extension: "ts",
+					Code: []byte(`
+import { User } from "./user";
+
+export class UserService {
+	users: User[];
+}`),
+				},
+				{
+					Name:      "user",
+					Extension: "ts",
+					Code: []byte(`
+export interface User {
+	name: string;
+}`),
+				},
+				{
+					Name:      "user.controller",
+					Extension: "ts",
+					Code: []byte(`
+import { UserService } from "./user.service.js";
+
+export class UserController {
+	constructor(private service: UserService) {}
+}`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{"user.service.UserService", "user.User", "user.controller.UserController"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("user.service.UserService"), ToClassId: []byte("user.User"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("user.controller.UserController"), ToClassId: []byte("user.service.UserService"), Type: &types.Association{ToArrow: true}},
+				},
+			},
+		},
+		{
+			// Index files are named after their folder, and folders resolve to their index file
+			Input: []types.File{
+				{
+					Path:      "app/models/index.ts",
+					Name:      "index",
+					Extension: "ts",
+					Code: []byte(`
+export class Model {}`),
+				},
+				{
+					Path:      "app/views/index.tsx",
+					Name:      "index",
+					Extension: "tsx",
+					Code: []byte(`
+import * as models from "../models";
+
+export class Model {
+	source: models.Model;
+}`),
+				},
+				{
+					Path:      "app/types/index.d.ts",
+					Name:      "index",
+					Extension: "ts",
+					Code: []byte(`
+export interface Options {}`),
+				},
+				{
+					Path:      "app/views/list/list.ts",
+					Name:      "list",
+					Extension: "ts",
+					Code: []byte(`
+import { Model } from "..";
+import { Model as Item } from "../../models/index";
+import { Options } from "../../types";
+
+export class List {
+	views: Model[];
+	items: Item[];
+	options: Options;
+}`),
+				},
+			},
+			Output: Output{
+				Nodes: []string{"models.index.Model", "views.index.Model", "types.index.Options", "views.list.list.List"},
+				Edges: []types.Relation{
+					{FromClassId: []byte("views.index.Model"), ToClassId: []byte("models.index.Model"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("views.list.list.List"), ToClassId: []byte("views.index.Model"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("views.list.list.List"), ToClassId: []byte("models.index.Model"), Type: &types.Association{ToArrow: true}},
+					{FromClassId: []byte("views.list.list.List"), ToClassId: []byte("types.index.Options"), Type: &types.Association{ToArrow: true}},
+				},
+			},
+		},
+	}
+
+	for testIndex, tt := range tests {
+		t.Run("Test index "+strconv.Itoa(testIndex), func(subtest *testing.T) {
+			response := ParseProject(context.Background(), tt.Input)
+
+			if len(response.Nodes) != len(tt.Output.Nodes) {
+				subtest.Fatalf("incorrect number of nodes.\nexpected: %d\ngot: %d\n", len(tt.Output.Nodes), len(response.Nodes))
+			}
+
+			for index, node := range response.Nodes {
+				var classId string
+				switch class := node.(type) {
+				case types.JavaAbstract:
+					classId = string(class.Package) + "." + string(class.Name)
+				case types.JavaClass:
+					classId = string(class.Package) + "." + string(class.Name)
+				case types.JavaInterface:
+					classId = string(class.Package) + "." + string(class.Name)
+				case types.JavaEnum:
+					classId = string(class.Package) + "." + string(class.Name)
+				}
+
+				if classId != tt.Output.Nodes[index] {
+					subtest.Errorf("incorrect node.\nexpected: %s\ngot: %s\n", tt.Output.Nodes[index], classId)
+				}
+			}
+
+			var expectedEdges, actualEdges []string
+			for _, edge := range tt.Output.Edges {
+				expectedEdges = append(expectedEdges, relationToString(edge))
+			}
+
+			for _, edge := range response.Edges {
+				actualEdges = append(actualEdges, relationToString(edge))
+			}
+
+			sort.Strings(expectedEdges)
+			sort.Strings(actualEdges)
+
+			if fmt.Sprint(expectedEdges) != fmt.Sprint(actualEdges) {
+				subtest.Errorf("incorrect edges.\nexpected:\n%s\ngot:\n%s\n", expectedEdges, actualEdges)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package types
 
-import "github.com/junioryono/ProUML/backend/sdk/postgres/models"
+import (
+	"time"
+
+	"github.com/junioryono/ProUML/backend/sdk/postgres/models"
+	transpilerTypes "github.com/junioryono/ProUML/backend/transpiler/types"
+)
 
 type Status struct {
 	Success  bool   `json:"success"`
@@ -8,6 +13,38 @@ type Status struct {
 	Response any    `json:"response,omitempty"`
 }
 
+// Statuses of an import job
+const (
+	ImportQueued    = "queued"
+	ImportRunning   = "running"
+	ImportSucceeded = "succeeded"
+	ImportFailed    = "failed"
+)
+
+// A project that is imported in the background. The diagnostics are kept when the import fails.
+type ImportJob struct {
+	Id          string                            `json:"id"`
+	UserId      string                            `json:"userId"`
+	Status      string                            `json:"status"`
+	Files       int                               `json:"files"`
+	ParsedFiles int                               `json:"parsedFiles"`
+	Parsed      bool                              `json:"parsed"`
+	LayoutDone  bool                              `json:"layoutDone"`
+	DiagramId   string                            `json:"diagramId,omitempty"`
+	Commit      string                            `json:"commit,omitempty"` // Set when the project was read from a Git repository
+	Sync        *transpilerTypes.SyncSummary      `json:"sync,omitempty"`   // Set when the project was synced into an existing diagram
+	Languages   []transpilerTypes.LanguageSummary `json:"languages"`
+	Diagnostics []transpilerTypes.Diagnostic      `json:"diagnostics"`
+	Reason      string                            `json:"reason,omitempty"`
+	CreatedAt   time.Time                         `json:"createdAt"`
+	UpdatedAt   time.Time                         `json:"updatedAt"`
+}
+
+// Returns true when the job will not change anymore
+func (job *ImportJob) Finished() bool {
+	return job.Status == ImportSucceeded || job.Status == ImportFailed
+}
+
 type WebSocketBody struct {
 	SessionId string `json:"sessionId,omitempty"`
 	Events    string `json:"event"`
@@ -20,4 +57,5 @@ type WebSocketBody struct {
 	User            *models.DiagramUsersHiddenContent `json:"user,omitempty"`
 	BackgroundColor string                            `json:"backgroundColor,omitempty"`
 	ShowGrid        bool                              `json:"showGrid,omitempty"`
+	Import          *ImportJob                        `json:"import,omitempty"`
 }
@@ -24,7 +24,24 @@ const (
 	ErrCouldNotMarshalJSON    = "Internal Error. Could not marshal JSON."
 	ErrUnsupportedLang        = "Unsupported language."
 	ErrCouldNotFigureOutLang  = "Could not figure out language."
+	ErrUnsupportedLayout      = "Unsupported layout."
+	ErrCouldNotGenerateLayout = "Could not generate diagram layout."
 	ErrInvalidRequest         = "Invalid request."
+	ErrTranspileCanceled      = "Transpiling the project was canceled."
+	ErrImportNotFound         = "Import not found."
+	ErrTooManyImports         = "Too many projects are being imported. Please try again later."
+	ErrGitRepositoryNotFound  = "Could not read the Git repository."
+	ErrGitRefNotFound         = "Could not find the branch, tag or commit in the Git repository."
+	ErrGitInvalidBundle       = "Could not read the Git bundle."
+	ErrBundleTooLarge         = "Bundle must be less than 50MB."
+	ErrGitPathNotAllowed      = "Importing from a repository path is only available when ProUML is self-hosted."
+	ErrUnsupportedProject     = "Project must be a zip, tar or tar.gz archive, or source files."
+	ErrCouldNotOpenProject    = "Could not open project file."
+	ErrInvalidArchive         = "Could not read project file."
+	ErrUnsafeArchivePath      = "Project contains a file outside of its root folder."
+	ErrProjectTooLarge        = "Project must be less than 50MB."
+	ErrUncompressedTooLarge   = "Project is too large once it is uncompressed."
+	ErrTooManyFiles           = "Project contains too many files."
 )
 
 type WrappedError struct {
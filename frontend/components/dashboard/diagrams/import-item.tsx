@@ -1,10 +1,10 @@
 import { useRouter } from "next/navigation";
-import { createDiagram } from "@/lib/auth-fetch";
+import { importDiagram, waitForImport } from "@/lib/auth-fetch";
 import { useState } from "react";
 import { Icons } from "@/components/icons";
 import { cn } from "@/lib/utils";
 import { toast } from "@/ui/toast";
-import { Project } from "types";
+import { ImportJob, Project } from "types";
 
 const validFileTypes = [
    "zip",
@@ -12,43 +12,89 @@ const validFileTypes = [
    "application/zip",
    "application/x-zip",
    "application/x-zip-compressed",
+   "application/x-tar",
+   "application/gzip",
+   "application/x-gzip",
+   "application/x-compressed-tar",
 ];
 
+// Browsers do not know the type of every archive, so the extension is checked too
+const validFileExtensions = [".zip", ".tar", ".tar.gz", ".tgz"];
+
+function isValidProject(file: File) {
+   return validFileTypes.includes(file.type) || validFileExtensions.some((extension) => file.name.endsWith(extension));
+}
+
+function getImportProgress(job: ImportJob) {
+   if (job.status === "queued") {
+      return "Waiting to import...";
+   } else if (job.layoutDone) {
+      return "Saving diagram...";
+   } else if (job.parsed) {
+      return "Generating layout...";
+   }
+
+   return `Parsed ${job.parsedFiles} of ${job.files} files`;
+}
+
 export default function ImportItem({ project }: { project?: Project }) {
    const router = useRouter();
    const [isLoading, setIsLoading] = useState<boolean>(false);
+   const [progress, setProgress] = useState<string>();
 
    async function onProjectImport(e: React.ChangeEvent<HTMLInputElement>) {
       setIsLoading(true);
 
-      if (!e.target.files || !validFileTypes.includes(e.target.files[0].type)) {
+      // Git bundles are checked out by the server at their default branch
+      const isBundle = e.target.files?.[0]?.name.endsWith(".bundle");
+
+      if (!e.target.files || (!isBundle && !isValidProject(e.target.files[0]))) {
          e.target.value = null; // Reset form files
          setIsLoading(false);
          return toast({
             title: "Something went wrong.",
-            message: "Your project must be uploaded in a compressed format (application/zip).",
+            message: "Your project must be uploaded in a zip, tar or tar.gz archive, or as a Git bundle.",
             type: "error",
          });
       }
 
       const formData = new FormData();
-      formData.append("project", e.target.files[0]);
+      formData.append(isBundle ? "bundle" : "project", e.target.files[0]);
 
       if (project) {
          formData.append("projectId", project.id);
       }
 
-      createDiagram(formData)
+      importDiagram(formData)
          .then((res) => {
             if (res.success === false) {
                throw new Error(res.reason);
             }
 
-            router.push(`/dashboard/diagrams/${res.response}`);
+            setProgress(getImportProgress(res.response));
+            return waitForImport(res.response, (job) => setProgress(getImportProgress(job)));
+         })
+         .then((job) => {
+            const problems = job.diagnostics?.length ?? 0;
+            const problemsMessage =
+               problems > 0 ? ` ${problems} parse problem${problems === 1 ? " was" : "s were"} recorded.` : "";
+
+            if (job.status === "failed") {
+               throw new Error(job.reason + problemsMessage);
+            }
+
+            router.push(`/dashboard/diagrams/${job.diagramId}`);
+
+            const skipped = (job.languages ?? [])
+               .filter((summary) => summary.skipped)
+               .map((summary) => `.${summary.language}`);
 
             return toast({
                title: "Success!",
-               message: "Your project was successfully imported. Redirecting you to the diagram editor.",
+               message:
+                  "Your project was successfully imported. Redirecting you to the diagram editor." +
+                  (skipped.length > 0 ? ` Skipped ${skipped.join(", ")} files.` : "") +
+                  problemsMessage,
                type: "success",
             });
          })
@@ -63,6 +109,7 @@ export default function ImportItem({ project }: { project?: Project }) {
          .finally(() => {
             e.target.value = null; // Reset form files
             setIsLoading(false);
+            setProgress(undefined);
          });
    }
 
@@ -93,13 +140,14 @@ export default function ImportItem({ project }: { project?: Project }) {
                      ></path>
                   </svg>
                   <p className="mb-2 text-sm text-gray-500 font-semibold">Import project</p>
-                  <p className="text-xs text-gray-500">.zip (max 2GB)</p>
+                  <p className="text-xs text-gray-500">.zip, .tar.gz, .bundle (max 50MB)</p>
                </div>
                {isLoading && <Icons.spinner className="h-4 w-4 animate-spin" />}
+               {isLoading && progress && <p className="mt-2 text-xs text-gray-500 text-center">{progress}</p>}
                <input
                   type="file"
                   id="dropzone-file"
-                  accept={validFileTypes.join(",")}
+                  accept={[...validFileTypes, ...validFileExtensions, ".bundle"].join(",")}
                   className="hidden"
                   onChange={onProjectImport}
                   disabled={isLoading}
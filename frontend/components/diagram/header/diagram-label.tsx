@@ -13,6 +13,8 @@ import {
    createDiagram,
    getProjects,
    removeDiagramUser,
+   syncDiagram,
+   waitForImport,
 } from "@/lib/auth-fetch";
 import { toast } from "@/ui/toast";
 import * as z from "zod";
@@ -34,6 +36,7 @@ export default function DiagramLabel({
    const [openArrow, setOpenArrow] = useState(false);
    const [open, setOpen] = useState(false);
    const [hovered, setHovered] = useState(false);
+   const syncInputRef = useRef<HTMLInputElement>(null);
    const router = useRouter();
 
    // if the diagram name changes, update the diagram name
@@ -92,6 +95,55 @@ export default function DiagramLabel({
       }
    }
 
+   // re-import the source of the diagram, the layout of the classes that are still in the source is kept
+   function onSyncFromSource(e: React.ChangeEvent<HTMLInputElement>) {
+      if (!e.target.files || e.target.files.length === 0) {
+         return;
+      }
+
+      const formData = new FormData();
+      formData.append("id", diagram.id);
+      formData.append("project", e.target.files[0]);
+      e.target.value = null; // Reset form files
+
+      toast({
+         message: "Syncing the diagram with its source...",
+         type: "default",
+      });
+
+      syncDiagram(formData)
+         .then((res) => {
+            if (res.success === false) {
+               throw new Error(res.reason);
+            }
+
+            return waitForImport(res.response, () => {});
+         })
+         .then((job) => {
+            if (job.status === "failed") {
+               throw new Error(job.reason);
+            }
+
+            // every open editor of the diagram reloads when the sync is saved
+            const summary = job.sync;
+            return toast({
+               title: "Diagram synced",
+               message: summary
+                  ? `${summary.updated} updated, ${summary.added} added, ${summary.removed + summary.flagged} no longer in the source.`
+                  : "The diagram was synced with its source.",
+               type: "success",
+            });
+         })
+         .catch((err) => {
+            console.error(err);
+            return toast({
+               title: "Something went wrong.",
+               message: err.message,
+               type: "error",
+            });
+         });
+   }
+
    // close diagram name text editing input when user clicks outside the input
    function handleClickOutside(e: MouseEvent) {
       if (editDiagramRef.current && !editDiagramRef.current.contains(e.target as Node)) {
@@ -129,6 +181,13 @@ export default function DiagramLabel({
 
    return (
       <div className="h-full basis-2/4 flex justify-center items-center gap-2 text-sm select-none">
+         <input
+            type="file"
+            ref={syncInputRef}
+            accept=".zip,.tar,.tar.gz,.tgz,application/zip,application/x-tar,application/gzip"
+            className="hidden"
+            onChange={onSyncFromSource}
+         />
          {/* show proj label if diagram is in a proj and if diagram name is not being edited */}
          {diagram.project && !editDiagramName && (
             <>
@@ -243,6 +302,18 @@ export default function DiagramLabel({
                         <div>Duplicate</div>
                      </DropdownMenu.Item>
 
+                     {/* Sync the diagram with its source */}
+                     {(role === "owner" || role === "editor") && (
+                        <DropdownMenu.Item
+                           className="flex text-white text-xs pl-7 h-6 focus:bg-diagram-menu-item-selected hover:bg-diagram-menu-item-hovered focus:text-white"
+                           onClick={() => {
+                              syncInputRef.current?.click();
+                           }}
+                        >
+                           <div>Sync from source...</div>
+                        </DropdownMenu.Item>
+                     )}
+
                      {/* Move diagram to project if its not in a project */}
                      {!diagram.project && role === "owner" && (
                         <>
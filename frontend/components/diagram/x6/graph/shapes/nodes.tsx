@@ -1,7 +1,33 @@
 import type X6Type from "@antv/x6";
 import { register } from "@antv/x6-react-shape";
 import { useEffect, useState } from "react";
-import { ClassNode } from "types";
+import { ClassNode, Javadoc } from "types";
+
+// Returns the text of a Javadoc comment that is shown when hovering over a class or a member
+function getJavadocTooltip(javadoc?: Javadoc) {
+   if (!javadoc) {
+      return undefined;
+   }
+
+   const lines = [];
+   if (javadoc.deprecated) {
+      lines.push(`Deprecated${javadoc.deprecatedReason ? `: ${javadoc.deprecatedReason}` : ""}`);
+   }
+
+   if (javadoc.summary) {
+      lines.push(javadoc.summary);
+   }
+
+   javadoc.params?.forEach((param) => {
+      lines.push(`@param ${param.name}${param.description ? ` ${param.description}` : ""}`);
+   });
+
+   if (javadoc.return) {
+      lines.push(`@return ${javadoc.return}`);
+   }
+
+   return lines.length > 0 ? lines.join("\n") : undefined;
+}
 
 function ShapeNodeClass({ node }: { node: X6Type.Node }) {
    const [selected, setSelected] = useState<boolean>(false);
@@ -9,12 +35,16 @@ function ShapeNodeClass({ node }: { node: X6Type.Node }) {
    const [type, setType] = useState<ClassNode["type"]>("class");
    const [packageName, setPackageName] = useState<ClassNode["package"]>();
    const [name, setName] = useState<ClassNode["name"]>();
+   const [typeParameters, setTypeParameters] = useState<ClassNode["typeParameters"]>([]);
+   const [stereotypes, setStereotypes] = useState<ClassNode["stereotypes"]>([]);
+   const [javadoc, setJavadoc] = useState<ClassNode["javadoc"]>();
    const [variables, setVariables] = useState<ClassNode["variables"]>([]);
    const [methods, setMethods] = useState<ClassNode["methods"]>([]);
    const [backgroundColor, setBackgroundColor] = useState("FFFFFF");
    const [borderColor, setBorderColor] = useState("000000");
    const [borderWidth, setBorderWidth] = useState(1);
    const [borderStyle, setBorderStyle] = useState("solid");
+   const [missingFromSource, setMissingFromSource] = useState(false);
 
    useEffect(() => {
       if (!node) {
@@ -25,23 +55,31 @@ function ShapeNodeClass({ node }: { node: X6Type.Node }) {
          type,
          package: packageName,
          name,
+         typeParameters,
+         stereotypes,
+         javadoc,
          variables,
          methods,
          backgroundColor,
          borderColor,
          borderWidth,
          borderStyle,
+         missingFromSource,
       } = node.getProp() as ClassNode;
 
       setType(type);
       setPackageName(packageName);
       setName(name);
+      setTypeParameters(typeParameters || []);
+      setStereotypes(stereotypes || []);
+      setJavadoc(javadoc);
       setVariables(variables || []);
       setMethods(methods || []);
       setBackgroundColor(backgroundColor || "FFFFFF");
       setBorderColor(borderColor || "000000");
       setBorderWidth(borderWidth || 1);
       setBorderStyle(borderStyle || "solid");
+      setMissingFromSource(missingFromSource || false);
    }, []);
 
    useEffect(() => {
@@ -252,6 +290,28 @@ function ShapeNodeClass({ node }: { node: X6Type.Node }) {
       node.prop("borderStyle", borderStyle, { silent: true });
    }, [borderStyle]);
 
+   // Interfaces, enums, traits, records and annotations show their type above their name,
+   // followed by annotations such as Entity and modifiers such as sealed
+   const headerStereotypes = [
+      ...(type === "interface" || type === "enum" || type === "trait" || type === "record" || type === "annotation"
+         ? [type]
+         : []),
+      ...stereotypes,
+   ];
+
+   // Generic classes show their type parameters after their name: Repository<T extends Entity, ID>
+   const title =
+      (!name ? "ClassName" : name) +
+      (typeParameters.length > 0
+         ? `<${typeParameters
+              .map((typeParameter) =>
+                 typeParameter.bounds?.length > 0
+                    ? `${typeParameter.name} extends ${typeParameter.bounds.join(" & ")}`
+                    : typeParameter.name,
+              )
+              .join(", ")}>`
+         : "");
+
    return (
       <div
          style={{
@@ -279,6 +339,7 @@ function ShapeNodeClass({ node }: { node: X6Type.Node }) {
             />
          )}
          <div
+            title={missingFromSource ? "This class was not found when the diagram was synced with its source." : undefined}
             style={{
                fontFamily: "Helvetica",
                fontSize: 12,
@@ -290,6 +351,7 @@ function ShapeNodeClass({ node }: { node: X6Type.Node }) {
                backgroundColor: `#${backgroundColor}`,
                border: `${borderWidth}px ${borderStyle} #${borderColor}`,
                overflow: "hidden",
+               opacity: missingFromSource ? 0.5 : 1,
             }}
          >
             <div
@@ -305,20 +367,21 @@ function ShapeNodeClass({ node }: { node: X6Type.Node }) {
                         : undefined,
                }}
             >
-               {(type === "interface" || type === "enum") && (
+               {headerStereotypes.length > 0 && (
                   <div
                      style={{
                         height: "17px",
                      }}
-                  >{`<<${type}>>`}</div>
+                  >{`<<${headerStereotypes.join(", ")}>>`}</div>
                )}
                <div
                   style={{
                      fontWeight: "bold",
                   }}
+                  title={getJavadocTooltip(javadoc)}
                >
                   {/* if the class is abstract, it's classname should be italicized */}
-                  {type === "abstract" ? <i>{!name ? "ClassName" : name}</i> : !name ? "ClassName" : name}
+                  {type === "abstract" ? <i>{title}</i> : title}
                </div>
             </div>
 
@@ -334,7 +397,8 @@ function ShapeNodeClass({ node }: { node: X6Type.Node }) {
                   }}
                >
                   {variables.map((variable, index) => (
-                     <div key={index}>
+                     <div key={index} title={getJavadocTooltip(variable.javadoc)}>
+                        {variable.stereotypes?.length > 0 && `<<${variable.stereotypes.join(", ")}>> `}
                         {variable.accessModifier === "protected" ? "#" : variable.accessModifier === "private" ? "-" : "+"}
                         {variable.name}
                         {variable.type && `: ${variable.type}`}
@@ -360,7 +424,10 @@ function ShapeNodeClass({ node }: { node: X6Type.Node }) {
                   }}
                >
                   {methods.map((method, index) => (
-                     <div key={index}>
+                     <div key={index} title={getJavadocTooltip(method.javadoc)}>
+                        {/* constructors are shown with the create stereotype */}
+                        {(method.isConstructor || method.stereotypes?.length > 0) &&
+                           `<<${[...(method.isConstructor ? ["create"] : []), ...(method.stereotypes || [])].join(", ")}>> `}
                         {method.accessModifier
                            ? method.accessModifier === "protected"
                               ? "#"
@@ -376,18 +443,21 @@ function ShapeNodeClass({ node }: { node: X6Type.Node }) {
                                  return `param${index + 1}`;
                               }
 
+                              const parameterType = parameter.varargs ? `${parameter.type}...` : parameter.type;
+
                               if (!parameter.type) {
                                  return parameter.name;
                               }
 
                               if (!parameter.name) {
-                                 return parameter.type;
+                                 return parameterType;
                               }
 
-                              return `${parameter.name}: ${parameter.type}`;
+                              return `${parameter.name}: ${parameterType}`;
                            })
                            .join(", ")}
                         &#41;{method.type && `: ${method.type}`}
+                        {method.throws?.length > 0 && ` throws ${method.throws.join(", ")}`}
                      </div>
                   ))}
                </div>
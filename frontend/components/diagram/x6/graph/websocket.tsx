@@ -38,7 +38,10 @@ function onWebSocketMessage(
    console.log("onWebSocketMessage", message);
 
    const events = message.event.split("/");
-   if (events.includes("connection")) {
+   if (events.includes("sync")) {
+      // The diagram was synced with its source, so every cell may have changed
+      window.location.reload();
+   } else if (events.includes("connection")) {
       const color = message.color;
       const user = message.user;
       layoutProps.setConnectedUsers((prev) => {
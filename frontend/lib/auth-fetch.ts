@@ -1,5 +1,5 @@
-import { Diagram, User, APIResponse, Project, Issue } from "types";
-import { fetchAPI } from "./utils";
+import { Diagram, ImportJob, User, APIResponse, Project, Issue } from "types";
+import { fetchAPI, getWSUrl } from "./utils";
 
 const defaultError: APIResponse<any> = {
    success: false,
@@ -313,6 +313,95 @@ export async function createDiagram(form: FormData, options?: RequestInit): Prom
       .catch(() => defaultError);
 }
 
+export async function importDiagram(form: FormData, options?: RequestInit): Promise<APIResponse<ImportJob>> {
+   return fetchAPI("/diagram", {
+      ...options,
+      method: "POST",
+      body: form,
+   })
+      .then((res) => jsonResponse<ImportJob>(res))
+      .catch(() => defaultError);
+}
+
+export async function getImportJob(jobId: string, options?: RequestInit): Promise<APIResponse<ImportJob>> {
+   return fetchAPI(
+      "/diagram/import?" +
+         new URLSearchParams({
+            id: jobId,
+         }),
+      {
+         ...options,
+      },
+   )
+      .then((res) => jsonResponse<ImportJob>(res))
+      .catch(() => defaultError);
+}
+
+export async function syncDiagram(form: FormData, options?: RequestInit): Promise<APIResponse<ImportJob>> {
+   return fetchAPI("/diagram/sync", {
+      ...options,
+      method: "POST",
+      body: form,
+   })
+      .then((res) => jsonResponse<ImportJob>(res))
+      .catch(() => defaultError);
+}
+
+export function isImportFinished(job: ImportJob) {
+   return job.status === "succeeded" || job.status === "failed";
+}
+
+// Resolves with the import job once it is finished. The progress is pushed over a websocket,
+// and the status of the job is polled if the websocket closes before the job is finished.
+export function waitForImport(job: ImportJob, onProgress: (job: ImportJob) => void): Promise<ImportJob> {
+   return new Promise((resolve, reject) => {
+      let finished = false;
+
+      const finish = (job: ImportJob) => {
+         finished = true;
+         resolve(job);
+      };
+
+      const poll = () => {
+         getImportJob(job.id).then((res) => {
+            if (res.success === false) {
+               return reject(new Error(res.reason));
+            }
+
+            onProgress(res.response);
+            if (isImportFinished(res.response)) {
+               return finish(res.response);
+            }
+
+            setTimeout(poll, 2000);
+         });
+      };
+
+      const websocket = new WebSocket(getWSUrl() + "/import/" + job.id);
+      websocket.onmessage = (event) => {
+         if (!event || !event.data || event.data === "ping") {
+            return;
+         }
+
+         const message = JSON.parse(event.data);
+         if (!message || !message.import) {
+            return;
+         }
+
+         onProgress(message.import);
+         if (isImportFinished(message.import)) {
+            finish(message.import);
+            websocket.close();
+         }
+      };
+      websocket.onclose = () => {
+         if (!finished) {
+            poll();
+         }
+      };
+   });
+}
+
 export async function deleteDiagram(diagramId: string, options?: RequestInit): Promise<APIResponse<null>> {
    return fetchAPI(
       "/diagram?" +
@@ -101,6 +101,51 @@ export type Diagram = {
    current_user_has_edit_permission?: boolean;
    background_color: string;
    show_grid: boolean;
+   diagnostics?: Diagnostic[];
+   source_commit?: string;
+};
+
+export type Diagnostic = {
+   severity: "warning" | "error";
+   path: string;
+   line?: number;
+   message: string;
+};
+
+export type LanguageSummary = {
+   language: string;
+   files: number;
+   nodes: number;
+   edges: number;
+   skipped?: boolean;
+   reason?: string;
+};
+
+export type SyncSummary = {
+   updated: number;
+   added: number;
+   removed: number;
+   flagged: number;
+   edgesAdded: number;
+   edgesRemoved: number;
+};
+
+export type ImportJob = {
+   id: string;
+   userId: string;
+   status: "queued" | "running" | "succeeded" | "failed";
+   files: number;
+   parsedFiles: number;
+   parsed: boolean;
+   layoutDone: boolean;
+   diagramId?: string;
+   commit?: string;
+   sync?: SyncSummary;
+   languages: LanguageSummary[] | null;
+   diagnostics: Diagnostic[] | null;
+   reason?: string;
+   createdAt: string;
+   updatedAt: string;
 };
 
 export type DiagramUserRole = {
@@ -145,6 +190,22 @@ const enum AccessModifier {
    NONE = "",
 }
 
+export type SourceSpan = {
+   startLine: number;
+   endLine: number;
+};
+
+export type Javadoc = {
+   summary?: string;
+   params?: {
+      name: string;
+      description?: string;
+   }[];
+   return?: string;
+   deprecated?: boolean;
+   deprecatedReason?: string;
+};
+
 export type ClassNode = {
    id: string;
    type: string;
@@ -163,26 +224,46 @@ export type ClassNode = {
    };
    lock: boolean;
    package: string;
+   language?: string;
+   missingFromSource?: boolean; // Set when syncing the diagram with its source did not find the class anymore
+   path?: string;
+   span?: SourceSpan;
    name: string;
+   typeParameters?: {
+      name: string;
+      bounds?: string[];
+   }[];
+   stereotypes?: string[];
+   javadoc?: Javadoc;
    variables?: {
+      stereotypes?: string[];
       type: string;
       name: string;
       value: string;
       accessModifier: AccessModifier;
       static: boolean;
       final: boolean;
+      javadoc?: Javadoc;
+      span?: SourceSpan;
    }[];
    methods?: {
+      stereotypes?: string[];
       type: string;
       name: string;
       accessModifier: AccessModifier;
       parameters?: {
+         stereotypes?: string[];
          type: string;
          name: string;
+         varargs?: boolean;
       }[];
+      throws?: string[];
+      isConstructor?: boolean;
       abstract: boolean;
       static: boolean;
       final: boolean;
+      javadoc?: Javadoc;
+      span?: SourceSpan;
    }[];
    declarations?: string[];
 };